@@ -12,6 +12,34 @@ import (
 var Version = "dev"
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "import-musicbrainz" {
+		if len(os.Args) < 3 {
+			fmt.Fprintf(os.Stderr, "usage: %s import-musicbrainz <file>...\n", os.Args[0])
+			os.Exit(1)
+		}
+		if err := engine.ImportMusicBrainzDump(
+			readEnvOrFile,
+			os.Stdout,
+			Version,
+			os.Args[2:],
+		); err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "doctor" {
+		if err := engine.Doctor(
+			readEnvOrFile,
+			os.Stdout,
+			Version,
+			os.Args[2:],
+		); err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 	if err := engine.Run(
 		readEnvOrFile,
 		os.Stdout,
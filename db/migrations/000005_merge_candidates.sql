@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE merge_candidates (
+    id integer NOT NULL GENERATED ALWAYS AS IDENTITY,
+    item_type text NOT NULL CHECK (item_type IN ('artist', 'album', 'track')),
+    from_id integer NOT NULL,
+    to_id integer NOT NULL,
+    score real NOT NULL,
+    reasons text[] NOT NULL DEFAULT '{}',
+    dismissed boolean NOT NULL DEFAULT false,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT merge_candidates_pkey PRIMARY KEY (id)
+);
+
+-- a pair of items can only be suggested once, regardless of merge direction
+CREATE UNIQUE INDEX merge_candidates_pair_idx ON merge_candidates (item_type, LEAST(from_id, to_id), GREATEST(from_id, to_id));
+
+-- +goose Down
+DROP TABLE IF EXISTS merge_candidates;
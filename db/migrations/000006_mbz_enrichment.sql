@@ -0,0 +1,28 @@
+-- +goose Up
+-- items that have been searched for on MusicBrainz, so that unmatched items are not searched for on every run
+CREATE TABLE mbz_enrichment_attempts (
+    item_type text NOT NULL CHECK (item_type IN ('artist', 'album', 'track')),
+    item_id integer NOT NULL,
+    attempted_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT mbz_enrichment_attempts_pkey PRIMARY KEY (item_type, item_id)
+);
+
+-- low confidence MusicBrainz matches, to be reviewed by the user
+CREATE TABLE mbz_match_suggestions (
+    id integer NOT NULL GENERATED ALWAYS AS IDENTITY,
+    item_type text NOT NULL CHECK (item_type IN ('artist', 'album', 'track')),
+    item_id integer NOT NULL,
+    musicbrainz_id uuid NOT NULL,
+    name text NOT NULL,
+    artist_name text NOT NULL DEFAULT '',
+    score integer NOT NULL,
+    dismissed boolean NOT NULL DEFAULT false,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT mbz_match_suggestions_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX mbz_match_suggestions_item_idx ON mbz_match_suggestions (item_type, item_id, musicbrainz_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS mbz_match_suggestions;
+DROP TABLE IF EXISTS mbz_enrichment_attempts;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- raw MusicBrainz responses, so that the same entity is not requested again on every import
+CREATE TABLE mbz_response_cache (
+    entity_type text NOT NULL,
+    musicbrainz_id uuid NOT NULL,
+    body jsonb NOT NULL,
+    fetched_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT mbz_response_cache_pkey PRIMARY KEY (entity_type, musicbrainz_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS mbz_response_cache;
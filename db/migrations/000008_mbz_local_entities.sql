@@ -0,0 +1,14 @@
+-- +goose Up
+-- entities imported from a MusicBrainz JSON data dump, used instead of the MusicBrainz API when running offline
+CREATE TABLE mbz_local_entities (
+    entity_type text NOT NULL,
+    musicbrainz_id uuid NOT NULL,
+    release_group_id uuid,
+    body jsonb NOT NULL,
+    CONSTRAINT mbz_local_entities_pkey PRIMARY KEY (entity_type, musicbrainz_id)
+);
+
+CREATE INDEX mbz_local_entities_release_group_idx ON mbz_local_entities (release_group_id) WHERE release_group_id IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS mbz_local_entities;
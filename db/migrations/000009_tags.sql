@@ -0,0 +1,64 @@
+-- +goose Up
+CREATE TABLE tags (
+    id integer NOT NULL GENERATED ALWAYS AS IDENTITY,
+    name text NOT NULL,
+    CONSTRAINT tags_pkey PRIMARY KEY (id),
+    CONSTRAINT tags_name_key UNIQUE (name)
+);
+
+CREATE TABLE artist_tags (
+    artist_id integer NOT NULL REFERENCES artists(id) ON DELETE CASCADE,
+    tag_id integer NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    source text NOT NULL,
+    CONSTRAINT artist_tags_pkey PRIMARY KEY (artist_id, tag_id)
+);
+
+CREATE TABLE release_tags (
+    release_id integer NOT NULL REFERENCES releases(id) ON DELETE CASCADE,
+    tag_id integer NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    source text NOT NULL,
+    CONSTRAINT release_tags_pkey PRIMARY KEY (release_id, tag_id)
+);
+
+CREATE TABLE track_tags (
+    track_id integer NOT NULL REFERENCES tracks(id) ON DELETE CASCADE,
+    tag_id integer NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    source text NOT NULL,
+    CONSTRAINT track_tags_pkey PRIMARY KEY (track_id, tag_id)
+);
+
+CREATE INDEX artist_tags_tag_id_idx ON artist_tags (tag_id);
+CREATE INDEX release_tags_tag_id_idx ON release_tags (tag_id);
+CREATE INDEX track_tags_tag_id_idx ON track_tags (tag_id);
+
+-- when the MusicBrainz tags of an item were last fetched
+CREATE TABLE mbz_tag_fetches (
+    item_type text NOT NULL CHECK (item_type IN ('artist', 'album', 'track')),
+    item_id integer NOT NULL,
+    fetched_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT mbz_tag_fetches_pkey PRIMARY KEY (item_type, item_id)
+);
+
+-- the tags of each track, including the tags of its album and artists, which is what tag charts are based on
+CREATE VIEW track_tags_inherited AS
+    SELECT tt.track_id, tt.tag_id
+    FROM track_tags tt
+    UNION
+    SELECT t.id AS track_id, rt.tag_id
+    FROM tracks t
+    JOIN release_tags rt ON rt.release_id = t.release_id
+    UNION
+    SELECT at.track_id, art.tag_id
+    FROM artist_tracks at
+    JOIN artist_tags art ON art.artist_id = at.artist_id;
+
+-- cached MusicBrainz responses were requested without genres and tags
+DELETE FROM mbz_response_cache;
+
+-- +goose Down
+DROP VIEW IF EXISTS track_tags_inherited;
+DROP TABLE IF EXISTS mbz_tag_fetches;
+DROP TABLE IF EXISTS track_tags;
+DROP TABLE IF EXISTS release_tags;
+DROP TABLE IF EXISTS artist_tags;
+DROP TABLE IF EXISTS tags;
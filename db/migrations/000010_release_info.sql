@@ -0,0 +1,41 @@
+-- +goose Up
+-- release dates are stored as MusicBrainz formats them, which can be just a year, or a year and month
+ALTER TABLE releases
+    ADD COLUMN release_date text CHECK (release_date ~ '^\d{4}(-\d{2}(-\d{2})?)?$'),
+    ADD COLUMN release_type text,
+    ADD COLUMN secondary_types text[] NOT NULL DEFAULT '{}';
+
+CREATE OR REPLACE VIEW releases_with_title AS
+    SELECT r.id,
+        r.musicbrainz_id,
+        r.image,
+        r.various_artists,
+        r.image_source,
+        ra.alias AS title,
+        r.release_date,
+        r.release_type,
+        r.secondary_types
+    FROM (releases r
+        JOIN release_aliases ra ON ((ra.release_id = r.id)))
+    WHERE (ra.is_primary = true);
+
+-- refetch the release groups of all albums, which now also fill in the release date and types
+DELETE FROM mbz_tag_fetches WHERE item_type = 'album';
+
+-- +goose Down
+DROP VIEW IF EXISTS releases_with_title;
+CREATE VIEW releases_with_title AS
+    SELECT r.id,
+        r.musicbrainz_id,
+        r.image,
+        r.various_artists,
+        r.image_source,
+        ra.alias AS title
+    FROM (releases r
+        JOIN release_aliases ra ON ((ra.release_id = r.id)))
+    WHERE (ra.is_primary = true);
+
+ALTER TABLE releases
+    DROP COLUMN IF EXISTS release_date,
+    DROP COLUMN IF EXISTS release_type,
+    DROP COLUMN IF EXISTS secondary_types;
@@ -0,0 +1,81 @@
+-- +goose Up
+-- begin and end dates are stored as MusicBrainz formats them, which can be just a year, or a year and month
+ALTER TABLE artists
+    ADD COLUMN sort_name text,
+    ADD COLUMN artist_type text,
+    ADD COLUMN gender text,
+    ADD COLUMN area text,
+    ADD COLUMN country text CHECK (country ~ '^[A-Z]{2}$'),
+    ADD COLUMN begin_date text CHECK (begin_date ~ '^\d{4}(-\d{2}(-\d{2})?)?$'),
+    ADD COLUMN end_date text CHECK (end_date ~ '^\d{4}(-\d{2}(-\d{2})?)?$');
+
+CREATE INDEX artists_country_idx ON artists (country);
+
+-- artists are listed by their sort name, when one is known
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION get_artists_for_release(release_id INTEGER)
+RETURNS JSONB AS $$
+    SELECT json_agg(
+        jsonb_build_object('id', a.id, 'name', a.name)
+        ORDER BY ar.is_primary DESC, COALESCE(art.sort_name, a.name)
+    )
+    FROM artist_releases ar
+    JOIN artists_with_name a ON a.id = ar.artist_id
+    JOIN artists art ON art.id = ar.artist_id
+    WHERE ar.release_id = $1;
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION get_artists_for_track(track_id INTEGER)
+RETURNS JSONB AS $$
+    SELECT json_agg(
+        jsonb_build_object('id', a.id, 'name', a.name)
+        ORDER BY at.is_primary DESC, COALESCE(art.sort_name, a.name)
+    )
+    FROM artist_tracks at
+    JOIN artists_with_name a ON a.id = at.artist_id
+    JOIN artists art ON art.id = at.artist_id
+    WHERE at.track_id = $1;
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- refetch all artists, which now also fills in the artist info
+DELETE FROM mbz_tag_fetches WHERE item_type = 'artist';
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION get_artists_for_release(release_id INTEGER)
+RETURNS JSONB AS $$
+    SELECT json_agg(
+        jsonb_build_object('id', a.id, 'name', a.name)
+        ORDER BY ar.is_primary DESC, a.name
+    )
+    FROM artist_releases ar
+    JOIN artists_with_name a ON a.id = ar.artist_id
+    WHERE ar.release_id = $1;
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION get_artists_for_track(track_id INTEGER)
+RETURNS JSONB AS $$
+    SELECT json_agg(
+        jsonb_build_object('id', a.id, 'name', a.name)
+        ORDER BY at.is_primary DESC, a.name
+    )
+    FROM artist_tracks at
+    JOIN artists_with_name a ON a.id = at.artist_id
+    WHERE at.track_id = $1;
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+DROP INDEX IF EXISTS artists_country_idx;
+ALTER TABLE artists
+    DROP COLUMN IF EXISTS sort_name,
+    DROP COLUMN IF EXISTS artist_type,
+    DROP COLUMN IF EXISTS gender,
+    DROP COLUMN IF EXISTS area,
+    DROP COLUMN IF EXISTS country,
+    DROP COLUMN IF EXISTS begin_date,
+    DROP COLUMN IF EXISTS end_date;
@@ -0,0 +1,64 @@
+-- +goose Up
+-- match keys are used to find existing artists, albums, and tracks with names that only differ
+-- in case, accents, punctuation, or spacing, such as "Beyonce" and "Beyoncé", or "AC/DC" and "ACDC"
+-- +goose StatementBegin
+CREATE FUNCTION match_key(name TEXT)
+RETURNS TEXT
+LANGUAGE SQL IMMUTABLE STRICT
+AS $$
+    SELECT NULLIF(btrim(regexp_replace(
+        regexp_replace(
+            normalize(regexp_replace(
+                -- accents are removed by decomposing characters and dropping the combining marks
+                normalize(lower(normalize(name, NFKC)), NFD),
+                '[\u0300-\u036f\u1ab0-\u1aff\u1dc0-\u1dff\u20d0-\u20ff\ufe20-\ufe2f]', '', 'g'
+            ), NFC),
+            '[[:punct:]\u00a1\u00ab\u00b7\u00bb\u00bf\u2010-\u2027\u2030-\u205e\u3001-\u3003\u3008-\u3011\u3014-\u301f\u30fb]', '', 'g'
+        ),
+        '\s+', ' ', 'g'
+    )), '');
+$$;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE FUNCTION set_alias_match_key() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    NEW.match_key := match_key(NEW.alias);
+    RETURN NEW;
+END;
+$$;
+-- +goose StatementEnd
+
+ALTER TABLE artist_aliases ADD COLUMN match_key TEXT;
+ALTER TABLE release_aliases ADD COLUMN match_key TEXT;
+ALTER TABLE track_aliases ADD COLUMN match_key TEXT;
+
+UPDATE artist_aliases SET match_key = match_key(alias);
+UPDATE release_aliases SET match_key = match_key(alias);
+UPDATE track_aliases SET match_key = match_key(alias);
+
+CREATE INDEX artist_aliases_match_key_idx ON artist_aliases (match_key);
+CREATE INDEX release_aliases_match_key_idx ON release_aliases (match_key);
+CREATE INDEX track_aliases_match_key_idx ON track_aliases (match_key);
+
+CREATE TRIGGER trg_artist_aliases_match_key BEFORE INSERT OR UPDATE OF alias ON artist_aliases FOR EACH ROW EXECUTE FUNCTION set_alias_match_key();
+CREATE TRIGGER trg_release_aliases_match_key BEFORE INSERT OR UPDATE OF alias ON release_aliases FOR EACH ROW EXECUTE FUNCTION set_alias_match_key();
+CREATE TRIGGER trg_track_aliases_match_key BEFORE INSERT OR UPDATE OF alias ON track_aliases FOR EACH ROW EXECUTE FUNCTION set_alias_match_key();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trg_artist_aliases_match_key ON artist_aliases;
+DROP TRIGGER IF EXISTS trg_release_aliases_match_key ON release_aliases;
+DROP TRIGGER IF EXISTS trg_track_aliases_match_key ON track_aliases;
+
+DROP INDEX IF EXISTS artist_aliases_match_key_idx;
+DROP INDEX IF EXISTS release_aliases_match_key_idx;
+DROP INDEX IF EXISTS track_aliases_match_key_idx;
+
+ALTER TABLE artist_aliases DROP COLUMN IF EXISTS match_key;
+ALTER TABLE release_aliases DROP COLUMN IF EXISTS match_key;
+ALTER TABLE track_aliases DROP COLUMN IF EXISTS match_key;
+
+DROP FUNCTION IF EXISTS set_alias_match_key();
+DROP FUNCTION IF EXISTS match_key(TEXT);
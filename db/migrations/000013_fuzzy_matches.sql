@@ -0,0 +1,32 @@
+-- +goose Up
+-- artists, albums, and tracks that listens were linked to by a fuzzy name match that was not confident
+-- enough to be accepted automatically, to be confirmed or rejected by the user
+CREATE TABLE fuzzy_matches (
+    id integer NOT NULL GENERATED ALWAYS AS IDENTITY,
+    item_type text NOT NULL CHECK (item_type IN ('artist', 'album', 'track')),
+    item_id integer NOT NULL,
+    name text NOT NULL,
+    score real NOT NULL,
+    rejected boolean NOT NULL DEFAULT false,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT fuzzy_matches_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX fuzzy_matches_item_name_idx ON fuzzy_matches (item_type, item_id, name);
+
+-- the listens linked by each match, so that they can be split back out when the match is rejected.
+-- listens keep their link when they are moved to another track by a merge
+CREATE TABLE fuzzy_match_listens (
+    fuzzy_match_id integer NOT NULL,
+    track_id integer NOT NULL,
+    listened_at timestamptz NOT NULL,
+    CONSTRAINT fuzzy_match_listens_pkey PRIMARY KEY (fuzzy_match_id, track_id, listened_at),
+    CONSTRAINT fuzzy_match_listens_fuzzy_match_id_fkey FOREIGN KEY (fuzzy_match_id) REFERENCES fuzzy_matches(id) ON DELETE CASCADE,
+    CONSTRAINT fuzzy_match_listens_listen_fkey FOREIGN KEY (track_id, listened_at) REFERENCES listens(track_id, listened_at) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX fuzzy_match_listens_listen_idx ON fuzzy_match_listens (track_id, listened_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS fuzzy_match_listens;
+DROP TABLE IF EXISTS fuzzy_matches;
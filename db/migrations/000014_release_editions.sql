@@ -0,0 +1,18 @@
+-- +goose Up
+-- albums that are editions (deluxe, remaster, anniversary, ...) of another album. listens stay attached to
+-- the edition itself, and charts and album pages can roll editions up into the album they are an edition of.
+-- a row with no edition_of marks an album as not being an edition, so that it is not grouped automatically
+CREATE TABLE release_editions (
+    release_id integer NOT NULL,
+    edition_of integer,
+    source text NOT NULL,
+    CONSTRAINT release_editions_pkey PRIMARY KEY (release_id),
+    CONSTRAINT release_editions_release_id_fkey FOREIGN KEY (release_id) REFERENCES releases(id) ON DELETE CASCADE,
+    CONSTRAINT release_editions_edition_of_fkey FOREIGN KEY (edition_of) REFERENCES releases(id) ON DELETE CASCADE,
+    CONSTRAINT release_editions_not_self CHECK (edition_of <> release_id)
+);
+
+CREATE INDEX release_editions_edition_of_idx ON release_editions (edition_of);
+
+-- +goose Down
+DROP TABLE IF EXISTS release_editions;
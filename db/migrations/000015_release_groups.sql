@@ -0,0 +1,23 @@
+-- +goose Up
+-- the MusicBrainz release group of an album, so that different releases of the same album (pressings,
+-- regional releases, ...) can be matched to the same album
+ALTER TABLE releases ADD COLUMN release_group_mbz_id uuid;
+
+CREATE INDEX releases_release_group_mbz_id_idx ON releases (release_group_mbz_id) WHERE release_group_mbz_id IS NOT NULL;
+
+-- fill in the release groups of existing albums from cached and imported MusicBrainz releases
+UPDATE releases r SET release_group_mbz_id = e.release_group_id
+FROM mbz_local_entities e
+WHERE e.entity_type = 'release'
+  AND e.musicbrainz_id = r.musicbrainz_id
+  AND e.release_group_id IS NOT NULL;
+
+UPDATE releases r SET release_group_mbz_id = (c.body->'release-group'->>'id')::uuid
+FROM mbz_response_cache c
+WHERE c.entity_type = 'release'
+  AND c.musicbrainz_id = r.musicbrainz_id
+  AND r.release_group_mbz_id IS NULL
+  AND c.body->'release-group'->>'id' ~ '^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$';
+
+-- +goose Down
+ALTER TABLE releases DROP COLUMN IF EXISTS release_group_mbz_id;
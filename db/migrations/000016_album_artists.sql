@@ -0,0 +1,39 @@
+-- +goose Up
+-- artists are credited on an album either as one of its album artists, or only through the
+-- tracks they perform on (features, compilation tracks, ...). existing credits stay album artist credits
+ALTER TABLE artist_releases ADD COLUMN is_album_artist boolean NOT NULL DEFAULT true;
+
+-- an album lists its album artists, or every credited artist if it has no album artist credits
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION get_artists_for_release(release_id INTEGER)
+RETURNS JSONB AS $$
+    SELECT json_agg(
+        jsonb_build_object('id', a.id, 'name', a.name)
+        ORDER BY ar.is_primary DESC, COALESCE(art.sort_name, a.name)
+    )
+    FROM artist_releases ar
+    JOIN artists_with_name a ON a.id = ar.artist_id
+    JOIN artists art ON art.id = ar.artist_id
+    WHERE ar.release_id = $1
+      AND (ar.is_album_artist OR NOT EXISTS (
+        SELECT 1 FROM artist_releases x WHERE x.release_id = $1 AND x.is_album_artist
+      ));
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION get_artists_for_release(release_id INTEGER)
+RETURNS JSONB AS $$
+    SELECT json_agg(
+        jsonb_build_object('id', a.id, 'name', a.name)
+        ORDER BY ar.is_primary DESC, COALESCE(art.sort_name, a.name)
+    )
+    FROM artist_releases ar
+    JOIN artists_with_name a ON a.id = ar.artist_id
+    JOIN artists art ON art.id = ar.artist_id
+    WHERE ar.release_id = $1;
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+ALTER TABLE artist_releases DROP COLUMN IF EXISTS is_album_artist;
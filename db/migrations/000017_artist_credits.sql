@@ -0,0 +1,86 @@
+-- +goose Up
+-- the artist credit of a track or album, as it is printed on the release: the artists in order, the
+-- name each artist is credited as, and the phrase that joins it to the next artist (" feat. ", " x ", ...).
+-- credits without a position were associated before credits were stored, or are not part of the credit
+ALTER TABLE artist_tracks
+    ADD COLUMN credit_position integer,
+    ADD COLUMN credited_name text,
+    ADD COLUMN join_phrase text NOT NULL DEFAULT '';
+
+ALTER TABLE artist_releases
+    ADD COLUMN credit_position integer,
+    ADD COLUMN credited_name text,
+    ADD COLUMN join_phrase text NOT NULL DEFAULT '';
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION get_artists_for_release(release_id INTEGER)
+RETURNS JSONB AS $$
+    SELECT json_agg(
+        jsonb_build_object('id', a.id, 'name', a.name, 'credited_name', ar.credited_name, 'join_phrase', ar.join_phrase)
+        ORDER BY ar.credit_position NULLS LAST, ar.is_primary DESC, COALESCE(art.sort_name, a.name)
+    )
+    FROM artist_releases ar
+    JOIN artists_with_name a ON a.id = ar.artist_id
+    JOIN artists art ON art.id = ar.artist_id
+    WHERE ar.release_id = $1
+      AND (ar.is_album_artist OR NOT EXISTS (
+        SELECT 1 FROM artist_releases x WHERE x.release_id = $1 AND x.is_album_artist
+      ));
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION get_artists_for_track(track_id INTEGER)
+RETURNS JSONB AS $$
+    SELECT json_agg(
+        jsonb_build_object('id', a.id, 'name', a.name, 'credited_name', at.credited_name, 'join_phrase', at.join_phrase)
+        ORDER BY at.credit_position NULLS LAST, at.is_primary DESC, COALESCE(art.sort_name, a.name)
+    )
+    FROM artist_tracks at
+    JOIN artists_with_name a ON a.id = at.artist_id
+    JOIN artists art ON art.id = at.artist_id
+    WHERE at.track_id = $1;
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION get_artists_for_release(release_id INTEGER)
+RETURNS JSONB AS $$
+    SELECT json_agg(
+        jsonb_build_object('id', a.id, 'name', a.name)
+        ORDER BY ar.is_primary DESC, COALESCE(art.sort_name, a.name)
+    )
+    FROM artist_releases ar
+    JOIN artists_with_name a ON a.id = ar.artist_id
+    JOIN artists art ON art.id = ar.artist_id
+    WHERE ar.release_id = $1
+      AND (ar.is_album_artist OR NOT EXISTS (
+        SELECT 1 FROM artist_releases x WHERE x.release_id = $1 AND x.is_album_artist
+      ));
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION get_artists_for_track(track_id INTEGER)
+RETURNS JSONB AS $$
+    SELECT json_agg(
+        jsonb_build_object('id', a.id, 'name', a.name)
+        ORDER BY at.is_primary DESC, COALESCE(art.sort_name, a.name)
+    )
+    FROM artist_tracks at
+    JOIN artists_with_name a ON a.id = at.artist_id
+    JOIN artists art ON art.id = at.artist_id
+    WHERE at.track_id = $1;
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+ALTER TABLE artist_tracks
+    DROP COLUMN IF EXISTS credit_position,
+    DROP COLUMN IF EXISTS credited_name,
+    DROP COLUMN IF EXISTS join_phrase;
+
+ALTER TABLE artist_releases
+    DROP COLUMN IF EXISTS credit_position,
+    DROP COLUMN IF EXISTS credited_name,
+    DROP COLUMN IF EXISTS join_phrase;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- user managed rules for splitting submitted artist strings into artists: extra separators that artists
+-- are split on, and artist names that are never split even though they contain a separator
+CREATE TABLE artist_split_rules (
+    id integer NOT NULL GENERATED ALWAYS AS IDENTITY,
+    kind text NOT NULL CHECK (kind IN ('separator', 'protected')),
+    value text NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT artist_split_rules_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX artist_split_rules_kind_value_idx ON artist_split_rules (kind, lower(value));
+
+-- +goose Down
+DROP TABLE IF EXISTS artist_split_rules;
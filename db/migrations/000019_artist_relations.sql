@@ -0,0 +1,37 @@
+-- +goose Up
+-- relationships between artists, read as "artist <relation> related artist": a person that is a member_of
+-- a group, or a person that performs_as a solo project or stage name
+CREATE TABLE artist_relations (
+    artist_id integer NOT NULL,
+    related_artist_id integer NOT NULL,
+    relation text NOT NULL CHECK (relation IN ('member_of', 'performs_as')),
+    source text NOT NULL,
+    CONSTRAINT artist_relations_pkey PRIMARY KEY (artist_id, related_artist_id, relation),
+    CONSTRAINT artist_relations_artist_id_fkey FOREIGN KEY (artist_id) REFERENCES artists(id) ON DELETE CASCADE,
+    CONSTRAINT artist_relations_related_artist_id_fkey FOREIGN KEY (related_artist_id) REFERENCES artists(id) ON DELETE CASCADE,
+    CONSTRAINT artist_relations_not_self CHECK (artist_id <> related_artist_id)
+);
+
+CREATE INDEX artist_relations_related_artist_id_idx ON artist_relations (related_artist_id);
+
+-- the artist, and when include_related is set, every artist related to it in either direction: the members
+-- of a group and the groups a person is a member of, and the projects of a person and the person behind a project
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION artist_roll_up(artist_id INTEGER, include_related BOOLEAN)
+RETURNS SETOF INTEGER AS $$
+    SELECT $1
+    UNION
+    SELECT r.related_artist_id FROM artist_relations r WHERE $2 AND r.artist_id = $1
+    UNION
+    SELECT r.artist_id FROM artist_relations r WHERE $2 AND r.related_artist_id = $1;
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- cached artist responses were requested without relationships. refetch all artists, which now also fills
+-- in their relations
+DELETE FROM mbz_response_cache WHERE entity_type = 'artist';
+DELETE FROM mbz_tag_fetches WHERE item_type = 'artist';
+
+-- +goose Down
+DROP FUNCTION IF EXISTS artist_roll_up(INTEGER, BOOLEAN);
+DROP TABLE IF EXISTS artist_relations;
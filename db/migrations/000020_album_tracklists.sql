@@ -0,0 +1,25 @@
+-- +goose Up
+-- the full tracklist of an album as released, from its MusicBrainz release, including tracks that have
+-- never been listened to. entries are linked to the track in the catalog they were matched to, if any
+CREATE TABLE release_tracks (
+    release_id integer NOT NULL,
+    disc_number integer NOT NULL,
+    position integer NOT NULL,
+    title text NOT NULL,
+    duration integer DEFAULT 0 NOT NULL,
+    musicbrainz_id uuid,
+    track_id integer,
+    CONSTRAINT release_tracks_pkey PRIMARY KEY (release_id, disc_number, position),
+    CONSTRAINT release_tracks_release_id_fkey FOREIGN KEY (release_id) REFERENCES releases(id) ON DELETE CASCADE,
+    CONSTRAINT release_tracks_track_id_fkey FOREIGN KEY (track_id) REFERENCES tracks(id) ON DELETE SET NULL
+);
+
+CREATE INDEX release_tracks_track_id_idx ON release_tracks (track_id);
+
+-- cached release responses were requested without recordings. refetch all albums, which now also fills in
+-- their tracklists
+DELETE FROM mbz_response_cache WHERE entity_type = 'release';
+DELETE FROM mbz_tag_fetches WHERE item_type = 'album';
+
+-- +goose Down
+DROP TABLE IF EXISTS release_tracks;
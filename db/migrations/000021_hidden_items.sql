@@ -0,0 +1,88 @@
+-- +goose Up
+-- artists, albums and tracks that are hidden from charts, counts and search without deleting their listens
+CREATE TABLE hidden_artists (
+    artist_id integer NOT NULL,
+    CONSTRAINT hidden_artists_pkey PRIMARY KEY (artist_id),
+    CONSTRAINT hidden_artists_artist_id_fkey FOREIGN KEY (artist_id) REFERENCES artists(id) ON DELETE CASCADE
+);
+
+CREATE TABLE hidden_releases (
+    release_id integer NOT NULL,
+    CONSTRAINT hidden_releases_pkey PRIMARY KEY (release_id),
+    CONSTRAINT hidden_releases_release_id_fkey FOREIGN KEY (release_id) REFERENCES releases(id) ON DELETE CASCADE
+);
+
+CREATE TABLE hidden_tracks (
+    track_id integer NOT NULL,
+    CONSTRAINT hidden_tracks_pkey PRIMARY KEY (track_id),
+    CONSTRAINT hidden_tracks_track_id_fkey FOREIGN KEY (track_id) REFERENCES tracks(id) ON DELETE CASCADE
+);
+
+-- clients whose listens are hidden from stats, matched against listens.client
+CREATE TABLE hidden_clients (
+    client text NOT NULL,
+    CONSTRAINT hidden_clients_pkey PRIMARY KEY (client)
+);
+
+-- whether an artist, album or track shows up in stats and search, unless include_hidden is set. an album is
+-- hidden along with its album artists, and a track along with its album and any of its artists
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION artist_visible(artist_id INTEGER, include_hidden BOOLEAN)
+RETURNS BOOLEAN AS $$
+    SELECT $2 OR NOT EXISTS (SELECT 1 FROM hidden_artists h WHERE h.artist_id = $1);
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION release_visible(release_id INTEGER, include_hidden BOOLEAN)
+RETURNS BOOLEAN AS $$
+    SELECT $2 OR NOT (
+        EXISTS (SELECT 1 FROM hidden_releases h WHERE h.release_id = $1)
+        OR EXISTS (
+            SELECT 1 FROM hidden_artists h
+            JOIN artist_releases ar ON ar.artist_id = h.artist_id
+            WHERE ar.release_id = $1 AND ar.is_album_artist
+        )
+    );
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION track_visible(track_id INTEGER, include_hidden BOOLEAN)
+RETURNS BOOLEAN AS $$
+    SELECT $2 OR NOT (
+        EXISTS (SELECT 1 FROM hidden_tracks h WHERE h.track_id = $1)
+        OR EXISTS (
+            SELECT 1 FROM tracks t
+            WHERE t.id = $1 AND NOT release_visible(t.release_id, false)
+        )
+        OR EXISTS (
+            SELECT 1 FROM hidden_artists h
+            JOIN artist_tracks at ON at.artist_id = h.artist_id
+            WHERE at.track_id = $1
+        )
+    );
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- whether a listen counts towards stats: it does not when its track is hidden or it was submitted from a
+-- hidden client, unless include_hidden is set
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION listen_visible(track_id INTEGER, client TEXT, include_hidden BOOLEAN)
+RETURNS BOOLEAN AS $$
+    SELECT $3 OR (
+        track_visible($1, false)
+        AND NOT EXISTS (SELECT 1 FROM hidden_clients h WHERE h.client = $2)
+    );
+$$ LANGUAGE sql STABLE;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP FUNCTION IF EXISTS listen_visible(INTEGER, TEXT, BOOLEAN);
+DROP FUNCTION IF EXISTS track_visible(INTEGER, BOOLEAN);
+DROP FUNCTION IF EXISTS release_visible(INTEGER, BOOLEAN);
+DROP FUNCTION IF EXISTS artist_visible(INTEGER, BOOLEAN);
+DROP TABLE IF EXISTS hidden_clients;
+DROP TABLE IF EXISTS hidden_tracks;
+DROP TABLE IF EXISTS hidden_releases;
+DROP TABLE IF EXISTS hidden_artists;
@@ -0,0 +1,220 @@
+-- +goose Up
+-- deleted artists, albums and tracks, kept until they are restored or purged
+CREATE TABLE trash (
+    id integer NOT NULL GENERATED ALWAYS AS IDENTITY,
+    item_type text NOT NULL CHECK (item_type IN ('artist', 'album', 'track')),
+    item_id integer NOT NULL,
+    name text NOT NULL,
+    deleted_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT trash_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX trash_deleted_at_idx ON trash (deleted_at);
+
+-- every row that was removed by a delete, including the rows removed by cascades and triggers, as it was
+-- before it was removed, so that it can be put back exactly
+CREATE TABLE trash_rows (
+    trash_id integer NOT NULL,
+    table_name text NOT NULL,
+    row jsonb NOT NULL,
+    CONSTRAINT trash_rows_trash_id_fkey FOREIGN KEY (trash_id) REFERENCES trash(id) ON DELETE CASCADE
+);
+
+CREATE INDEX trash_rows_trash_id_idx ON trash_rows (trash_id, table_name);
+
+-- copies a removed row to the trash when the transaction removing it has set koito.trash_id
+-- +goose StatementBegin
+CREATE FUNCTION trash_removed_row() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+DECLARE
+    current_trash_id text := current_setting('koito.trash_id', true);
+BEGIN
+    IF current_trash_id IS NOT NULL AND current_trash_id <> '' THEN
+        INSERT INTO trash_rows (trash_id, table_name, row)
+        VALUES (current_trash_id::integer, TG_TABLE_NAME, to_jsonb(OLD));
+    END IF;
+    RETURN OLD;
+END;
+$$;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_trash_artists AFTER DELETE ON artists FOR EACH ROW EXECUTE FUNCTION trash_removed_row();
+CREATE TRIGGER trg_trash_artist_aliases AFTER DELETE ON artist_aliases FOR EACH ROW EXECUTE FUNCTION trash_removed_row();
+CREATE TRIGGER trg_trash_artist_releases AFTER DELETE ON artist_releases FOR EACH ROW EXECUTE FUNCTION trash_removed_row();
+CREATE TRIGGER trg_trash_artist_tracks AFTER DELETE ON artist_tracks FOR EACH ROW EXECUTE FUNCTION trash_removed_row();
+CREATE TRIGGER trg_trash_artist_tags AFTER DELETE ON artist_tags FOR EACH ROW EXECUTE FUNCTION trash_removed_row();
+CREATE TRIGGER trg_trash_artist_relations AFTER DELETE ON artist_relations FOR EACH ROW EXECUTE FUNCTION trash_removed_row();
+CREATE TRIGGER trg_trash_hidden_artists AFTER DELETE ON hidden_artists FOR EACH ROW EXECUTE FUNCTION trash_removed_row();
+CREATE TRIGGER trg_trash_releases AFTER DELETE ON releases FOR EACH ROW EXECUTE FUNCTION trash_removed_row();
+CREATE TRIGGER trg_trash_release_aliases AFTER DELETE ON release_aliases FOR EACH ROW EXECUTE FUNCTION trash_removed_row();
+CREATE TRIGGER trg_trash_release_tags AFTER DELETE ON release_tags FOR EACH ROW EXECUTE FUNCTION trash_removed_row();
+CREATE TRIGGER trg_trash_release_editions AFTER DELETE ON release_editions FOR EACH ROW EXECUTE FUNCTION trash_removed_row();
+-- tracklist entries of other albums are unlinked rather than removed when their track is deleted
+CREATE TRIGGER trg_trash_release_tracks AFTER DELETE OR UPDATE OF track_id ON release_tracks FOR EACH ROW EXECUTE FUNCTION trash_removed_row();
+CREATE TRIGGER trg_trash_hidden_releases AFTER DELETE ON hidden_releases FOR EACH ROW EXECUTE FUNCTION trash_removed_row();
+CREATE TRIGGER trg_trash_tracks AFTER DELETE ON tracks FOR EACH ROW EXECUTE FUNCTION trash_removed_row();
+CREATE TRIGGER trg_trash_track_aliases AFTER DELETE ON track_aliases FOR EACH ROW EXECUTE FUNCTION trash_removed_row();
+CREATE TRIGGER trg_trash_track_tags AFTER DELETE ON track_tags FOR EACH ROW EXECUTE FUNCTION trash_removed_row();
+CREATE TRIGGER trg_trash_hidden_tracks AFTER DELETE ON hidden_tracks FOR EACH ROW EXECUTE FUNCTION trash_removed_row();
+CREATE TRIGGER trg_trash_listens AFTER DELETE ON listens FOR EACH ROW EXECUTE FUNCTION trash_removed_row();
+CREATE TRIGGER trg_trash_fuzzy_match_listens AFTER DELETE ON fuzzy_match_listens FOR EACH ROW EXECUTE FUNCTION trash_removed_row();
+
+-- puts the rows of a trash entry back in place. links to items that no longer exist are left out
+-- +goose StatementBegin
+CREATE FUNCTION restore_trash(entry_id INTEGER) RETURNS void
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    -- tags that were removed as orphans after the delete are brought back along with their links
+    INSERT INTO tags OVERRIDING SYSTEM VALUE
+    SELECT (jsonb_populate_record(NULL::tags, r.row)).*
+    FROM trash_rows r WHERE r.trash_id = $1 AND r.table_name = 'tags'
+    ON CONFLICT DO NOTHING;
+
+    INSERT INTO artists OVERRIDING SYSTEM VALUE
+    SELECT (jsonb_populate_record(NULL::artists, r.row)).*
+    FROM trash_rows r WHERE r.trash_id = $1 AND r.table_name = 'artists';
+
+    INSERT INTO releases OVERRIDING SYSTEM VALUE
+    SELECT (jsonb_populate_record(NULL::releases, r.row)).*
+    FROM trash_rows r WHERE r.trash_id = $1 AND r.table_name = 'releases';
+
+    INSERT INTO tracks OVERRIDING SYSTEM VALUE
+    SELECT (jsonb_populate_record(NULL::tracks, r.row)).*
+    FROM trash_rows r WHERE r.trash_id = $1 AND r.table_name = 'tracks';
+
+    INSERT INTO artist_aliases
+    SELECT (jsonb_populate_record(NULL::artist_aliases, r.row)).*
+    FROM trash_rows r WHERE r.trash_id = $1 AND r.table_name = 'artist_aliases'
+    ON CONFLICT DO NOTHING;
+
+    INSERT INTO release_aliases
+    SELECT (jsonb_populate_record(NULL::release_aliases, r.row)).*
+    FROM trash_rows r WHERE r.trash_id = $1 AND r.table_name = 'release_aliases'
+    ON CONFLICT DO NOTHING;
+
+    INSERT INTO track_aliases
+    SELECT (jsonb_populate_record(NULL::track_aliases, r.row)).*
+    FROM trash_rows r WHERE r.trash_id = $1 AND r.table_name = 'track_aliases'
+    ON CONFLICT DO NOTHING;
+
+    INSERT INTO artist_releases
+    SELECT (jsonb_populate_record(NULL::artist_releases, r.row)).*
+    FROM trash_rows r WHERE r.trash_id = $1 AND r.table_name = 'artist_releases'
+      AND EXISTS (SELECT 1 FROM artists a WHERE a.id = (r.row->>'artist_id')::integer)
+      AND EXISTS (SELECT 1 FROM releases x WHERE x.id = (r.row->>'release_id')::integer)
+    ON CONFLICT DO NOTHING;
+
+    INSERT INTO artist_tracks
+    SELECT (jsonb_populate_record(NULL::artist_tracks, r.row)).*
+    FROM trash_rows r WHERE r.trash_id = $1 AND r.table_name = 'artist_tracks'
+      AND EXISTS (SELECT 1 FROM artists a WHERE a.id = (r.row->>'artist_id')::integer)
+      AND EXISTS (SELECT 1 FROM tracks t WHERE t.id = (r.row->>'track_id')::integer)
+    ON CONFLICT DO NOTHING;
+
+    INSERT INTO artist_tags
+    SELECT (jsonb_populate_record(NULL::artist_tags, r.row)).*
+    FROM trash_rows r WHERE r.trash_id = $1 AND r.table_name = 'artist_tags'
+      AND EXISTS (SELECT 1 FROM tags tg WHERE tg.id = (r.row->>'tag_id')::integer)
+    ON CONFLICT DO NOTHING;
+
+    INSERT INTO release_tags
+    SELECT (jsonb_populate_record(NULL::release_tags, r.row)).*
+    FROM trash_rows r WHERE r.trash_id = $1 AND r.table_name = 'release_tags'
+      AND EXISTS (SELECT 1 FROM tags tg WHERE tg.id = (r.row->>'tag_id')::integer)
+    ON CONFLICT DO NOTHING;
+
+    INSERT INTO track_tags
+    SELECT (jsonb_populate_record(NULL::track_tags, r.row)).*
+    FROM trash_rows r WHERE r.trash_id = $1 AND r.table_name = 'track_tags'
+      AND EXISTS (SELECT 1 FROM tags tg WHERE tg.id = (r.row->>'tag_id')::integer)
+    ON CONFLICT DO NOTHING;
+
+    INSERT INTO artist_relations
+    SELECT (jsonb_populate_record(NULL::artist_relations, r.row)).*
+    FROM trash_rows r WHERE r.trash_id = $1 AND r.table_name = 'artist_relations'
+      AND EXISTS (SELECT 1 FROM artists a WHERE a.id = (r.row->>'artist_id')::integer)
+      AND EXISTS (SELECT 1 FROM artists a WHERE a.id = (r.row->>'related_artist_id')::integer)
+    ON CONFLICT DO NOTHING;
+
+    INSERT INTO release_editions
+    SELECT (jsonb_populate_record(NULL::release_editions, r.row)).*
+    FROM trash_rows r WHERE r.trash_id = $1 AND r.table_name = 'release_editions'
+      AND EXISTS (SELECT 1 FROM releases x WHERE x.id = (r.row->>'release_id')::integer)
+      AND EXISTS (SELECT 1 FROM releases x WHERE x.id = (r.row->>'edition_of')::integer)
+    ON CONFLICT DO NOTHING;
+
+    -- tracklist entries are either put back with their album, or relinked to their track
+    -- an entry can be in the trash twice, once unlinked and once removed with its album, so the linked copy
+    -- is preferred
+    INSERT INTO release_tracks AS rt
+    SELECT DISTINCT ON (x.release_id, x.disc_number, x.position) x.*
+    FROM (
+        SELECT (jsonb_populate_record(NULL::release_tracks, r.row)).*
+        FROM trash_rows r WHERE r.trash_id = $1 AND r.table_name = 'release_tracks'
+    ) x
+    WHERE EXISTS (SELECT 1 FROM releases rl WHERE rl.id = x.release_id)
+      AND (x.track_id IS NULL OR EXISTS (SELECT 1 FROM tracks t WHERE t.id = x.track_id))
+    ORDER BY x.release_id, x.disc_number, x.position, x.track_id IS NULL
+    ON CONFLICT (release_id, disc_number, position) DO UPDATE SET track_id = EXCLUDED.track_id
+    WHERE rt.track_id IS NULL;
+
+    INSERT INTO hidden_artists
+    SELECT (jsonb_populate_record(NULL::hidden_artists, r.row)).*
+    FROM trash_rows r WHERE r.trash_id = $1 AND r.table_name = 'hidden_artists'
+    ON CONFLICT DO NOTHING;
+
+    INSERT INTO hidden_releases
+    SELECT (jsonb_populate_record(NULL::hidden_releases, r.row)).*
+    FROM trash_rows r WHERE r.trash_id = $1 AND r.table_name = 'hidden_releases'
+    ON CONFLICT DO NOTHING;
+
+    INSERT INTO hidden_tracks
+    SELECT (jsonb_populate_record(NULL::hidden_tracks, r.row)).*
+    FROM trash_rows r WHERE r.trash_id = $1 AND r.table_name = 'hidden_tracks'
+    ON CONFLICT DO NOTHING;
+
+    INSERT INTO listens
+    SELECT (jsonb_populate_record(NULL::listens, r.row)).*
+    FROM trash_rows r WHERE r.trash_id = $1 AND r.table_name = 'listens'
+      AND EXISTS (SELECT 1 FROM users u WHERE u.id = (r.row->>'user_id')::integer)
+    ON CONFLICT DO NOTHING;
+
+    INSERT INTO fuzzy_match_listens
+    SELECT (jsonb_populate_record(NULL::fuzzy_match_listens, r.row)).*
+    FROM trash_rows r WHERE r.trash_id = $1 AND r.table_name = 'fuzzy_match_listens'
+      AND EXISTS (SELECT 1 FROM fuzzy_matches f WHERE f.id = (r.row->>'fuzzy_match_id')::integer)
+      AND EXISTS (
+        SELECT 1 FROM listens l
+        WHERE l.track_id = (r.row->>'track_id')::integer AND l.listened_at = (r.row->>'listened_at')::timestamptz
+      )
+    ON CONFLICT DO NOTHING;
+END;
+$$;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP FUNCTION IF EXISTS restore_trash(INTEGER);
+DROP TRIGGER IF EXISTS trg_trash_fuzzy_match_listens ON fuzzy_match_listens;
+DROP TRIGGER IF EXISTS trg_trash_listens ON listens;
+DROP TRIGGER IF EXISTS trg_trash_hidden_tracks ON hidden_tracks;
+DROP TRIGGER IF EXISTS trg_trash_track_tags ON track_tags;
+DROP TRIGGER IF EXISTS trg_trash_track_aliases ON track_aliases;
+DROP TRIGGER IF EXISTS trg_trash_tracks ON tracks;
+DROP TRIGGER IF EXISTS trg_trash_hidden_releases ON hidden_releases;
+DROP TRIGGER IF EXISTS trg_trash_release_tracks ON release_tracks;
+DROP TRIGGER IF EXISTS trg_trash_release_editions ON release_editions;
+DROP TRIGGER IF EXISTS trg_trash_release_tags ON release_tags;
+DROP TRIGGER IF EXISTS trg_trash_release_aliases ON release_aliases;
+DROP TRIGGER IF EXISTS trg_trash_releases ON releases;
+DROP TRIGGER IF EXISTS trg_trash_hidden_artists ON hidden_artists;
+DROP TRIGGER IF EXISTS trg_trash_artist_relations ON artist_relations;
+DROP TRIGGER IF EXISTS trg_trash_artist_tags ON artist_tags;
+DROP TRIGGER IF EXISTS trg_trash_artist_tracks ON artist_tracks;
+DROP TRIGGER IF EXISTS trg_trash_artist_releases ON artist_releases;
+DROP TRIGGER IF EXISTS trg_trash_artist_aliases ON artist_aliases;
+DROP TRIGGER IF EXISTS trg_trash_artists ON artists;
+DROP FUNCTION IF EXISTS trash_removed_row();
+DROP TABLE IF EXISTS trash_rows;
+DROP TABLE IF EXISTS trash;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- the inc parameter a response was requested with, since the same entity requested with other includes
+-- has a different body. existing entries don't know theirs, so they are treated as stale
+ALTER TABLE mbz_response_cache ADD COLUMN includes text NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE mbz_response_cache DROP COLUMN IF EXISTS includes;
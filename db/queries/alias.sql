@@ -62,4 +62,40 @@ UPDATE track_aliases SET is_primary = $1 WHERE track_id = $2 AND alias = $3;
 DELETE FROM track_aliases 
 WHERE track_id = $1
 AND alias = $2
-AND is_primary = false;
\ No newline at end of file
+AND is_primary = false;
+
+-- name: GetArtistsWithoutRomanizedAlias :many
+SELECT a.id, a.name
+FROM artists_with_name a
+WHERE a.id > $1
+  AND a.name ~ '[^\x01-\x7F]'
+  AND NOT EXISTS (
+    SELECT 1 FROM artist_aliases al
+    WHERE al.artist_id = a.id AND al.source = 'Romanized'
+  )
+ORDER BY a.id
+LIMIT $2;
+
+-- name: GetReleasesWithoutRomanizedAlias :many
+SELECT r.id, r.title
+FROM releases_with_title r
+WHERE r.id > $1
+  AND r.title ~ '[^\x01-\x7F]'
+  AND NOT EXISTS (
+    SELECT 1 FROM release_aliases al
+    WHERE al.release_id = r.id AND al.source = 'Romanized'
+  )
+ORDER BY r.id
+LIMIT $2;
+
+-- name: GetTracksWithoutRomanizedAlias :many
+SELECT t.id, t.title
+FROM tracks_with_title t
+WHERE t.id > $1
+  AND t.title ~ '[^\x01-\x7F]'
+  AND NOT EXISTS (
+    SELECT 1 FROM track_aliases al
+    WHERE al.track_id = t.id AND al.source = 'Romanized'
+  )
+ORDER BY t.id
+LIMIT $2;
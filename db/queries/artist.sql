@@ -6,11 +6,13 @@ RETURNING *;
 -- name: GetArtist :one
 SELECT 
   a.*,
+  ai.sort_name, ai.artist_type, ai.gender, ai.area, ai.country, ai.begin_date, ai.end_date,
   array_agg(aa.alias)::text[] AS aliases
 FROM artists_with_name a
+JOIN artists ai ON ai.id = a.id
 LEFT JOIN artist_aliases aa ON a.id = aa.artist_id
 WHERE a.id = $1
-GROUP BY a.id, a.musicbrainz_id, a.image, a.image_source, a.name;
+GROUP BY a.id, a.musicbrainz_id, a.image, a.image_source, a.name, ai.sort_name, ai.artist_type, ai.gender, ai.area, ai.country, ai.begin_date, ai.end_date;
 
 -- name: GetTrackArtists :many
 SELECT 
@@ -27,34 +29,58 @@ SELECT * FROM artists WHERE image = $1 LIMIT 1;
 -- name: GetReleaseArtists :many
 SELECT 
   a.*,
-  ar.is_primary as is_primary
+  ar.is_primary as is_primary,
+  ar.is_album_artist as is_album_artist
 FROM artists_with_name a
 LEFT JOIN artist_releases ar ON a.id = ar.artist_id
 WHERE ar.release_id = $1
-GROUP BY a.id, a.musicbrainz_id, a.image, a.image_source, a.name, ar.is_primary;
+GROUP BY a.id, a.musicbrainz_id, a.image, a.image_source, a.name, ar.is_primary, ar.is_album_artist;
 
 -- name: GetArtistByName :one
 WITH artist_with_aliases AS (
   SELECT 
     a.*,
+    ai.sort_name, ai.artist_type, ai.gender, ai.area, ai.country, ai.begin_date, ai.end_date,
     COALESCE(array_agg(aa.alias), '{}')::text[] AS aliases
   FROM artists_with_name a
+  JOIN artists ai ON ai.id = a.id
   LEFT JOIN artist_aliases aa ON a.id = aa.artist_id
   WHERE a.id IN (
     SELECT aa2.artist_id FROM artist_aliases aa2 WHERE aa2.alias = $1
   )
-  GROUP BY a.id, a.musicbrainz_id, a.image, a.image_source, a.name
+  GROUP BY a.id, a.musicbrainz_id, a.image, a.image_source, a.name, ai.sort_name, ai.artist_type, ai.gender, ai.area, ai.country, ai.begin_date, ai.end_date
+)
+SELECT * FROM artist_with_aliases;
+
+-- name: GetArtistByMatchKey :one
+WITH artist_with_aliases AS (
+  SELECT 
+    a.*,
+    ai.sort_name, ai.artist_type, ai.gender, ai.area, ai.country, ai.begin_date, ai.end_date,
+    COALESCE(array_agg(aa.alias), '{}')::text[] AS aliases
+  FROM artists_with_name a
+  JOIN artists ai ON ai.id = a.id
+  LEFT JOIN artist_aliases aa ON a.id = aa.artist_id
+  WHERE a.id = (
+    SELECT aa2.artist_id FROM artist_aliases aa2
+    WHERE aa2.match_key = match_key($1)
+    ORDER BY aa2.is_primary DESC, aa2.artist_id
+    LIMIT 1
+  )
+  GROUP BY a.id, a.musicbrainz_id, a.image, a.image_source, a.name, ai.sort_name, ai.artist_type, ai.gender, ai.area, ai.country, ai.begin_date, ai.end_date
 )
 SELECT * FROM artist_with_aliases;
 
 -- name: GetArtistByMbzID :one
 SELECT 
   a.*,
+  ai.sort_name, ai.artist_type, ai.gender, ai.area, ai.country, ai.begin_date, ai.end_date,
   array_agg(aa.alias)::text[] AS aliases
 FROM artists_with_name a
+JOIN artists ai ON ai.id = a.id
 LEFT JOIN artist_aliases aa ON a.id = aa.artist_id
 WHERE a.musicbrainz_id = $1
-GROUP BY a.id, a.musicbrainz_id, a.image, a.image_source, a.name;
+GROUP BY a.id, a.musicbrainz_id, a.image, a.image_source, a.name, ai.sort_name, ai.artist_type, ai.gender, ai.area, ai.country, ai.begin_date, ai.end_date;
 
 -- name: GetTopArtistsPaginated :many
 SELECT
@@ -68,6 +94,7 @@ JOIN tracks t ON l.track_id = t.id
 JOIN artist_tracks at ON at.track_id = t.id
 JOIN artists_with_name a ON a.id = at.artist_id
 WHERE l.listened_at BETWEEN $1 AND $2
+  AND listen_visible(l.track_id, l.client, $5)
 GROUP BY a.id, a.name, a.musicbrainz_id, a.image, a.image_source, a.name
 ORDER BY listen_count DESC, a.id
 LIMIT $3 OFFSET $4;
@@ -76,12 +103,24 @@ LIMIT $3 OFFSET $4;
 SELECT COUNT(DISTINCT at.artist_id) AS total_count
 FROM listens l
 JOIN artist_tracks at ON l.track_id = at.track_id
-WHERE l.listened_at BETWEEN $1 AND $2;
+WHERE l.listened_at BETWEEN $1 AND $2
+  AND listen_visible(l.track_id, l.client, $3);
 
 -- name: UpdateArtistMbzID :exec
 UPDATE artists SET musicbrainz_id = $2
 WHERE id = $1;
 
+-- name: UpdateArtistInfo :exec
+UPDATE artists SET
+  sort_name = $2,
+  artist_type = $3,
+  gender = $4,
+  area = $5,
+  country = $6,
+  begin_date = $7,
+  end_date = $8
+WHERE id = $1;
+
 -- name: UpdateArtistImage :exec
 UPDATE artists SET image = $2, image_source = $3
 WHERE id = $1;
@@ -98,6 +137,28 @@ UPDATE artist_tracks
 SET artist_id = $2
 WHERE artist_id = $1;
 
+-- name: UpdateConflictingArtistTracks :exec
+UPDATE artist_tracks at
+SET credit_position = f.credit_position, credited_name = f.credited_name, join_phrase = f.join_phrase
+FROM artist_tracks f
+WHERE f.artist_id = $1
+  AND f.track_id = at.track_id
+  AND at.artist_id = $2
+  AND at.credit_position IS NULL
+  AND f.credit_position IS NOT NULL;
+
+-- name: UpdateConflictingArtistReleases :exec
+UPDATE artist_releases ar
+SET
+  is_album_artist = ar.is_album_artist OR f.is_album_artist,
+  credit_position = COALESCE(ar.credit_position, f.credit_position),
+  credited_name = CASE WHEN ar.credit_position IS NULL THEN f.credited_name ELSE ar.credited_name END,
+  join_phrase = CASE WHEN ar.credit_position IS NULL THEN f.join_phrase ELSE ar.join_phrase END
+FROM artist_releases f
+WHERE f.artist_id = $1
+  AND f.release_id = ar.release_id
+  AND ar.artist_id = $2;
+
 -- name: DeleteConflictingArtistReleases :exec
 DELETE FROM artist_releases ar
 WHERE ar.artist_id = $1
@@ -110,5 +171,66 @@ UPDATE artist_releases
 SET artist_id = $2
 WHERE artist_id = $1;
 
+-- name: DeleteArtistTrack :exec
+DELETE FROM artist_tracks
+WHERE artist_id = $1 AND track_id = $2;
+
+-- name: DeleteArtistRelease :exec
+DELETE FROM artist_releases
+WHERE artist_id = $1 AND release_id = $2;
+
+-- removes the credit of an artist on a release only if they are not one of its album artists
+-- name: DeleteNonAlbumArtistRelease :exec
+DELETE FROM artist_releases
+WHERE artist_id = $1 AND release_id = $2 AND NOT is_album_artist;
+
 -- name: DeleteArtist :exec
-DELETE FROM artists WHERE id = $1;
\ No newline at end of file
+DELETE FROM artists WHERE id = $1;
+
+-- name: GetTopArtistsByTagPaginated :many
+SELECT
+    a.id,
+    a.name,
+    a.musicbrainz_id,
+    a.image,
+    COUNT(*) AS listen_count
+FROM listens l
+JOIN tracks t ON l.track_id = t.id
+JOIN artist_tracks at ON at.track_id = t.id
+JOIN artists_with_name a ON a.id = at.artist_id
+WHERE l.listened_at BETWEEN $1 AND $2
+AND listen_visible(l.track_id, l.client, $6)
+AND l.track_id IN (
+    SELECT tti.track_id FROM track_tags_inherited tti
+    JOIN tags tg ON tg.id = tti.tag_id
+    WHERE tg.name = $3
+)
+GROUP BY a.id, a.name, a.musicbrainz_id, a.image, a.image_source, a.name
+ORDER BY listen_count DESC, a.id
+LIMIT $4 OFFSET $5;
+
+-- name: CountTopArtistsByTag :one
+SELECT COUNT(DISTINCT at.artist_id) AS total_count
+FROM listens l
+JOIN artist_tracks at ON l.track_id = at.track_id
+WHERE l.listened_at BETWEEN $1 AND $2
+AND listen_visible(l.track_id, l.client, $4)
+AND l.track_id IN (
+    SELECT tti.track_id FROM track_tags_inherited tti
+    JOIN tags tg ON tg.id = tti.tag_id
+    WHERE tg.name = $3
+);
+
+-- name: CountListensByArtistCountry :many
+SELECT
+  a.country::text AS country,
+  COUNT(DISTINCT (l.track_id, l.listened_at)) AS listen_count,
+  COUNT(DISTINCT a.id) AS artist_count
+FROM listens l
+JOIN artist_tracks at ON l.track_id = at.track_id
+JOIN artists a ON a.id = at.artist_id
+WHERE l.listened_at BETWEEN $1 AND $2
+  AND a.country IS NOT NULL
+  AND listen_visible(l.track_id, l.client, $3)
+GROUP BY a.country
+ORDER BY listen_count DESC, country;
@@ -0,0 +1,19 @@
+-- name: ClearTrackArtistCredit :exec
+UPDATE artist_tracks
+SET credit_position = NULL, credited_name = NULL, join_phrase = ''
+WHERE track_id = $1;
+
+-- name: ClearReleaseArtistCredit :exec
+UPDATE artist_releases
+SET credit_position = NULL, credited_name = NULL, join_phrase = ''
+WHERE release_id = $1;
+
+-- name: UpdateTrackArtistCredit :execrows
+UPDATE artist_tracks
+SET credit_position = $3, credited_name = $4, join_phrase = $5
+WHERE artist_id = $1 AND track_id = $2;
+
+-- name: UpdateReleaseArtistCredit :execrows
+UPDATE artist_releases
+SET credit_position = $3, credited_name = $4, join_phrase = $5
+WHERE artist_id = $1 AND release_id = $2;
@@ -0,0 +1,50 @@
+-- name: InsertArtistRelation :exec
+INSERT INTO artist_relations (artist_id, related_artist_id, relation, source)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteArtistRelation :execrows
+DELETE FROM artist_relations
+WHERE artist_id = $1 AND related_artist_id = $2 AND relation = $3;
+
+-- name: GetRelatedArtists :many
+SELECT
+  a.id,
+  a.name,
+  a.image,
+  r.relation,
+  false AS reverse,
+  r.source
+FROM artist_relations r
+JOIN artists_with_name a ON a.id = r.related_artist_id
+WHERE r.artist_id = $1
+UNION ALL
+SELECT
+  a.id,
+  a.name,
+  a.image,
+  r.relation,
+  true AS reverse,
+  r.source
+FROM artist_relations r
+JOIN artists_with_name a ON a.id = r.artist_id
+WHERE r.related_artist_id = $1
+ORDER BY relation, reverse, name;
+
+-- name: UpdateArtistRelations :exec
+UPDATE artist_relations r SET artist_id = @to_id::int
+WHERE r.artist_id = @from_id::int
+  AND r.related_artist_id <> @to_id::int
+  AND NOT EXISTS (
+    SELECT 1 FROM artist_relations x
+    WHERE x.artist_id = @to_id::int AND x.related_artist_id = r.related_artist_id AND x.relation = r.relation
+  );
+
+-- name: UpdateRelatedArtistRelations :exec
+UPDATE artist_relations r SET related_artist_id = @to_id::int
+WHERE r.related_artist_id = @from_id::int
+  AND r.artist_id <> @to_id::int
+  AND NOT EXISTS (
+    SELECT 1 FROM artist_relations x
+    WHERE x.related_artist_id = @to_id::int AND x.artist_id = r.artist_id AND x.relation = r.relation
+  );
@@ -0,0 +1,12 @@
+-- name: GetArtistSplitRules :many
+SELECT * FROM artist_split_rules
+ORDER BY kind, value;
+
+-- name: InsertArtistSplitRule :one
+INSERT INTO artist_split_rules (kind, value)
+VALUES ($1, $2)
+ON CONFLICT (kind, lower(value)) DO UPDATE SET value = EXCLUDED.value
+RETURNING *;
+
+-- name: DeleteArtistSplitRule :execrows
+DELETE FROM artist_split_rules WHERE id = $1;
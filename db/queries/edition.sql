@@ -0,0 +1,107 @@
+-- name: GetReleaseEdition :one
+SELECT * FROM release_editions WHERE release_id = $1;
+
+-- name: GetReleaseEditions :many
+SELECT r.id, r.title
+FROM release_editions e
+JOIN releases_with_title r ON r.id = e.release_id
+WHERE e.edition_of = @edition_of::int
+ORDER BY r.id;
+
+-- name: GetReleasesWithoutEdition :many
+SELECT
+  r.id,
+  r.title,
+  COALESCE((
+    SELECT ar.artist_id FROM artist_releases ar
+    WHERE ar.release_id = r.id
+    ORDER BY ar.is_album_artist DESC, ar.is_primary DESC, ar.artist_id
+    LIMIT 1
+  ), 0)::int AS artist_id
+FROM releases_with_title r
+WHERE r.id > $1
+  AND NOT EXISTS (SELECT 1 FROM release_editions e WHERE e.release_id = r.id)
+ORDER BY r.id
+LIMIT $2;
+
+-- name: InsertReleaseEdition :exec
+INSERT INTO release_editions (release_id, edition_of, source)
+VALUES ($1, $2, $3)
+ON CONFLICT (release_id) DO UPDATE
+SET edition_of = EXCLUDED.edition_of, source = EXCLUDED.source
+-- inferred grouping never overrides the user
+WHERE release_editions.source <> 'User' OR EXCLUDED.source = 'User';
+
+-- name: UpdateReleaseEditionsOf :exec
+UPDATE release_editions SET edition_of = @new_edition_of::int
+WHERE edition_of = @edition_of::int;
+
+-- name: DeleteReleaseEdition :exec
+DELETE FROM release_editions WHERE release_id = $1;
+
+-- name: CountListensFromReleaseEditions :one
+SELECT
+  COUNT(*) AS listen_count,
+  COALESCE(SUM(t.duration), 0)::BIGINT AS seconds_listened
+FROM listens l
+JOIN tracks t ON l.track_id = t.id
+LEFT JOIN release_editions e ON e.release_id = t.release_id
+WHERE l.listened_at BETWEEN $1 AND $2
+  AND (t.release_id = @release_id::int OR e.edition_of = @release_id::int)
+  AND listen_visible(l.track_id, l.client, @include_hidden::bool);
+
+-- name: GetTopReleaseGroupsPaginated :many
+SELECT
+  r.*,
+  g.listen_count,
+  get_artists_for_release(r.id) AS artists
+FROM (
+  SELECT COALESCE(e.edition_of, t.release_id) AS release_id, COUNT(*) AS listen_count
+  FROM listens l
+  JOIN tracks t ON l.track_id = t.id
+  LEFT JOIN release_editions e ON e.release_id = t.release_id
+  WHERE l.listened_at BETWEEN $1 AND $2
+    AND listen_visible(l.track_id, l.client, $5)
+  GROUP BY COALESCE(e.edition_of, t.release_id)
+) g
+JOIN releases_with_title r ON r.id = g.release_id
+ORDER BY g.listen_count DESC, r.id
+LIMIT $3 OFFSET $4;
+
+-- name: CountTopReleaseGroups :one
+SELECT COUNT(DISTINCT COALESCE(e.edition_of, t.release_id)) AS total_count
+FROM listens l
+JOIN tracks t ON l.track_id = t.id
+LEFT JOIN release_editions e ON e.release_id = t.release_id
+WHERE l.listened_at BETWEEN $1 AND $2
+  AND listen_visible(l.track_id, l.client, $3);
+
+-- name: GetTopReleaseGroupsFromArtist :many
+SELECT
+  r.*,
+  g.listen_count,
+  get_artists_for_release(r.id) AS artists
+FROM (
+  SELECT COALESCE(e.edition_of, t.release_id) AS release_id, COUNT(*) AS listen_count
+  FROM listens l
+  JOIN tracks t ON l.track_id = t.id
+  LEFT JOIN release_editions e ON e.release_id = t.release_id
+  WHERE EXISTS (
+      SELECT 1 FROM artist_releases ar
+      WHERE ar.release_id = t.release_id AND ar.is_album_artist AND ar.artist_id IN (SELECT artist_roll_up($5, $6))
+    )
+    AND l.listened_at BETWEEN $1 AND $2
+    AND listen_visible(l.track_id, l.client, $7)
+  GROUP BY COALESCE(e.edition_of, t.release_id)
+) g
+JOIN releases_with_title r ON r.id = g.release_id
+ORDER BY g.listen_count DESC, r.id
+LIMIT $3 OFFSET $4;
+
+-- name: CountReleaseGroupsFromArtist :one
+SELECT COUNT(DISTINCT COALESCE(e.edition_of, r.id))
+FROM releases r
+JOIN artist_releases ar ON r.id = ar.release_id
+LEFT JOIN release_editions e ON e.release_id = r.id
+WHERE ar.artist_id IN (SELECT artist_roll_up($1, $2)) AND ar.is_album_artist
+  AND release_visible(r.id, $3);
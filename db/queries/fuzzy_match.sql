@@ -0,0 +1,114 @@
+-- name: GetArtistFuzzyMatch :one
+SELECT
+  aa.artist_id AS id,
+  aa.alias AS name,
+  similarity(aa.alias, @name::text)::real AS score
+FROM artist_aliases aa
+WHERE similarity(aa.alias, @name::text) >= @min_score::real
+  AND NOT EXISTS (
+    SELECT 1 FROM fuzzy_matches f
+    WHERE f.item_type = 'artist' AND f.item_id = aa.artist_id AND f.name = @name::text AND f.rejected
+  )
+ORDER BY score DESC, aa.artist_id
+LIMIT 1;
+
+-- name: GetAlbumFuzzyMatch :one
+SELECT
+  ra.release_id AS id,
+  ra.alias AS name,
+  similarity(ra.alias, @name::text)::real AS score
+FROM release_aliases ra
+JOIN artist_releases ar ON ar.release_id = ra.release_id
+WHERE ar.artist_id = @artist_id::int AND ar.is_album_artist
+  AND similarity(ra.alias, @name::text) >= @min_score::real
+  AND NOT EXISTS (
+    SELECT 1 FROM fuzzy_matches f
+    WHERE f.item_type = 'album' AND f.item_id = ra.release_id AND f.name = @name::text AND f.rejected
+  )
+ORDER BY score DESC, ra.release_id
+LIMIT 1;
+
+-- name: GetTrackFuzzyMatch :one
+SELECT
+  ta.track_id AS id,
+  ta.alias AS name,
+  similarity(ta.alias, @name::text)::real AS score
+FROM track_aliases ta
+JOIN tracks t ON t.id = ta.track_id
+WHERE t.release_id = @release_id::int
+  AND EXISTS (
+    SELECT 1 FROM artist_tracks at
+    WHERE at.track_id = t.id AND at.artist_id = ANY(@artist_ids::int[])
+  )
+  AND similarity(ta.alias, @name::text) >= @min_score::real
+  AND NOT EXISTS (
+    SELECT 1 FROM fuzzy_matches f
+    WHERE f.item_type = 'track' AND f.item_id = ta.track_id AND f.name = @name::text AND f.rejected
+  )
+ORDER BY score DESC, ta.track_id
+LIMIT 1;
+
+-- name: InsertFuzzyMatch :one
+INSERT INTO fuzzy_matches (item_type, item_id, name, score)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (item_type, item_id, name) DO UPDATE SET score = EXCLUDED.score
+RETURNING id;
+
+-- name: InsertFuzzyMatchListen :exec
+INSERT INTO fuzzy_match_listens (fuzzy_match_id, track_id, listened_at)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING;
+
+-- name: GetFuzzyMatch :one
+SELECT * FROM fuzzy_matches WHERE id = $1;
+
+-- name: GetFuzzyMatchesPaginated :many
+SELECT
+  f.id,
+  f.item_type,
+  f.item_id,
+  COALESCE(a.name, r.title, t.title)::text AS item_name,
+  f.name,
+  f.score,
+  (SELECT COUNT(*) FROM fuzzy_match_listens fl WHERE fl.fuzzy_match_id = f.id) AS listen_count
+FROM fuzzy_matches f
+LEFT JOIN artists_with_name a ON f.item_type = 'artist' AND a.id = f.item_id
+LEFT JOIN releases_with_title r ON f.item_type = 'album' AND r.id = f.item_id
+LEFT JOIN tracks_with_title t ON f.item_type = 'track' AND t.id = f.item_id
+WHERE f.rejected = false
+  -- hide matches for items that have been deleted or merged in the meantime
+  AND COALESCE(a.id, r.id, t.id) IS NOT NULL
+ORDER BY f.score, f.id
+LIMIT $1 OFFSET $2;
+
+-- name: CountFuzzyMatches :one
+SELECT COUNT(*)
+FROM fuzzy_matches f
+LEFT JOIN artists_with_name a ON f.item_type = 'artist' AND a.id = f.item_id
+LEFT JOIN releases_with_title r ON f.item_type = 'album' AND r.id = f.item_id
+LEFT JOIN tracks_with_title t ON f.item_type = 'track' AND t.id = f.item_id
+WHERE f.rejected = false
+  AND COALESCE(a.id, r.id, t.id) IS NOT NULL;
+
+-- name: GetFuzzyMatchTrackIDs :many
+SELECT DISTINCT track_id
+FROM fuzzy_match_listens
+WHERE fuzzy_match_id = $1
+ORDER BY track_id;
+
+-- name: MoveFuzzyMatchListens :exec
+UPDATE listens l SET track_id = @new_track_id::int
+FROM fuzzy_match_listens fl
+WHERE fl.fuzzy_match_id = @fuzzy_match_id::int
+  AND fl.track_id = @track_id::int
+  AND l.track_id = fl.track_id
+  AND l.listened_at = fl.listened_at;
+
+-- name: RejectFuzzyMatch :exec
+UPDATE fuzzy_matches SET rejected = true WHERE id = $1;
+
+-- name: DeleteFuzzyMatchListens :exec
+DELETE FROM fuzzy_match_listens WHERE fuzzy_match_id = $1;
+
+-- name: DeleteFuzzyMatch :exec
+DELETE FROM fuzzy_matches WHERE id = $1;
@@ -0,0 +1,45 @@
+-- name: InsertHiddenArtist :exec
+INSERT INTO hidden_artists (artist_id) VALUES ($1)
+ON CONFLICT DO NOTHING;
+
+-- name: InsertHiddenRelease :exec
+INSERT INTO hidden_releases (release_id) VALUES ($1)
+ON CONFLICT DO NOTHING;
+
+-- name: InsertHiddenTrack :exec
+INSERT INTO hidden_tracks (track_id) VALUES ($1)
+ON CONFLICT DO NOTHING;
+
+-- name: InsertHiddenClient :exec
+INSERT INTO hidden_clients (client) VALUES ($1)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteHiddenArtist :execrows
+DELETE FROM hidden_artists WHERE artist_id = $1;
+
+-- name: DeleteHiddenRelease :execrows
+DELETE FROM hidden_releases WHERE release_id = $1;
+
+-- name: DeleteHiddenTrack :execrows
+DELETE FROM hidden_tracks WHERE track_id = $1;
+
+-- name: DeleteHiddenClient :execrows
+DELETE FROM hidden_clients WHERE client = $1;
+
+-- name: GetHiddenItems :many
+SELECT 'artist'::text AS item_type, a.id, a.name::text AS name
+FROM hidden_artists h
+JOIN artists_with_name a ON a.id = h.artist_id
+UNION ALL
+SELECT 'album'::text, r.id, r.title::text
+FROM hidden_releases h
+JOIN releases_with_title r ON r.id = h.release_id
+UNION ALL
+SELECT 'track'::text, t.id, t.title::text
+FROM hidden_tracks h
+JOIN tracks_with_title t ON t.id = h.track_id
+ORDER BY item_type, name;
+
+-- name: GetHiddenClients :many
+SELECT client FROM hidden_clients
+ORDER BY client;
@@ -0,0 +1,80 @@
+-- name: GetReleasesWithoutArtists :many
+SELECT
+  r.id,
+  COALESCE((SELECT ra.alias FROM release_aliases ra WHERE ra.release_id = r.id AND ra.is_primary LIMIT 1), '')::text AS title,
+  EXISTS (
+    SELECT 1 FROM tracks t JOIN artist_tracks at ON at.track_id = t.id WHERE t.release_id = r.id
+  ) AS repairable
+FROM releases r
+WHERE NOT EXISTS (SELECT 1 FROM artist_releases ar WHERE ar.release_id = r.id)
+ORDER BY r.id;
+
+-- name: CreditTrackArtistsOnReleasesWithoutArtists :exec
+INSERT INTO artist_releases (artist_id, release_id, is_album_artist)
+SELECT DISTINCT at.artist_id, t.release_id, true
+FROM tracks t
+JOIN artist_tracks at ON at.track_id = t.id
+WHERE NOT EXISTS (SELECT 1 FROM artist_releases ar WHERE ar.release_id = t.release_id)
+ON CONFLICT DO NOTHING;
+
+-- name: GetItemsWithoutPrimaryAlias :many
+SELECT 'artist'::text AS item_type, a.id,
+  COALESCE((SELECT x.alias FROM artist_aliases x WHERE x.artist_id = a.id ORDER BY x.source = 'Canonical' DESC, x.alias LIMIT 1), '')::text AS name,
+  EXISTS (SELECT 1 FROM artist_aliases x WHERE x.artist_id = a.id) AS repairable
+FROM artists a
+WHERE NOT EXISTS (SELECT 1 FROM artist_aliases x WHERE x.artist_id = a.id AND x.is_primary)
+UNION ALL
+SELECT 'album'::text AS item_type, r.id,
+  COALESCE((SELECT x.alias FROM release_aliases x WHERE x.release_id = r.id ORDER BY x.source = 'Canonical' DESC, x.alias LIMIT 1), '')::text AS name,
+  EXISTS (SELECT 1 FROM release_aliases x WHERE x.release_id = r.id) AS repairable
+FROM releases r
+WHERE NOT EXISTS (SELECT 1 FROM release_aliases x WHERE x.release_id = r.id AND x.is_primary)
+UNION ALL
+SELECT 'track'::text AS item_type, t.id,
+  COALESCE((SELECT x.alias FROM track_aliases x WHERE x.track_id = t.id ORDER BY x.source = 'Canonical' DESC, x.alias LIMIT 1), '')::text AS name,
+  EXISTS (SELECT 1 FROM track_aliases x WHERE x.track_id = t.id) AS repairable
+FROM tracks t
+WHERE NOT EXISTS (SELECT 1 FROM track_aliases x WHERE x.track_id = t.id AND x.is_primary)
+ORDER BY item_type, id;
+
+-- name: SetMissingPrimaryArtistAliases :exec
+UPDATE artist_aliases SET is_primary = true
+WHERE (artist_id, alias) IN (
+  SELECT DISTINCT ON (x.artist_id) x.artist_id, x.alias
+  FROM artist_aliases x
+  WHERE NOT EXISTS (SELECT 1 FROM artist_aliases y WHERE y.artist_id = x.artist_id AND y.is_primary)
+  ORDER BY x.artist_id, x.source = 'Canonical' DESC, x.alias
+);
+
+-- name: SetMissingPrimaryReleaseAliases :exec
+UPDATE release_aliases SET is_primary = true
+WHERE (release_id, alias) IN (
+  SELECT DISTINCT ON (x.release_id) x.release_id, x.alias
+  FROM release_aliases x
+  WHERE NOT EXISTS (SELECT 1 FROM release_aliases y WHERE y.release_id = x.release_id AND y.is_primary)
+  ORDER BY x.release_id, x.source = 'Canonical' DESC, x.alias
+);
+
+-- name: SetMissingPrimaryTrackAliases :exec
+UPDATE track_aliases SET is_primary = true
+WHERE (track_id, alias) IN (
+  SELECT DISTINCT ON (x.track_id) x.track_id, x.alias
+  FROM track_aliases x
+  WHERE NOT EXISTS (SELECT 1 FROM track_aliases y WHERE y.track_id = x.track_id AND y.is_primary)
+  ORDER BY x.track_id, x.source = 'Canonical' DESC, x.alias
+);
+
+-- name: GetArtistsWithoutTracks :many
+-- artists that are only credited on albums are not orphaned, as album artists do not need to perform on any track
+SELECT
+  a.id,
+  COALESCE((SELECT x.alias FROM artist_aliases x WHERE x.artist_id = a.id AND x.is_primary LIMIT 1), '')::text AS name
+FROM artists a
+WHERE NOT EXISTS (SELECT 1 FROM artist_tracks at WHERE at.artist_id = a.id)
+  AND NOT EXISTS (SELECT 1 FROM artist_releases ar WHERE ar.artist_id = a.id)
+ORDER BY a.id;
+
+-- name: DeleteArtistsWithoutTracks :exec
+DELETE FROM artists a
+WHERE NOT EXISTS (SELECT 1 FROM artist_tracks at WHERE at.artist_id = a.id)
+  AND NOT EXISTS (SELECT 1 FROM artist_releases ar WHERE ar.artist_id = a.id);
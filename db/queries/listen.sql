@@ -58,33 +58,38 @@ LIMIT $3 OFFSET $4;
 -- name: CountListens :one
 SELECT COUNT(*) AS total_count
 FROM listens l
-WHERE l.listened_at BETWEEN $1 AND $2;
+WHERE l.listened_at BETWEEN $1 AND $2
+  AND listen_visible(l.track_id, l.client, $3);
 
 -- name: CountListensFromTrack :one
 SELECT COUNT(*) AS total_count
 FROM listens l
 WHERE l.listened_at BETWEEN $1 AND $2
-  AND l.track_id = $3;
+  AND l.track_id = $3
+  AND listen_visible(l.track_id, l.client, $4);
 
 -- name: CountListensFromArtist :one
 SELECT COUNT(*) AS total_count
 FROM listens l
 JOIN artist_tracks at ON l.track_id = at.track_id
 WHERE l.listened_at BETWEEN $1 AND $2
-  AND at.artist_id = $3;
+  AND at.artist_id = $3
+  AND listen_visible(l.track_id, l.client, $4);
 
 -- name: CountListensFromRelease :one
 SELECT COUNT(*) AS total_count
 FROM listens l
 JOIN tracks t ON l.track_id = t.id
 WHERE l.listened_at BETWEEN $1 AND $2
-  AND t.release_id = $3;
+  AND t.release_id = $3
+  AND listen_visible(l.track_id, l.client, $4);
 
 -- name: CountTimeListened :one
 SELECT COALESCE(SUM(t.duration), 0)::BIGINT AS seconds_listened
 FROM listens l
 JOIN tracks t ON l.track_id = t.id
-WHERE l.listened_at BETWEEN $1 AND $2;
+WHERE l.listened_at BETWEEN $1 AND $2
+  AND listen_visible(l.track_id, l.client, $3);
 
 -- name: CountTimeListenedToArtist :one
 SELECT COALESCE(SUM(t.duration), 0)::BIGINT AS seconds_listened
@@ -92,21 +97,24 @@ FROM listens l
 JOIN tracks t ON l.track_id = t.id
 JOIN artist_tracks at ON t.id = at.track_id
 WHERE l.listened_at BETWEEN $1 AND $2
-  AND at.artist_id = $3;
+  AND at.artist_id = $3
+  AND listen_visible(l.track_id, l.client, $4);
 
 -- name: CountTimeListenedToRelease :one
 SELECT COALESCE(SUM(t.duration), 0)::BIGINT AS seconds_listened
 FROM listens l
 JOIN tracks t ON l.track_id = t.id
 WHERE l.listened_at BETWEEN $1 AND $2
-  AND t.release_id = $3;
+  AND t.release_id = $3
+  AND listen_visible(l.track_id, l.client, $4);
 
 -- name: CountTimeListenedToTrack :one
 SELECT COALESCE(SUM(t.duration), 0)::BIGINT AS seconds_listened
 FROM listens l
 JOIN tracks t ON l.track_id = t.id
 WHERE l.listened_at BETWEEN $1 AND $2
-  AND t.id = $3;
+  AND t.id = $3
+  AND listen_visible(l.track_id, l.client, $4);
 
 -- name: ListenActivity :many
 WITH buckets AS (
@@ -120,6 +128,7 @@ bucketed_listens AS (
   LEFT JOIN listens l
     ON l.listened_at >= b.bucket_start
     AND l.listened_at < b.bucket_start + $3::interval
+    AND listen_visible(l.track_id, l.client, $4)
   GROUP BY b.bucket_start
   ORDER BY b.bucket_start
 )
@@ -132,8 +141,11 @@ WITH buckets AS (
 filtered_listens AS (
   SELECT l.*
   FROM listens l
-  JOIN artist_tracks t ON l.track_id = t.track_id
-  WHERE t.artist_id = $4
+  WHERE EXISTS (
+    SELECT 1 FROM artist_tracks t
+    WHERE t.track_id = l.track_id AND t.artist_id IN (SELECT artist_roll_up($4, $5))
+  )
+  AND listen_visible(l.track_id, l.client, $6)
 ),
 bucketed_listens AS (
   SELECT
@@ -157,6 +169,7 @@ filtered_listens AS (
   FROM listens l
   JOIN tracks t ON l.track_id = t.id
   WHERE t.release_id = $4
+    AND listen_visible(l.track_id, l.client, $5)
 ),
 bucketed_listens AS (
   SELECT
@@ -180,6 +193,34 @@ filtered_listens AS (
   FROM listens l
   JOIN tracks t ON l.track_id = t.id
   WHERE t.id = $4
+    AND listen_visible(l.track_id, l.client, $5)
+),
+bucketed_listens AS (
+  SELECT
+    b.bucket_start,
+    COUNT(l.listened_at) AS listen_count
+  FROM buckets b
+  LEFT JOIN filtered_listens l
+    ON l.listened_at >= b.bucket_start
+    AND l.listened_at < b.bucket_start + $3::interval
+  GROUP BY b.bucket_start
+  ORDER BY b.bucket_start
+)
+SELECT * FROM bucketed_listens;
+
+-- name: ListenActivityForTag :many
+WITH buckets AS (
+  SELECT generate_series($1::timestamptz, $2::timestamptz, $3::interval) AS bucket_start
+),
+filtered_listens AS (
+  SELECT l.*
+  FROM listens l
+  WHERE l.track_id IN (
+    SELECT tti.track_id FROM track_tags_inherited tti
+    JOIN tags tg ON tg.id = tti.tag_id
+    WHERE tg.name = $4
+  )
+  AND listen_visible(l.track_id, l.client, $5)
 ),
 bucketed_listens AS (
   SELECT
@@ -237,13 +278,16 @@ SELECT
         WHERE ra.release_id = r.id
     ) AS release_aliases,
 
-    -- Artists
+    -- Artists, in credit order
     (
         SELECT json_agg(json_build_object(
             'id', a.id,
             'musicbrainz_id', a.musicbrainz_id,
             'image', a.image,
             'image_source', a.image_source,
+            'is_primary', at.is_primary,
+            'credited_name', at.credited_name,
+            'join_phrase', at.join_phrase,
             'aliases', (
                 SELECT json_agg(json_build_object(
                     'alias', aa.alias,
@@ -253,11 +297,36 @@ SELECT
                 FROM artist_aliases aa
                 WHERE aa.artist_id = a.id
             )
-        ))
+        ) ORDER BY at.credit_position NULLS LAST, at.is_primary DESC, a.id)
         FROM artist_tracks at
         JOIN artists a ON a.id = at.artist_id
         WHERE at.track_id = t.id
-    ) AS artists
+    ) AS artists,
+
+    -- Album artists, in credit order
+    (
+        SELECT json_agg(json_build_object(
+            'id', a.id,
+            'musicbrainz_id', a.musicbrainz_id,
+            'image', a.image,
+            'image_source', a.image_source,
+            'is_primary', ar.is_primary,
+            'credited_name', ar.credited_name,
+            'join_phrase', ar.join_phrase,
+            'aliases', (
+                SELECT json_agg(json_build_object(
+                    'alias', aa.alias,
+                    'source', aa.source,
+                    'is_primary', aa.is_primary
+                ))
+                FROM artist_aliases aa
+                WHERE aa.artist_id = a.id
+            )
+        ) ORDER BY ar.credit_position NULLS LAST, ar.is_primary DESC, a.id)
+        FROM artist_releases ar
+        JOIN artists a ON a.id = ar.artist_id
+        WHERE ar.release_id = r.id AND ar.is_album_artist
+    ) AS release_artists
 
 FROM listens l
 JOIN tracks t ON l.track_id = t.id
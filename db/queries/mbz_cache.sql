@@ -0,0 +1,18 @@
+-- name: GetMbzCacheEntry :one
+SELECT body FROM mbz_response_cache
+WHERE entity_type = $1 AND musicbrainz_id = $2 AND includes = $3 AND fetched_at > $4;
+
+-- name: SaveMbzCacheEntry :exec
+INSERT INTO mbz_response_cache (entity_type, musicbrainz_id, includes, body)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (entity_type, musicbrainz_id) DO UPDATE
+SET includes = EXCLUDED.includes, body = EXCLUDED.body, fetched_at = now();
+
+-- name: CountMbzCacheEntries :one
+SELECT COUNT(*) FROM mbz_response_cache;
+
+-- name: DeleteMbzCacheEntries :execrows
+DELETE FROM mbz_response_cache;
+
+-- name: DeleteMbzCacheEntriesForEntity :execrows
+DELETE FROM mbz_response_cache WHERE entity_type = $1;
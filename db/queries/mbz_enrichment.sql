@@ -0,0 +1,118 @@
+-- name: GetArtistsWithoutMbzID :many
+SELECT a.id, a.name
+FROM artists_with_name a
+WHERE a.musicbrainz_id IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM mbz_enrichment_attempts e
+    WHERE e.item_type = 'artist' AND e.item_id = a.id AND e.attempted_at > $1
+  )
+ORDER BY a.id
+LIMIT $2;
+
+-- name: GetAlbumsWithoutMbzID :many
+SELECT
+  r.id,
+  r.title,
+  r.various_artists,
+  COALESCE((
+    SELECT a.name FROM artist_releases ar
+    JOIN artists_with_name a ON a.id = ar.artist_id
+    WHERE ar.release_id = r.id
+    ORDER BY ar.is_primary DESC, a.id
+    LIMIT 1
+  ), '')::text AS artist_name
+FROM releases_with_title r
+WHERE r.musicbrainz_id IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM mbz_enrichment_attempts e
+    WHERE e.item_type = 'album' AND e.item_id = r.id AND e.attempted_at > $1
+  )
+ORDER BY r.id
+LIMIT $2;
+
+-- name: GetTracksWithoutMbzID :many
+SELECT
+  t.id,
+  t.title,
+  t.duration,
+  r.title AS album_title,
+  COALESCE((
+    SELECT a.name FROM artist_tracks at
+    JOIN artists_with_name a ON a.id = at.artist_id
+    WHERE at.track_id = t.id
+    ORDER BY at.is_primary DESC, a.id
+    LIMIT 1
+  ), '')::text AS artist_name
+FROM tracks_with_title t
+JOIN releases_with_title r ON r.id = t.release_id
+WHERE t.musicbrainz_id IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM mbz_enrichment_attempts e
+    WHERE e.item_type = 'track' AND e.item_id = t.id AND e.attempted_at > $1
+  )
+ORDER BY t.id
+LIMIT $2;
+
+-- name: UpsertMbzEnrichmentAttempt :exec
+INSERT INTO mbz_enrichment_attempts (item_type, item_id)
+VALUES ($1, $2)
+ON CONFLICT (item_type, item_id) DO UPDATE SET attempted_at = now();
+
+-- name: InsertMbzMatchSuggestion :exec
+INSERT INTO mbz_match_suggestions (item_type, item_id, musicbrainz_id, name, artist_name, score)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT DO NOTHING;
+
+-- name: GetMbzMatchSuggestion :one
+SELECT
+  s.id,
+  s.item_type,
+  s.item_id,
+  COALESCE(a.name, r.title, t.title, '')::text AS item_name,
+  s.musicbrainz_id,
+  s.name,
+  s.artist_name,
+  s.score
+FROM mbz_match_suggestions s
+LEFT JOIN artists_with_name a ON s.item_type = 'artist' AND a.id = s.item_id
+LEFT JOIN releases_with_title r ON s.item_type = 'album' AND r.id = s.item_id
+LEFT JOIN tracks_with_title t ON s.item_type = 'track' AND t.id = s.item_id
+WHERE s.id = $1;
+
+-- name: GetMbzMatchSuggestionsPaginated :many
+SELECT
+  s.id,
+  s.item_type,
+  s.item_id,
+  COALESCE(a.name, r.title, t.title)::text AS item_name,
+  s.musicbrainz_id,
+  s.name,
+  s.artist_name,
+  s.score
+FROM mbz_match_suggestions s
+LEFT JOIN artists_with_name a ON s.item_type = 'artist' AND a.id = s.item_id
+LEFT JOIN releases_with_title r ON s.item_type = 'album' AND r.id = s.item_id
+LEFT JOIN tracks_with_title t ON s.item_type = 'track' AND t.id = s.item_id
+WHERE s.dismissed = false
+  -- hide suggestions for items that have been deleted or matched in the meantime
+  AND COALESCE(a.id, r.id, t.id) IS NOT NULL
+  AND COALESCE(a.musicbrainz_id, r.musicbrainz_id, t.musicbrainz_id) IS NULL
+ORDER BY s.score DESC, s.id
+LIMIT $1 OFFSET $2;
+
+-- name: CountMbzMatchSuggestions :one
+SELECT COUNT(*)
+FROM mbz_match_suggestions s
+LEFT JOIN artists_with_name a ON s.item_type = 'artist' AND a.id = s.item_id
+LEFT JOIN releases_with_title r ON s.item_type = 'album' AND r.id = s.item_id
+LEFT JOIN tracks_with_title t ON s.item_type = 'track' AND t.id = s.item_id
+WHERE s.dismissed = false
+  AND COALESCE(a.id, r.id, t.id) IS NOT NULL
+  AND COALESCE(a.musicbrainz_id, r.musicbrainz_id, t.musicbrainz_id) IS NULL;
+
+-- name: DismissMbzMatchSuggestion :exec
+UPDATE mbz_match_suggestions SET dismissed = true WHERE id = $1;
+
+-- name: DeleteMbzMatchSuggestionsForItem :exec
+DELETE FROM mbz_match_suggestions
+WHERE item_type = $1 AND item_id = $2 AND dismissed = false;
@@ -0,0 +1,14 @@
+-- name: GetMbzLocalEntity :one
+SELECT body FROM mbz_local_entities
+WHERE entity_type = $1 AND musicbrainz_id = $2;
+
+-- name: GetMbzLocalReleasesForReleaseGroup :many
+SELECT body FROM mbz_local_entities
+WHERE entity_type = 'release' AND release_group_id = $1
+ORDER BY musicbrainz_id;
+
+-- name: SaveMbzLocalEntity :exec
+INSERT INTO mbz_local_entities (entity_type, musicbrainz_id, release_group_id, body)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (entity_type, musicbrainz_id) DO UPDATE
+SET release_group_id = EXCLUDED.release_group_id, body = EXCLUDED.body;
@@ -0,0 +1,197 @@
+-- name: GetArtistDuplicatePairs :many
+WITH pairs AS (
+  SELECT
+    a1.artist_id AS id_1,
+    a2.artist_id AS id_2,
+    MAX(similarity(a1.alias, a2.alias))::real AS similarity
+  FROM artist_aliases a1
+  JOIN artist_aliases a2 ON a1.alias % a2.alias AND a1.artist_id < a2.artist_id
+  GROUP BY a1.artist_id, a2.artist_id
+)
+SELECT
+  p.id_1,
+  p.id_2,
+  a1.name AS name_1,
+  a2.name AS name_2,
+  a1.musicbrainz_id AS mbz_id_1,
+  a2.musicbrainz_id AS mbz_id_2,
+  p.similarity,
+  (
+    SELECT COUNT(*) FROM (
+      SELECT LOWER(r.title) FROM artist_releases ar JOIN releases_with_title r ON r.id = ar.release_id WHERE ar.artist_id = p.id_1
+      INTERSECT
+      SELECT LOWER(r.title) FROM artist_releases ar JOIN releases_with_title r ON r.id = ar.release_id WHERE ar.artist_id = p.id_2
+    ) s
+  ) AS shared_albums,
+  (
+    SELECT COUNT(*) FROM (
+      SELECT LOWER(t.title) FROM artist_tracks at JOIN tracks_with_title t ON t.id = at.track_id WHERE at.artist_id = p.id_1
+      INTERSECT
+      SELECT LOWER(t.title) FROM artist_tracks at JOIN tracks_with_title t ON t.id = at.track_id WHERE at.artist_id = p.id_2
+    ) s
+  ) AS shared_tracks,
+  (SELECT COUNT(*) FROM listens l JOIN artist_tracks at ON at.track_id = l.track_id WHERE at.artist_id = p.id_1) AS listen_count_1,
+  (SELECT COUNT(*) FROM listens l JOIN artist_tracks at ON at.track_id = l.track_id WHERE at.artist_id = p.id_2) AS listen_count_2
+FROM pairs p
+JOIN artists_with_name a1 ON a1.id = p.id_1
+JOIN artists_with_name a2 ON a2.id = p.id_2
+WHERE p.similarity >= @min_similarity::real
+  AND (a1.musicbrainz_id IS NULL OR a2.musicbrainz_id IS NULL)
+  -- artists credited together on a track are collaborators, not duplicates
+  AND NOT EXISTS (
+    SELECT 1 FROM artist_tracks t1
+    JOIN artist_tracks t2 ON t1.track_id = t2.track_id
+    WHERE t1.artist_id = p.id_1 AND t2.artist_id = p.id_2
+  );
+
+-- name: GetAlbumDuplicatePairs :many
+WITH pairs AS (
+  SELECT
+    r1.release_id AS id_1,
+    r2.release_id AS id_2,
+    MAX(similarity(r1.alias, r2.alias))::real AS similarity
+  FROM release_aliases r1
+  JOIN release_aliases r2 ON r1.alias % r2.alias AND r1.release_id < r2.release_id
+  GROUP BY r1.release_id, r2.release_id
+), artist_sets AS (
+  SELECT release_id, array_agg(artist_id ORDER BY artist_id) AS artist_ids
+  FROM artist_releases
+  GROUP BY release_id
+)
+SELECT
+  p.id_1,
+  p.id_2,
+  r1.title AS name_1,
+  r2.title AS name_2,
+  r1.musicbrainz_id AS mbz_id_1,
+  r2.musicbrainz_id AS mbz_id_2,
+  p.similarity,
+  0::bigint AS shared_albums,
+  (
+    SELECT COUNT(*) FROM (
+      SELECT LOWER(t.title) FROM tracks_with_title t WHERE t.release_id = p.id_1
+      INTERSECT
+      SELECT LOWER(t.title) FROM tracks_with_title t WHERE t.release_id = p.id_2
+    ) s
+  ) AS shared_tracks,
+  (SELECT COUNT(*) FROM listens l JOIN tracks t ON t.id = l.track_id WHERE t.release_id = p.id_1) AS listen_count_1,
+  (SELECT COUNT(*) FROM listens l JOIN tracks t ON t.id = l.track_id WHERE t.release_id = p.id_2) AS listen_count_2
+FROM pairs p
+JOIN releases_with_title r1 ON r1.id = p.id_1
+JOIN releases_with_title r2 ON r2.id = p.id_2
+JOIN artist_sets s1 ON s1.release_id = p.id_1
+JOIN artist_sets s2 ON s2.release_id = p.id_2
+WHERE p.similarity >= @min_similarity::real
+  AND s1.artist_ids = s2.artist_ids
+  AND (r1.musicbrainz_id IS NULL OR r2.musicbrainz_id IS NULL);
+
+-- name: GetTrackDuplicatePairs :many
+WITH pairs AS (
+  SELECT
+    t1.track_id AS id_1,
+    t2.track_id AS id_2,
+    MAX(similarity(t1.alias, t2.alias))::real AS similarity
+  FROM track_aliases t1
+  JOIN track_aliases t2 ON t1.alias % t2.alias AND t1.track_id < t2.track_id
+  GROUP BY t1.track_id, t2.track_id
+), artist_sets AS (
+  SELECT track_id, array_agg(artist_id ORDER BY artist_id) AS artist_ids
+  FROM artist_tracks
+  GROUP BY track_id
+)
+SELECT
+  p.id_1,
+  p.id_2,
+  t1.title AS name_1,
+  t2.title AS name_2,
+  t1.musicbrainz_id AS mbz_id_1,
+  t2.musicbrainz_id AS mbz_id_2,
+  p.similarity,
+  (CASE WHEN t1.release_id = t2.release_id THEN 1 ELSE 0 END)::bigint AS shared_albums,
+  0::bigint AS shared_tracks,
+  (SELECT COUNT(*) FROM listens l WHERE l.track_id = p.id_1) AS listen_count_1,
+  (SELECT COUNT(*) FROM listens l WHERE l.track_id = p.id_2) AS listen_count_2
+FROM pairs p
+JOIN tracks_with_title t1 ON t1.id = p.id_1
+JOIN tracks_with_title t2 ON t2.id = p.id_2
+JOIN artist_sets s1 ON s1.track_id = p.id_1
+JOIN artist_sets s2 ON s2.track_id = p.id_2
+WHERE p.similarity >= @min_similarity::real
+  AND s1.artist_ids = s2.artist_ids
+  AND (t1.musicbrainz_id IS NULL OR t2.musicbrainz_id IS NULL);
+
+-- name: InsertMergeCandidate :exec
+INSERT INTO merge_candidates (item_type, from_id, to_id, score, reasons)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT DO NOTHING;
+
+-- name: GetMergeCandidate :one
+SELECT * FROM merge_candidates WHERE id = $1 LIMIT 1;
+
+-- name: GetArtistMergeCandidatesPaginated :many
+SELECT
+  mc.id, mc.from_id, mc.to_id, mc.score, mc.reasons,
+  f.name AS from_name,
+  t.name AS to_name
+FROM merge_candidates mc
+JOIN artists_with_name f ON f.id = mc.from_id
+JOIN artists_with_name t ON t.id = mc.to_id
+WHERE mc.item_type = 'artist' AND mc.dismissed = false
+ORDER BY mc.score DESC, mc.id
+LIMIT $1 OFFSET $2;
+
+-- name: CountArtistMergeCandidates :one
+SELECT COUNT(*)
+FROM merge_candidates mc
+JOIN artists_with_name f ON f.id = mc.from_id
+JOIN artists_with_name t ON t.id = mc.to_id
+WHERE mc.item_type = 'artist' AND mc.dismissed = false;
+
+-- name: GetAlbumMergeCandidatesPaginated :many
+SELECT
+  mc.id, mc.from_id, mc.to_id, mc.score, mc.reasons,
+  f.title AS from_name,
+  t.title AS to_name
+FROM merge_candidates mc
+JOIN releases_with_title f ON f.id = mc.from_id
+JOIN releases_with_title t ON t.id = mc.to_id
+WHERE mc.item_type = 'album' AND mc.dismissed = false
+ORDER BY mc.score DESC, mc.id
+LIMIT $1 OFFSET $2;
+
+-- name: CountAlbumMergeCandidates :one
+SELECT COUNT(*)
+FROM merge_candidates mc
+JOIN releases_with_title f ON f.id = mc.from_id
+JOIN releases_with_title t ON t.id = mc.to_id
+WHERE mc.item_type = 'album' AND mc.dismissed = false;
+
+-- name: GetTrackMergeCandidatesPaginated :many
+SELECT
+  mc.id, mc.from_id, mc.to_id, mc.score, mc.reasons,
+  f.title AS from_name,
+  t.title AS to_name
+FROM merge_candidates mc
+JOIN tracks_with_title f ON f.id = mc.from_id
+JOIN tracks_with_title t ON t.id = mc.to_id
+WHERE mc.item_type = 'track' AND mc.dismissed = false
+ORDER BY mc.score DESC, mc.id
+LIMIT $1 OFFSET $2;
+
+-- name: CountTrackMergeCandidates :one
+SELECT COUNT(*)
+FROM merge_candidates mc
+JOIN tracks_with_title f ON f.id = mc.from_id
+JOIN tracks_with_title t ON t.id = mc.to_id
+WHERE mc.item_type = 'track' AND mc.dismissed = false;
+
+-- name: DismissMergeCandidate :exec
+UPDATE merge_candidates SET dismissed = true
+WHERE id = $1;
+
+-- name: DeleteMergeCandidate :exec
+DELETE FROM merge_candidates WHERE id = $1;
+
+-- name: DeleteMergeCandidatesByType :exec
+DELETE FROM merge_candidates
+WHERE item_type = $1 AND dismissed = false;
@@ -0,0 +1,17 @@
+-- name: CountCatalogItems :one
+SELECT
+  (SELECT COUNT(*) FROM listens) AS listen_count,
+  (SELECT COUNT(*) FROM tracks) AS track_count,
+  (SELECT COUNT(*) FROM releases) AS album_count,
+  (SELECT COUNT(*) FROM artists) AS artist_count;
+
+-- images of artists and albums in the trash are still in use, as they are kept until the trash is purged.
+-- the trash entry being filled by the current transaction, if any, is left out
+-- name: GetUsedImages :many
+SELECT a.image FROM artists a WHERE a.image IS NOT NULL
+UNION
+SELECT r.image FROM releases r WHERE r.image IS NOT NULL
+UNION
+SELECT (t.row->>'image')::uuid FROM trash_rows t
+WHERE t.table_name IN ('artists', 'releases') AND t.row->>'image' IS NOT NULL
+  AND t.trash_id::text IS DISTINCT FROM current_setting('koito.trash_id', true);
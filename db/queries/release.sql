@@ -1,19 +1,39 @@
 -- name: InsertRelease :one
-INSERT INTO releases (musicbrainz_id, various_artists, image, image_source)
-VALUES ($1, $2, $3, $4)
+INSERT INTO releases (musicbrainz_id, various_artists, image, image_source, release_date, release_type, secondary_types, release_group_mbz_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 RETURNING *;
 
 -- name: GetRelease :one
 SELECT 
-  *,
-  get_artists_for_release(id) AS artists
-FROM releases_with_title
-WHERE id = $1 LIMIT 1;
+  r.*,
+  rel.release_group_mbz_id,
+  get_artists_for_release(r.id) AS artists
+FROM releases_with_title r
+JOIN releases rel ON rel.id = r.id
+WHERE r.id = $1 LIMIT 1;
 
 -- name: GetReleaseByMbzID :one
 SELECT * FROM releases_with_title
 WHERE musicbrainz_id = $1 LIMIT 1;
 
+-- name: GetReleaseByReleaseGroupMbzID :one
+SELECT r.*
+FROM releases_with_title r
+JOIN releases rel ON rel.id = r.id
+WHERE rel.release_group_mbz_id = $1
+ORDER BY r.id
+LIMIT 1;
+
+-- name: GetReleasesInReleaseGroup :many
+SELECT
+  r.*,
+  (SELECT COUNT(*) FROM listens l JOIN tracks t ON l.track_id = t.id WHERE t.release_id = r.id) AS listen_count,
+  get_artists_for_release(r.id) AS artists
+FROM releases_with_title r
+JOIN releases rel ON rel.id = r.id
+WHERE rel.release_group_mbz_id = $1
+ORDER BY r.release_date NULLS LAST, r.id;
+
 -- name: GetReleaseByImageID :one
 SELECT * FROM releases
 WHERE image = $1 LIMIT 1;
@@ -22,14 +42,26 @@ WHERE image = $1 LIMIT 1;
 SELECT r.*
 FROM releases_with_title r
 JOIN artist_releases ar ON r.id = ar.release_id
-WHERE r.title = $1 AND ar.artist_id = $2
+WHERE r.title = $1 AND ar.artist_id = $2 AND ar.is_album_artist
 LIMIT 1;
 
 -- name: GetReleaseByArtistAndTitles :one
 SELECT r.*
 FROM releases_with_title r
 JOIN artist_releases ar ON r.id = ar.release_id
-WHERE r.title = ANY ($1::TEXT[]) AND ar.artist_id = $2
+WHERE r.title = ANY ($1::TEXT[]) AND ar.artist_id = $2 AND ar.is_album_artist
+LIMIT 1;
+
+-- name: GetReleaseByArtistAndMatchKeys :one
+SELECT r.*
+FROM releases_with_title r
+JOIN artist_releases ar ON r.id = ar.release_id
+WHERE ar.artist_id = $2 AND ar.is_album_artist
+  AND r.id IN (
+    SELECT ra.release_id FROM release_aliases ra
+    WHERE ra.match_key IN (SELECT match_key(title) FROM unnest($1::TEXT[]) AS title)
+  )
+ORDER BY r.id
 LIMIT 1;
 
 -- name: GetTopReleasesFromArtist :many
@@ -40,10 +72,13 @@ SELECT
 FROM listens l
 JOIN tracks t ON l.track_id = t.id
 JOIN releases_with_title r ON t.release_id = r.id
-JOIN artist_releases ar ON r.id = ar.release_id
-WHERE ar.artist_id = $5
+WHERE EXISTS (
+    SELECT 1 FROM artist_releases ar
+    WHERE ar.release_id = r.id AND ar.is_album_artist AND ar.artist_id IN (SELECT artist_roll_up($5, $6))
+  )
   AND l.listened_at BETWEEN $1 AND $2
-GROUP BY r.id, r.title, r.musicbrainz_id, r.various_artists, r.image, r.image_source
+  AND listen_visible(l.track_id, l.client, $7)
+GROUP BY r.id, r.title, r.musicbrainz_id, r.various_artists, r.image, r.image_source, r.release_date, r.release_type, r.secondary_types
 ORDER BY listen_count DESC, r.id
 LIMIT $3 OFFSET $4;
 
@@ -56,7 +91,8 @@ FROM listens l
 JOIN tracks t ON l.track_id = t.id
 JOIN releases_with_title r ON t.release_id = r.id
 WHERE l.listened_at BETWEEN $1 AND $2
-GROUP BY r.id, r.title, r.musicbrainz_id, r.various_artists, r.image, r.image_source
+  AND listen_visible(l.track_id, l.client, $5)
+GROUP BY r.id, r.title, r.musicbrainz_id, r.various_artists, r.image, r.image_source, r.release_date, r.release_type, r.secondary_types
 ORDER BY listen_count DESC, r.id
 LIMIT $3 OFFSET $4;
 
@@ -65,18 +101,27 @@ SELECT COUNT(DISTINCT r.id) AS total_count
 FROM listens l
 JOIN tracks t ON l.track_id = t.id
 JOIN releases r ON t.release_id = r.id
-WHERE l.listened_at BETWEEN $1 AND $2;
+WHERE l.listened_at BETWEEN $1 AND $2
+  AND listen_visible(l.track_id, l.client, $3);
 
 -- name: CountReleasesFromArtist :one
-SELECT COUNT(*)
-FROM releases r 
+SELECT COUNT(DISTINCT r.id)
+FROM releases r
 JOIN artist_releases ar ON r.id = ar.release_id
-WHERE ar.artist_id = $1;
+WHERE ar.artist_id IN (SELECT artist_roll_up($1, $2)) AND ar.is_album_artist
+  AND release_visible(r.id, $3);
+
+-- name: CountArtistTracksInRelease :one
+SELECT COUNT(*)
+FROM tracks t
+JOIN artist_tracks at ON t.id = at.track_id
+WHERE at.artist_id = $1 AND t.release_id = $2;
 
 -- name: AssociateArtistToRelease :exec
-INSERT INTO artist_releases (artist_id, release_id)
-VALUES ($1, $2)
-ON CONFLICT DO NOTHING;
+INSERT INTO artist_releases (artist_id, release_id, is_album_artist)
+VALUES ($1, $2, $3)
+ON CONFLICT (artist_id, release_id) DO UPDATE
+SET is_album_artist = artist_releases.is_album_artist OR EXCLUDED.is_album_artist;
 
 -- name: GetReleasesWithoutImages :many
 SELECT
@@ -92,10 +137,18 @@ LIMIT $1;
 UPDATE releases SET musicbrainz_id = $2
 WHERE id = $1;
 
+-- name: UpdateReleaseGroupMbzID :exec
+UPDATE releases SET release_group_mbz_id = $2
+WHERE id = $1;
+
 -- name: UpdateReleaseVariousArtists :exec
 UPDATE releases SET various_artists = $2
 WHERE id = $1;
 
+-- name: UpdateReleaseInfo :exec
+UPDATE releases SET release_date = $2, release_type = $3, secondary_types = $4
+WHERE id = $1;
+
 -- name: UpdateReleasePrimaryArtist :exec
 UPDATE artist_releases SET is_primary = $3
 WHERE artist_id = $1 AND release_id = $2;
@@ -111,4 +164,62 @@ DELETE FROM releases WHERE id = $1;
 DELETE FROM releases r
 USING artist_releases ar
 WHERE ar.release_id = r.id
-  AND ar.artist_id = $1;
\ No newline at end of file
+  AND ar.artist_id = $1;
+
+-- name: GetTopReleasesByTagPaginated :many
+SELECT
+  r.id, r.musicbrainz_id, r.image, r.various_artists, r.image_source, r.title,
+  r.release_date, r.release_type, r.secondary_types,
+  COUNT(*) AS listen_count,
+  get_artists_for_release(r.id) AS artists
+FROM listens l
+JOIN tracks t ON l.track_id = t.id
+JOIN releases_with_title r ON t.release_id = r.id
+WHERE l.listened_at BETWEEN $1 AND $2
+AND listen_visible(l.track_id, l.client, $6)
+AND l.track_id IN (
+    SELECT tti.track_id FROM track_tags_inherited tti
+    JOIN tags tg ON tg.id = tti.tag_id
+    WHERE tg.name = $3
+)
+GROUP BY r.id, r.title, r.musicbrainz_id, r.various_artists, r.image, r.image_source, r.release_date, r.release_type, r.secondary_types
+ORDER BY listen_count DESC, r.id
+LIMIT $4 OFFSET $5;
+
+-- name: CountTopReleasesByTag :one
+SELECT COUNT(DISTINCT t.release_id) AS total_count
+FROM listens l
+JOIN tracks t ON l.track_id = t.id
+WHERE l.listened_at BETWEEN $1 AND $2
+AND listen_visible(l.track_id, l.client, $4)
+AND l.track_id IN (
+    SELECT tti.track_id FROM track_tags_inherited tti
+    JOIN tags tg ON tg.id = tti.tag_id
+    WHERE tg.name = $3
+);
+
+-- name: CountListensByReleaseYear :many
+SELECT
+  (LEFT(r.release_date, 4)::int / $3::int * $3::int)::int AS year,
+  COUNT(*) AS listen_count
+FROM listens l
+JOIN tracks t ON l.track_id = t.id
+JOIN releases r ON t.release_id = r.id
+WHERE l.listened_at BETWEEN $1 AND $2
+  AND r.release_date IS NOT NULL
+  AND listen_visible(l.track_id, l.client, $4)
+GROUP BY year
+ORDER BY year;
+
+-- name: CountListensByReleaseType :many
+SELECT
+  (CASE WHEN 'Compilation' = ANY(r.secondary_types) THEN 'Compilation' ELSE r.release_type END)::text AS release_type,
+  COUNT(*) AS listen_count
+FROM listens l
+JOIN tracks t ON l.track_id = t.id
+JOIN releases r ON t.release_id = r.id
+WHERE l.listened_at BETWEEN $1 AND $2
+  AND r.release_type IS NOT NULL
+  AND listen_visible(l.track_id, l.client, $3)
+GROUP BY 1
+ORDER BY listen_count DESC, release_type;
@@ -0,0 +1,57 @@
+-- name: InsertReleaseTrack :exec
+INSERT INTO release_tracks (release_id, disc_number, position, title, duration, musicbrainz_id)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: DeleteReleaseTracks :exec
+DELETE FROM release_tracks WHERE release_id = $1;
+
+-- name: LinkReleaseTracks :exec
+-- tracklist entries are linked to the track on the album with the same recording, or otherwise a matching title
+UPDATE release_tracks rt SET track_id = (
+  SELECT t.id FROM tracks t
+  WHERE t.release_id = rt.release_id
+    AND (t.musicbrainz_id = rt.musicbrainz_id OR EXISTS (
+      SELECT 1 FROM track_aliases ta WHERE ta.track_id = t.id AND ta.match_key = match_key(rt.title)
+    ))
+  ORDER BY COALESCE(t.musicbrainz_id = rt.musicbrainz_id, false) DESC, t.id
+  LIMIT 1
+)
+WHERE rt.release_id = $1;
+
+-- name: GetReleaseTracklist :many
+SELECT
+  rt.disc_number,
+  rt.position,
+  rt.title,
+  rt.duration,
+  rt.musicbrainz_id,
+  rt.track_id,
+  COUNT(l.track_id) AS listen_count
+FROM release_tracks rt
+LEFT JOIN listens l ON l.track_id = rt.track_id
+WHERE rt.release_id = $1
+GROUP BY rt.release_id, rt.disc_number, rt.position
+ORDER BY rt.disc_number, rt.position;
+
+-- name: GetReleaseCompletion :one
+SELECT
+  COUNT(*) AS track_count,
+  COUNT(*) FILTER (WHERE EXISTS (SELECT 1 FROM listens l WHERE l.track_id = rt.track_id)) AS heard_count
+FROM release_tracks rt
+WHERE rt.release_id = $1;
+
+-- name: CountCompletedReleases :one
+-- albums with a tracklist that have had every track listened to in the period
+SELECT COUNT(*)
+FROM releases r
+WHERE EXISTS (SELECT 1 FROM release_tracks rt WHERE rt.release_id = r.id)
+  AND release_visible(r.id, $3)
+  AND NOT EXISTS (
+    SELECT 1 FROM release_tracks rt
+    WHERE rt.release_id = r.id
+      AND NOT EXISTS (
+        SELECT 1 FROM listens l
+        WHERE l.track_id = rt.track_id AND l.listened_at BETWEEN $1 AND $2
+          AND listen_visible(l.track_id, l.client, $3)
+      )
+  );
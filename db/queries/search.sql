@@ -10,7 +10,8 @@ FROM (
         ROW_NUMBER() OVER (PARTITION BY a.id ORDER BY similarity(aa.alias, $1) DESC) AS rn
     FROM artist_aliases aa
     JOIN artists_with_name a ON aa.artist_id = a.id
-    WHERE similarity(aa.alias, $1) > 0.22
+    WHERE artist_visible(a.id, $3)
+      AND similarity(aa.alias, $1) > 0.22
 ) ranked
 WHERE rn = 1
 ORDER BY score DESC
@@ -28,7 +29,8 @@ FROM (
         ROW_NUMBER() OVER (PARTITION BY a.id ORDER BY aa.alias) AS rn
     FROM artist_aliases aa
     JOIN artists_with_name a ON aa.artist_id = a.id
-    WHERE aa.alias ILIKE $1 || '%'
+    WHERE artist_visible(a.id, $3)
+      AND aa.alias ILIKE $1 || '%'
 ) ranked
 WHERE rn = 1
 ORDER BY score DESC
@@ -55,7 +57,8 @@ FROM (
     FROM track_aliases ta
     JOIN tracks_with_title t ON ta.track_id = t.id
     JOIN releases r ON t.release_id = r.id
-    WHERE similarity(ta.alias, $1) > 0.22
+    WHERE track_visible(t.id, $3)
+      AND similarity(ta.alias, $1) > 0.22
 ) ranked
 WHERE rn = 1
 ORDER BY score DESC, title
@@ -82,7 +85,8 @@ FROM (
     FROM track_aliases ta
     JOIN tracks_with_title t ON ta.track_id = t.id
     JOIN releases r ON t.release_id = r.id
-    WHERE ta.alias ILIKE $1 || '%'
+    WHERE track_visible(t.id, $3)
+      AND ta.alias ILIKE $1 || '%'
 ) ranked
 WHERE rn = 1
 ORDER BY score DESC, title
@@ -108,7 +112,8 @@ FROM (
         ROW_NUMBER() OVER (PARTITION BY r.id ORDER BY similarity(ra.alias, $1) DESC) AS rn
     FROM release_aliases ra
     JOIN releases_with_title r ON ra.release_id = r.id
-    WHERE similarity(ra.alias, $1) > 0.22
+    WHERE release_visible(r.id, $3)
+      AND similarity(ra.alias, $1) > 0.22
 ) ranked
 WHERE rn = 1
 ORDER BY score DESC, title
@@ -134,7 +139,8 @@ FROM (
         ROW_NUMBER() OVER (PARTITION BY r.id ORDER BY ra.alias) AS rn
     FROM release_aliases ra
     JOIN releases_with_title r ON ra.release_id = r.id
-    WHERE ra.alias ILIKE $1 || '%'
+    WHERE release_visible(r.id, $3)
+      AND ra.alias ILIKE $1 || '%'
 ) ranked
 WHERE rn = 1
 ORDER BY score DESC, title
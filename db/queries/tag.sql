@@ -0,0 +1,136 @@
+-- name: InsertTag :one
+INSERT INTO tags (name)
+VALUES ($1)
+ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+RETURNING id;
+
+-- name: InsertArtistTag :exec
+INSERT INTO artist_tags (artist_id, tag_id, source)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING;
+
+-- name: InsertReleaseTag :exec
+INSERT INTO release_tags (release_id, tag_id, source)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING;
+
+-- name: InsertTrackTag :exec
+INSERT INTO track_tags (track_id, tag_id, source)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING;
+
+-- name: GetArtistTags :many
+SELECT tg.id, tg.name, at.source
+FROM artist_tags at
+JOIN tags tg ON tg.id = at.tag_id
+WHERE at.artist_id = $1
+ORDER BY tg.name;
+
+-- name: GetReleaseTags :many
+SELECT tg.id, tg.name, rt.source
+FROM release_tags rt
+JOIN tags tg ON tg.id = rt.tag_id
+WHERE rt.release_id = $1
+ORDER BY tg.name;
+
+-- name: GetTrackTags :many
+SELECT tg.id, tg.name, tt.source
+FROM track_tags tt
+JOIN tags tg ON tg.id = tt.tag_id
+WHERE tt.track_id = $1
+ORDER BY tg.name;
+
+-- name: DeleteArtistTag :exec
+DELETE FROM artist_tags at
+USING tags tg
+WHERE tg.id = at.tag_id AND at.artist_id = $1 AND tg.name = $2;
+
+-- name: DeleteReleaseTag :exec
+DELETE FROM release_tags rt
+USING tags tg
+WHERE tg.id = rt.tag_id AND rt.release_id = $1 AND tg.name = $2;
+
+-- name: DeleteTrackTag :exec
+DELETE FROM track_tags tt
+USING tags tg
+WHERE tg.id = tt.tag_id AND tt.track_id = $1 AND tg.name = $2;
+
+-- name: CopyArtistTags :exec
+INSERT INTO artist_tags (artist_id, tag_id, source)
+SELECT @to_id::int, tag_id, source FROM artist_tags WHERE artist_id = @from_id::int
+ON CONFLICT DO NOTHING;
+
+-- name: CopyReleaseTags :exec
+INSERT INTO release_tags (release_id, tag_id, source)
+SELECT @to_id::int, tag_id, source FROM release_tags WHERE release_id = @from_id::int
+ON CONFLICT DO NOTHING;
+
+-- name: CopyTrackTags :exec
+INSERT INTO track_tags (track_id, tag_id, source)
+SELECT @to_id::int, tag_id, source FROM track_tags WHERE track_id = @from_id::int
+ON CONFLICT DO NOTHING;
+
+-- name: GetTopTagsPaginated :many
+SELECT
+    tg.id,
+    tg.name,
+    COUNT(*) AS listen_count
+FROM listens l
+JOIN track_tags_inherited tti ON tti.track_id = l.track_id
+JOIN tags tg ON tg.id = tti.tag_id
+WHERE l.listened_at BETWEEN $1 AND $2
+  AND listen_visible(l.track_id, l.client, $5)
+GROUP BY tg.id, tg.name
+ORDER BY listen_count DESC, tg.id
+LIMIT $3 OFFSET $4;
+
+-- name: CountTopTags :one
+SELECT COUNT(DISTINCT tti.tag_id) AS total_count
+FROM listens l
+JOIN track_tags_inherited tti ON tti.track_id = l.track_id
+WHERE l.listened_at BETWEEN $1 AND $2
+  AND listen_visible(l.track_id, l.client, $3);
+
+-- name: DeleteOrphanedTags :exec
+DELETE FROM tags tg
+WHERE NOT EXISTS (SELECT 1 FROM artist_tags WHERE tag_id = tg.id)
+  AND NOT EXISTS (SELECT 1 FROM release_tags WHERE tag_id = tg.id)
+  AND NOT EXISTS (SELECT 1 FROM track_tags WHERE tag_id = tg.id);
+
+-- name: GetArtistsForMbzTagFetch :many
+SELECT a.id, a.musicbrainz_id
+FROM artists a
+WHERE a.musicbrainz_id IS NOT NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM mbz_tag_fetches f
+    WHERE f.item_type = 'artist' AND f.item_id = a.id AND f.fetched_at > $1
+  )
+ORDER BY a.id
+LIMIT $2;
+
+-- name: GetAlbumsForMbzTagFetch :many
+SELECT r.id, r.musicbrainz_id
+FROM releases r
+WHERE r.musicbrainz_id IS NOT NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM mbz_tag_fetches f
+    WHERE f.item_type = 'album' AND f.item_id = r.id AND f.fetched_at > $1
+  )
+ORDER BY r.id
+LIMIT $2;
+
+-- name: GetTracksForMbzTagFetch :many
+SELECT t.id, t.musicbrainz_id
+FROM tracks t
+WHERE t.musicbrainz_id IS NOT NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM mbz_tag_fetches f
+    WHERE f.item_type = 'track' AND f.item_id = t.id AND f.fetched_at > $1
+  )
+ORDER BY t.id
+LIMIT $2;
+
+-- name: UpsertMbzTagFetch :exec
+INSERT INTO mbz_tag_fetches (item_type, item_id)
+VALUES ($1, $2)
+ON CONFLICT (item_type, item_id) DO UPDATE SET fetched_at = now();
@@ -36,6 +36,19 @@ WHERE t.title = $1
 GROUP BY t.id, t.title, t.musicbrainz_id, t.duration, t.release_id
 HAVING COUNT(DISTINCT at.artist_id) = cardinality($2::int[]);
 
+-- name: GetTrackByMatchKeyAndArtists :one
+SELECT t.*
+FROM tracks_with_title t
+JOIN artist_tracks at ON at.track_id = t.id
+WHERE t.id IN (
+    SELECT ta.track_id FROM track_aliases ta WHERE ta.match_key = match_key($1)
+  )
+  AND at.artist_id = ANY($2::int[])
+GROUP BY t.id, t.title, t.musicbrainz_id, t.duration, t.release_id
+HAVING COUNT(DISTINCT at.artist_id) = cardinality($2::int[])
+ORDER BY t.id
+LIMIT 1;
+
 -- name: GetTopTracksPaginated :many
 SELECT
     t.id,
@@ -49,6 +62,7 @@ FROM listens l
 JOIN tracks_with_title t ON l.track_id = t.id
 JOIN releases r ON t.release_id = r.id
 WHERE l.listened_at BETWEEN $1 AND $2
+  AND listen_visible(l.track_id, l.client, $5)
 GROUP BY t.id, t.title, t.musicbrainz_id, t.release_id, r.image
 ORDER BY listen_count DESC, t.id
 LIMIT $3 OFFSET $4;
@@ -65,9 +79,12 @@ SELECT
 FROM listens l
 JOIN tracks_with_title t ON l.track_id = t.id
 JOIN releases r ON t.release_id = r.id
-JOIN artist_tracks at ON at.track_id = t.id
 WHERE l.listened_at BETWEEN $1 AND $2
-  AND at.artist_id = $5
+  AND listen_visible(l.track_id, l.client, $7)
+  AND EXISTS (
+    SELECT 1 FROM artist_tracks at
+    WHERE at.track_id = t.id AND at.artist_id IN (SELECT artist_roll_up($5, $6))
+  )
 GROUP BY t.id, t.title, t.musicbrainz_id, t.release_id, r.image
 ORDER BY listen_count DESC, t.id
 LIMIT $3 OFFSET $4;
@@ -86,6 +103,7 @@ JOIN tracks_with_title t ON l.track_id = t.id
 JOIN releases r ON t.release_id = r.id
 WHERE l.listened_at BETWEEN $1 AND $2
   AND t.release_id = $5
+  AND listen_visible(l.track_id, l.client, $6)
 GROUP BY t.id, t.title, t.musicbrainz_id, t.release_id, r.image
 ORDER BY listen_count DESC, t.id
 LIMIT $3 OFFSET $4;
@@ -93,21 +111,24 @@ LIMIT $3 OFFSET $4;
 -- name: CountTopTracks :one
 SELECT COUNT(DISTINCT l.track_id) AS total_count
 FROM listens l
-WHERE l.listened_at BETWEEN $1 AND $2;
+WHERE l.listened_at BETWEEN $1 AND $2
+  AND listen_visible(l.track_id, l.client, $3);
 
 -- name: CountTopTracksByArtist :one
 SELECT COUNT(DISTINCT l.track_id) AS total_count
 FROM listens l
 JOIN artist_tracks at ON l.track_id = at.track_id
 WHERE l.listened_at BETWEEN $1 AND $2
-AND at.artist_id = $3;
+AND at.artist_id IN (SELECT artist_roll_up($3, $4))
+AND listen_visible(l.track_id, l.client, $5);
 
 -- name: CountTopTracksByRelease :one
 SELECT COUNT(DISTINCT l.track_id) AS total_count
 FROM listens l
 JOIN tracks t ON l.track_id = t.id
 WHERE l.listened_at BETWEEN $1 AND $2
-AND t.release_id = $3;
+AND t.release_id = $3
+AND listen_visible(l.track_id, l.client, $4);
 
 -- name: UpdateTrackMbzID :exec
 UPDATE tracks SET musicbrainz_id = $2
@@ -121,9 +142,47 @@ WHERE id = $1;
 UPDATE tracks SET release_id = $2
 WHERE release_id = $1;
 
+-- name: UpdateTrackRelease :exec
+UPDATE tracks SET release_id = $2
+WHERE id = $1;
+
 -- name: UpdateTrackPrimaryArtist :exec
 UPDATE artist_tracks SET is_primary = $3
 WHERE artist_id = $1 AND track_id = $2;
 
 -- name: DeleteTrack :exec
-DELETE FROM tracks WHERE id = $1;
\ No newline at end of file
+DELETE FROM tracks WHERE id = $1;
+
+-- name: GetTopTracksByTagPaginated :many
+SELECT
+    t.id,
+    t.title,
+    t.musicbrainz_id,
+    t.release_id,
+    r.image,
+    COUNT(*) AS listen_count,
+    get_artists_for_track(t.id) AS artists
+FROM listens l
+JOIN tracks_with_title t ON l.track_id = t.id
+JOIN releases r ON t.release_id = r.id
+WHERE l.listened_at BETWEEN $1 AND $2
+AND listen_visible(l.track_id, l.client, $6)
+AND l.track_id IN (
+    SELECT tti.track_id FROM track_tags_inherited tti
+    JOIN tags tg ON tg.id = tti.tag_id
+    WHERE tg.name = $3
+)
+GROUP BY t.id, t.title, t.musicbrainz_id, t.release_id, r.image
+ORDER BY listen_count DESC, t.id
+LIMIT $4 OFFSET $5;
+
+-- name: CountTopTracksByTag :one
+SELECT COUNT(DISTINCT l.track_id) AS total_count
+FROM listens l
+WHERE l.listened_at BETWEEN $1 AND $2
+AND listen_visible(l.track_id, l.client, $4)
+AND l.track_id IN (
+    SELECT tti.track_id FROM track_tags_inherited tti
+    JOIN tags tg ON tg.id = tti.tag_id
+    WHERE tg.name = $3
+);
@@ -0,0 +1,50 @@
+-- name: InsertTrash :one
+INSERT INTO trash (item_type, item_id, name)
+VALUES ($1, $2, $3)
+RETURNING id;
+
+-- name: SetCurrentTrash :exec
+-- rows removed in the rest of the transaction are copied to this trash entry
+SELECT set_config('koito.trash_id', (@trash_id::int)::text, true);
+
+-- name: InsertTrashTags :exec
+-- keeps the tags of the removed tag links, so they can be restored if they are cleaned up as orphans
+INSERT INTO trash_rows (trash_id, table_name, row)
+SELECT $1, 'tags', to_jsonb(tg)
+FROM tags tg
+WHERE tg.id IN (
+  SELECT (r.row->>'tag_id')::int FROM trash_rows r
+  WHERE r.trash_id = $1 AND r.table_name IN ('artist_tags', 'release_tags', 'track_tags')
+);
+
+-- name: GetTrash :many
+SELECT
+  t.id,
+  t.item_type,
+  t.item_id,
+  t.name,
+  t.deleted_at,
+  (SELECT COUNT(*) FROM trash_rows r WHERE r.trash_id = t.id AND r.table_name = 'listens') AS listen_count,
+  (SELECT COUNT(*) FROM trash_rows r WHERE r.trash_id = t.id AND r.table_name = 'tracks') AS track_count,
+  (SELECT COUNT(*) FROM trash_rows r WHERE r.trash_id = t.id AND r.table_name = 'releases') AS album_count,
+  (SELECT COUNT(*) FROM trash_rows r WHERE r.trash_id = t.id AND r.table_name = 'artists') AS artist_count
+FROM trash t
+ORDER BY t.deleted_at DESC, t.id DESC;
+
+-- name: GetTrashEntry :one
+SELECT * FROM trash WHERE id = $1;
+
+-- name: RestoreTrash :exec
+SELECT restore_trash($1);
+
+-- name: DeleteTrash :execrows
+DELETE FROM trash WHERE id = $1;
+
+-- name: DeleteTrashBefore :execrows
+DELETE FROM trash WHERE deleted_at < $1;
+
+-- name: TrashHasImage :one
+SELECT EXISTS (
+  SELECT 1 FROM trash_rows r
+  WHERE r.table_name IN ('artists', 'releases') AND r.row->>'image' = @image::text
+);
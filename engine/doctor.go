@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/gabehf/koito/internal/catalog"
+	"github.com/gabehf/koito/internal/cfg"
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/db/psql"
+	"github.com/gabehf/koito/internal/logger"
+)
+
+// Doctor checks the catalog for inconsistencies and writes a report of them to w. args are the
+// command line arguments after "doctor": -repair repairs the issues that can be repaired safely,
+// -json writes the report as JSON, and any other arguments are the checks to run.
+func Doctor(
+	getenv func(string) string,
+	w io.Writer,
+	version string,
+	args []string,
+) error {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	fs.SetOutput(w)
+	repair := fs.Bool("repair", false, "repair the issues that can be repaired safely")
+	asJSON := fs.Bool("json", false, "write the report as JSON")
+	err := fs.Parse(args)
+	if err != nil {
+		return fmt.Errorf("Doctor: %w", err)
+	}
+	checks := make([]db.IntegrityCheck, 0, fs.NArg())
+	for _, c := range fs.Args() {
+		checks = append(checks, db.IntegrityCheck(c))
+	}
+
+	err = cfg.Load(getenv, version)
+	if err != nil {
+		return fmt.Errorf("Doctor: %w", err)
+	}
+
+	l := logger.Get()
+	setLogOutput(l, w)
+	ctx := logger.NewContext(l)
+
+	l.Info().Msgf("Koito %s", version)
+
+	store, err := psql.New()
+	if err != nil {
+		return fmt.Errorf("Doctor: %w", err)
+	}
+	defer store.Close(ctx)
+
+	report, err := catalog.CheckIntegrity(ctx, store, catalog.CheckIntegrityOpts{
+		Checks: checks,
+		Repair: *repair,
+	})
+	if err != nil {
+		return fmt.Errorf("Doctor: %w", err)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	for _, c := range report.Checks {
+		fmt.Fprintf(w, "%s: %d issues, %d repaired\n", c.Check, len(c.Issues), c.Repaired)
+		for _, issue := range c.Issues {
+			note := ""
+			if !issue.Repairable {
+				note = " (cannot be repaired automatically)"
+			}
+			if issue.Type != "" {
+				fmt.Fprintf(w, "  %s %d %q%s\n", issue.Type, issue.ID, issue.Name, note)
+			} else {
+				fmt.Fprintf(w, "  %s%s\n", issue.Name, note)
+			}
+		}
+	}
+	fmt.Fprintf(w, "%d issues found, %d repaired\n", report.IssueCount, report.Repaired)
+	return nil
+}
@@ -43,19 +43,7 @@ func Run(
 
 	l.Debug().Msg("Engine: Starting application initialization")
 
-	if cfg.StructuredLogging() {
-		l.Debug().Msg("Engine: Enabling structured logging")
-		*l = l.Output(w)
-	} else {
-		l.Debug().Msg("Engine: Enabling console logging")
-		*l = l.Output(zerolog.ConsoleWriter{
-			Out:        w,
-			TimeFormat: time.RFC3339,
-			FormatMessage: func(i interface{}) string {
-				return fmt.Sprintf("\u001b[30;1m>\u001b[0m %s |", i)
-			},
-		})
-	}
+	setLogOutput(l, w)
 
 	ctx := logger.NewContext(l)
 
@@ -97,8 +85,11 @@ func Run(
 
 	l.Debug().Msg("Engine: Initializing MusicBrainz client")
 	var mbzC mbz.MusicBrainzCaller
-	if !cfg.MusicBrainzDisabled() {
-		mbzC = mbz.NewMusicBrainzClient()
+	if cfg.LocalMusicBrainzEnabled() {
+		mbzC = mbz.NewMbzLocalCaller(store)
+		l.Info().Msg("Engine: Using local MusicBrainz data")
+	} else if !cfg.MusicBrainzDisabled() {
+		mbzC = mbz.NewMusicBrainzClient(store)
 		l.Info().Msg("Engine: MusicBrainz client initialized")
 	} else {
 		mbzC = &mbz.MbzErrorCaller{}
@@ -201,6 +192,42 @@ func Run(
 	l.Info().Msg("Engine: Pruning orphaned images")
 	go catalog.PruneOrphanedImages(logger.NewContext(l), store)
 
+	if !cfg.DuplicateDetectionDisabled() {
+		l.Info().Msg("Engine: Scheduling duplicate detection")
+		go scheduleJob(logger.NewContext(l), "duplicate detection", 24*time.Hour, func(ctx context.Context) error {
+			return catalog.FindDuplicates(ctx, store)
+		})
+	}
+
+	if !cfg.MusicBrainzDisabled() && !cfg.LocalMusicBrainzEnabled() && !cfg.MusicBrainzEnrichmentDisabled() {
+		l.Info().Msg("Engine: Scheduling MusicBrainz enrichment")
+		go scheduleJob(logger.NewContext(l), "musicbrainz enrichment", 24*time.Hour, func(ctx context.Context) error {
+			return catalog.EnrichMetadata(ctx, store, mbzC)
+		})
+	}
+
+	if !cfg.MusicBrainzDisabled() {
+		l.Info().Msg("Engine: Scheduling MusicBrainz tag fetching")
+		go scheduleJob(logger.NewContext(l), "musicbrainz tags", 24*time.Hour, func(ctx context.Context) error {
+			return catalog.FetchMbzTags(ctx, store, mbzC)
+		})
+	}
+
+	l.Info().Msg("Engine: Scheduling alias romanization")
+	go scheduleJob(logger.NewContext(l), "alias romanization", 24*time.Hour, func(ctx context.Context) error {
+		return catalog.RomanizeAliases(ctx, store)
+	})
+
+	l.Info().Msg("Engine: Scheduling album edition grouping")
+	go scheduleJob(logger.NewContext(l), "album edition grouping", 24*time.Hour, func(ctx context.Context) error {
+		return catalog.GroupAlbumEditions(ctx, store)
+	})
+
+	l.Info().Msg("Engine: Scheduling trash purge")
+	go scheduleJob(logger.NewContext(l), "trash purge", 24*time.Hour, func(ctx context.Context) error {
+		return catalog.PurgeTrash(ctx, store)
+	})
+
 	l.Info().Msg("Engine: Initialization finished")
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
@@ -219,6 +246,22 @@ func Run(
 	return nil
 }
 
+func setLogOutput(l *zerolog.Logger, w io.Writer) {
+	if cfg.StructuredLogging() {
+		l.Debug().Msg("Engine: Enabling structured logging")
+		*l = l.Output(w)
+	} else {
+		l.Debug().Msg("Engine: Enabling console logging")
+		*l = l.Output(zerolog.ConsoleWriter{
+			Out:        w,
+			TimeFormat: time.RFC3339,
+			FormatMessage: func(i interface{}) string {
+				return fmt.Sprintf("\u001b[30;1m>\u001b[0m %s |", i)
+			},
+		})
+	}
+}
+
 func RunImporter(l *zerolog.Logger, store db.DB, mbzc mbz.MusicBrainzCaller) {
 	l.Debug().Msg("Checking for import files...")
 	files, err := os.ReadDir(path.Join(cfg.ConfigDir(), "import"))
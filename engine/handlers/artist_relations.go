@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+// artistRelationFromForm reads the artist_id, related_artist_id, and relation form values of a
+// request, writing an error response and returning false if any of them is invalid.
+func artistRelationFromForm(w http.ResponseWriter, r *http.Request, handler string) (int32, int32, db.ArtistRelationType, bool) {
+	l := logger.FromContext(r.Context())
+
+	err := r.ParseForm()
+	if err != nil {
+		l.Debug().AnErr("error", err).Msgf("%s: Failed to parse form", handler)
+		utils.WriteError(w, "invalid request body", http.StatusBadRequest)
+		return 0, 0, "", false
+	}
+
+	artistID, err := strconv.Atoi(r.FormValue("artist_id"))
+	if err != nil {
+		l.Debug().AnErr("error", err).Msgf("%s: Invalid artist id", handler)
+		utils.WriteError(w, "invalid artist_id", http.StatusBadRequest)
+		return 0, 0, "", false
+	}
+	relatedArtistID, err := strconv.Atoi(r.FormValue("related_artist_id"))
+	if err != nil {
+		l.Debug().AnErr("error", err).Msgf("%s: Invalid related artist id", handler)
+		utils.WriteError(w, "invalid related_artist_id", http.StatusBadRequest)
+		return 0, 0, "", false
+	}
+	if artistID == relatedArtistID {
+		l.Debug().Msgf("%s: Artist cannot be related to itself", handler)
+		utils.WriteError(w, "artist_id and related_artist_id must be different", http.StatusBadRequest)
+		return 0, 0, "", false
+	}
+	relation := db.ArtistRelationType(r.FormValue("relation"))
+	if relation != db.ArtistRelationMemberOf && relation != db.ArtistRelationPerformsAs {
+		l.Debug().Msgf("%s: Invalid relation '%s'", handler, relation)
+		utils.WriteError(w, "relation must be one of 'member_of' or 'performs_as'", http.StatusBadRequest)
+		return 0, 0, "", false
+	}
+	return int32(artistID), int32(relatedArtistID), relation, true
+}
+
+// CreateArtistRelationHandler relates two artists, read as "artist_id <relation> related_artist_id",
+// e.g. a person that is a member_of a group.
+func CreateArtistRelationHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("CreateArtistRelationHandler: Got request")
+
+		artistID, relatedArtistID, relation, ok := artistRelationFromForm(w, r, "CreateArtistRelationHandler")
+		if !ok {
+			return
+		}
+
+		for _, id := range []int32{artistID, relatedArtistID} {
+			_, err := store.GetArtist(ctx, db.GetArtistOpts{ID: id})
+			if err != nil {
+				l.Debug().AnErr("error", err).Msgf("CreateArtistRelationHandler: Artist %d not found", id)
+				utils.WriteError(w, "artist not found", http.StatusNotFound)
+				return
+			}
+		}
+
+		err := store.SaveArtistRelation(ctx, db.SaveArtistRelationOpts{
+			ArtistID:        artistID,
+			RelatedArtistID: relatedArtistID,
+			Relation:        relation,
+			Source:          "Manual",
+		})
+		if err != nil {
+			l.Err(err).Msg("CreateArtistRelationHandler: Failed to save artist relation")
+			utils.WriteError(w, "failed to save artist relation", http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msgf("CreateArtistRelationHandler: Related artist %d as %s artist %d", artistID, relation, relatedArtistID)
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+func DeleteArtistRelationHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("DeleteArtistRelationHandler: Got request")
+
+		artistID, relatedArtistID, relation, ok := artistRelationFromForm(w, r, "DeleteArtistRelationHandler")
+		if !ok {
+			return
+		}
+
+		err := store.DeleteArtistRelation(ctx, db.DeleteArtistRelationOpts{
+			ArtistID:        artistID,
+			RelatedArtistID: relatedArtistID,
+			Relation:        relation,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			l.Debug().Msg("DeleteArtistRelationHandler: Artist relation not found")
+			utils.WriteError(w, "artist relation not found", http.StatusNotFound)
+			return
+		} else if err != nil {
+			l.Err(err).Msg("DeleteArtistRelationHandler: Failed to delete artist relation")
+			utils.WriteError(w, "failed to delete artist relation", http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msgf("DeleteArtistRelationHandler: Deleted relation of artist %d as %s artist %d", artistID, relation, relatedArtistID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
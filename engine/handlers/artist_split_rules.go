@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+func GetArtistSplitRulesHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("GetArtistSplitRulesHandler: Received request to retrieve artist split rules")
+
+		rules, err := store.GetArtistSplitRules(ctx)
+		if err != nil {
+			l.Err(err).Msg("GetArtistSplitRulesHandler: Failed to retrieve artist split rules")
+			utils.WriteError(w, "failed to get artist split rules", http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msg("GetArtistSplitRulesHandler: Successfully retrieved artist split rules")
+		utils.WriteJSON(w, http.StatusOK, rules)
+	}
+}
+
+// CreateArtistSplitRuleHandler saves a custom separator (kind=separator) or an artist name that
+// is never split (kind=protected).
+func CreateArtistSplitRuleHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("CreateArtistSplitRuleHandler: Got request")
+
+		err := r.ParseForm()
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("CreateArtistSplitRuleHandler: Failed to parse form")
+			utils.WriteError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		kind := db.ArtistSplitRuleKind(r.FormValue("kind"))
+		if kind != db.ArtistSplitRuleSeparator && kind != db.ArtistSplitRuleProtected {
+			l.Debug().Msgf("CreateArtistSplitRuleHandler: Invalid kind '%s'", kind)
+			utils.WriteError(w, "kind must be one of 'separator' or 'protected'", http.StatusBadRequest)
+			return
+		}
+		value := r.FormValue("value")
+		if value == "" {
+			l.Debug().Msg("CreateArtistSplitRuleHandler: Value parameter missing")
+			utils.WriteError(w, "value must be provided", http.StatusBadRequest)
+			return
+		}
+
+		rule, err := store.SaveArtistSplitRule(ctx, db.SaveArtistSplitRuleOpts{
+			Kind:  kind,
+			Value: value,
+		})
+		if err != nil {
+			l.Err(err).Msg("CreateArtistSplitRuleHandler: Failed to save artist split rule")
+			utils.WriteError(w, "failed to save artist split rule", http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msgf("CreateArtistSplitRuleHandler: Saved %s rule '%s'", rule.Kind, rule.Value)
+		utils.WriteJSON(w, http.StatusCreated, rule)
+	}
+}
+
+func DeleteArtistSplitRuleHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("DeleteArtistSplitRuleHandler: Got request")
+
+		id, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("DeleteArtistSplitRuleHandler: Invalid id parameter")
+			utils.WriteError(w, "id is invalid", http.StatusBadRequest)
+			return
+		}
+
+		err = store.DeleteArtistSplitRule(ctx, int32(id))
+		if errors.Is(err, pgx.ErrNoRows) {
+			l.Debug().Msgf("DeleteArtistSplitRuleHandler: Artist split rule %d not found", id)
+			utils.WriteError(w, "artist split rule not found", http.StatusNotFound)
+			return
+		} else if err != nil {
+			l.Err(err).Msg("DeleteArtistSplitRuleHandler: Failed to delete artist split rule")
+			utils.WriteError(w, "failed to delete artist split rule", http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msgf("DeleteArtistSplitRuleHandler: Successfully deleted artist split rule %d", id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
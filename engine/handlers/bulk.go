@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gabehf/koito/internal/catalog"
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/utils"
+)
+
+type BulkOperationsRequest struct {
+	Operations []catalog.BulkOperation `json:"operations"`
+}
+
+// BulkOperationsHandler runs a list of merge, delete, alias, and hide operations in a single
+// transaction. When any operation fails, none of the changes are kept, and the report says which
+// operation failed and why.
+func BulkOperationsHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("BulkOperationsHandler: Received request to run bulk operations")
+
+		var req BulkOperationsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			l.Debug().Err(err).Msg("BulkOperationsHandler: Failed to decode request")
+			utils.WriteError(w, "failed to decode request", http.StatusBadRequest)
+			return
+		}
+		if len(req.Operations) == 0 {
+			l.Debug().Msg("BulkOperationsHandler: No operations in request")
+			utils.WriteError(w, "operations are required", http.StatusBadRequest)
+			return
+		}
+
+		report, err := catalog.RunBulkOperations(ctx, store, req.Operations)
+		if errors.Is(err, catalog.ErrInvalidBulkOperation) {
+			l.Debug().Err(err).Msg("BulkOperationsHandler: Invalid operation in request")
+			utils.WriteError(w, err.Error(), http.StatusBadRequest)
+			return
+		} else if err != nil {
+			l.Err(err).Msg("BulkOperationsHandler: Failed to run bulk operations")
+			utils.WriteError(w, "failed to run bulk operations", http.StatusInternalServerError)
+			return
+		}
+
+		if !report.Committed {
+			l.Debug().Msg("BulkOperationsHandler: Bulk operations were rolled back")
+			utils.WriteJSON(w, http.StatusConflict, report)
+			return
+		}
+
+		l.Debug().Msgf("BulkOperationsHandler: Ran %d bulk operations", len(req.Operations))
+		utils.WriteJSON(w, http.StatusOK, report)
+	}
+}
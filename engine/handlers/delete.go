@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -8,6 +9,7 @@ import (
 	"github.com/gabehf/koito/internal/db"
 	"github.com/gabehf/koito/internal/logger"
 	"github.com/gabehf/koito/internal/utils"
+	"github.com/jackc/pgx/v5"
 )
 
 func DeleteTrackHandler(store db.DB) http.HandlerFunc {
@@ -34,7 +36,11 @@ func DeleteTrackHandler(store db.DB) http.HandlerFunc {
 		l.Debug().Msgf("DeleteTrackHandler: Deleting track with ID %d", trackID)
 
 		err = store.DeleteTrack(ctx, int32(trackID))
-		if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			l.Debug().Msgf("DeleteTrackHandler: Track %d not found", trackID)
+			utils.WriteError(w, "track not found", http.StatusNotFound)
+			return
+		} else if err != nil {
 			l.Err(err).Msg("DeleteTrackHandler: Failed to delete track")
 			utils.WriteError(w, "failed to delete track", http.StatusInternalServerError)
 			return
@@ -118,7 +124,11 @@ func DeleteArtistHandler(store db.DB) http.HandlerFunc {
 		l.Debug().Msgf("DeleteArtistHandler: Deleting artist with ID %d", artistID)
 
 		err = store.DeleteArtist(ctx, int32(artistID))
-		if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			l.Debug().Msgf("DeleteArtistHandler: Artist %d not found", artistID)
+			utils.WriteError(w, "artist not found", http.StatusNotFound)
+			return
+		} else if err != nil {
 			l.Err(err).Msg("DeleteArtistHandler: Failed to delete artist")
 			utils.WriteError(w, "failed to delete artist", http.StatusInternalServerError)
 			return
@@ -153,7 +163,11 @@ func DeleteAlbumHandler(store db.DB) http.HandlerFunc {
 		l.Debug().Msgf("DeleteAlbumHandler: Deleting album with ID %d", albumID)
 
 		err = store.DeleteAlbum(ctx, int32(albumID))
-		if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			l.Debug().Msgf("DeleteAlbumHandler: Album %d not found", albumID)
+			utils.WriteError(w, "album not found", http.StatusNotFound)
+			return
+		} else if err != nil {
 			l.Err(err).Msg("DeleteAlbumHandler: Failed to delete album")
 			utils.WriteError(w, "failed to delete album", http.StatusInternalServerError)
 			return
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/utils"
+)
+
+// SetAlbumEditionHandler marks an album as an edition of another album, or as not being an edition
+// when edition_of is 0. This overrides the editions that are detected automatically.
+func SetAlbumEditionHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("SetAlbumEditionHandler: Received request")
+
+		id, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("SetAlbumEditionHandler: Invalid id parameter")
+			utils.WriteError(w, "id is invalid", http.StatusBadRequest)
+			return
+		}
+		editionOf, err := strconv.Atoi(r.URL.Query().Get("edition_of"))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("SetAlbumEditionHandler: Invalid edition_of parameter")
+			utils.WriteError(w, "edition_of is invalid", http.StatusBadRequest)
+			return
+		}
+		if id == editionOf {
+			utils.WriteError(w, "an album cannot be an edition of itself", http.StatusBadRequest)
+			return
+		}
+
+		for _, albumId := range []int{id, editionOf} {
+			if albumId == 0 {
+				continue
+			}
+			_, err = store.GetAlbum(ctx, db.GetAlbumOpts{ID: int32(albumId)})
+			if err != nil {
+				l.Debug().AnErr("error", err).Msgf("SetAlbumEditionHandler: Failed to get album with id %d", albumId)
+				utils.WriteError(w, "album with specified id could not be found", http.StatusNotFound)
+				return
+			}
+		}
+
+		err = store.SetAlbumEdition(ctx, db.SetAlbumEditionOpts{
+			ID:        int32(id),
+			EditionOf: int32(editionOf),
+			Source:    db.InformationSourceUserProvided,
+		})
+		if err != nil {
+			l.Err(err).Msg("SetAlbumEditionHandler: Failed to set album edition")
+			utils.WriteError(w, "failed to set album edition", http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msgf("SetAlbumEditionHandler: Successfully set album %d as edition of %d", id, editionOf)
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// DeleteAlbumEditionHandler removes the edition information of an album, so that it is grouped
+// automatically again.
+func DeleteAlbumEditionHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("DeleteAlbumEditionHandler: Received request")
+
+		id, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("DeleteAlbumEditionHandler: Invalid id parameter")
+			utils.WriteError(w, "id is invalid", http.StatusBadRequest)
+			return
+		}
+
+		err = store.DeleteAlbumEdition(ctx, int32(id))
+		if err != nil {
+			l.Err(err).Msg("DeleteAlbumEditionHandler: Failed to delete album edition")
+			utils.WriteError(w, "failed to delete album edition", http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msgf("DeleteAlbumEditionHandler: Successfully deleted edition of album %d", id)
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/utils"
+)
+
+func GetFuzzyMatchesHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("GetFuzzyMatchesHandler: Received request to retrieve fuzzy matches pending review")
+
+		opts := OptsFromRequest(r)
+		matches, err := store.GetFuzzyMatches(ctx, db.GetFuzzyMatchesOpts{
+			Limit: opts.Limit,
+			Page:  opts.Page,
+		})
+		if err != nil {
+			l.Err(err).Msg("GetFuzzyMatchesHandler: Failed to retrieve fuzzy matches")
+			utils.WriteError(w, "failed to get fuzzy matches", http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msg("GetFuzzyMatchesHandler: Successfully retrieved fuzzy matches")
+		utils.WriteJSON(w, http.StatusOK, matches)
+	}
+}
+
+func ConfirmFuzzyMatchHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("ConfirmFuzzyMatchHandler: Received request to confirm fuzzy match")
+
+		id, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("ConfirmFuzzyMatchHandler: Invalid id parameter")
+			utils.WriteError(w, "id is invalid", http.StatusBadRequest)
+			return
+		}
+
+		_, err = store.GetFuzzyMatch(ctx, int32(id))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("ConfirmFuzzyMatchHandler: Fuzzy match not found")
+			utils.WriteError(w, "fuzzy match not found", http.StatusNotFound)
+			return
+		}
+
+		err = store.ConfirmFuzzyMatch(ctx, int32(id))
+		if err != nil {
+			l.Err(err).Msg("ConfirmFuzzyMatchHandler: Failed to confirm fuzzy match")
+			utils.WriteError(w, "failed to confirm fuzzy match: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msgf("ConfirmFuzzyMatchHandler: Successfully confirmed fuzzy match %d", id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// RejectFuzzyMatchHandler rejects a fuzzy match, splitting the listens that were linked through it
+// back out into a new item with the submitted name.
+func RejectFuzzyMatchHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("RejectFuzzyMatchHandler: Received request to reject fuzzy match")
+
+		id, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("RejectFuzzyMatchHandler: Invalid id parameter")
+			utils.WriteError(w, "id is invalid", http.StatusBadRequest)
+			return
+		}
+
+		_, err = store.GetFuzzyMatch(ctx, int32(id))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("RejectFuzzyMatchHandler: Fuzzy match not found")
+			utils.WriteError(w, "fuzzy match not found", http.StatusNotFound)
+			return
+		}
+
+		err = store.RejectFuzzyMatch(ctx, int32(id))
+		if err != nil {
+			l.Err(err).Msg("RejectFuzzyMatchHandler: Failed to reject fuzzy match")
+			utils.WriteError(w, "failed to reject fuzzy match: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msgf("RejectFuzzyMatchHandler: Successfully rejected fuzzy match %d", id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
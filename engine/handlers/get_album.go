@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gabehf/koito/internal/db"
 	"github.com/gabehf/koito/internal/logger"
@@ -32,7 +33,11 @@ func GetAlbumHandler(store db.DB) func(w http.ResponseWriter, r *http.Request) {
 
 		l.Debug().Msgf("GetAlbumHandler: Retrieving album with ID %d", id)
 
-		album, err := store.GetAlbum(ctx, db.GetAlbumOpts{ID: int32(id)})
+		album, err := store.GetAlbum(ctx, db.GetAlbumOpts{
+			ID:            int32(id),
+			GroupEditions: strings.ToLower(r.URL.Query().Get("group_editions")) == "true",
+			IncludeHidden: includeHiddenFromRequest(r),
+		})
 		if err != nil {
 			l.Err(err).Msgf("GetAlbumHandler: Failed to retrieve album with ID %d", id)
 			utils.WriteError(w, "album with specified id could not be found", http.StatusNotFound)
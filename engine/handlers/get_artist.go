@@ -32,13 +32,20 @@ func GetArtistHandler(store db.DB) func(w http.ResponseWriter, r *http.Request)
 
 		l.Debug().Msgf("GetArtistHandler: Retrieving artist with ID %d", id)
 
-		artist, err := store.GetArtist(ctx, db.GetArtistOpts{ID: int32(id)})
+		artist, err := store.GetArtist(ctx, db.GetArtistOpts{ID: int32(id), IncludeHidden: includeHiddenFromRequest(r)})
 		if err != nil {
 			l.Err(err).Msgf("GetArtistHandler: Failed to retrieve artist with ID %d", id)
 			utils.WriteError(w, "artist with specified id could not be found", http.StatusNotFound)
 			return
 		}
 
+		artist.RelatedArtists, err = store.GetRelatedArtists(ctx, artist.ID)
+		if err != nil {
+			l.Err(err).Msgf("GetArtistHandler: Failed to retrieve related artists of artist with ID %d", id)
+			utils.WriteError(w, "failed to retrieve related artists", http.StatusInternalServerError)
+			return
+		}
+
 		l.Debug().Msgf("GetArtistHandler: Successfully retrieved artist with ID %d", id)
 		utils.WriteJSON(w, http.StatusOK, artist)
 	}
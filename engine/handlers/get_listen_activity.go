@@ -65,6 +65,9 @@ func GetListenActivityHandler(store db.DB) func(w http.ResponseWriter, r *http.R
 			return
 		}
 
+		tag := r.URL.Query().Get("tag")
+		includeRelated := strings.ToLower(r.URL.Query().Get("include_related")) == "true"
+
 		var step db.StepInterval
 		switch strings.ToLower(r.URL.Query().Get("step")) {
 		case "day":
@@ -88,6 +91,10 @@ func GetListenActivityHandler(store db.DB) func(w http.ResponseWriter, r *http.R
 			AlbumID:  int32(albumId),
 			ArtistID: int32(artistId),
 			TrackID:  int32(trackId),
+			Tag:      tag,
+
+			IncludeRelated: includeRelated,
+			IncludeHidden:  includeHiddenFromRequest(r),
 		}
 
 		l.Debug().Msgf("GetListenActivityHandler: Retrieving listen activity with options: %+v", opts)
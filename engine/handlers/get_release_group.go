@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/utils"
+	"github.com/google/uuid"
+)
+
+// GetReleaseGroupHandler lists the albums that are releases of a MusicBrainz release group.
+func GetReleaseGroupHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("GetReleaseGroupHandler: Received request to retrieve release group")
+
+		idStr := r.URL.Query().Get("id")
+		if idStr == "" {
+			l.Debug().Msg("GetReleaseGroupHandler: Missing release group ID in request")
+			utils.WriteError(w, "id must be provided", http.StatusBadRequest)
+			return
+		}
+
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("GetReleaseGroupHandler: Invalid release group ID")
+			utils.WriteError(w, "id is invalid", http.StatusBadRequest)
+			return
+		}
+
+		albums, err := store.GetAlbumsInReleaseGroup(ctx, id)
+		if err != nil {
+			l.Err(err).Msgf("GetReleaseGroupHandler: Failed to retrieve albums in release group %s", id)
+			utils.WriteError(w, "failed to retrieve release group", http.StatusInternalServerError)
+			return
+		}
+		if len(albums) == 0 {
+			l.Debug().Msgf("GetReleaseGroupHandler: No albums found in release group %s", id)
+			utils.WriteError(w, "release group with specified id could not be found", http.StatusNotFound)
+			return
+		}
+
+		l.Debug().Msgf("GetReleaseGroupHandler: Successfully retrieved %d albums in release group %s", len(albums), id)
+		utils.WriteJSON(w, http.StatusOK, albums)
+	}
+}
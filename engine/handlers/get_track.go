@@ -32,7 +32,7 @@ func GetTrackHandler(store db.DB) func(w http.ResponseWriter, r *http.Request) {
 
 		l.Debug().Msgf("GetTrackHandler: Retrieving track with ID %d", id)
 
-		track, err := store.GetTrack(ctx, db.GetTrackOpts{ID: int32(id)})
+		track, err := store.GetTrack(ctx, db.GetTrackOpts{ID: int32(id), IncludeHidden: includeHiddenFromRequest(r)})
 		if err != nil {
 			l.Err(err).Msgf("GetTrackHandler: Failed to retrieve track with ID %d", id)
 			utils.WriteError(w, "track with specified id could not be found", http.StatusNotFound)
@@ -49,6 +49,10 @@ func OptsFromRequest(r *http.Request) db.GetItemsOpts {
 	albumId, _ := strconv.Atoi(albumIdStr)
 	trackIdStr := r.URL.Query().Get("track_id")
 	trackId, _ := strconv.Atoi(trackIdStr)
+	tag := r.URL.Query().Get("tag")
+	groupEditions := strings.ToLower(r.URL.Query().Get("group_editions")) == "true"
+	includeRelated := strings.ToLower(r.URL.Query().Get("include_related")) == "true"
+	includeHidden := includeHiddenFromRequest(r)
 
 	var period db.Period
 	switch strings.ToLower(r.URL.Query().Get("period")) {
@@ -67,8 +71,8 @@ func OptsFromRequest(r *http.Request) db.GetItemsOpts {
 		period = db.PeriodDay
 	}
 
-	l.Debug().Msgf("OptsFromRequest: Parsed options: limit=%d, page=%d, week=%d, month=%d, year=%d, artist_id=%d, album_id=%d, track_id=%d, period=%s",
-		limit, page, week, month, year, artistId, albumId, trackId, period)
+	l.Debug().Msgf("OptsFromRequest: Parsed options: limit=%d, page=%d, week=%d, month=%d, year=%d, artist_id=%d, album_id=%d, track_id=%d, tag=%s, period=%s, group_editions=%t, include_related=%t, include_hidden=%t",
+		limit, page, week, month, year, artistId, albumId, trackId, tag, period, groupEditions, includeRelated, includeHidden)
 
 	return db.GetItemsOpts{
 		Limit:    limit,
@@ -80,5 +84,10 @@ func OptsFromRequest(r *http.Request) db.GetItemsOpts {
 		ArtistID: artistId,
 		AlbumID:  albumId,
 		TrackID:  trackId,
+		Tag:      tag,
+
+		GroupEditions:  groupEditions,
+		IncludeRelated: includeRelated,
+		IncludeHidden:  includeHidden,
 	}
 }
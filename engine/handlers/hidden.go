@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/utils"
+)
+
+type HiddenResponse struct {
+	Items   []db.HiddenItem `json:"items"`
+	Clients []string        `json:"clients"`
+}
+
+// GetHiddenHandler returns the artists, albums, tracks and clients that are hidden from stats.
+func GetHiddenHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("GetHiddenHandler: Got request")
+
+		items, err := store.GetHiddenItems(ctx)
+		if err != nil {
+			l.Err(err).Msg("GetHiddenHandler: Failed to get hidden items")
+			utils.WriteError(w, "failed to get hidden items", http.StatusInternalServerError)
+			return
+		}
+		clients, err := store.GetHiddenClients(ctx)
+		if err != nil {
+			l.Err(err).Msg("GetHiddenHandler: Failed to get hidden clients")
+			utils.WriteError(w, "failed to get hidden clients", http.StatusInternalServerError)
+			return
+		}
+
+		utils.WriteJSON(w, http.StatusOK, HiddenResponse{
+			Items:   items,
+			Clients: clients,
+		})
+	}
+}
+
+// HideHandler hides an artist, album, track, or the listens from a client from charts, counts and search,
+// without deleting any listens.
+func HideHandler(store db.DB) http.HandlerFunc {
+	return setHiddenHandler(store, true, "HideHandler")
+}
+
+func UnhideHandler(store db.DB) http.HandlerFunc {
+	return setHiddenHandler(store, false, "UnhideHandler")
+}
+
+func setHiddenHandler(store db.DB, hidden bool, handler string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msgf("%s: Got request", handler)
+
+		err := r.ParseForm()
+		if err != nil {
+			l.Debug().AnErr("error", err).Msgf("%s: Failed to parse form", handler)
+			utils.WriteError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		artistIDStr := r.FormValue("artist_id")
+		albumIDStr := r.FormValue("album_id")
+		trackIDStr := r.FormValue("track_id")
+		client := r.FormValue("client")
+
+		if artistIDStr == "" && albumIDStr == "" && trackIDStr == "" && client == "" {
+			l.Debug().Msgf("%s: Request is missing required parameters", handler)
+			utils.WriteError(w, "artist_id, album_id, track_id, or client must be provided", http.StatusBadRequest)
+			return
+		}
+		if utils.MoreThanOneString(artistIDStr, albumIDStr, trackIDStr, client) {
+			l.Debug().Msgf("%s: Request has more than one of artist_id, album_id, track_id, and client", handler)
+			utils.WriteError(w, "only one of artist_id, album_id, track_id, or client can be provided at a time", http.StatusBadRequest)
+			return
+		}
+
+		if client != "" {
+			err = store.SetClientHidden(ctx, client, hidden)
+			if err != nil {
+				l.Err(err).Msgf("%s: Failed to update hidden client", handler)
+				utils.WriteError(w, "failed to update hidden client", http.StatusInternalServerError)
+				return
+			}
+			l.Debug().Msgf("%s: Set client '%s' hidden to %t", handler, client, hidden)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		var t db.ItemType
+		var idStr string
+		switch {
+		case artistIDStr != "":
+			t, idStr = db.ItemTypeArtist, artistIDStr
+		case albumIDStr != "":
+			t, idStr = db.ItemTypeAlbum, albumIDStr
+		default:
+			t, idStr = db.ItemTypeTrack, trackIDStr
+		}
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			l.Debug().AnErr("error", err).Msgf("%s: Invalid %s id", handler, t)
+			utils.WriteError(w, "invalid "+string(t)+"_id", http.StatusBadRequest)
+			return
+		}
+
+		switch t {
+		case db.ItemTypeArtist:
+			_, err = store.GetArtist(ctx, db.GetArtistOpts{ID: int32(id)})
+		case db.ItemTypeAlbum:
+			_, err = store.GetAlbum(ctx, db.GetAlbumOpts{ID: int32(id)})
+		case db.ItemTypeTrack:
+			_, err = store.GetTrack(ctx, db.GetTrackOpts{ID: int32(id)})
+		}
+		if err != nil {
+			l.Debug().AnErr("error", err).Msgf("%s: %s %d not found", handler, t, id)
+			utils.WriteError(w, string(t)+" not found", http.StatusNotFound)
+			return
+		}
+
+		err = store.SetItemHidden(ctx, t, int32(id), hidden)
+		if err != nil {
+			l.Err(err).Msgf("%s: Failed to update hidden %s", handler, t)
+			utils.WriteError(w, "failed to update hidden "+string(t), http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msgf("%s: Set %s %d hidden to %t", handler, t, id, hidden)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gabehf/koito/internal/catalog"
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/utils"
+)
+
+// integrityChecksFromRequest reads the comma separated checks to run from the check parameter
+func integrityChecksFromRequest(r *http.Request) []db.IntegrityCheck {
+	var checks []db.IntegrityCheck
+	for _, c := range strings.Split(r.URL.Query().Get("check"), ",") {
+		c = strings.TrimSpace(c)
+		if c != "" {
+			checks = append(checks, db.IntegrityCheck(c))
+		}
+	}
+	return checks
+}
+
+// CheckIntegrityHandler reports inconsistencies in the catalog. Only the checks given in the check
+// parameter are run, or every check when it is empty.
+func CheckIntegrityHandler(store db.DB) http.HandlerFunc {
+	return integrityHandler(store, false)
+}
+
+// RepairIntegrityHandler reports inconsistencies in the catalog, and repairs the ones that can be
+// repaired safely.
+func RepairIntegrityHandler(store db.DB) http.HandlerFunc {
+	return integrityHandler(store, true)
+}
+
+func integrityHandler(store db.DB, repair bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msgf("integrityHandler: Received request (repair: %t)", repair)
+
+		checks := integrityChecksFromRequest(r)
+		for _, c := range checks {
+			if !slices.Contains(catalog.IntegrityChecks, c) {
+				l.Debug().Msgf("integrityHandler: Unknown check '%s'", c)
+				utils.WriteError(w, "unknown check: "+string(c), http.StatusBadRequest)
+				return
+			}
+		}
+
+		report, err := catalog.CheckIntegrity(ctx, store, catalog.CheckIntegrityOpts{
+			Checks: checks,
+			Repair: repair,
+		})
+		if err != nil {
+			l.Err(err).Msg("integrityHandler: Failed to check catalog integrity")
+			utils.WriteError(w, "failed to check catalog integrity", http.StatusInternalServerError)
+			return
+		}
+
+		utils.WriteJSON(w, http.StatusOK, report)
+	}
+}
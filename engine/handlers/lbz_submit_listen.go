@@ -204,10 +204,13 @@ func LbzSubmitListenHandler(store db.DB, mbzc mbz.MusicBrainzCaller) func(w http
 				TrackTitle:         payload.TrackMeta.TrackName,
 				RecordingMbzID:     recordingMbzID,
 				ReleaseTitle:       payload.TrackMeta.ReleaseName,
+				AlbumArtist:        payload.TrackMeta.AdditionalInfo.AlbumArtist,
 				ReleaseMbzID:       releaseMbzID,
 				ReleaseGroupMbzID:  rgMbzID,
 				ArtistMbidMappings: artistMbidMap,
+				ResolveMbzIDs:      cfg.MusicBrainzSearchEnabled(),
 				Duration:           duration,
+				Tags:               payload.TrackMeta.AdditionalInfo.Tags,
 				Time:               listenedAt,
 				UserID:             u.ID,
 				Client:             client,
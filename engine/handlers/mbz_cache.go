@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/mbz"
+	"github.com/gabehf/koito/internal/utils"
+)
+
+// the MusicBrainz entity types that responses are cached for
+var mbzCacheEntities = []string{"artist", "release-group", "release", "recording"}
+
+type MbzCacheStatsResponse struct {
+	Entries int64 `json:"entries"`
+	Hits    int64 `json:"hits"`
+	Misses  int64 `json:"misses"`
+}
+
+func GetMbzCacheStatsHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("GetMbzCacheStatsHandler: Received request to retrieve MusicBrainz cache stats")
+
+		count, err := store.CountMbzCacheEntries(ctx)
+		if err != nil {
+			l.Err(err).Msg("GetMbzCacheStatsHandler: Failed to count MusicBrainz cache entries")
+			utils.WriteError(w, "failed to get cache stats", http.StatusInternalServerError)
+			return
+		}
+		stats := mbz.GetCacheStats()
+
+		utils.WriteJSON(w, http.StatusOK, MbzCacheStatsResponse{
+			Entries: count,
+			Hits:    stats.Hits,
+			Misses:  stats.Misses,
+		})
+	}
+}
+
+// PurgeMbzCacheHandler removes cached MusicBrainz responses. The type query parameter
+// (artist, release-group, release, or recording) limits the purge to a single entity type.
+func PurgeMbzCacheHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		entity := r.URL.Query().Get("type")
+		l.Debug().Msgf("PurgeMbzCacheHandler: Received request to purge MusicBrainz cache (type: '%s')", entity)
+
+		if entity != "" && !slices.Contains(mbzCacheEntities, entity) {
+			l.Debug().Msgf("PurgeMbzCacheHandler: Unknown type '%s'", entity)
+			utils.WriteError(w, "type must be one of artist, release-group, release, or recording", http.StatusBadRequest)
+			return
+		}
+
+		count, err := store.DeleteMbzCacheEntries(ctx, entity)
+		if err != nil {
+			l.Err(err).Msg("PurgeMbzCacheHandler: Failed to purge MusicBrainz cache")
+			utils.WriteError(w, "failed to purge cache", http.StatusInternalServerError)
+			return
+		}
+
+		l.Info().Msgf("PurgeMbzCacheHandler: Purged %d entries from the MusicBrainz cache", count)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gabehf/koito/internal/catalog"
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/mbz"
+	"github.com/gabehf/koito/internal/utils"
+)
+
+func GetMbzMatchSuggestionsHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("GetMbzMatchSuggestionsHandler: Received request to retrieve MusicBrainz match suggestions")
+
+		opts := OptsFromRequest(r)
+		suggestions, err := store.GetMbzMatchSuggestions(ctx, db.GetMbzMatchSuggestionsOpts{
+			Limit: opts.Limit,
+			Page:  opts.Page,
+		})
+		if err != nil {
+			l.Err(err).Msg("GetMbzMatchSuggestionsHandler: Failed to retrieve MusicBrainz match suggestions")
+			utils.WriteError(w, "failed to get suggestions", http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msg("GetMbzMatchSuggestionsHandler: Successfully retrieved MusicBrainz match suggestions")
+		utils.WriteJSON(w, http.StatusOK, suggestions)
+	}
+}
+
+func AcceptMbzMatchSuggestionHandler(store db.DB, mbzc mbz.MusicBrainzCaller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("AcceptMbzMatchSuggestionHandler: Received request to accept MusicBrainz match suggestion")
+
+		id, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("AcceptMbzMatchSuggestionHandler: Invalid id parameter")
+			utils.WriteError(w, "id is invalid", http.StatusBadRequest)
+			return
+		}
+
+		suggestion, err := store.GetMbzMatchSuggestion(ctx, int32(id))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("AcceptMbzMatchSuggestionHandler: Suggestion not found")
+			utils.WriteError(w, "suggestion not found", http.StatusNotFound)
+			return
+		}
+
+		err = catalog.AcceptMbzMatchSuggestion(ctx, store, mbzc, suggestion)
+		if err != nil {
+			l.Err(err).Msg("AcceptMbzMatchSuggestionHandler: Failed to accept suggestion")
+			utils.WriteError(w, "Failed to accept suggestion: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msgf("AcceptMbzMatchSuggestionHandler: Successfully matched %s %d to MusicBrainz ID %s", suggestion.Type, suggestion.ItemID, suggestion.MbzID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func DismissMbzMatchSuggestionHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("DismissMbzMatchSuggestionHandler: Received request to dismiss MusicBrainz match suggestion")
+
+		id, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("DismissMbzMatchSuggestionHandler: Invalid id parameter")
+			utils.WriteError(w, "id is invalid", http.StatusBadRequest)
+			return
+		}
+
+		err = store.DismissMbzMatchSuggestion(ctx, int32(id))
+		if err != nil {
+			l.Err(err).Msg("DismissMbzMatchSuggestionHandler: Failed to dismiss suggestion")
+			utils.WriteError(w, "failed to dismiss suggestion", http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msgf("DismissMbzMatchSuggestionHandler: Successfully dismissed suggestion %d", id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gabehf/koito/internal/catalog"
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/utils"
+)
+
+func parseItemType(s string) (db.ItemType, bool) {
+	switch strings.ToLower(s) {
+	case "artist":
+		return db.ItemTypeArtist, true
+	case "album":
+		return db.ItemTypeAlbum, true
+	case "track":
+		return db.ItemTypeTrack, true
+	default:
+		return "", false
+	}
+}
+
+func GetMergeCandidatesHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("GetMergeCandidatesHandler: Received request to retrieve merge candidates")
+
+		t, ok := parseItemType(r.URL.Query().Get("type"))
+		if !ok {
+			l.Debug().Msg("GetMergeCandidatesHandler: Invalid type parameter")
+			utils.WriteError(w, "type must be one of artist, album, or track", http.StatusBadRequest)
+			return
+		}
+
+		opts := OptsFromRequest(r)
+		candidates, err := store.GetMergeCandidates(ctx, db.GetMergeCandidatesOpts{
+			Type:  t,
+			Limit: opts.Limit,
+			Page:  opts.Page,
+		})
+		if err != nil {
+			l.Err(err).Msg("GetMergeCandidatesHandler: Failed to retrieve merge candidates")
+			utils.WriteError(w, "failed to get merge candidates", http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msg("GetMergeCandidatesHandler: Successfully retrieved merge candidates")
+		utils.WriteJSON(w, http.StatusOK, candidates)
+	}
+}
+
+func AcceptMergeCandidateHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("AcceptMergeCandidateHandler: Received request to accept merge candidate")
+
+		id, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("AcceptMergeCandidateHandler: Invalid id parameter")
+			utils.WriteError(w, "id is invalid", http.StatusBadRequest)
+			return
+		}
+
+		var replaceImage bool
+		if strings.ToLower(r.URL.Query().Get("replace_image")) == "true" {
+			l.Debug().Msg("AcceptMergeCandidateHandler: Merge will replace image")
+			replaceImage = true
+		}
+
+		candidate, err := store.GetMergeCandidate(ctx, int32(id))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("AcceptMergeCandidateHandler: Merge candidate not found")
+			utils.WriteError(w, "merge candidate not found", http.StatusNotFound)
+			return
+		}
+
+		l.Debug().Msgf("AcceptMergeCandidateHandler: Merging %s %d into %s %d", candidate.Type, candidate.FromID, candidate.Type, candidate.ToID)
+
+		switch db.ItemType(candidate.Type) {
+		case db.ItemTypeArtist:
+			err = store.MergeArtists(ctx, candidate.FromID, candidate.ToID, replaceImage)
+		case db.ItemTypeAlbum:
+			err = store.MergeAlbums(ctx, candidate.FromID, candidate.ToID, replaceImage)
+		case db.ItemTypeTrack:
+			err = store.MergeTracks(ctx, candidate.FromID, candidate.ToID)
+		}
+		if err != nil {
+			l.Err(err).Msg("AcceptMergeCandidateHandler: Failed to merge items")
+			utils.WriteError(w, "Failed to merge items: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		err = store.DeleteMergeCandidate(ctx, candidate.ID)
+		if err != nil {
+			l.Err(err).Msg("AcceptMergeCandidateHandler: Failed to delete merge candidate")
+		}
+
+		l.Debug().Msgf("AcceptMergeCandidateHandler: Successfully accepted merge candidate %d", candidate.ID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func DismissMergeCandidateHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("DismissMergeCandidateHandler: Received request to dismiss merge candidate")
+
+		id, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("DismissMergeCandidateHandler: Invalid id parameter")
+			utils.WriteError(w, "id is invalid", http.StatusBadRequest)
+			return
+		}
+
+		err = store.DismissMergeCandidate(ctx, int32(id))
+		if err != nil {
+			l.Err(err).Msg("DismissMergeCandidateHandler: Failed to dismiss merge candidate")
+			utils.WriteError(w, "failed to dismiss merge candidate", http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msgf("DismissMergeCandidateHandler: Successfully dismissed merge candidate %d", id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Starts a scan for duplicates in the background. New candidates can be retrieved once it is done.
+func RefreshMergeCandidatesHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logger.FromContext(r.Context())
+
+		l.Debug().Msg("RefreshMergeCandidatesHandler: Received request to scan for duplicates")
+
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					l.Error().Interface("recover", r).Msg("RefreshMergeCandidatesHandler: Panic occurred while scanning for duplicates")
+				}
+			}()
+			err := catalog.FindDuplicates(logger.NewContext(l), store)
+			if err != nil {
+				l.Err(err).Msg("RefreshMergeCandidatesHandler: Failed to scan for duplicates")
+			}
+		}()
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
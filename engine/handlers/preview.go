@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+// PreviewDeleteHandler returns how many listens, tracks, albums and artists deleting an artist, album
+// or track would delete, and which images would no longer be used, without deleting anything.
+func PreviewDeleteHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("PreviewDeleteHandler: Received request to preview delete")
+
+		t, ok := parseItemType(r.URL.Query().Get("type"))
+		if !ok {
+			l.Debug().Msg("PreviewDeleteHandler: Invalid type parameter")
+			utils.WriteError(w, "type must be one of artist, album, or track", http.StatusBadRequest)
+			return
+		}
+		id, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("PreviewDeleteHandler: Invalid id parameter")
+			utils.WriteError(w, "id is invalid", http.StatusBadRequest)
+			return
+		}
+
+		preview, err := store.PreviewDelete(ctx, t, int32(id))
+		if errors.Is(err, pgx.ErrNoRows) {
+			l.Debug().Msgf("PreviewDeleteHandler: %s %d not found", t, id)
+			utils.WriteError(w, string(t)+" not found", http.StatusNotFound)
+			return
+		} else if err != nil {
+			l.Err(err).Msg("PreviewDeleteHandler: Failed to preview delete")
+			utils.WriteError(w, "failed to preview delete", http.StatusInternalServerError)
+			return
+		}
+
+		utils.WriteJSON(w, http.StatusOK, preview)
+	}
+}
+
+// PreviewMergeHandler returns how many listens merging an artist, album or track into another would
+// move, how many tracks, albums and artists it would remove, and which images would no longer be used,
+// without merging anything.
+func PreviewMergeHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("PreviewMergeHandler: Received request to preview merge")
+
+		t, ok := parseItemType(r.URL.Query().Get("type"))
+		if !ok {
+			l.Debug().Msg("PreviewMergeHandler: Invalid type parameter")
+			utils.WriteError(w, "type must be one of artist, album, or track", http.StatusBadRequest)
+			return
+		}
+		fromId, err := strconv.Atoi(r.URL.Query().Get("from_id"))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("PreviewMergeHandler: Invalid from_id parameter")
+			utils.WriteError(w, "from_id is invalid", http.StatusBadRequest)
+			return
+		}
+		toId, err := strconv.Atoi(r.URL.Query().Get("to_id"))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("PreviewMergeHandler: Invalid to_id parameter")
+			utils.WriteError(w, "to_id is invalid", http.StatusBadRequest)
+			return
+		}
+		replaceImage := strings.ToLower(r.URL.Query().Get("replace_image")) == "true"
+
+		preview, err := store.PreviewMerge(ctx, t, int32(fromId), int32(toId), replaceImage)
+		if errors.Is(err, pgx.ErrNoRows) {
+			l.Debug().Msgf("PreviewMergeHandler: %s %d or %d not found", t, fromId, toId)
+			utils.WriteError(w, string(t)+" not found", http.StatusNotFound)
+			return
+		} else if err != nil {
+			l.Err(err).Msg("PreviewMergeHandler: Failed to preview merge")
+			utils.WriteError(w, "failed to preview merge: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		utils.WriteJSON(w, http.StatusOK, preview)
+	}
+}
@@ -22,6 +22,7 @@ func SearchHandler(store db.DB) http.HandlerFunc {
 		ctx := r.Context()
 		l := logger.FromContext(ctx)
 		q := r.URL.Query().Get("q")
+		includeHidden := includeHiddenFromRequest(r)
 
 		l.Debug().Msgf("SearchHandler: Received search with query: %s", r.URL.Query().Encode())
 
@@ -58,19 +59,19 @@ func SearchHandler(store db.DB) http.HandlerFunc {
 			}
 		} else {
 			var err error
-			artists, err = store.SearchArtists(ctx, q)
+			artists, err = store.SearchArtists(ctx, q, db.SearchOpts{IncludeHidden: includeHidden})
 			if err != nil {
 				l.Err(err).Msg("Failed to search for artists")
 				utils.WriteError(w, "failed to search in database", http.StatusInternalServerError)
 				return
 			}
-			albums, err = store.SearchAlbums(ctx, q)
+			albums, err = store.SearchAlbums(ctx, q, db.SearchOpts{IncludeHidden: includeHidden})
 			if err != nil {
 				l.Err(err).Msg("Failed to search for albums")
 				utils.WriteError(w, "failed to search in database", http.StatusInternalServerError)
 				return
 			}
-			tracks, err = store.SearchTracks(ctx, q)
+			tracks, err = store.SearchTracks(ctx, q, db.SearchOpts{IncludeHidden: includeHidden})
 			if err != nil {
 				l.Err(err).Msg("Failed to search for tracks")
 				utils.WriteError(w, "failed to search in database", http.StatusInternalServerError)
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/utils"
+)
+
+// parses a comma separated list of ids, e.g. "1,2,3"
+func parseIDList(s string) ([]int32, error) {
+	ids := make([]int32, 0)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, int32(id))
+	}
+	if len(ids) == 0 {
+		return nil, errors.New("no ids provided")
+	}
+	return ids, nil
+}
+
+func SplitArtistHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logger.FromContext(r.Context())
+
+		l.Debug().Msg("SplitArtistHandler: Received request to split artist")
+
+		fromidStr := r.URL.Query().Get("from_id")
+		fromId, err := strconv.Atoi(fromidStr)
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("SplitArtistHandler: Invalid from_id parameter")
+			utils.WriteError(w, "from_id is invalid", http.StatusBadRequest)
+			return
+		}
+
+		var toId int
+		toidStr := r.URL.Query().Get("to_id")
+		name := r.URL.Query().Get("name")
+		if toidStr != "" {
+			toId, err = strconv.Atoi(toidStr)
+			if err != nil {
+				l.Debug().AnErr("error", err).Msg("SplitArtistHandler: Invalid to_id parameter")
+				utils.WriteError(w, "to_id is invalid", http.StatusBadRequest)
+				return
+			}
+		} else if strings.TrimSpace(name) == "" {
+			l.Debug().Msg("SplitArtistHandler: Request is missing both to_id and name")
+			utils.WriteError(w, "one of to_id or name must be provided", http.StatusBadRequest)
+			return
+		}
+
+		trackIds, err := parseIDList(r.URL.Query().Get("track_ids"))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("SplitArtistHandler: Invalid track_ids parameter")
+			utils.WriteError(w, "track_ids is invalid", http.StatusBadRequest)
+			return
+		}
+
+		l.Debug().Msgf("SplitArtistHandler: Splitting %d tracks from artist with ID %d", len(trackIds), fromId)
+
+		artist, err := store.SplitArtist(r.Context(), db.SplitArtistOpts{
+			FromID:   int32(fromId),
+			ToID:     int32(toId),
+			NewName:  name,
+			TrackIDs: trackIds,
+		})
+		if err != nil {
+			l.Err(err).Msg("SplitArtistHandler: Failed to split artist")
+			utils.WriteError(w, "Failed to split artist: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msgf("SplitArtistHandler: Successfully split tracks from artist %d into artist %d", fromId, artist.ID)
+		utils.WriteJSON(w, http.StatusOK, artist)
+	}
+}
+
+func SplitAlbumHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logger.FromContext(r.Context())
+
+		l.Debug().Msg("SplitAlbumHandler: Received request to split album")
+
+		fromidStr := r.URL.Query().Get("from_id")
+		fromId, err := strconv.Atoi(fromidStr)
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("SplitAlbumHandler: Invalid from_id parameter")
+			utils.WriteError(w, "from_id is invalid", http.StatusBadRequest)
+			return
+		}
+
+		var toId int
+		toidStr := r.URL.Query().Get("to_id")
+		title := r.URL.Query().Get("title")
+		if toidStr != "" {
+			toId, err = strconv.Atoi(toidStr)
+			if err != nil {
+				l.Debug().AnErr("error", err).Msg("SplitAlbumHandler: Invalid to_id parameter")
+				utils.WriteError(w, "to_id is invalid", http.StatusBadRequest)
+				return
+			}
+		} else if strings.TrimSpace(title) == "" {
+			l.Debug().Msg("SplitAlbumHandler: Request is missing both to_id and title")
+			utils.WriteError(w, "one of to_id or title must be provided", http.StatusBadRequest)
+			return
+		}
+
+		trackIds, err := parseIDList(r.URL.Query().Get("track_ids"))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("SplitAlbumHandler: Invalid track_ids parameter")
+			utils.WriteError(w, "track_ids is invalid", http.StatusBadRequest)
+			return
+		}
+
+		l.Debug().Msgf("SplitAlbumHandler: Splitting %d tracks from album with ID %d", len(trackIds), fromId)
+
+		album, err := store.SplitAlbum(r.Context(), db.SplitAlbumOpts{
+			FromID:   int32(fromId),
+			ToID:     int32(toId),
+			NewTitle: title,
+			TrackIDs: trackIds,
+		})
+		if err != nil {
+			l.Err(err).Msg("SplitAlbumHandler: Failed to split album")
+			utils.WriteError(w, "Failed to split album: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msgf("SplitAlbumHandler: Successfully split tracks from album %d into album %d", fromId, album.ID)
+		utils.WriteJSON(w, http.StatusOK, album)
+	}
+}
@@ -10,11 +10,12 @@ import (
 )
 
 type StatsResponse struct {
-	ListenCount     int64 `json:"listen_count"`
-	TrackCount      int64 `json:"track_count"`
-	AlbumCount      int64 `json:"album_count"`
-	ArtistCount     int64 `json:"artist_count"`
-	MinutesListened int64 `json:"minutes_listened"`
+	ListenCount         int64 `json:"listen_count"`
+	TrackCount          int64 `json:"track_count"`
+	AlbumCount          int64 `json:"album_count"`
+	ArtistCount         int64 `json:"artist_count"`
+	MinutesListened     int64 `json:"minutes_listened"`
+	CompletedAlbumCount int64 `json:"completed_album_count"`
 }
 
 func StatsHandler(store db.DB) http.HandlerFunc {
@@ -23,67 +24,159 @@ func StatsHandler(store db.DB) http.HandlerFunc {
 
 		l.Debug().Msg("StatsHandler: Received request to retrieve statistics")
 
-		var period db.Period
-		switch strings.ToLower(r.URL.Query().Get("period")) {
-		case "day":
-			period = db.PeriodDay
-		case "week":
-			period = db.PeriodWeek
-		case "month":
-			period = db.PeriodMonth
-		case "year":
-			period = db.PeriodYear
-		case "all_time":
-			period = db.PeriodAllTime
-		default:
-			l.Debug().Msgf("StatsHandler: Using default value '%s' for period", db.PeriodDay)
-			period = db.PeriodDay
-		}
+		period := periodFromRequest(r)
+		includeHidden := includeHiddenFromRequest(r)
 
 		l.Debug().Msgf("StatsHandler: Fetching statistics for period '%s'", period)
 
-		listens, err := store.CountListens(r.Context(), period)
+		listens, err := store.CountListens(r.Context(), period, db.CountOpts{IncludeHidden: includeHidden})
 		if err != nil {
 			l.Err(err).Msg("StatsHandler: Failed to fetch listen count")
 			utils.WriteError(w, "failed to get listens: "+err.Error(), http.StatusInternalServerError)
 			return
 		}
 
-		tracks, err := store.CountTracks(r.Context(), period)
+		tracks, err := store.CountTracks(r.Context(), period, db.CountOpts{IncludeHidden: includeHidden})
 		if err != nil {
 			l.Err(err).Msg("StatsHandler: Failed to fetch track count")
 			utils.WriteError(w, "failed to get tracks: "+err.Error(), http.StatusInternalServerError)
 			return
 		}
 
-		albums, err := store.CountAlbums(r.Context(), period)
+		albums, err := store.CountAlbums(r.Context(), period, db.CountOpts{IncludeHidden: includeHidden})
 		if err != nil {
 			l.Err(err).Msg("StatsHandler: Failed to fetch album count")
 			utils.WriteError(w, "failed to get albums: "+err.Error(), http.StatusInternalServerError)
 			return
 		}
 
-		artists, err := store.CountArtists(r.Context(), period)
+		artists, err := store.CountArtists(r.Context(), period, db.CountOpts{IncludeHidden: includeHidden})
 		if err != nil {
 			l.Err(err).Msg("StatsHandler: Failed to fetch artist count")
 			utils.WriteError(w, "failed to get artists: "+err.Error(), http.StatusInternalServerError)
 			return
 		}
 
-		timeListenedS, err := store.CountTimeListened(r.Context(), period)
+		timeListenedS, err := store.CountTimeListened(r.Context(), period, db.CountOpts{IncludeHidden: includeHidden})
 		if err != nil {
 			l.Err(err).Msg("StatsHandler: Failed to fetch time listened")
 			utils.WriteError(w, "failed to get time listened: "+err.Error(), http.StatusInternalServerError)
 			return
 		}
 
+		completedAlbums, err := store.CountCompletedAlbums(r.Context(), period, db.CountOpts{IncludeHidden: includeHidden})
+		if err != nil {
+			l.Err(err).Msg("StatsHandler: Failed to fetch completed album count")
+			utils.WriteError(w, "failed to get completed albums: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
 		l.Debug().Msg("StatsHandler: Successfully fetched statistics")
 		utils.WriteJSON(w, http.StatusOK, StatsResponse{
-			ListenCount:     listens,
-			TrackCount:      tracks,
-			AlbumCount:      albums,
-			ArtistCount:     artists,
-			MinutesListened: timeListenedS / 60,
+			ListenCount:         listens,
+			TrackCount:          tracks,
+			AlbumCount:          albums,
+			ArtistCount:         artists,
+			MinutesListened:     timeListenedS / 60,
+			CompletedAlbumCount: completedAlbums,
 		})
 	}
 }
+
+// ReleaseYearStatsHandler returns the number of listens to albums by the year they were released,
+// or by decade when group=decade is given. Albums without a known release date are left out.
+func ReleaseYearStatsHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logger.FromContext(r.Context())
+
+		l.Debug().Msg("ReleaseYearStatsHandler: Received request to retrieve release year statistics")
+
+		period := periodFromRequest(r)
+		includeHidden := includeHiddenFromRequest(r)
+		byDecade := strings.ToLower(r.URL.Query().Get("group")) == "decade"
+
+		l.Debug().Msgf("ReleaseYearStatsHandler: Fetching release year statistics for period '%s'", period)
+
+		counts, err := store.CountListensByReleaseYear(r.Context(), period, byDecade, db.CountOpts{IncludeHidden: includeHidden})
+		if err != nil {
+			l.Err(err).Msg("ReleaseYearStatsHandler: Failed to fetch listens by release year")
+			utils.WriteError(w, "failed to get listens by release year: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msg("ReleaseYearStatsHandler: Successfully fetched release year statistics")
+		utils.WriteJSON(w, http.StatusOK, counts)
+	}
+}
+
+// ReleaseTypeStatsHandler returns the number of listens to albums by their release type.
+// Albums without a known release type are left out.
+func ReleaseTypeStatsHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logger.FromContext(r.Context())
+
+		l.Debug().Msg("ReleaseTypeStatsHandler: Received request to retrieve release type statistics")
+
+		period := periodFromRequest(r)
+		includeHidden := includeHiddenFromRequest(r)
+
+		l.Debug().Msgf("ReleaseTypeStatsHandler: Fetching release type statistics for period '%s'", period)
+
+		counts, err := store.CountListensByReleaseType(r.Context(), period, db.CountOpts{IncludeHidden: includeHidden})
+		if err != nil {
+			l.Err(err).Msg("ReleaseTypeStatsHandler: Failed to fetch listens by release type")
+			utils.WriteError(w, "failed to get listens by release type: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msg("ReleaseTypeStatsHandler: Successfully fetched release type statistics")
+		utils.WriteJSON(w, http.StatusOK, counts)
+	}
+}
+
+func CountryStatsHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		l := logger.FromContext(r.Context())
+
+		l.Debug().Msg("CountryStatsHandler: Received request to retrieve country statistics")
+
+		period := periodFromRequest(r)
+		includeHidden := includeHiddenFromRequest(r)
+
+		l.Debug().Msgf("CountryStatsHandler: Fetching country statistics for period '%s'", period)
+
+		counts, err := store.CountListensByCountry(r.Context(), period, db.CountOpts{IncludeHidden: includeHidden})
+		if err != nil {
+			l.Err(err).Msg("CountryStatsHandler: Failed to fetch listens by country")
+			utils.WriteError(w, "failed to get listens by country: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msg("CountryStatsHandler: Successfully fetched country statistics")
+		utils.WriteJSON(w, http.StatusOK, counts)
+	}
+}
+
+func periodFromRequest(r *http.Request) db.Period {
+	switch strings.ToLower(r.URL.Query().Get("period")) {
+	case "day":
+		return db.PeriodDay
+	case "week":
+		return db.PeriodWeek
+	case "month":
+		return db.PeriodMonth
+	case "year":
+		return db.PeriodYear
+	case "all_time":
+		return db.PeriodAllTime
+	default:
+		logger.FromContext(r.Context()).Debug().Msgf("Using default value '%s' for period", db.PeriodDay)
+		return db.PeriodDay
+	}
+}
+
+// includeHiddenFromRequest reports whether hidden items and clients should be counted, which they are
+// not unless include_hidden=true is given
+func includeHiddenFromRequest(r *http.Request) bool {
+	return strings.ToLower(r.URL.Query().Get("include_hidden")) == "true"
+}
@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/models"
+	"github.com/gabehf/koito/internal/utils"
+)
+
+func GetTopTagsHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("GetTopTagsHandler: Received request to retrieve top tags")
+
+		opts := OptsFromRequest(r)
+		l.Debug().Msgf("GetTopTagsHandler: Retrieving top tags with options: %+v", opts)
+
+		tags, err := store.GetTopTagsPaginated(ctx, opts)
+		if err != nil {
+			l.Err(err).Msg("GetTopTagsHandler: Failed to retrieve top tags")
+			utils.WriteError(w, "failed to get tags", http.StatusBadRequest)
+			return
+		}
+
+		l.Debug().Msg("GetTopTagsHandler: Successfully retrieved top tags")
+		utils.WriteJSON(w, http.StatusOK, tags)
+	}
+}
+
+// GetTagsHandler retrieves the tags of a given artist, album, or track ID.
+func GetTagsHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msgf("GetTagsHandler: Got request with params: '%s'", r.URL.Query().Encode())
+
+		artistIDStr := r.URL.Query().Get("artist_id")
+		albumIDStr := r.URL.Query().Get("album_id")
+		trackIDStr := r.URL.Query().Get("track_id")
+
+		if artistIDStr == "" && albumIDStr == "" && trackIDStr == "" {
+			l.Debug().Msg("GetTagsHandler: Request is missing required parameters")
+			utils.WriteError(w, "artist_id, album_id, or track_id must be provided", http.StatusBadRequest)
+			return
+		}
+		if utils.MoreThanOneString(artistIDStr, albumIDStr, trackIDStr) {
+			l.Debug().Msg("GetTagsHandler: Request has more than one of artist_id, album_id, and track_id")
+			utils.WriteError(w, "only one of artist_id, album_id, or track_id can be provided at a time", http.StatusBadRequest)
+			return
+		}
+
+		var tags []models.Tag
+
+		if artistIDStr != "" {
+			artistID, err := strconv.Atoi(artistIDStr)
+			if err != nil {
+				l.Debug().AnErr("error", err).Msg("GetTagsHandler: Invalid artist id")
+				utils.WriteError(w, "invalid artist_id", http.StatusBadRequest)
+				return
+			}
+			tags, err = store.GetArtistTags(ctx, int32(artistID))
+			if err != nil {
+				l.Err(err).Msg("GetTagsHandler: Failed to get artist tags")
+				utils.WriteError(w, "failed to retrieve tags", http.StatusInternalServerError)
+				return
+			}
+		} else if albumIDStr != "" {
+			albumID, err := strconv.Atoi(albumIDStr)
+			if err != nil {
+				l.Debug().AnErr("error", err).Msg("GetTagsHandler: Invalid album id")
+				utils.WriteError(w, "invalid album_id", http.StatusBadRequest)
+				return
+			}
+			tags, err = store.GetAlbumTags(ctx, int32(albumID))
+			if err != nil {
+				l.Err(err).Msg("GetTagsHandler: Failed to get album tags")
+				utils.WriteError(w, "failed to retrieve tags", http.StatusInternalServerError)
+				return
+			}
+		} else if trackIDStr != "" {
+			trackID, err := strconv.Atoi(trackIDStr)
+			if err != nil {
+				l.Debug().AnErr("error", err).Msg("GetTagsHandler: Invalid track id")
+				utils.WriteError(w, "invalid track_id", http.StatusBadRequest)
+				return
+			}
+			tags, err = store.GetTrackTags(ctx, int32(trackID))
+			if err != nil {
+				l.Err(err).Msg("GetTagsHandler: Failed to get track tags")
+				utils.WriteError(w, "failed to retrieve tags", http.StatusInternalServerError)
+				return
+			}
+		}
+		utils.WriteJSON(w, http.StatusOK, tags)
+	}
+}
+
+// CreateTagHandler adds a tag to a given artist, album, or track.
+func CreateTagHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("CreateTagHandler: Got request")
+
+		err := r.ParseForm()
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("CreateTagHandler: Failed to parse form")
+			utils.WriteError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		tag := utils.NormalizeTag(r.FormValue("tag"))
+		if tag == "" {
+			l.Debug().Msg("CreateTagHandler: Tag parameter missing")
+			utils.WriteError(w, "tag must be provided", http.StatusBadRequest)
+			return
+		}
+
+		artistIDStr := r.FormValue("artist_id")
+		albumIDStr := r.FormValue("album_id")
+		trackIDStr := r.FormValue("track_id")
+
+		if artistIDStr == "" && albumIDStr == "" && trackIDStr == "" {
+			l.Debug().Msg("CreateTagHandler: Missing ID parameter")
+			utils.WriteError(w, "artist_id, album_id, or track_id must be provided", http.StatusBadRequest)
+			return
+		}
+		if utils.MoreThanOneString(artistIDStr, albumIDStr, trackIDStr) {
+			l.Debug().Msg("CreateTagHandler: Multiple ID parameters provided")
+			utils.WriteError(w, "only one of artist_id, album_id, or track_id can be provided", http.StatusBadRequest)
+			return
+		}
+
+		var id int
+		if artistIDStr != "" {
+			id, err = strconv.Atoi(artistIDStr)
+			if err != nil {
+				l.Debug().AnErr("error", err).Msg("CreateTagHandler: Invalid artist id")
+				utils.WriteError(w, "invalid artist_id", http.StatusBadRequest)
+				return
+			}
+			err = store.SaveArtistTags(ctx, int32(id), []string{tag}, "Manual")
+			if err != nil {
+				l.Error().Err(err).Msg("CreateTagHandler: Failed to save artist tag")
+				utils.WriteError(w, "failed to save tag", http.StatusInternalServerError)
+				return
+			}
+		} else if albumIDStr != "" {
+			id, err = strconv.Atoi(albumIDStr)
+			if err != nil {
+				l.Debug().AnErr("error", err).Msg("CreateTagHandler: Invalid album id")
+				utils.WriteError(w, "invalid album_id", http.StatusBadRequest)
+				return
+			}
+			err = store.SaveAlbumTags(ctx, int32(id), []string{tag}, "Manual")
+			if err != nil {
+				l.Error().Err(err).Msg("CreateTagHandler: Failed to save album tag")
+				utils.WriteError(w, "failed to save tag", http.StatusInternalServerError)
+				return
+			}
+		} else if trackIDStr != "" {
+			id, err = strconv.Atoi(trackIDStr)
+			if err != nil {
+				l.Debug().AnErr("error", err).Msg("CreateTagHandler: Invalid track id")
+				utils.WriteError(w, "invalid track_id", http.StatusBadRequest)
+				return
+			}
+			err = store.SaveTrackTags(ctx, int32(id), []string{tag}, "Manual")
+			if err != nil {
+				l.Error().Err(err).Msg("CreateTagHandler: Failed to save track tag")
+				utils.WriteError(w, "failed to save tag", http.StatusInternalServerError)
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+// DeleteTagHandler removes a tag from a given artist, album, or track.
+func DeleteTagHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("DeleteTagHandler: Got request")
+
+		err := r.ParseForm()
+		if err != nil {
+			l.Debug().Msg("DeleteTagHandler: Failed to parse form")
+			utils.WriteError(w, "form is invalid", http.StatusBadRequest)
+			return
+		}
+
+		artistIDStr := r.FormValue("artist_id")
+		albumIDStr := r.FormValue("album_id")
+		trackIDStr := r.FormValue("track_id")
+		tag := r.FormValue("tag")
+
+		if tag == "" || (artistIDStr == "" && albumIDStr == "" && trackIDStr == "") {
+			l.Debug().Msg("DeleteTagHandler: Request is missing required parameters")
+			utils.WriteError(w, "tag and artist_id, album_id, or track_id must be provided", http.StatusBadRequest)
+			return
+		}
+		if utils.MoreThanOneString(artistIDStr, albumIDStr, trackIDStr) {
+			l.Debug().Msg("DeleteTagHandler: Request has more than one of artist_id, album_id, and track_id")
+			utils.WriteError(w, "only one of artist_id, album_id, or track_id can be provided at a time", http.StatusBadRequest)
+			return
+		}
+
+		var id int
+		if artistIDStr != "" {
+			id, err = strconv.Atoi(artistIDStr)
+			if err != nil {
+				l.Debug().AnErr("error", err).Msg("DeleteTagHandler: Invalid artist id")
+				utils.WriteError(w, "invalid artist_id", http.StatusBadRequest)
+				return
+			}
+			err = store.DeleteArtistTag(ctx, int32(id), tag)
+			if err != nil {
+				l.Error().Err(err).Msg("DeleteTagHandler: Failed to delete artist tag")
+				utils.WriteError(w, "failed to delete tag", http.StatusInternalServerError)
+				return
+			}
+		} else if albumIDStr != "" {
+			id, err = strconv.Atoi(albumIDStr)
+			if err != nil {
+				l.Debug().AnErr("error", err).Msg("DeleteTagHandler: Invalid album id")
+				utils.WriteError(w, "invalid album_id", http.StatusBadRequest)
+				return
+			}
+			err = store.DeleteAlbumTag(ctx, int32(id), tag)
+			if err != nil {
+				l.Error().Err(err).Msg("DeleteTagHandler: Failed to delete album tag")
+				utils.WriteError(w, "failed to delete tag", http.StatusInternalServerError)
+				return
+			}
+		} else if trackIDStr != "" {
+			id, err = strconv.Atoi(trackIDStr)
+			if err != nil {
+				l.Debug().AnErr("error", err).Msg("DeleteTagHandler: Invalid track id")
+				utils.WriteError(w, "invalid track_id", http.StatusBadRequest)
+				return
+			}
+			err = store.DeleteTrackTag(ctx, int32(id), tag)
+			if err != nil {
+				l.Error().Err(err).Msg("DeleteTagHandler: Failed to delete track tag")
+				utils.WriteError(w, "failed to delete tag", http.StatusInternalServerError)
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gabehf/koito/internal/catalog"
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/mbz"
+	"github.com/gabehf/koito/internal/models"
+	"github.com/gabehf/koito/internal/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetAlbumTracklistHandler returns the tracklist of an album with the listen count of each track,
+// or only the tracks that have never been listened to when unplayed=true is given.
+func GetAlbumTracklistHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("GetAlbumTracklistHandler: Received request")
+
+		id, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("GetAlbumTracklistHandler: Invalid id parameter")
+			utils.WriteError(w, "id is invalid", http.StatusBadRequest)
+			return
+		}
+
+		tracklist, err := store.GetAlbumTracklist(ctx, int32(id))
+		if err != nil {
+			l.Err(err).Msgf("GetAlbumTracklistHandler: Failed to get tracklist of album %d", id)
+			utils.WriteError(w, "failed to get tracklist", http.StatusInternalServerError)
+			return
+		}
+
+		if strings.ToLower(r.URL.Query().Get("unplayed")) == "true" {
+			unplayed := make([]models.TracklistTrack, 0)
+			for _, t := range tracklist {
+				if t.ListenCount == 0 {
+					unplayed = append(unplayed, t)
+				}
+			}
+			tracklist = unplayed
+		}
+
+		utils.WriteJSON(w, http.StatusOK, tracklist)
+	}
+}
+
+// FetchAlbumTracklistHandler fetches the tracklist of an album from its MusicBrainz release again.
+func FetchAlbumTracklistHandler(store db.DB, mbzc mbz.MusicBrainzCaller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("FetchAlbumTracklistHandler: Received request")
+
+		id, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("FetchAlbumTracklistHandler: Invalid id parameter")
+			utils.WriteError(w, "id is invalid", http.StatusBadRequest)
+			return
+		}
+
+		err = catalog.FetchMbzTracklist(ctx, store, mbzc, int32(id))
+		if errors.Is(err, pgx.ErrNoRows) {
+			l.Debug().Msgf("FetchAlbumTracklistHandler: Album %d not found", id)
+			utils.WriteError(w, "album not found", http.StatusNotFound)
+			return
+		} else if errors.Is(err, catalog.ErrAlbumWithoutMbzID) {
+			l.Debug().Msgf("FetchAlbumTracklistHandler: Album %d has no MusicBrainz ID", id)
+			utils.WriteError(w, "album has no MusicBrainz release ID", http.StatusBadRequest)
+			return
+		} else if errors.Is(err, catalog.ErrReleaseWithoutTracklist) {
+			l.Warn().Msgf("FetchAlbumTracklistHandler: MusicBrainz release of album %d has no tracklist", id)
+			utils.WriteError(w, "MusicBrainz release has no tracklist", http.StatusBadGateway)
+			return
+		} else if err != nil {
+			l.Err(err).Msgf("FetchAlbumTracklistHandler: Failed to fetch tracklist of album %d", id)
+			utils.WriteError(w, "failed to fetch tracklist", http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msgf("FetchAlbumTracklistHandler: Fetched tracklist of album %d", id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
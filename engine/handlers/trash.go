@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gabehf/koito/internal/cfg"
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+type TrashEntry struct {
+	db.TrashItem
+	// when the item will be deleted permanently by the daily trash purge
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// GetTrashHandler returns the deleted artists, albums and tracks that can still be restored.
+func GetTrashHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("GetTrashHandler: Received request to retrieve trash")
+
+		items, err := store.GetTrash(ctx)
+		if err != nil {
+			l.Err(err).Msg("GetTrashHandler: Failed to get trash")
+			utils.WriteError(w, "failed to get trash", http.StatusInternalServerError)
+			return
+		}
+
+		retention := cfg.TrashRetention()
+		entries := make([]TrashEntry, len(items))
+		for i, item := range items {
+			entries[i] = TrashEntry{
+				TrashItem: item,
+				ExpiresAt: item.DeletedAt.Add(retention),
+			}
+		}
+
+		utils.WriteJSON(w, http.StatusOK, entries)
+	}
+}
+
+// RestoreTrashHandler restores a deleted artist, album or track, along with everything that was deleted
+// with it.
+func RestoreTrashHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("RestoreTrashHandler: Received request to restore item from trash")
+
+		id, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("RestoreTrashHandler: Invalid id parameter")
+			utils.WriteError(w, "id is invalid", http.StatusBadRequest)
+			return
+		}
+
+		err = store.RestoreTrash(ctx, int32(id))
+		if errors.Is(err, pgx.ErrNoRows) {
+			l.Debug().Msgf("RestoreTrashHandler: Trash entry %d not found", id)
+			utils.WriteError(w, "trash entry not found", http.StatusNotFound)
+			return
+		} else if err != nil {
+			l.Err(err).Msg("RestoreTrashHandler: Failed to restore item from trash")
+			utils.WriteError(w, "failed to restore item from trash: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msgf("RestoreTrashHandler: Successfully restored trash entry %d", id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// DeleteTrashHandler permanently deletes an item in the trash, without waiting for it to expire.
+func DeleteTrashHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("DeleteTrashHandler: Received request to delete item from trash")
+
+		id, err := strconv.Atoi(r.URL.Query().Get("id"))
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("DeleteTrashHandler: Invalid id parameter")
+			utils.WriteError(w, "id is invalid", http.StatusBadRequest)
+			return
+		}
+
+		err = store.DeleteTrash(ctx, int32(id))
+		if errors.Is(err, pgx.ErrNoRows) {
+			l.Debug().Msgf("DeleteTrashHandler: Trash entry %d not found", id)
+			utils.WriteError(w, "trash entry not found", http.StatusNotFound)
+			return
+		} else if err != nil {
+			l.Err(err).Msg("DeleteTrashHandler: Failed to delete item from trash")
+			utils.WriteError(w, "failed to delete item from trash", http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msgf("DeleteTrashHandler: Successfully deleted trash entry %d", id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/utils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// UpdateTrackHandler changes the album, duration, MusicBrainz ID, or artists of a track.
+// Only the parameters that are provided are updated.
+func UpdateTrackHandler(store db.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l := logger.FromContext(ctx)
+
+		l.Debug().Msg("UpdateTrackHandler: Received request")
+
+		idStr := r.URL.Query().Get("id")
+		id, err := strconv.Atoi(idStr)
+		if err != nil {
+			l.Debug().AnErr("error", err).Msg("UpdateTrackHandler: Invalid id parameter")
+			utils.WriteError(w, "id is invalid", http.StatusBadRequest)
+			return
+		}
+		opts := db.UpdateTrackOpts{ID: int32(id)}
+
+		if albumIdStr := r.URL.Query().Get("album_id"); albumIdStr != "" {
+			albumId, err := strconv.Atoi(albumIdStr)
+			if err != nil {
+				l.Debug().AnErr("error", err).Msg("UpdateTrackHandler: Invalid album_id parameter")
+				utils.WriteError(w, "album_id is invalid", http.StatusBadRequest)
+				return
+			}
+			opts.AlbumID = int32(albumId)
+		}
+		if durationStr := r.URL.Query().Get("duration"); durationStr != "" {
+			duration, err := strconv.Atoi(durationStr)
+			if err != nil || duration < 1 {
+				l.Debug().Msg("UpdateTrackHandler: Invalid duration parameter")
+				utils.WriteError(w, "duration is invalid", http.StatusBadRequest)
+				return
+			}
+			opts.Duration = int32(duration)
+		}
+		if mbzIdStr := r.URL.Query().Get("musicbrainz_id"); mbzIdStr != "" {
+			mbzId, err := uuid.Parse(mbzIdStr)
+			if err != nil {
+				l.Debug().AnErr("error", err).Msg("UpdateTrackHandler: Invalid musicbrainz_id parameter")
+				utils.WriteError(w, "musicbrainz_id is invalid", http.StatusBadRequest)
+				return
+			}
+			opts.MusicBrainzID = mbzId
+		}
+		if artistIdsStr := r.URL.Query().Get("artist_ids"); artistIdsStr != "" {
+			opts.ArtistIDs, err = parseIDList(artistIdsStr)
+			if err != nil {
+				l.Debug().AnErr("error", err).Msg("UpdateTrackHandler: Invalid artist_ids parameter")
+				utils.WriteError(w, "artist_ids is invalid", http.StatusBadRequest)
+				return
+			}
+		}
+		if primaryIdStr := r.URL.Query().Get("primary_artist_id"); primaryIdStr != "" {
+			primaryId, err := strconv.Atoi(primaryIdStr)
+			if err != nil {
+				l.Debug().AnErr("error", err).Msg("UpdateTrackHandler: Invalid primary_artist_id parameter")
+				utils.WriteError(w, "primary_artist_id is invalid", http.StatusBadRequest)
+				return
+			}
+			opts.PrimaryArtistID = int32(primaryId)
+		}
+
+		err = store.UpdateTrack(ctx, opts)
+		if errors.Is(err, db.ErrInvalidTrackUpdate) {
+			l.Debug().AnErr("error", err).Msg("UpdateTrackHandler: Invalid track update")
+			utils.WriteError(w, "failed to update track: "+err.Error(), http.StatusBadRequest)
+			return
+		} else if errors.Is(err, pgx.ErrNoRows) {
+			l.Debug().Msgf("UpdateTrackHandler: Track %d not found", id)
+			utils.WriteError(w, "track not found", http.StatusNotFound)
+			return
+		} else if err != nil {
+			l.Err(err).Msg("UpdateTrackHandler: Failed to update track")
+			utils.WriteError(w, "failed to update track", http.StatusInternalServerError)
+			return
+		}
+
+		l.Debug().Msg("UpdateTrackHandler: Successfully updated track")
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/gabehf/koito/engine"
 	"github.com/gabehf/koito/internal/cfg"
 	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/importer"
 	"github.com/gabehf/koito/internal/logger"
 	"github.com/gabehf/koito/internal/mbz"
 	"github.com/gabehf/koito/internal/utils"
@@ -338,3 +339,52 @@ func TestImportKoito(t *testing.T) {
 
 	truncateTestData(t)
 }
+
+func TestImportMusicBrainzDump(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	artists := `{"id":"00000000-0000-0000-0000-000000000001","name":"ATARASHII GAKKO!","aliases":[{"name":"新しい学校のリーダーズ","primary":true},{"name":"Atarashii Gakko","primary":false}]}
+not json
+`
+	releaseGroups := `{"id":"00000000-0000-0000-0000-000000000011","title":"AG! Calling"}
+`
+	releases := `{"id":"00000000-0000-0000-0000-000000000101","title":"AG! Calling","release-group":{"id":"00000000-0000-0000-0000-000000000011","primary-type":"Album"}}
+{"id":"00000000-0000-0000-0000-000000000102","title":"AG! Calling (Deluxe)","release-group":{"id":"00000000-0000-0000-0000-000000000011","primary-type":"Album"}}
+`
+	recordings := `{"id":"00000000-0000-0000-0000-000000001001","title":"Tokyo Calling","length":191000}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "artist"), []byte(artists), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "release-group"), []byte(releaseGroups), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "release.jsonl"), []byte(releases), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "recording"), []byte(recordings), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "labels"), []byte("{}\n"), 0644))
+
+	for _, f := range []string{"artist", "release-group", "release.jsonl", "recording"} {
+		require.NoError(t, importer.ImportMusicBrainzDump(ctx, store, filepath.Join(dir, f)))
+	}
+	assert.Error(t, importer.ImportMusicBrainzDump(ctx, store, filepath.Join(dir, "labels")))
+
+	mbzc := mbz.NewMbzLocalCaller(store)
+
+	aliases, err := mbzc.GetArtistPrimaryAliases(ctx, uuid.MustParse("00000000-0000-0000-0000-000000000001"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ATARASHII GAKKO!", "新しい学校のリーダーズ"}, aliases)
+
+	titles, err := mbzc.GetReleaseTitles(ctx, uuid.MustParse("00000000-0000-0000-0000-000000000011"))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"AG! Calling", "AG! Calling (Deluxe)"}, titles)
+
+	release, err := mbzc.GetRelease(ctx, uuid.MustParse("00000000-0000-0000-0000-000000000102"))
+	require.NoError(t, err)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000011", release.ReleaseGroup.ID)
+
+	track, err := mbzc.GetTrack(ctx, uuid.MustParse("00000000-0000-0000-0000-000000001001"))
+	require.NoError(t, err)
+	assert.Equal(t, 191000, track.LengthMs)
+
+	_, err = mbzc.GetTrack(ctx, uuid.MustParse("00000000-0000-0000-0000-000000001002"))
+	assert.Error(t, err)
+	_, err = mbzc.SearchRecordings(ctx, mbz.SearchRecordingsOpts{Title: "Tokyo Calling"})
+	assert.ErrorIs(t, err, mbz.ErrSearchUnavailable)
+}
@@ -0,0 +1,36 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/gabehf/koito/internal/logger"
+)
+
+// runs the job right away, and then again every interval for as long as the server is running
+func scheduleJob(ctx context.Context, name string, interval time.Duration, job func(context.Context) error) {
+	l := logger.FromContext(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		l.Debug().Msgf("Running job '%s'", name)
+		if err := runJob(ctx, name, job); err != nil {
+			l.Err(err).Msgf("Job '%s' failed", name)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runs the job once, recovering from a panic so that the next scheduled run still happens
+func runJob(ctx context.Context, name string, job func(context.Context) error) error {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.FromContext(ctx).Error().Interface("recover", r).Msgf("Panic when running job '%s'", name)
+		}
+	}()
+	return job(ctx)
+}
@@ -0,0 +1,45 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/gabehf/koito/internal/cfg"
+	"github.com/gabehf/koito/internal/db/psql"
+	"github.com/gabehf/koito/internal/importer"
+	"github.com/gabehf/koito/internal/logger"
+)
+
+// ImportMusicBrainzDump imports entity files from a MusicBrainz JSON data dump into the database,
+// so that they can be used with KOITO_USE_LOCAL_MUSICBRAINZ.
+func ImportMusicBrainzDump(
+	getenv func(string) string,
+	w io.Writer,
+	version string,
+	files []string,
+) error {
+	err := cfg.Load(getenv, version)
+	if err != nil {
+		return fmt.Errorf("ImportMusicBrainzDump: %w", err)
+	}
+
+	l := logger.Get()
+	setLogOutput(l, w)
+	ctx := logger.NewContext(l)
+
+	l.Info().Msgf("Koito %s", version)
+
+	store, err := psql.New()
+	if err != nil {
+		return fmt.Errorf("ImportMusicBrainzDump: %w", err)
+	}
+	defer store.Close(ctx)
+
+	for _, file := range files {
+		err = importer.ImportMusicBrainzDump(ctx, store, file)
+		if err != nil {
+			return fmt.Errorf("ImportMusicBrainzDump: %w", err)
+		}
+	}
+	return nil
+}
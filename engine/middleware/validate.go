@@ -116,6 +116,27 @@ func ValidateApiKey(store db.DB) func(next http.Handler) http.Handler {
 	}
 }
 
+// RequireAdmin only allows admin users through. It must be used after ValidateSession or ValidateApiKey.
+func RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l := logger.FromContext(r.Context())
+
+		u := GetUserFromContext(r.Context())
+		if u == nil {
+			l.Debug().Msg("RequireAdmin: No user in request context")
+			utils.WriteError(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if u.Role != models.UserRoleAdmin {
+			l.Debug().Msgf("RequireAdmin: User '%s' is not an admin", u.Username)
+			utils.WriteError(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 func GetUserFromContext(ctx context.Context) *models.User {
 	user, ok := ctx.Value(UserContextKey).(*models.User)
 	if !ok {
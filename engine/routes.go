@@ -38,15 +38,22 @@ func bindRoutes(
 		r.Get("/artist", handlers.GetArtistHandler(db))
 		r.Get("/artists", handlers.GetArtistsForItemHandler(db))
 		r.Get("/album", handlers.GetAlbumHandler(db))
+		r.Get("/album/tracklist", handlers.GetAlbumTracklistHandler(db))
+		r.Get("/release-group", handlers.GetReleaseGroupHandler(db))
 		r.Get("/track", handlers.GetTrackHandler(db))
 		r.Get("/top-tracks", handlers.GetTopTracksHandler(db))
 		r.Get("/top-albums", handlers.GetTopAlbumsHandler(db))
 		r.Get("/top-artists", handlers.GetTopArtistsHandler(db))
+		r.Get("/top-tags", handlers.GetTopTagsHandler(db))
 		r.Get("/listens", handlers.GetListensHandler(db))
 		r.Get("/listen-activity", handlers.GetListenActivityHandler(db))
 		r.Get("/stats", handlers.StatsHandler(db))
+		r.Get("/stats/release-years", handlers.ReleaseYearStatsHandler(db))
+		r.Get("/stats/release-types", handlers.ReleaseTypeStatsHandler(db))
+		r.Get("/stats/countries", handlers.CountryStatsHandler(db))
 		r.Get("/search", handlers.SearchHandler(db))
 		r.Get("/aliases", handlers.GetAliasesHandler(db))
+		r.Get("/tags", handlers.GetTagsHandler(db))
 		r.Post("/logout", handlers.LogoutHandler(db))
 		if !cfg.RateLimitDisabled() {
 			r.With(httprate.Limit(
@@ -73,23 +80,59 @@ func bindRoutes(
 			r.Get("/export", handlers.ExportHandler(db))
 			r.Post("/replace-image", handlers.ReplaceImageHandler(db))
 			r.Patch("/album", handlers.UpdateAlbumHandler(db))
+			r.Post("/album/edition", handlers.SetAlbumEditionHandler(db))
+			r.Delete("/album/edition", handlers.DeleteAlbumEditionHandler(db))
+			r.Post("/album/tracklist", handlers.FetchAlbumTracklistHandler(db, mbz))
+			r.Patch("/track", handlers.UpdateTrackHandler(db))
 			r.Post("/merge/tracks", handlers.MergeTracksHandler(db))
 			r.Post("/merge/albums", handlers.MergeReleaseGroupsHandler(db))
 			r.Post("/merge/artists", handlers.MergeArtistsHandler(db))
+			r.Get("/merge/preview", handlers.PreviewMergeHandler(db))
+			r.Get("/merge/candidates", handlers.GetMergeCandidatesHandler(db))
+			r.Post("/merge/candidates/accept", handlers.AcceptMergeCandidateHandler(db))
+			r.Post("/merge/candidates/dismiss", handlers.DismissMergeCandidateHandler(db))
+			r.Post("/merge/candidates/refresh", handlers.RefreshMergeCandidatesHandler(db))
+			r.Post("/bulk", handlers.BulkOperationsHandler(db))
+			r.Get("/musicbrainz/suggestions", handlers.GetMbzMatchSuggestionsHandler(db))
+			r.Post("/musicbrainz/suggestions/accept", handlers.AcceptMbzMatchSuggestionHandler(db, mbz))
+			r.Post("/musicbrainz/suggestions/dismiss", handlers.DismissMbzMatchSuggestionHandler(db))
+			r.Get("/fuzzy-matches", handlers.GetFuzzyMatchesHandler(db))
+			r.Post("/fuzzy-matches/confirm", handlers.ConfirmFuzzyMatchHandler(db))
+			r.Post("/fuzzy-matches/reject", handlers.RejectFuzzyMatchHandler(db))
+			r.Get("/musicbrainz/cache", handlers.GetMbzCacheStatsHandler(db))
+			r.Delete("/musicbrainz/cache", handlers.PurgeMbzCacheHandler(db))
+			r.Get("/artist-split-rules", handlers.GetArtistSplitRulesHandler(db))
+			r.Post("/artist-split-rules", handlers.CreateArtistSplitRuleHandler(db))
+			r.Delete("/artist-split-rules", handlers.DeleteArtistSplitRuleHandler(db))
+			r.Post("/split/artists", handlers.SplitArtistHandler(db))
+			r.Post("/split/albums", handlers.SplitAlbumHandler(db))
 			r.Delete("/artist", handlers.DeleteArtistHandler(db))
 			r.Post("/artists/primary", handlers.SetPrimaryArtistHandler(db))
+			r.Post("/artists/relations", handlers.CreateArtistRelationHandler(db))
+			r.Post("/artists/relations/delete", handlers.DeleteArtistRelationHandler(db))
 			r.Delete("/album", handlers.DeleteAlbumHandler(db))
 			r.Delete("/track", handlers.DeleteTrackHandler(db))
 			r.Delete("/listen", handlers.DeleteListenHandler(db))
+			r.Get("/delete/preview", handlers.PreviewDeleteHandler(db))
+			r.Get("/trash", handlers.GetTrashHandler(db))
+			r.Post("/trash/restore", handlers.RestoreTrashHandler(db))
+			r.Delete("/trash", handlers.DeleteTrashHandler(db))
 			r.Post("/aliases", handlers.CreateAliasHandler(db))
 			r.Post("/aliases/delete", handlers.DeleteAliasHandler(db))
 			r.Post("/aliases/primary", handlers.SetPrimaryAliasHandler(db))
+			r.Post("/tags", handlers.CreateTagHandler(db))
+			r.Post("/tags/delete", handlers.DeleteTagHandler(db))
+			r.Get("/hidden", handlers.GetHiddenHandler(db))
+			r.Post("/hidden", handlers.HideHandler(db))
+			r.Post("/hidden/delete", handlers.UnhideHandler(db))
 			r.Get("/user/apikeys", handlers.GetApiKeysHandler(db))
 			r.Post("/user/apikeys", handlers.GenerateApiKeyHandler(db))
 			r.Patch("/user/apikeys", handlers.UpdateApiKeyLabelHandler(db))
 			r.Delete("/user/apikeys", handlers.DeleteApiKeyHandler(db))
 			r.Get("/user/me", handlers.MeHandler(db))
 			r.Patch("/user", handlers.UpdateUserHandler(db))
+			r.With(middleware.RequireAdmin).Get("/integrity", handlers.CheckIntegrityHandler(db))
+			r.With(middleware.RequireAdmin).Post("/integrity/repair", handlers.RepairIntegrityHandler(db))
 		})
 	})
 
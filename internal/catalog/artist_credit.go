@@ -0,0 +1,168 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/mbz"
+	"github.com/gabehf/koito/internal/models"
+	"github.com/google/uuid"
+)
+
+// mbzArtistCredit maps a MusicBrainz artist credit onto the artists it was associated with, by
+// MusicBrainz ID or name. Nil is returned if any artist of the credit is not one of the artists.
+func mbzArtistCredit(credits []mbz.MusicBrainzArtistCredit, artists []*models.Artist) []db.ArtistCredit {
+	if len(credits) == 0 {
+		return nil
+	}
+	ret := make([]db.ArtistCredit, 0, len(credits))
+	for _, c := range credits {
+		i := slices.IndexFunc(artists, func(a *models.Artist) bool {
+			return a.MbzID != nil && a.MbzID.String() == c.Artist.ID
+		})
+		if i < 0 {
+			i = slices.IndexFunc(artists, func(a *models.Artist) bool {
+				return namesMatch(a.Name, c.Name) || namesMatch(a.Name, c.Artist.Name)
+			})
+		}
+		if i < 0 || slices.ContainsFunc(ret, func(rc db.ArtistCredit) bool { return rc.ArtistID == artists[i].ID }) {
+			return nil
+		}
+		ret = append(ret, db.ArtistCredit{
+			ArtistID:   artists[i].ID,
+			Name:       c.Name,
+			JoinPhrase: c.JoinPhrase,
+		})
+	}
+	return ret
+}
+
+// parseArtistCredit finds the artists in a submitted artist credit such as "A feat. B" or "A x B",
+// and returns the credit with the name each artist is credited as and the phrases joining them.
+// Artists that do not appear in the credit are left out of it.
+func parseArtistCredit(credit string, artists []*models.Artist) []db.ArtistCredit {
+	// searching case insensitively only works if lowercasing keeps the byte offsets intact
+	search := strings.ToLower(credit)
+	fold := len(search) == len(credit)
+	if !fold {
+		search = credit
+	}
+
+	type found struct {
+		artist     *models.Artist
+		start, end int
+	}
+	var matches []found
+	for _, a := range artists {
+		name := strings.TrimSpace(a.Name)
+		if name == "" {
+			continue
+		}
+		if fold {
+			name = strings.ToLower(name)
+		}
+		i := indexName(search, name)
+		if i < 0 {
+			continue
+		}
+		matches = append(matches, found{artist: a, start: i, end: i + len(name)})
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+	slices.SortFunc(matches, func(a, b found) int { return a.start - b.start })
+
+	ret := make([]db.ArtistCredit, len(matches))
+	for i, m := range matches {
+		ret[i] = db.ArtistCredit{
+			ArtistID: m.artist.ID,
+			Name:     credit[m.start:m.end],
+		}
+		if i < len(matches)-1 {
+			next := matches[i+1]
+			if next.start < m.end {
+				// one name is part of another, so the credit can't be told apart
+				return nil
+			}
+			ret[i].JoinPhrase = credit[m.end:next.start]
+		}
+	}
+	return ret
+}
+
+// indexName returns the index of the first occurrence of name in s that is not part of a longer word
+func indexName(s, name string) int {
+	for offset := 0; offset < len(s); {
+		i := strings.Index(s[offset:], name)
+		if i < 0 {
+			return -1
+		}
+		start, end := offset+i, offset+i+len(name)
+		before, _ := utf8.DecodeLastRuneInString(s[:start])
+		after, _ := utf8.DecodeRuneInString(s[end:])
+		if (start == 0 || !isWordRune(before)) && (end == len(s) || !isWordRune(after)) {
+			return start
+		}
+		offset = start + 1
+	}
+	return -1
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// saveTrackArtistCredit stores the artist credit of a new track, from its MusicBrainz recording
+// when there is one, or from the artist credit it was submitted with
+func saveTrackArtistCredit(ctx context.Context, d db.DB, opts AssociateTrackOpts, trackID int32) error {
+	artists, err := d.GetArtistsForTrack(ctx, trackID)
+	if err != nil {
+		return fmt.Errorf("saveTrackArtistCredit: %w", err)
+	}
+	var credit []db.ArtistCredit
+	if opts.TrackMbzID != uuid.Nil && opts.Mbzc != nil {
+		mbzTrack, err := opts.Mbzc.GetTrack(ctx, opts.TrackMbzID)
+		if err == nil {
+			credit = mbzArtistCredit(mbzTrack.ArtistCredit, artists)
+		}
+	}
+	if credit == nil {
+		credit = parseArtistCredit(opts.ArtistCredit, artists)
+	}
+	if len(credit) == 0 {
+		return nil
+	}
+	logger.FromContext(ctx).Debug().Msgf("Saving artist credit for track %d", trackID)
+	err = d.SetTrackArtistCredit(ctx, trackID, credit)
+	if err != nil {
+		return fmt.Errorf("saveTrackArtistCredit: %w", err)
+	}
+	return nil
+}
+
+// saveAlbumArtistCredit stores the artist credit of a new album, from its MusicBrainz release when
+// there is one, or from the artist credit it was submitted with
+func saveAlbumArtistCredit(ctx context.Context, d db.DB, opts AssociateAlbumOpts, albumID int32, release *mbz.MusicBrainzRelease) error {
+	var credit []db.ArtistCredit
+	if release != nil {
+		credit = mbzArtistCredit(release.ArtistCredit, opts.Artists)
+	}
+	if credit == nil {
+		credit = parseArtistCredit(opts.ArtistCredit, opts.Artists)
+	}
+	if len(credit) == 0 {
+		return nil
+	}
+	logger.FromContext(ctx).Debug().Msgf("Saving artist credit for album %d", albumID)
+	err := d.SetAlbumArtistCredit(ctx, albumID, credit)
+	if err != nil {
+		return fmt.Errorf("saveAlbumArtistCredit: %w", err)
+	}
+	return nil
+}
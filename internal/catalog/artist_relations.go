@@ -0,0 +1,62 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/mbz"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// the MusicBrainz artist relationship types that artists are related by. The first artist of each
+// type is the member or the person behind the performance name
+var mbzArtistRelationTypes = map[string]db.ArtistRelationType{
+	"member of band": db.ArtistRelationMemberOf,
+	"collaboration":  db.ArtistRelationMemberOf,
+	"is person":      db.ArtistRelationPerformsAs,
+}
+
+// saveMbzArtistRelations relates an artist to the artists in the catalog that it has a MusicBrainz
+// relationship with. Related artists that are not in the catalog yet are related once they are added,
+// through their own relationships.
+func saveMbzArtistRelations(ctx context.Context, d db.DB, artistID int32, artist *mbz.MusicBrainzArtist) error {
+	l := logger.FromContext(ctx)
+	for _, rel := range artist.Relations {
+		relation, ok := mbzArtistRelationTypes[rel.Type]
+		if !ok {
+			continue
+		}
+		mbzID, err := uuid.Parse(rel.Artist.ID)
+		if err != nil {
+			continue
+		}
+		related, err := d.GetArtist(ctx, db.GetArtistOpts{MusicBrainzID: mbzID})
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("saveMbzArtistRelations: %w", err)
+		}
+		if related.ID == artistID {
+			continue
+		}
+		opts := db.SaveArtistRelationOpts{
+			ArtistID:        artistID,
+			RelatedArtistID: related.ID,
+			Relation:        relation,
+			Source:          "MusicBrainz",
+		}
+		if rel.Direction == "backward" {
+			opts.ArtistID, opts.RelatedArtistID = related.ID, artistID
+		}
+		l.Debug().Msgf("Relating artist %d as %s artist %d", opts.ArtistID, relation, opts.RelatedArtistID)
+		err = d.SaveArtistRelation(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("saveMbzArtistRelations: %w", err)
+		}
+	}
+	return nil
+}
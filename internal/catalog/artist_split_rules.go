@@ -0,0 +1,173 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gabehf/koito/internal/db"
+)
+
+// ArtistSplitRules are the user managed rules that artist strings are split by, on top of the built in
+// feat. handling. Both lists are matched case insensitively.
+type ArtistSplitRules struct {
+	// Separators that artists are split on, e.g. ";" or " / "
+	Separators []string
+	// Artist names that are never split, e.g. "Simon & Garfunkel"
+	Protected []string
+
+	// the patterns of the rules, when they have been compiled ahead of time
+	compiled *compiledSplitRules
+}
+
+type compiledSplitRules struct {
+	separators *regexp.Regexp
+	protected  []*regexp.Regexp
+}
+
+// LoadArtistSplitRules reads the artist split rules from the database
+func LoadArtistSplitRules(ctx context.Context, d db.DB) (ArtistSplitRules, error) {
+	rows, err := d.GetArtistSplitRules(ctx)
+	if err != nil {
+		return ArtistSplitRules{}, fmt.Errorf("LoadArtistSplitRules: %w", err)
+	}
+	var rules ArtistSplitRules
+	for _, r := range rows {
+		switch db.ArtistSplitRuleKind(r.Kind) {
+		case db.ArtistSplitRuleSeparator:
+			rules.Separators = append(rules.Separators, r.Value)
+		case db.ArtistSplitRuleProtected:
+			rules.Protected = append(rules.Protected, r.Value)
+		}
+	}
+	return rules, nil
+}
+
+// Compile returns the rules with their patterns compiled, so that applying them to many artist strings,
+// like every scrobble of an import, doesn't compile them again each time. The separators and protected
+// names must not be changed after the rules are compiled.
+func (r ArtistSplitRules) Compile() ArtistSplitRules {
+	r.compiled = &compiledSplitRules{
+		separators: r.compileSeparators(),
+		protected:  r.compileProtected(),
+	}
+	return r
+}
+
+// Split splits s on separator and the custom separators, without splitting any protected name
+func (r ArtistSplitRules) Split(s string, separator *regexp.Regexp) []string {
+	p := r.protector()
+	var out []string
+	for _, name := range splitOn(p.protect(s), separator, r.separatorPattern()) {
+		name = strings.TrimSpace(p.restore(name))
+		if name != "" {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// separatorPattern returns a pattern matching any of the custom separators, or nil if there are none
+func (r ArtistSplitRules) separatorPattern() *regexp.Regexp {
+	if r.compiled != nil {
+		return r.compiled.separators
+	}
+	return r.compileSeparators()
+}
+
+func (r ArtistSplitRules) compileSeparators() *regexp.Regexp {
+	var alts []string
+	for _, sep := range r.Separators {
+		if strings.TrimSpace(sep) != "" {
+			alts = append(alts, regexp.QuoteMeta(sep))
+		}
+	}
+	if len(alts) == 0 {
+		return nil
+	}
+	return regexp.MustCompile(`(?i)(?:` + strings.Join(alts, "|") + `)`)
+}
+
+// splitOn splits s on each of the delimiters in turn, skipping nil delimiters
+func splitOn(s string, delimiters ...*regexp.Regexp) []string {
+	parts := []string{s}
+	for _, re := range delimiters {
+		if re == nil {
+			continue
+		}
+		var next []string
+		for _, part := range parts {
+			next = append(next, re.Split(part, -1)...)
+		}
+		parts = next
+	}
+	return parts
+}
+
+// protectedPlaceholder matches the placeholders that protected names are replaced with. They contain
+// nothing that any delimiter could split on.
+var protectedPlaceholder = regexp.MustCompile("\x00([0-9]+)\x00")
+
+// nameProtector swaps protected names for placeholders before splitting, and back after
+type nameProtector struct {
+	patterns []*regexp.Regexp
+	names    []string
+}
+
+func (r ArtistSplitRules) protector() *nameProtector {
+	if r.compiled != nil {
+		return &nameProtector{patterns: r.compiled.protected}
+	}
+	return &nameProtector{patterns: r.compileProtected()}
+}
+
+func (r ArtistSplitRules) compileProtected() []*regexp.Regexp {
+	protected := slices.Clone(r.Protected)
+	// longer names first, so that a protected name containing another is kept whole
+	slices.SortFunc(protected, func(a, b string) int { return len(b) - len(a) })
+	var patterns []*regexp.Regexp
+	for _, name := range protected {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			patterns = append(patterns, regexp.MustCompile(`(?i)`+regexp.QuoteMeta(name)))
+		}
+	}
+	return patterns
+}
+
+// protect replaces every protected name in s that is not part of a longer word with a placeholder
+func (p *nameProtector) protect(s string) string {
+	for _, re := range p.patterns {
+		var b strings.Builder
+		last := 0
+		for _, m := range re.FindAllStringIndex(s, -1) {
+			before, _ := utf8.DecodeLastRuneInString(s[:m[0]])
+			after, _ := utf8.DecodeRuneInString(s[m[1]:])
+			if (m[0] > 0 && isWordRune(before)) || (m[1] < len(s) && isWordRune(after)) {
+				continue
+			}
+			b.WriteString(s[last:m[0]])
+			b.WriteString("\x00" + strconv.Itoa(len(p.names)) + "\x00")
+			p.names = append(p.names, s[m[0]:m[1]])
+			last = m[1]
+		}
+		b.WriteString(s[last:])
+		s = b.String()
+	}
+	return s
+}
+
+// restore puts the protected names back in place of their placeholders
+func (p *nameProtector) restore(s string) string {
+	return protectedPlaceholder.ReplaceAllStringFunc(s, func(m string) string {
+		i, err := strconv.Atoi(strings.Trim(m, "\x00"))
+		if err != nil || i >= len(p.names) {
+			return m
+		}
+		return p.names[i]
+	})
+}
@@ -25,6 +25,11 @@ type AssociateAlbumOpts struct {
 	TrackName         string // required
 	Mbzc              mbz.MusicBrainzCaller
 	SkipCacheImage    bool
+	// The artist credit of the album artists as submitted, e.g. "A x B"
+	ArtistCredit string
+
+	// Collects the ids of provisional fuzzy matches, see fuzzyMatch
+	FuzzyMatches *[]int32
 }
 
 func AssociateAlbum(ctx context.Context, d db.DB, opts AssociateAlbumOpts) (*models.Album, error) {
@@ -39,6 +44,9 @@ func AssociateAlbum(ctx context.Context, d db.DB, opts AssociateAlbumOpts) (*mod
 	if opts.ReleaseMbzID != uuid.Nil {
 		l.Debug().Msgf("Associating album '%s' by MusicBrainz release ID", releaseTitle)
 		return matchAlbumByMbzReleaseID(ctx, d, opts)
+	} else if opts.ReleaseGroupMbzID != uuid.Nil {
+		l.Debug().Msgf("Associating album '%s' by MusicBrainz release group ID", releaseTitle)
+		return matchAlbumByMbzReleaseGroupID(ctx, d, opts)
 	} else {
 		l.Debug().Msgf("Associating album '%s' by title and artist", releaseTitle)
 		return matchAlbumByTitle(ctx, d, opts)
@@ -61,6 +69,14 @@ func matchAlbumByMbzReleaseID(ctx context.Context, d db.DB, opts AssociateAlbumO
 		return nil, fmt.Errorf("matchAlbumByMbzReleaseID: %w", err)
 	} else {
 		l.Debug().Msgf("Album '%s' could not be found by MusicBrainz Release ID", opts.ReleaseName)
+		if opts.ReleaseGroupMbzID != uuid.Nil {
+			a, err = findAlbumByReleaseGroup(ctx, d, opts.ReleaseGroupMbzID)
+			if err == nil {
+				return a, nil
+			} else if !errors.Is(err, pgx.ErrNoRows) {
+				return nil, fmt.Errorf("matchAlbumByMbzReleaseID: %w", err)
+			}
+		}
 		rg, err := createOrUpdateAlbumWithMbzReleaseID(ctx, d, opts)
 		if err != nil {
 			return matchAlbumByTitle(ctx, d, opts)
@@ -69,6 +85,34 @@ func matchAlbumByMbzReleaseID(ctx context.Context, d db.DB, opts AssociateAlbumO
 	}
 }
 
+// matchAlbumByMbzReleaseGroupID matches an album by its MusicBrainz release group, so that different
+// releases of the same album are associated with the same album.
+func matchAlbumByMbzReleaseGroupID(ctx context.Context, d db.DB, opts AssociateAlbumOpts) (*models.Album, error) {
+	a, err := findAlbumByReleaseGroup(ctx, d, opts.ReleaseGroupMbzID)
+	if err == nil {
+		return a, nil
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("matchAlbumByMbzReleaseGroupID: %w", err)
+	}
+	return matchAlbumByTitle(ctx, d, opts)
+}
+
+func findAlbumByReleaseGroup(ctx context.Context, d db.DB, rgID uuid.UUID) (*models.Album, error) {
+	a, err := d.GetAlbum(ctx, db.GetAlbumOpts{ReleaseGroupMbzID: rgID})
+	if err != nil {
+		return nil, err
+	}
+	logger.FromContext(ctx).Debug().Msgf("Found album '%s' by MusicBrainz Release Group ID", a.Title)
+	return &models.Album{
+		ID:                a.ID,
+		MbzID:             a.MbzID,
+		ReleaseGroupMbzID: &rgID,
+		Title:             a.Title,
+		VariousArtists:    a.VariousArtists,
+		Image:             a.Image,
+	}, nil
+}
+
 func createOrUpdateAlbumWithMbzReleaseID(ctx context.Context, d db.DB, opts AssociateAlbumOpts) (*models.Album, error) {
 	l := logger.FromContext(ctx)
 
@@ -78,20 +122,33 @@ func createOrUpdateAlbumWithMbzReleaseID(ctx context.Context, d db.DB, opts Asso
 		return matchAlbumByTitle(ctx, d, opts)
 	}
 
+	if opts.ReleaseGroupMbzID == uuid.Nil {
+		if rgID, err := uuid.Parse(release.ReleaseGroup.ID); err == nil {
+			opts.ReleaseGroupMbzID = rgID
+			a, err := findAlbumByReleaseGroup(ctx, d, rgID)
+			if err == nil {
+				return a, nil
+			} else if !errors.Is(err, pgx.ErrNoRows) {
+				return nil, fmt.Errorf("createOrUpdateAlbumWithMbzReleaseID: %w", err)
+			}
+		}
+	}
+
 	var album *models.Album
 	titles := []string{release.Title, opts.ReleaseName}
 	utils.Unique(&titles)
 
 	l.Debug().Msgf("Searching for albums '%v' from artist id %d in DB", titles, opts.Artists[0].ID)
-	album, err = d.GetAlbum(ctx, db.GetAlbumOpts{
+	album, err = findAlbum(ctx, d, db.GetAlbumOpts{
 		ArtistID: opts.Artists[0].ID,
 		Titles:   titles,
 	})
 	if err == nil {
 		l.Debug().Msgf("Found album %s, updating with MusicBrainz Release ID...", album.Title)
 		err := d.UpdateAlbum(ctx, db.UpdateAlbumOpts{
-			ID:            album.ID,
-			MusicBrainzID: opts.ReleaseMbzID,
+			ID:                album.ID,
+			MusicBrainzID:     opts.ReleaseMbzID,
+			ReleaseGroupMbzID: opts.ReleaseGroupMbzID,
 		})
 		if err != nil {
 			l.Err(err).Msg("createOrUpdateAlbumWithMbzReleaseID: failed to update album with MusicBrainz Release ID")
@@ -99,6 +156,11 @@ func createOrUpdateAlbumWithMbzReleaseID(ctx context.Context, d db.DB, opts Asso
 		}
 		l.Debug().Msgf("Updated album '%s' with MusicBrainz Release ID", album.Title)
 
+		saveMbzReleaseInfo(ctx, d, opts.Mbzc, album.ID, release, opts.ReleaseGroupMbzID)
+		if err := saveMbzTracklist(ctx, d, album.ID, release); err != nil {
+			l.Err(err).Msg("createOrUpdateAlbumWithMbzReleaseID: failed to save tracklist")
+		}
+
 		if opts.ReleaseGroupMbzID != uuid.Nil {
 			aliases, err := opts.Mbzc.GetReleaseTitles(ctx, opts.ReleaseGroupMbzID)
 			if err == nil {
@@ -155,16 +217,29 @@ func createOrUpdateAlbumWithMbzReleaseID(ctx context.Context, d db.DB, opts Asso
 		}
 
 		album, err = d.SaveAlbum(ctx, db.SaveAlbumOpts{
-			Title:          release.Title,
-			MusicBrainzID:  opts.ReleaseMbzID,
-			ArtistIDs:      utils.FlattenArtistIDs(opts.Artists),
-			VariousArtists: variousArtists,
-			Image:          imgid,
-			ImageSrc:       imgUrl,
+			Title:             release.Title,
+			MusicBrainzID:     opts.ReleaseMbzID,
+			ReleaseGroupMbzID: opts.ReleaseGroupMbzID,
+			ArtistIDs:         utils.FlattenArtistIDs(opts.Artists),
+			VariousArtists:    variousArtists,
+			Image:             imgid,
+			ImageSrc:          imgUrl,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("createOrUpdateAlbumWithMbzReleaseID: %w", err)
 		}
+		if err := saveRomanizedAlias(ctx, d, db.ItemTypeAlbum, album.ID, album.Title); err != nil {
+			l.Err(err).Msg("createOrUpdateAlbumWithMbzReleaseID: failed to save romanized alias")
+		}
+		if err := groupAlbumEdition(ctx, d, album.ID, album.Title, opts.Artists[0].ID); err != nil {
+			l.Err(err).Msg("createOrUpdateAlbumWithMbzReleaseID: failed to group album edition")
+		}
+		if err := saveAlbumArtistCredit(ctx, d, opts, album.ID, release); err != nil {
+			l.Err(err).Msg("createOrUpdateAlbumWithMbzReleaseID: failed to save artist credit")
+		}
+		if err := saveMbzTracklist(ctx, d, album.ID, release); err != nil {
+			l.Err(err).Msg("createOrUpdateAlbumWithMbzReleaseID: failed to save tracklist")
+		}
 
 		if opts.ReleaseGroupMbzID != uuid.Nil {
 			aliases, err := opts.Mbzc.GetReleaseTitles(ctx, opts.ReleaseGroupMbzID)
@@ -179,6 +254,8 @@ func createOrUpdateAlbumWithMbzReleaseID(ctx context.Context, d db.DB, opts Asso
 			}
 		}
 
+		saveMbzReleaseInfo(ctx, d, opts.Mbzc, album.ID, release, opts.ReleaseGroupMbzID)
+
 		l.Info().Msgf("Created album '%s' with MusicBrainz Release ID", album.Title)
 	}
 
@@ -190,6 +267,62 @@ func createOrUpdateAlbumWithMbzReleaseID(ctx context.Context, d db.DB, opts Asso
 	}, nil
 }
 
+// saveMbzReleaseInfo saves the release date and types of an album from its MusicBrainz release group.
+// Failures are only logged, as the album is still usable without them.
+func saveMbzReleaseInfo(ctx context.Context, d db.DB, mbzc mbz.MusicBrainzCaller, albumID int32, release *mbz.MusicBrainzRelease, rgID uuid.UUID) {
+	l := logger.FromContext(ctx)
+	if rgID == uuid.Nil {
+		var err error
+		rgID, err = uuid.Parse(release.ReleaseGroup.ID)
+		if err != nil {
+			l.Debug().Msgf("saveMbzReleaseInfo: no release group found for album %d", albumID)
+			return
+		}
+	}
+	rg, err := mbzc.GetReleaseGroup(ctx, rgID)
+	if err != nil {
+		l.Info().AnErr("err", err).Msg("saveMbzReleaseInfo: failed to get release group from MusicBrainz")
+		return
+	}
+	err = updateReleaseInfo(ctx, d, albumID, rgID, rg)
+	if err != nil {
+		l.Err(err).Msg("saveMbzReleaseInfo: failed to save release info")
+	}
+}
+
+func updateReleaseInfo(ctx context.Context, d db.DB, albumID int32, rgID uuid.UUID, rg *mbz.MusicBrainzReleaseGroup) error {
+	return d.UpdateAlbum(ctx, db.UpdateAlbumOpts{
+		ID:                albumID,
+		ReleaseGroupMbzID: rgID,
+		ReleaseInfoUpdate: true,
+		ReleaseDate:       rg.FirstReleaseDate,
+		ReleaseType:       rg.Type,
+		SecondaryTypes:    rg.SecondaryTypes,
+	})
+}
+
+// setMissingReleaseGroup saves the MusicBrainz release group of an album that was matched by title, unless
+// it already belongs to a release group. Failures are only logged.
+func setMissingReleaseGroup(ctx context.Context, d db.DB, albumID int32, rgID uuid.UUID) {
+	l := logger.FromContext(ctx)
+	album, err := d.GetAlbum(ctx, db.GetAlbumOpts{ID: albumID})
+	if err != nil {
+		l.Err(err).Msg("setMissingReleaseGroup: failed to get album")
+		return
+	}
+	if album.ReleaseGroupMbzID != nil {
+		return
+	}
+	l.Debug().Msgf("Updating album with id %d with MusicBrainz Release Group ID %s", albumID, rgID)
+	err = d.UpdateAlbum(ctx, db.UpdateAlbumOpts{
+		ID:                albumID,
+		ReleaseGroupMbzID: rgID,
+	})
+	if err != nil {
+		l.Err(err).Msg("setMissingReleaseGroup: failed to associate existing release with MusicBrainz Release Group ID")
+	}
+}
+
 func matchAlbumByTitle(ctx context.Context, d db.DB, opts AssociateAlbumOpts) (*models.Album, error) {
 	l := logger.FromContext(ctx)
 
@@ -200,10 +333,13 @@ func matchAlbumByTitle(ctx context.Context, d db.DB, opts AssociateAlbumOpts) (*
 		releaseName = opts.TrackName
 	}
 
-	a, err := d.GetAlbum(ctx, db.GetAlbumOpts{
+	a, err := findAlbum(ctx, d, db.GetAlbumOpts{
 		Title:    releaseName,
 		ArtistID: opts.Artists[0].ID,
 	})
+	if errors.Is(err, pgx.ErrNoRows) && opts.ReleaseMbzID == uuid.Nil {
+		a, err = fuzzyFindAlbum(ctx, d, releaseName, opts.Artists[0].ID, opts.FuzzyMatches)
+	}
 	if err == nil {
 		l.Debug().Msgf("Found album '%s' by artist and title", a.Title)
 		if a.MbzID == nil && opts.ReleaseMbzID != uuid.Nil {
@@ -216,6 +352,9 @@ func matchAlbumByTitle(ctx context.Context, d db.DB, opts AssociateAlbumOpts) (*
 				l.Err(err).Msg("matchAlbumByTitle: failed to associate existing release with MusicBrainz ID")
 			}
 		}
+		if opts.ReleaseGroupMbzID != uuid.Nil {
+			setMissingReleaseGroup(ctx, d, a.ID, opts.ReleaseGroupMbzID)
+		}
 	} else if !errors.Is(err, pgx.ErrNoRows) {
 		return nil, fmt.Errorf("matchAlbumByTitle: %w", err)
 	} else {
@@ -246,16 +385,26 @@ func matchAlbumByTitle(ctx context.Context, d db.DB, opts AssociateAlbumOpts) (*
 		}
 
 		a, err = d.SaveAlbum(ctx, db.SaveAlbumOpts{
-			Title:         releaseName,
-			ArtistIDs:     utils.FlattenArtistIDs(opts.Artists),
-			Image:         imgid,
-			MusicBrainzID: opts.ReleaseMbzID,
-			ImageSrc:      imgUrl,
+			Title:             releaseName,
+			ArtistIDs:         utils.FlattenArtistIDs(opts.Artists),
+			Image:             imgid,
+			MusicBrainzID:     opts.ReleaseMbzID,
+			ReleaseGroupMbzID: opts.ReleaseGroupMbzID,
+			ImageSrc:          imgUrl,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("matchAlbumByTitle: %w", err)
 		}
 		l.Info().Msgf("Created album '%s' with artist and title", a.Title)
+		if err := saveRomanizedAlias(ctx, d, db.ItemTypeAlbum, a.ID, a.Title); err != nil {
+			l.Err(err).Msg("matchAlbumByTitle: failed to save romanized alias")
+		}
+		if err := groupAlbumEdition(ctx, d, a.ID, a.Title, opts.Artists[0].ID); err != nil {
+			l.Err(err).Msg("matchAlbumByTitle: failed to group album edition")
+		}
+		if err := saveAlbumArtistCredit(ctx, d, opts, a.ID, nil); err != nil {
+			l.Err(err).Msg("matchAlbumByTitle: failed to save artist credit")
+		}
 	}
 
 	return &models.Album{
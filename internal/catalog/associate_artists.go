@@ -26,6 +26,9 @@ type AssociateArtistsOpts struct {
 	Mbzc          mbz.MusicBrainzCaller
 
 	SkipCacheImage bool
+
+	// Collects the ids of provisional fuzzy matches, see fuzzyMatch
+	FuzzyMatches *[]int32
 }
 
 func AssociateArtists(ctx context.Context, d db.DB, opts AssociateArtistsOpts) ([]*models.Artist, error) {
@@ -62,7 +65,11 @@ func AssociateArtists(ctx context.Context, d db.DB, opts AssociateArtistsOpts) (
 	}
 
 	if len(result) < 1 {
-		allArtists := slices.Concat(opts.ArtistNames, ParseArtists(opts.ArtistName, opts.TrackTitle))
+		parsed, err := parseArtists(ctx, d, opts)
+		if err != nil {
+			return nil, fmt.Errorf("AssociateArtists: %w", err)
+		}
+		allArtists := slices.Concat(opts.ArtistNames, parsed)
 		l.Debug().Msgf("Associating artists by artist name(s) %v and track title '%s'", allArtists, opts.TrackTitle)
 		fallbackMatches, err := matchArtistsByNames(ctx, allArtists, nil, d, opts)
 		if err != nil {
@@ -74,6 +81,16 @@ func AssociateArtists(ctx context.Context, d db.DB, opts AssociateArtistsOpts) (
 	return result, nil
 }
 
+// parseArtists parses the artist names out of the artist string and track title, using the artist
+// split rules saved in the database
+func parseArtists(ctx context.Context, d db.DB, opts AssociateArtistsOpts) ([]string, error) {
+	rules, err := LoadArtistSplitRules(ctx, d)
+	if err != nil {
+		return nil, fmt.Errorf("parseArtists: %w", err)
+	}
+	return ParseArtists(opts.ArtistName, opts.TrackTitle, rules), nil
+}
+
 func matchArtistsByMBIDMappings(ctx context.Context, d db.DB, opts AssociateArtistsOpts) ([]*models.Artist, error) {
 	l := logger.FromContext(ctx)
 	var result []*models.Artist
@@ -91,7 +108,7 @@ func matchArtistsByMBIDMappings(ctx context.Context, d db.DB, opts AssociateArti
 			return nil, fmt.Errorf("matchArtistsByMBIDMappings: %w", err)
 		}
 
-		artist, err = d.GetArtist(ctx, db.GetArtistOpts{
+		artist, err = findArtist(ctx, d, db.GetArtistOpts{
 			Name: a.Artist,
 		})
 		if err == nil {
@@ -146,6 +163,9 @@ func matchArtistsByMBIDMappings(ctx context.Context, d db.DB, opts AssociateArti
 				l.Err(err).Msgf("matchArtistsByMBIDMappings: Failed to create artist '%s' in database", a.Artist)
 				return nil, fmt.Errorf("matchArtistsByMBIDMappings: %w", err)
 			}
+			if err := saveRomanizedAlias(ctx, d, db.ItemTypeArtist, artist.ID, artist.Name); err != nil {
+				l.Err(err).Msg("matchArtistsByMBIDMappings: Failed to save romanized alias")
+			}
 		}
 
 		result = append(result, artist)
@@ -180,7 +200,11 @@ func matchArtistsByMBID(ctx context.Context, d db.DB, opts AssociateArtistsOpts,
 		}
 
 		if len(opts.ArtistNames) < 1 {
-			opts.ArtistNames = slices.Concat(opts.ArtistNames, ParseArtists(opts.ArtistName, opts.TrackTitle))
+			parsed, err := parseArtists(ctx, d, opts)
+			if err != nil {
+				return nil, err
+			}
+			opts.ArtistNames = slices.Concat(opts.ArtistNames, parsed)
 		}
 
 		a, err = resolveAliasOrCreateArtist(ctx, id, opts.ArtistNames, d, opts)
@@ -205,7 +229,7 @@ func resolveAliasOrCreateArtist(ctx context.Context, mbzID uuid.UUID, names []st
 	l.Debug().Msgf("Got aliases %v from MusicBrainz", aliases)
 
 	for _, alias := range aliases {
-		a, err := d.GetArtist(ctx, db.GetArtistOpts{
+		a, err := findArtist(ctx, d, db.GetArtistOpts{
 			Name: alias,
 		})
 		if err == nil && (a.MbzID == nil || *a.MbzID == uuid.Nil) {
@@ -217,6 +241,7 @@ func resolveAliasOrCreateArtist(ctx context.Context, mbzID uuid.UUID, names []st
 			if saveAliasErr := d.SaveArtistAliases(ctx, a.ID, aliases, "MusicBrainz"); saveAliasErr != nil {
 				return nil, fmt.Errorf("resolveAliasOrCreateArtist: %w", saveAliasErr)
 			}
+			saveMbzArtistInfo(ctx, d, opts.Mbzc, a.ID, mbzID)
 			return a, nil
 		}
 	}
@@ -266,9 +291,50 @@ func resolveAliasOrCreateArtist(ctx context.Context, mbzID uuid.UUID, names []st
 		return nil, fmt.Errorf("resolveAliasOrCreateArtist: %w", err)
 	}
 	l.Info().Msgf("Created artist '%s' with MusicBrainz Artist ID", canonical)
+	if err := saveRomanizedAlias(ctx, d, db.ItemTypeArtist, u.ID, canonical); err != nil {
+		l.Err(err).Msg("resolveAliasOrCreateArtist: Failed to save romanized alias")
+	}
+	saveMbzArtistInfo(ctx, d, opts.Mbzc, u.ID, mbzID)
 	return u, nil
 }
 
+// saveMbzArtistInfo stores the sort name, type, country, life span, and related
+// artists of an artist. Failures are logged, since the artist is still usable without them.
+func saveMbzArtistInfo(ctx context.Context, d db.DB, mbzc mbz.MusicBrainzCaller, artistID int32, mbzID uuid.UUID) {
+	l := logger.FromContext(ctx)
+	artist, err := mbzc.GetArtist(ctx, mbzID)
+	if err != nil {
+		l.Info().AnErr("err", err).Msg("saveMbzArtistInfo: failed to get artist from MusicBrainz")
+		return
+	}
+	err = updateArtistInfo(ctx, d, artistID, artist)
+	if err != nil {
+		l.Err(err).Msg("saveMbzArtistInfo: failed to save artist info")
+	}
+	err = saveMbzArtistRelations(ctx, d, artistID, artist)
+	if err != nil {
+		l.Err(err).Msg("saveMbzArtistInfo: failed to save artist relations")
+	}
+}
+
+func updateArtistInfo(ctx context.Context, d db.DB, artistID int32, artist *mbz.MusicBrainzArtist) error {
+	info := &models.ArtistInfo{
+		SortName:  artist.SortName,
+		Type:      artist.Type,
+		Gender:    artist.Gender,
+		Area:      artist.Area.Name,
+		BeginDate: artist.LifeSpan.Begin,
+		EndDate:   artist.LifeSpan.End,
+	}
+	if len(artist.Area.Iso3166_1Codes) > 0 {
+		info.Country = artist.Area.Iso3166_1Codes[0]
+	}
+	return d.UpdateArtist(ctx, db.UpdateArtistOpts{
+		ID:   artistID,
+		Info: info,
+	})
+}
+
 func matchArtistsByNames(ctx context.Context, names []string, existing []*models.Artist, d db.DB, opts AssociateArtistsOpts) ([]*models.Artist, error) {
 	l := logger.FromContext(ctx)
 	var result []*models.Artist
@@ -278,9 +344,12 @@ func matchArtistsByNames(ctx context.Context, names []string, existing []*models
 			l.Debug().Msgf("Artist '%s' already found, skipping...", name)
 			continue
 		}
-		a, err := d.GetArtist(ctx, db.GetArtistOpts{
+		a, err := findArtist(ctx, d, db.GetArtistOpts{
 			Name: name,
 		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			a, err = fuzzyFindArtist(ctx, d, name, opts.FuzzyMatches)
+		}
 		if err == nil {
 			l.Debug().Msgf("Artist '%s' found in DB", name)
 			result = append(result, a)
@@ -314,6 +383,9 @@ func matchArtistsByNames(ctx context.Context, names []string, existing []*models
 				return nil, fmt.Errorf("matchArtistsByNames: %w", err)
 			}
 			l.Info().Msgf("Created artist '%s' with artist name", name)
+			if err := saveRomanizedAlias(ctx, d, db.ItemTypeArtist, a.ID, name); err != nil {
+				l.Err(err).Msg("matchArtistsByNames: Failed to save romanized alias")
+			}
 			result = append(result, a)
 		} else {
 			return nil, fmt.Errorf("matchArtistsByNames: %w", err)
@@ -19,7 +19,12 @@ type AssociateTrackOpts struct {
 	TrackMbzID uuid.UUID
 	TrackName  string
 	Duration   int32
-	Mbzc       mbz.MusicBrainzCaller
+	// The artist credit the track was submitted with, e.g. "A feat. B"
+	ArtistCredit string
+	Mbzc         mbz.MusicBrainzCaller
+
+	// Collects the ids of provisional fuzzy matches, see fuzzyMatch
+	FuzzyMatches *[]int32
 }
 
 func AssociateTrack(ctx context.Context, d db.DB, opts AssociateTrackOpts) (*models.Track, error) {
@@ -76,7 +81,7 @@ func matchTrackByMbzID(ctx context.Context, d db.DB, opts AssociateTrackOpts) (*
 func matchTrackByTitleAndArtist(ctx context.Context, d db.DB, opts AssociateTrackOpts) (*models.Track, error) {
 	l := logger.FromContext(ctx)
 	// try provided track title
-	track, err := d.GetTrack(ctx, db.GetTrackOpts{
+	track, err := findTrack(ctx, d, db.GetTrackOpts{
 		Title:     opts.TrackName,
 		ArtistIDs: opts.ArtistIDs,
 	})
@@ -89,7 +94,7 @@ func matchTrackByTitleAndArtist(ctx context.Context, d db.DB, opts AssociateTrac
 		if opts.TrackMbzID != uuid.Nil {
 			mbzTrack, err := opts.Mbzc.GetTrack(ctx, opts.TrackMbzID)
 			if err == nil {
-				track, err := d.GetTrack(ctx, db.GetTrackOpts{
+				track, err := findTrack(ctx, d, db.GetTrackOpts{
 					Title:     mbzTrack.Title,
 					ArtistIDs: opts.ArtistIDs,
 				})
@@ -99,6 +104,14 @@ func matchTrackByTitleAndArtist(ctx context.Context, d db.DB, opts AssociateTrac
 				}
 			}
 		}
+		if opts.TrackMbzID == uuid.Nil {
+			track, err := fuzzyFindTrack(ctx, d, opts.TrackName, opts.AlbumID, opts.ArtistIDs, opts.FuzzyMatches)
+			if err == nil {
+				return track, nil
+			} else if !errors.Is(err, pgx.ErrNoRows) {
+				l.Err(err).Msg("matchTrackByTitleAndArtist: failed to fuzzy match track")
+			}
+		}
 		l.Debug().Msgf("Track '%s' could not be found by title and artist match", opts.TrackName)
 		t, err := d.SaveTrack(ctx, db.SaveTrackOpts{
 			RecordingMbzID: opts.TrackMbzID,
@@ -115,6 +128,12 @@ func matchTrackByTitleAndArtist(ctx context.Context, d db.DB, opts AssociateTrac
 		} else {
 			l.Info().Msgf("Created track '%s' with MusicBrainz Recording ID", opts.TrackName)
 		}
+		if err := saveRomanizedAlias(ctx, d, db.ItemTypeTrack, t.ID, t.Title); err != nil {
+			l.Err(err).Msg("matchTrackByTitleAndArtist: failed to save romanized alias")
+		}
+		if err := saveTrackArtistCredit(ctx, d, opts, t.ID); err != nil {
+			l.Err(err).Msg("matchTrackByTitleAndArtist: failed to save artist credit")
+		}
 		return t, nil
 	}
 }
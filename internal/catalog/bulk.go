@@ -0,0 +1,202 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+type BulkOperationType string
+
+const (
+	// merges every artist in FromIDs into ToID
+	BulkMergeArtists BulkOperationType = "merge_artists"
+	// moves every track in IDs to the trash
+	BulkDeleteTracks BulkOperationType = "delete_tracks"
+	// sets Alias as the primary alias of the artist, album or track ID of type Type
+	BulkSetPrimaryAlias BulkOperationType = "set_primary_alias"
+	// hides every artist, album or track in IDs of type Type from stats
+	BulkHide BulkOperationType = "hide"
+)
+
+// One operation of a bulk request. Which fields are used depends on Op.
+type BulkOperation struct {
+	Op           BulkOperationType `json:"op"`
+	Type         db.ItemType       `json:"type,omitempty"`
+	ID           int32             `json:"id,omitempty"`
+	IDs          []int32           `json:"ids,omitempty"`
+	FromIDs      []int32           `json:"from_ids,omitempty"`
+	ToID         int32             `json:"to_id,omitempty"`
+	ReplaceImage bool              `json:"replace_image,omitempty"`
+	Alias        string            `json:"alias,omitempty"`
+}
+
+type BulkOperationStatus string
+
+const (
+	BulkOperationDone    BulkOperationStatus = "done"
+	BulkOperationFailed  BulkOperationStatus = "failed"
+	BulkOperationSkipped BulkOperationStatus = "skipped"
+)
+
+type BulkOperationResult struct {
+	Op     BulkOperationType   `json:"op"`
+	Status BulkOperationStatus `json:"status"`
+	// the number of artists, albums or tracks the operation was applied to
+	Affected int    `json:"affected"`
+	Error    string `json:"error,omitempty"`
+}
+
+type BulkReport struct {
+	// whether the changes of every operation were kept. When any operation fails, none are
+	Committed bool                  `json:"committed"`
+	Results   []BulkOperationResult `json:"results"`
+}
+
+// ErrInvalidBulkOperation is returned when an operation is missing the fields it needs. No operation is
+// run when any of them is invalid.
+var ErrInvalidBulkOperation = errors.New("invalid bulk operation")
+
+// RunBulkOperations runs a list of catalog operations in order, in a single transaction. Either every
+// operation succeeds and all of their changes are kept, or the first one that fails stops the run and
+// the changes of every operation are rolled back. The report has a result for every operation, with the
+// operations after a failed one skipped.
+func RunBulkOperations(ctx context.Context, store db.DB, ops []BulkOperation) (*BulkReport, error) {
+	l := logger.FromContext(ctx)
+	for i, op := range ops {
+		if err := validateBulkOperation(op); err != nil {
+			return nil, fmt.Errorf("RunBulkOperations: operation %d: %w", i, err)
+		}
+	}
+
+	report := &BulkReport{Results: make([]BulkOperationResult, len(ops))}
+	for i, op := range ops {
+		report.Results[i] = BulkOperationResult{Op: op.Op, Status: BulkOperationSkipped}
+	}
+	failed := false
+	err := store.RunInTx(ctx, func(tx db.DB) error {
+		for i, op := range ops {
+			affected, err := runBulkOperation(ctx, tx, op)
+			if err != nil {
+				failed = true
+				report.Results[i].Status = BulkOperationFailed
+				report.Results[i].Error = err.Error()
+				return fmt.Errorf("operation %d (%s): %w", i, op.Op, err)
+			}
+			report.Results[i].Status = BulkOperationDone
+			report.Results[i].Affected = affected
+		}
+		return nil
+	})
+	if err != nil && failed {
+		l.Info().Err(err).Msg("RunBulkOperations: Bulk operations rolled back")
+		return report, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("RunBulkOperations: %w", err)
+	}
+	report.Committed = true
+	l.Info().Msgf("RunBulkOperations: Ran %d bulk operations", len(ops))
+	return report, nil
+}
+
+func validateBulkOperation(op BulkOperation) error {
+	switch op.Op {
+	case BulkMergeArtists:
+		if len(op.FromIDs) == 0 || op.ToID == 0 {
+			return fmt.Errorf("%w: %s requires from_ids and to_id", ErrInvalidBulkOperation, op.Op)
+		}
+	case BulkDeleteTracks:
+		if len(op.IDs) == 0 {
+			return fmt.Errorf("%w: %s requires ids", ErrInvalidBulkOperation, op.Op)
+		}
+	case BulkSetPrimaryAlias:
+		if !validItemType(op.Type) || op.ID == 0 || op.Alias == "" {
+			return fmt.Errorf("%w: %s requires type, id, and alias", ErrInvalidBulkOperation, op.Op)
+		}
+	case BulkHide:
+		if !validItemType(op.Type) || len(op.IDs) == 0 {
+			return fmt.Errorf("%w: %s requires type and ids", ErrInvalidBulkOperation, op.Op)
+		}
+	default:
+		return fmt.Errorf("%w: unknown operation '%s'", ErrInvalidBulkOperation, op.Op)
+	}
+	return nil
+}
+
+func validItemType(t db.ItemType) bool {
+	return t == db.ItemTypeArtist || t == db.ItemTypeAlbum || t == db.ItemTypeTrack
+}
+
+// runBulkOperation runs one operation and returns the number of items it was applied to
+func runBulkOperation(ctx context.Context, store db.DB, op BulkOperation) (int, error) {
+	switch op.Op {
+	case BulkMergeArtists:
+		if err := itemExists(ctx, store, db.ItemTypeArtist, op.ToID); err != nil {
+			return 0, err
+		}
+		for _, id := range op.FromIDs {
+			if id == op.ToID {
+				return 0, fmt.Errorf("cannot merge artist %d into itself", id)
+			}
+			if err := itemExists(ctx, store, db.ItemTypeArtist, id); err != nil {
+				return 0, err
+			}
+			if err := store.MergeArtists(ctx, id, op.ToID, op.ReplaceImage); err != nil {
+				return 0, err
+			}
+		}
+		return len(op.FromIDs), nil
+	case BulkDeleteTracks:
+		for _, id := range op.IDs {
+			if err := store.DeleteTrack(ctx, id); err != nil {
+				return 0, err
+			}
+		}
+		return len(op.IDs), nil
+	case BulkSetPrimaryAlias:
+		var err error
+		switch op.Type {
+		case db.ItemTypeArtist:
+			err = store.SetPrimaryArtistAlias(ctx, op.ID, op.Alias)
+		case db.ItemTypeAlbum:
+			err = store.SetPrimaryAlbumAlias(ctx, op.ID, op.Alias)
+		case db.ItemTypeTrack:
+			err = store.SetPrimaryTrackAlias(ctx, op.ID, op.Alias)
+		}
+		if err != nil {
+			return 0, err
+		}
+		return 1, nil
+	case BulkHide:
+		for _, id := range op.IDs {
+			if err := itemExists(ctx, store, op.Type, id); err != nil {
+				return 0, err
+			}
+			if err := store.SetItemHidden(ctx, op.Type, id, true); err != nil {
+				return 0, err
+			}
+		}
+		return len(op.IDs), nil
+	}
+	return 0, fmt.Errorf("unknown operation '%s'", op.Op)
+}
+
+func itemExists(ctx context.Context, store db.DB, t db.ItemType, id int32) error {
+	var err error
+	switch t {
+	case db.ItemTypeArtist:
+		_, err = store.GetArtist(ctx, db.GetArtistOpts{ID: id})
+	case db.ItemTypeAlbum:
+		_, err = store.GetAlbum(ctx, db.GetAlbumOpts{ID: id})
+	case db.ItemTypeTrack:
+		_, err = store.GetTrack(ctx, db.GetTrackOpts{ID: id})
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%s %d not found", t, id)
+	}
+	return err
+}
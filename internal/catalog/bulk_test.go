@@ -0,0 +1,104 @@
+package catalog_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabehf/koito/internal/catalog"
+	"github.com/gabehf/koito/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBulkTestData(t *testing.T) {
+	truncateTestData(t)
+	ctx := context.Background()
+
+	for _, name := range []string{"Artist One", "Artist Two", "Artist Three"} {
+		_, err := store.SaveArtist(ctx, db.SaveArtistOpts{Name: name})
+		require.NoError(t, err)
+	}
+	album, err := store.SaveAlbum(ctx, db.SaveAlbumOpts{Title: "Album", ArtistIDs: []int32{1}})
+	require.NoError(t, err)
+	for _, title := range []string{"Track One", "Track Two"} {
+		_, err := store.SaveTrack(ctx, db.SaveTrackOpts{Title: title, AlbumID: album.ID, ArtistIDs: []int32{1}})
+		require.NoError(t, err)
+	}
+}
+
+func TestRunBulkOperations(t *testing.T) {
+	setupBulkTestData(t)
+	ctx := context.Background()
+
+	report, err := catalog.RunBulkOperations(ctx, store, []catalog.BulkOperation{
+		{Op: catalog.BulkMergeArtists, FromIDs: []int32{2, 3}, ToID: 1},
+		{Op: catalog.BulkDeleteTracks, IDs: []int32{2}},
+		{Op: catalog.BulkSetPrimaryAlias, Type: db.ItemTypeArtist, ID: 1, Alias: "Artist One"},
+		{Op: catalog.BulkHide, Type: db.ItemTypeTrack, IDs: []int32{1}},
+	})
+	require.NoError(t, err)
+	assert.True(t, report.Committed)
+	require.Len(t, report.Results, 4)
+	for _, r := range report.Results {
+		assert.Equal(t, catalog.BulkOperationDone, r.Status)
+	}
+	assert.Equal(t, 2, report.Results[0].Affected)
+
+	count, err := store.Count(ctx, "SELECT COUNT(*) FROM artists")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+	count, err = store.Count(ctx, "SELECT COUNT(*) FROM tracks")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+	count, err = store.Count(ctx, "SELECT COUNT(*) FROM hidden_tracks WHERE track_id = 1")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+}
+
+func TestRunBulkOperations_RollsBackOnFailure(t *testing.T) {
+	setupBulkTestData(t)
+	ctx := context.Background()
+
+	report, err := catalog.RunBulkOperations(ctx, store, []catalog.BulkOperation{
+		{Op: catalog.BulkMergeArtists, FromIDs: []int32{2}, ToID: 1},
+		{Op: catalog.BulkDeleteTracks, IDs: []int32{1, 999}},
+		{Op: catalog.BulkHide, Type: db.ItemTypeArtist, IDs: []int32{1}},
+	})
+	require.NoError(t, err)
+	assert.False(t, report.Committed)
+	require.Len(t, report.Results, 3)
+	assert.Equal(t, catalog.BulkOperationDone, report.Results[0].Status)
+	assert.Equal(t, catalog.BulkOperationFailed, report.Results[1].Status)
+	assert.NotEmpty(t, report.Results[1].Error)
+	assert.Equal(t, catalog.BulkOperationSkipped, report.Results[2].Status)
+
+	// nothing was changed
+	count, err := store.Count(ctx, "SELECT COUNT(*) FROM artists")
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, count)
+	count, err = store.Count(ctx, "SELECT COUNT(*) FROM tracks")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+	count, err = store.Count(ctx, "SELECT COUNT(*) FROM trash")
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, count)
+}
+
+func TestRunBulkOperations_Invalid(t *testing.T) {
+	setupBulkTestData(t)
+	ctx := context.Background()
+
+	_, err := catalog.RunBulkOperations(ctx, store, []catalog.BulkOperation{
+		{Op: catalog.BulkDeleteTracks, IDs: []int32{1}},
+		{Op: catalog.BulkHide, IDs: []int32{1}},
+	})
+	assert.ErrorIs(t, err, catalog.ErrInvalidBulkOperation)
+
+	_, err = catalog.RunBulkOperations(ctx, store, []catalog.BulkOperation{{Op: "other"}})
+	assert.ErrorIs(t, err, catalog.ErrInvalidBulkOperation)
+
+	// invalid requests are rejected before anything is run
+	count, err := store.Count(ctx, "SELECT COUNT(*) FROM tracks")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+}
@@ -14,7 +14,7 @@ import (
 	"github.com/gabehf/koito/internal/db"
 	"github.com/gabehf/koito/internal/logger"
 	"github.com/gabehf/koito/internal/mbz"
-	"github.com/gabehf/koito/internal/models"
+	"github.com/gabehf/koito/internal/utils"
 	"github.com/google/uuid"
 )
 
@@ -43,6 +43,9 @@ type SubmitListenOpts struct {
 	// When true, skips caching the images and only stores the image url in the db
 	SkipCacheImage bool
 
+	// When true, MusicBrainz is searched for the IDs of a listen that was submitted without them
+	ResolveMbzIDs bool
+
 	MbzCaller          mbz.MusicBrainzCaller
 	ArtistNames        []string
 	Artist             string
@@ -52,9 +55,13 @@ type SubmitListenOpts struct {
 	RecordingMbzID     uuid.UUID
 	Duration           int32 // in seconds
 	ReleaseTitle       string
-	ReleaseMbzID       uuid.UUID
-	ReleaseGroupMbzID  uuid.UUID
-	Time               time.Time
+	// The artist credit of the release, when it differs from that of the track (compilations,
+	// features, ...). When empty, the track artists are used as the album artists
+	AlbumArtist       string
+	ReleaseMbzID      uuid.UUID
+	ReleaseGroupMbzID uuid.UUID
+	Tags              []string
+	Time              time.Time
 
 	UserID int32
 	Client string
@@ -74,6 +81,17 @@ func SubmitListen(ctx context.Context, store db.DB, opts SubmitListenOpts) error
 	// bandaid to ensure new activity does not have sub-second precision
 	opts.Time = opts.Time.Truncate(time.Second)
 
+	if opts.ResolveMbzIDs {
+		resolveMbzIDs(ctx, store, &opts)
+	}
+
+	// listens are linked to the provisional fuzzy matches they were associated through, so
+	// that they can be split back out if the user rejects a match
+	var fuzzyMatches *[]int32
+	if !opts.SkipSaveListen {
+		fuzzyMatches = new([]int32)
+	}
+
 	artists, err := AssociateArtists(
 		ctx,
 		store,
@@ -85,6 +103,7 @@ func SubmitListen(ctx context.Context, store db.DB, opts SubmitListenOpts) error
 			Mbzc:           opts.MbzCaller,
 			TrackTitle:     opts.TrackTitle,
 			SkipCacheImage: opts.SkipCacheImage,
+			FuzzyMatches:   fuzzyMatches,
 		})
 	if err != nil {
 		l.Err(err).Msg("Failed to associate artists to listen")
@@ -99,14 +118,42 @@ func SubmitListen(ctx context.Context, store db.DB, opts SubmitListenOpts) error
 		artistIDs[i] = artist.ID
 		l.Debug().Any("artist", artist).Msg("Matched listen to artist")
 	}
+
+	albumArtists, albumArtistCredit := artists, opts.Artist
+	if opts.AlbumArtist != "" {
+		albumArtistCredit = opts.AlbumArtist
+		albumArtists, err = AssociateArtists(
+			ctx,
+			store,
+			AssociateArtistsOpts{
+				ArtistName:     opts.AlbumArtist,
+				Mbzc:           opts.MbzCaller,
+				SkipCacheImage: opts.SkipCacheImage,
+				FuzzyMatches:   fuzzyMatches,
+			})
+		if err != nil {
+			l.Err(err).Msg("Failed to associate album artists to listen")
+			return fmt.Errorf("SubmitListen: %w", err)
+		}
+		if len(albumArtists) < 1 {
+			l.Debug().Msg("Failed to associate any album artists to listen, using track artists instead")
+			albumArtists = artists
+		}
+		for _, artist := range albumArtists {
+			l.Debug().Any("artist", artist).Msg("Matched listen to album artist")
+		}
+	}
+
 	rg, err := AssociateAlbum(ctx, store, AssociateAlbumOpts{
 		ReleaseMbzID:      opts.ReleaseMbzID,
 		ReleaseGroupMbzID: opts.ReleaseGroupMbzID,
 		ReleaseName:       opts.ReleaseTitle,
 		TrackName:         opts.TrackTitle,
 		Mbzc:              opts.MbzCaller,
-		Artists:           artists,
+		Artists:           albumArtists,
+		ArtistCredit:      albumArtistCredit,
 		SkipCacheImage:    opts.SkipCacheImage,
+		FuzzyMatches:      fuzzyMatches,
 	})
 	if err != nil {
 		l.Error().Err(err).Msg("Failed to associate release group to listen")
@@ -114,19 +161,29 @@ func SubmitListen(ctx context.Context, store db.DB, opts SubmitListenOpts) error
 	}
 	l.Debug().Any("album", rg).Msg("Matched listen to release")
 
-	// ensure artists are associated with release group
+	// ensure artists are associated with release group. album artists from the submission are
+	// credited as such even when the album was matched through other artists
+	if opts.AlbumArtist != "" {
+		store.AddArtistsToAlbum(ctx, db.AddArtistsToAlbumOpts{
+			ArtistIDs:    utils.FlattenArtistIDs(albumArtists),
+			AlbumID:      rg.ID,
+			AlbumArtists: true,
+		})
+	}
 	store.AddArtistsToAlbum(ctx, db.AddArtistsToAlbumOpts{
 		ArtistIDs: artistIDs,
 		AlbumID:   rg.ID,
 	})
 
 	track, err := AssociateTrack(ctx, store, AssociateTrackOpts{
-		ArtistIDs:  artistIDs,
-		AlbumID:    rg.ID,
-		TrackMbzID: opts.RecordingMbzID,
-		TrackName:  opts.TrackTitle,
-		Duration:   opts.Duration,
-		Mbzc:       opts.MbzCaller,
+		ArtistIDs:    artistIDs,
+		AlbumID:      rg.ID,
+		TrackMbzID:   opts.RecordingMbzID,
+		TrackName:    opts.TrackTitle,
+		ArtistCredit: opts.Artist,
+		Duration:     opts.Duration,
+		Mbzc:         opts.MbzCaller,
+		FuzzyMatches: fuzzyMatches,
 	})
 	if err != nil {
 		l.Error().Err(err).Msg("Failed to associate track to listen")
@@ -165,28 +222,28 @@ func SubmitListen(ctx context.Context, store db.DB, opts SubmitListenOpts) error
 		}
 	}
 
+	if len(opts.Tags) > 0 {
+		err = store.SaveTrackTags(ctx, track.ID, opts.Tags, "Submission")
+		if err != nil {
+			l.Err(err).Msgf("Failed to save tags for track %s", track.Title)
+		}
+	}
+
 	if opts.SkipSaveListen {
 		return nil
 	}
 
-	l.Info().Msgf("Received listen: '%s' by %s, from release '%s'", track.Title, buildArtistStr(artists), rg.Title)
+	l.Info().Msgf("Received listen: '%s' by %s, from release '%s'", track.Title, opts.Artist, rg.Title)
 
 	return store.SaveListen(ctx, db.SaveListenOpts{
-		TrackID: track.ID,
-		Time:    opts.Time,
-		UserID:  opts.UserID,
-		Client:  opts.Client,
+		TrackID:       track.ID,
+		Time:          opts.Time,
+		UserID:        opts.UserID,
+		Client:        opts.Client,
+		FuzzyMatchIDs: *fuzzyMatches,
 	})
 }
 
-func buildArtistStr(artists []*models.Artist) string {
-	artistNames := make([]string, len(artists))
-	for i, artist := range artists {
-		artistNames[i] = artist.Name
-	}
-	return strings.Join(artistNames, " & ")
-}
-
 var (
 	// Bracketed feat patterns
 	bracketFeatPatterns = []*regexp.Regexp{
@@ -203,13 +260,20 @@ var (
 	mainArtistDotSplitter = regexp.MustCompile(`\s+·\s+`)
 )
 
-// ParseArtists extracts all contributing artist names from the artist and title strings
-func ParseArtists(artist string, title string) []string {
+// ParseArtists extracts all contributing artist names from the artist and title strings. Protected
+// names in rules are never split, and the base artist string and feat. sections are also split on
+// the custom separators in rules.
+func ParseArtists(artist string, title string, rules ArtistSplitRules) []string {
 	seen := make(map[string]struct{})
 	var out []string
 
+	p := rules.protector()
+	artist = p.protect(artist)
+	title = p.protect(title)
+	separators := rules.separatorPattern()
+
 	add := func(name string) {
-		name = strings.TrimSpace(name)
+		name = strings.TrimSpace(p.restore(name))
 		if name == "" {
 			return
 		}
@@ -226,7 +290,7 @@ func ParseArtists(artist string, title string) []string {
 		if matches := re.FindStringSubmatch(artist); matches != nil {
 			foundFeat = true
 			artist = strings.Replace(artist, matches[0], "", 1)
-			for _, name := range featSplitDelimiters.Split(matches[1], -1) {
+			for _, name := range splitOn(matches[1], featSplitDelimiters, separators) {
 				add(name)
 			}
 		}
@@ -235,17 +299,19 @@ func ParseArtists(artist string, title string) []string {
 	if matches := inlineFeatPattern.FindStringSubmatch(artist); matches != nil {
 		foundFeat = true
 		artist = strings.Replace(artist, matches[0], "", 1)
-		for _, name := range featSplitDelimiters.Split(matches[1], -1) {
+		for _, name := range splitOn(matches[1], featSplitDelimiters, separators) {
 			add(name)
 		}
 	}
 
 	// Add base artist(s)
 	if foundFeat {
-		add(strings.TrimSpace(artist))
+		for _, name := range splitOn(artist, separators) {
+			add(name)
+		}
 	} else {
-		// Only split on " · " in base artist string
-		for _, name := range mainArtistDotSplitter.Split(artist, -1) {
+		// Only split on " · " and custom separators in base artist string
+		for _, name := range splitOn(artist, mainArtistDotSplitter, separators) {
 			add(name)
 		}
 	}
@@ -253,13 +319,13 @@ func ParseArtists(artist string, title string) []string {
 	// Extract features from title
 	for _, re := range bracketFeatPatterns {
 		if matches := re.FindStringSubmatch(title); matches != nil {
-			for _, name := range featSplitDelimiters.Split(matches[1], -1) {
+			for _, name := range splitOn(matches[1], featSplitDelimiters, separators) {
 				add(name)
 			}
 		}
 	}
 	if matches := inlineFeatPattern.FindStringSubmatch(title); matches != nil {
-		for _, name := range featSplitDelimiters.Split(matches[1], -1) {
+		for _, name := range splitOn(matches[1], featSplitDelimiters, separators) {
 			add(name)
 		}
 	}
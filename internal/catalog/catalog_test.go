@@ -25,6 +25,9 @@ var (
 		uuid.MustParse("00000000-0000-0000-0000-000000000001"): {
 			Name:     "ATARASHII GAKKO!",
 			SortName: "Atarashii Gakko",
+			Type:     "Group",
+			Area:     mbz.MusicBrainzArea{Name: "Japan", Iso3166_1Codes: []string{"JP"}},
+			LifeSpan: mbz.MusicBrainzLifeSpan{Begin: "2015"},
 			Aliases: []mbz.MusicBrainzArtistAlias{
 				{
 					Name:    "新しい学校のリーダーズ",
@@ -36,8 +39,9 @@ var (
 	}
 	mbzReleaseGroupData = map[uuid.UUID]*mbz.MusicBrainzReleaseGroup{
 		uuid.MustParse("00000000-0000-0000-0000-000000000011"): {
-			Title: "AG! Calling",
-			Type:  "Album",
+			Title:            "AG! Calling",
+			Type:             "Album",
+			FirstReleaseDate: "2024-01-26",
 			ArtistCredit: []mbz.MusicBrainzArtistCredit{
 				{
 					Artist: mbz.MusicBrainzArtist{
@@ -117,6 +121,25 @@ var (
 				},
 			},
 			Status: "Official",
+			Media: []mbz.MusicBrainzMedium{
+				{
+					Position: 1,
+					Tracks: []mbz.MusicBrainzReleaseTrack{
+						{
+							Position:  1,
+							Title:     "Tokyo Calling",
+							LengthMs:  191000,
+							Recording: mbz.MusicBrainzRecordingSummary{ID: "00000000-0000-0000-0000-000000001001"},
+						},
+						{
+							Position:  2,
+							Title:     "Otona Blue",
+							LengthMs:  218000,
+							Recording: mbz.MusicBrainzRecordingSummary{ID: "00000000-0000-0000-0000-000000001002"},
+						},
+					},
+				},
+			},
 		},
 		uuid.MustParse("00000000-0000-0000-0000-000000000202"): {
 			Title: "EVANGELION FINALLY",
@@ -175,7 +198,13 @@ func truncateTestData(t *testing.T) {
 		releases, 
 		artist_releases, 
 		release_aliases,
-		listens 
+		listens,
+		merge_candidates,
+		mbz_enrichment_attempts,
+		mbz_match_suggestions,
+		tags,
+		mbz_tag_fetches,
+		trash
 		RESTART IDENTITY CASCADE`)
 	require.NoError(t, err)
 }
@@ -361,7 +390,43 @@ func TestArtistStringParse(t *testing.T) {
 	}
 
 	for in, out := range cases {
-		artists := catalog.ParseArtists(in.Name, in.Title)
+		artists := catalog.ParseArtists(in.Name, in.Title, catalog.ArtistSplitRules{})
 		assert.ElementsMatch(t, out, artists)
 	}
 }
+
+func TestArtistStringParse_SplitRules(t *testing.T) {
+	rules := catalog.ArtistSplitRules{
+		Separators: []string{";", " / ", " x "},
+		Protected:  []string{"Simon & Garfunkel", "AC/DC", "Tyler; The Creator", "Crosby, Stills & Nash"},
+	}
+	type input struct {
+		Name  string
+		Title string
+	}
+	cases := map[input][]string{
+		// custom separators in the base artist string
+		{"Kessoku Band; Hitori Gotoh", ""}:  {"Kessoku Band", "Hitori Gotoh"},
+		{"Hatsune Miku / Kagamine Rin", ""}: {"Hatsune Miku", "Kagamine Rin"},
+		{"Artist One X Artist Two", ""}:     {"Artist One", "Artist Two"},
+		{"Alex x Max feat. Sam", ""}:        {"Alex", "Max", "Sam"},
+		// custom separators in feat. sections
+		{"Paramore (feat. Joy Williams; Hayley Williams)", ""}: {"Paramore", "Joy Williams", "Hayley Williams"},
+		{"Rat Tally", "In My Car feat. Madeline Kenney; Sam"}:  {"Rat Tally", "Madeline Kenney", "Sam"},
+		// protected names are never split
+		{"Simon & Garfunkel", ""}:                            {"Simon & Garfunkel"},
+		{"simon & garfunkel", ""}:                            {"simon & garfunkel"},
+		{"AC/DC", ""}:                                        {"AC/DC"},
+		{"AC/DC / Simon & Garfunkel", ""}:                    {"AC/DC", "Simon & Garfunkel"},
+		{"Tyler; The Creator", "EARFQUAKE"}:                  {"Tyler; The Creator"},
+		{"Paul Simon (feat. Simon & Garfunkel)", ""}:         {"Paul Simon", "Simon & Garfunkel"},
+		{"Neil Young", "Ohio (feat. Crosby, Stills & Nash)"}: {"Neil Young", "Crosby, Stills & Nash"},
+		// protected names inside a longer word are still split
+		{"Macdonald & Garfunkel feat. Simon & Garfunkelson", ""}: {"Macdonald & Garfunkel", "Simon", "Garfunkelson"},
+	}
+
+	for in, out := range cases {
+		artists := catalog.ParseArtists(in.Name, in.Title, rules)
+		assert.ElementsMatch(t, out, artists, "parsing %q, %q", in.Name, in.Title)
+	}
+}
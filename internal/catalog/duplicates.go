@@ -0,0 +1,122 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+)
+
+const (
+	// minimum trigram similarity for two names to be considered at all
+	DuplicateMinSimilarity = 0.4
+	// minimum score for a pair to be suggested as a merge candidate
+	DuplicateMinScore = 0.6
+)
+
+var numberPattern = regexp.MustCompile(`\d+`)
+
+// FindDuplicates scans artists, albums, and tracks for items that are likely duplicates of each
+// other, and saves them as merge candidates to be reviewed. Candidates that were dismissed are
+// never suggested again.
+func FindDuplicates(ctx context.Context, store db.DB) error {
+	l := logger.FromContext(ctx)
+	for _, t := range []db.ItemType{db.ItemTypeArtist, db.ItemTypeAlbum, db.ItemTypeTrack} {
+		pairs, err := store.GetDuplicatePairs(ctx, db.GetDuplicatePairsOpts{
+			Type:          t,
+			MinSimilarity: DuplicateMinSimilarity,
+		})
+		if err != nil {
+			return fmt.Errorf("FindDuplicates: %w", err)
+		}
+		candidates := make([]db.SaveMergeCandidateOpts, 0)
+		for _, p := range pairs {
+			score, reasons, ok := ScoreDuplicatePair(t, p)
+			if !ok {
+				continue
+			}
+			from, to := MergeDirection(p)
+			candidates = append(candidates, db.SaveMergeCandidateOpts{
+				FromID:  from,
+				ToID:    to,
+				Score:   score,
+				Reasons: reasons,
+			})
+		}
+		err = store.SaveMergeCandidates(ctx, t, candidates)
+		if err != nil {
+			return fmt.Errorf("FindDuplicates: %w", err)
+		}
+		l.Info().Msgf("Found %d %s merge candidates", len(candidates), t)
+	}
+	return nil
+}
+
+// ScoreDuplicatePair ranks how likely it is that the two items are the same. The returned reasons
+// are meant to be shown to the user. ok is false when the pair should not be suggested.
+func ScoreDuplicatePair(t db.ItemType, p db.DuplicatePair) (score float32, reasons []string, ok bool) {
+	if p.MbzID1 != nil && p.MbzID2 != nil && *p.MbzID1 != *p.MbzID2 {
+		// MusicBrainz says these are different
+		return 0, nil, false
+	}
+	// e.g. 'Part 1' and 'Part 2', or 'Vol. 1' and 'Vol. 2' are very similar, but never the same
+	if !slices.Equal(numberPattern.FindAllString(p.Name1, -1), numberPattern.FindAllString(p.Name2, -1)) {
+		return 0, nil, false
+	}
+
+	score = p.Similarity
+	if strings.EqualFold(p.Name1, p.Name2) {
+		reasons = append(reasons, "Same name")
+	} else {
+		reasons = append(reasons, fmt.Sprintf("Names are %d%% similar", int(p.Similarity*100)))
+	}
+
+	if t != db.ItemTypeArtist {
+		// only pairs with the same artists are returned for albums and tracks
+		score += 0.1
+		reasons = append(reasons, "Same artists")
+	}
+	if (p.MbzID1 == nil) != (p.MbzID2 == nil) {
+		score += 0.1
+		reasons = append(reasons, "Only one has a MusicBrainz ID")
+	}
+	if p.SharedAlbums > 0 {
+		if t == db.ItemTypeTrack {
+			score += 0.2
+			reasons = append(reasons, "On the same album")
+		} else {
+			score += min(0.1*float32(p.SharedAlbums), 0.3)
+			reasons = append(reasons, fmt.Sprintf("%d album title(s) in common", p.SharedAlbums))
+		}
+	}
+	if p.SharedTracks > 0 {
+		score += min(0.05*float32(p.SharedTracks), 0.3)
+		reasons = append(reasons, fmt.Sprintf("%d track title(s) in common", p.SharedTracks))
+	}
+
+	score = min(score, 1)
+	return score, reasons, score >= DuplicateMinScore
+}
+
+// MergeDirection returns which item of the pair should be merged into the other. The item
+// with a MusicBrainz ID is kept, then the one with the most listens, then the oldest.
+func MergeDirection(p db.DuplicatePair) (from, to int32) {
+	switch {
+	case p.MbzID1 != nil && p.MbzID2 == nil:
+		return p.ID2, p.ID1
+	case p.MbzID1 == nil && p.MbzID2 != nil:
+		return p.ID1, p.ID2
+	case p.ListenCount2 > p.ListenCount1:
+		return p.ID1, p.ID2
+	case p.ListenCount1 > p.ListenCount2:
+		return p.ID2, p.ID1
+	case p.ID1 < p.ID2:
+		return p.ID2, p.ID1
+	default:
+		return p.ID1, p.ID2
+	}
+}
@@ -0,0 +1,105 @@
+package catalog_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabehf/koito/internal/catalog"
+	"github.com/gabehf/koito/internal/db"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreDuplicatePair(t *testing.T) {
+	mbzID1 := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	mbzID2 := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	// similar names, one mbid, shared albums
+	score, reasons, ok := catalog.ScoreDuplicatePair(db.ItemTypeArtist, db.DuplicatePair{
+		ID1: 1, ID2: 2, Name1: "The Beatles", Name2: "Beatles", MbzID1: &mbzID1,
+		Similarity: 0.67, SharedAlbums: 1,
+	})
+	assert.True(t, ok)
+	assert.InDelta(t, 0.87, score, 0.001)
+	assert.Equal(t, []string{"Names are 67% similar", "Only one has a MusicBrainz ID", "1 album title(s) in common"}, reasons)
+
+	// same name and artists
+	score, reasons, ok = catalog.ScoreDuplicatePair(db.ItemTypeTrack, db.DuplicatePair{
+		ID1: 1, ID2: 2, Name1: "Airbag", Name2: "airbag", Similarity: 1, SharedAlbums: 1,
+	})
+	assert.True(t, ok)
+	assert.EqualValues(t, 1, score, "expected score to be capped")
+	assert.Equal(t, []string{"Same name", "Same artists", "On the same album"}, reasons)
+
+	// different musicbrainz ids
+	_, _, ok = catalog.ScoreDuplicatePair(db.ItemTypeArtist, db.DuplicatePair{
+		ID1: 1, ID2: 2, Name1: "Nirvana", Name2: "Nirvana", MbzID1: &mbzID1, MbzID2: &mbzID2, Similarity: 1,
+	})
+	assert.False(t, ok)
+
+	// different numbers
+	_, _, ok = catalog.ScoreDuplicatePair(db.ItemTypeAlbum, db.DuplicatePair{
+		ID1: 1, ID2: 2, Name1: "Kill Bill Vol. 1", Name2: "Kill Bill Vol. 2", Similarity: 0.9,
+	})
+	assert.False(t, ok)
+
+	// not similar enough on its own
+	_, _, ok = catalog.ScoreDuplicatePair(db.ItemTypeArtist, db.DuplicatePair{
+		ID1: 1, ID2: 2, Name1: "Radiohead", Name2: "Radio", Similarity: 0.45,
+	})
+	assert.False(t, ok)
+}
+
+func TestMergeDirection(t *testing.T) {
+	mbzID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+	from, to := catalog.MergeDirection(db.DuplicatePair{ID1: 1, ID2: 2, MbzID2: &mbzID, ListenCount1: 10})
+	assert.EqualValues(t, 1, from)
+	assert.EqualValues(t, 2, to)
+
+	from, to = catalog.MergeDirection(db.DuplicatePair{ID1: 1, ID2: 2, ListenCount1: 1, ListenCount2: 5})
+	assert.EqualValues(t, 1, from)
+	assert.EqualValues(t, 2, to)
+
+	from, to = catalog.MergeDirection(db.DuplicatePair{ID1: 1, ID2: 2, ListenCount1: 3, ListenCount2: 3})
+	assert.EqualValues(t, 2, from)
+	assert.EqualValues(t, 1, to)
+}
+
+func TestFindDuplicates(t *testing.T) {
+	ctx := context.Background()
+	setupTestDataSansMbzIDs(t)
+
+	err := store.Exec(ctx,
+		`INSERT INTO artists (musicbrainz_id) VALUES (NULL)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx,
+		`INSERT INTO artist_aliases (artist_id, alias, source, is_primary)
+			VALUES (2, 'Atarashii Gakko', 'Testing', true)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx,
+		`INSERT INTO releases (musicbrainz_id) VALUES (NULL)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx,
+		`INSERT INTO release_aliases (release_id, alias, source, is_primary)
+			VALUES (2, 'AG! Calling', 'Testing', true)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx,
+		`INSERT INTO artist_releases (artist_id, release_id) VALUES (2, 2)`)
+	require.NoError(t, err)
+
+	require.NoError(t, catalog.FindDuplicates(ctx, store))
+
+	resp, err := store.GetMergeCandidates(ctx, db.GetMergeCandidatesOpts{Type: db.ItemTypeArtist})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 1)
+	assert.EqualValues(t, 2, resp.Items[0].FromID)
+	assert.EqualValues(t, 1, resp.Items[0].ToID)
+	assert.Contains(t, resp.Items[0].Reasons, "1 album title(s) in common")
+
+	// albums have different artists
+	resp, err = store.GetMergeCandidates(ctx, db.GetMergeCandidatesOpts{Type: db.ItemTypeAlbum})
+	require.NoError(t, err)
+	assert.Len(t, resp.Items, 0)
+}
@@ -0,0 +1,126 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gabehf/koito/internal/cfg"
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+// The same album is often scrobbled as "Album", "Album (Deluxe Edition)", and "Album - 2015 Remaster".
+// Albums with a title that ends in an edition suffix are grouped under the album with the title
+// without the suffix, so that charts and album pages can roll the editions up, while listens stay
+// attached to the edition that was listened to. Edition suffixes are configured as patterns.
+
+const editionBatchSize = 500
+
+var editionSuffixPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^(.*\S)\s*\(([^()]+)\)$`),
+	regexp.MustCompile(`^(.*\S)\s*\[([^\[\]]+)\]$`),
+	regexp.MustCompile(`^(.*\S)\s+-\s+(.+)$`),
+}
+
+// EditionBaseTitle strips edition suffixes from an album title, returning the title of the album
+// it is an edition of, and whether the title had any edition suffixes.
+func EditionBaseTitle(title string) (string, bool) {
+	base := strings.TrimSpace(title)
+	var stripped bool
+	for {
+		var found bool
+		for _, re := range editionSuffixPatterns {
+			m := re.FindStringSubmatch(base)
+			if m == nil || !isEditionSuffix(m[2]) {
+				continue
+			}
+			base = m[1]
+			stripped = true
+			found = true
+			break
+		}
+		if !found {
+			return base, stripped
+		}
+	}
+}
+
+func isEditionSuffix(s string) bool {
+	s = strings.TrimSpace(s)
+	for _, re := range cfg.EditionPatterns() {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// groupAlbumEdition groups an album under the album by the same artist with its title without
+// edition suffixes, if there is one.
+func groupAlbumEdition(ctx context.Context, d db.DB, id int32, title string, artistID int32) error {
+	base, ok := EditionBaseTitle(title)
+	if !ok || artistID == 0 {
+		return nil
+	}
+	album, err := findAlbum(ctx, d, db.GetAlbumOpts{
+		Title:    base,
+		ArtistID: artistID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("groupAlbumEdition: %w", err)
+	}
+	if album.ID == id {
+		return nil
+	}
+	err = d.SetAlbumEdition(ctx, db.SetAlbumEditionOpts{
+		ID:        id,
+		EditionOf: album.ID,
+		Source:    db.InformationSourceInferred,
+	})
+	if err != nil {
+		return fmt.Errorf("groupAlbumEdition: %w", err)
+	}
+	logger.FromContext(ctx).Info().Msgf("Grouped album '%s' as an edition of '%s'", title, album.Title)
+	return nil
+}
+
+// GroupAlbumEditions groups existing albums that are editions of other albums. Albums with an
+// edition set by the user are left alone.
+func GroupAlbumEditions(ctx context.Context, store db.DB) error {
+	l := logger.FromContext(ctx)
+	var afterId int32
+	var checked int
+	for {
+		albums, err := store.GetAlbumsWithoutEdition(ctx, db.GetAlbumsWithoutEditionOpts{
+			AfterID: afterId,
+			Limit:   editionBatchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("GroupAlbumEditions: %w", err)
+		}
+		for _, a := range albums {
+			if _, ok := EditionBaseTitle(a.Title); !ok {
+				continue
+			}
+			err = groupAlbumEdition(ctx, store, a.ID, a.Title, a.ArtistID)
+			if err != nil {
+				return fmt.Errorf("GroupAlbumEditions: %w", err)
+			}
+			checked++
+		}
+		if len(albums) < editionBatchSize {
+			break
+		}
+		afterId = albums[len(albums)-1].ID
+	}
+	if checked > 0 {
+		l.Info().Msgf("GroupAlbumEditions: Checked %d albums with edition suffixes", checked)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package catalog_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabehf/koito/internal/catalog"
+	"github.com/gabehf/koito/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditionBaseTitle(t *testing.T) {
+	for _, tc := range []struct {
+		title   string
+		base    string
+		edition bool
+	}{
+		{"Album (Deluxe Edition)", "Album", true},
+		{"Album [Super Deluxe]", "Album", true},
+		{"Album - 2015 Remaster", "Album", true},
+		{"Album (Remastered 2011)", "Album", true},
+		{"Album (25th Anniversary Edition)", "Album", true},
+		{"Album (Expanded Edition) [Remastered]", "Album", true},
+		{"Album (Live)", "Album (Live)", false},
+		{"Deluxe Dreams", "Deluxe Dreams", false},
+		{"Album - Deluxe Dreams", "Album - Deluxe Dreams", false},
+		{"Album", "Album", false},
+	} {
+		base, ok := catalog.EditionBaseTitle(tc.title)
+		assert.Equal(t, tc.base, base, tc.title)
+		assert.Equal(t, tc.edition, ok, tc.title)
+	}
+}
+
+func TestGroupAlbumEditions(t *testing.T) {
+	ctx := context.Background()
+	truncateTestData(t)
+
+	err := store.Exec(ctx,
+		`INSERT INTO artists (musicbrainz_id) VALUES (NULL)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx,
+		`INSERT INTO artist_aliases (artist_id, alias, source, is_primary)
+			VALUES (1, 'Artist One', 'Testing', true)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx,
+		`INSERT INTO releases (musicbrainz_id) VALUES (NULL), (NULL), (NULL)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx,
+		`INSERT INTO release_aliases (release_id, alias, source, is_primary)
+			VALUES (1, 'Album (Deluxe Edition)', 'Testing', true),
+				   (2, 'Album', 'Testing', true),
+				   (3, 'Other Album (Remastered)', 'Testing', true)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx,
+		`INSERT INTO artist_releases (artist_id, release_id, is_primary)
+			VALUES (1, 1, true), (1, 2, true), (1, 3, true)`)
+	require.NoError(t, err)
+
+	err = catalog.GroupAlbumEditions(ctx, store)
+	require.NoError(t, err)
+
+	album, err := store.GetAlbum(ctx, db.GetAlbumOpts{ID: 1})
+	require.NoError(t, err)
+	require.NotNil(t, album.EditionOf)
+	assert.EqualValues(t, 2, *album.EditionOf)
+
+	// editions of albums that do not exist are left alone
+	album, err = store.GetAlbum(ctx, db.GetAlbumOpts{ID: 3})
+	require.NoError(t, err)
+	assert.Nil(t, album.EditionOf)
+}
@@ -0,0 +1,364 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/mbz"
+	"github.com/gabehf/koito/internal/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// minimum MusicBrainz search score for a match to be applied without review
+	EnrichMinScore = 95
+	// minimum MusicBrainz search score for a match to be suggested for review
+	EnrichMinSuggestScore = 70
+	// items that could not be matched are searched for again after this long
+	EnrichRetryInterval = 30 * 24 * time.Hour
+	// max difference in seconds between a track's duration and a recording's length
+	enrichMaxDurationDiff = 10
+	enrichBatchSize       = 250
+	enrichMaxSuggestions  = 3
+)
+
+// A MusicBrainz search result for an item without a MusicBrainz ID
+type enrichCandidate struct {
+	MbzID      uuid.UUID
+	Name       string
+	ArtistName string
+	Score      int
+	// true when the name, artist, and length of the result all match the item
+	Matches bool
+	// tracks only, true when the recording appears on a release with the same title as the item's album
+	OnAlbum        bool
+	Duration       int32
+	ReleaseGroupID uuid.UUID
+	ArtistCredit   []mbz.MusicBrainzArtistCredit
+	Releases       []mbz.MusicBrainzRelease
+}
+
+// EnrichMetadata searches MusicBrainz for artists, albums, and tracks that don't have a MusicBrainz ID.
+// High confidence matches are saved along with any aliases and durations found, while lower confidence
+// matches are saved as suggestions for the user to review. All requests go through the rate limited
+// MusicBrainz client, so a run over a large library can take a while.
+func EnrichMetadata(ctx context.Context, store db.DB, mbzc mbz.MusicBrainzCaller) error {
+	l := logger.FromContext(ctx)
+	for _, t := range []db.ItemType{db.ItemTypeArtist, db.ItemTypeAlbum, db.ItemTypeTrack} {
+		items, err := store.GetItemsWithoutMbzID(ctx, db.GetItemsWithoutMbzIDOpts{
+			Type:           t,
+			AttemptedAfter: time.Now().Add(-EnrichRetryInterval),
+			Limit:          enrichBatchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("EnrichMetadata: %w", err)
+		}
+		var matched, suggested int
+		for _, item := range items {
+			candidates, err := searchEnrichCandidates(ctx, mbzc, t, item)
+			if err != nil {
+				// the attempt isn't recorded, so the item is searched for again on the next run
+				l.Err(err).Msgf("EnrichMetadata: Failed to search MusicBrainz for %s '%s'", t, item.Name)
+				continue
+			}
+			match, suggestions := selectEnrichMatch(t, candidates)
+			if match != nil && mbzIDInUse(ctx, store, t, match.MbzID) {
+				l.Info().Msgf("EnrichMetadata: MusicBrainz ID %s for %s '%s' already belongs to another %s; it may be a duplicate", match.MbzID, t, item.Name, t)
+				match = nil
+			}
+			if match != nil {
+				err = applyEnrichMatch(ctx, store, mbzc, t, item, match)
+				if err != nil {
+					l.Err(err).Msgf("EnrichMetadata: Failed to update %s '%s' with MusicBrainz data", t, item.Name)
+				} else {
+					l.Debug().Msgf("EnrichMetadata: Matched %s '%s' to MusicBrainz ID %s", t, item.Name, match.MbzID)
+					matched++
+				}
+			} else if len(suggestions) > 0 {
+				opts := make([]db.SaveMbzMatchSuggestionOpts, len(suggestions))
+				for i, s := range suggestions {
+					opts[i] = db.SaveMbzMatchSuggestionOpts{
+						MusicBrainzID: s.MbzID,
+						Name:          s.Name,
+						ArtistName:    s.ArtistName,
+						Score:         int32(s.Score),
+					}
+				}
+				err = store.SaveMbzMatchSuggestions(ctx, t, item.ID, opts)
+				if err != nil {
+					return fmt.Errorf("EnrichMetadata: %w", err)
+				}
+				suggested++
+			}
+			err = store.SaveMbzEnrichmentAttempt(ctx, t, item.ID)
+			if err != nil {
+				return fmt.Errorf("EnrichMetadata: %w", err)
+			}
+		}
+		l.Info().Msgf("EnrichMetadata: Matched %d of %d %ss to MusicBrainz, %d need review", matched, len(items), t, suggested)
+	}
+	return nil
+}
+
+// AcceptMbzMatchSuggestion applies a suggested MusicBrainz match to its item, as if it were a high confidence match.
+func AcceptMbzMatchSuggestion(ctx context.Context, store db.DB, mbzc mbz.MusicBrainzCaller, s *models.MbzMatchSuggestion) error {
+	t := db.ItemType(s.Type)
+	if mbzIDInUse(ctx, store, t, s.MbzID) {
+		return fmt.Errorf("AcceptMbzMatchSuggestion: MusicBrainz ID %s already belongs to another %s", s.MbzID, t)
+	}
+	item := db.UnmatchedItem{ID: s.ItemID, Name: s.ItemName}
+	if t == db.ItemTypeTrack {
+		track, err := store.GetTrack(ctx, db.GetTrackOpts{ID: s.ItemID})
+		if err != nil {
+			return fmt.Errorf("AcceptMbzMatchSuggestion: %w", err)
+		}
+		item.Duration = track.Duration
+	}
+	err := applyEnrichMatch(ctx, store, mbzc, t, item, &enrichCandidate{
+		MbzID: s.MbzID,
+		Name:  s.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("AcceptMbzMatchSuggestion: %w", err)
+	}
+	return nil
+}
+
+func searchEnrichCandidates(ctx context.Context, mbzc mbz.MusicBrainzCaller, t db.ItemType, item db.UnmatchedItem) ([]enrichCandidate, error) {
+	var candidates []enrichCandidate
+	switch t {
+	case db.ItemTypeArtist:
+		results, err := mbzc.SearchArtists(ctx, mbz.SearchArtistsOpts{
+			Name:     item.Name,
+			MinScore: EnrichMinSuggestScore,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("searchEnrichCandidates: %w", err)
+		}
+		for _, r := range results {
+			id, err := uuid.Parse(r.ID)
+			if err != nil {
+				continue
+			}
+			matches := namesMatch(item.Name, r.Name)
+			for _, alias := range r.Aliases {
+				matches = matches || namesMatch(item.Name, alias.Name)
+			}
+			candidates = append(candidates, enrichCandidate{
+				MbzID:   id,
+				Name:    r.Name,
+				Score:   r.Score,
+				Matches: matches,
+			})
+		}
+	case db.ItemTypeAlbum:
+		results, err := mbzc.SearchReleases(ctx, mbz.SearchReleasesOpts{
+			Title:    item.Name,
+			Artist:   item.ArtistName,
+			MinScore: EnrichMinSuggestScore,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("searchEnrichCandidates: %w", err)
+		}
+		for _, r := range results {
+			id, err := uuid.Parse(r.ID)
+			if err != nil {
+				continue
+			}
+			rgID, _ := uuid.Parse(r.ReleaseGroup.ID)
+			candidates = append(candidates, enrichCandidate{
+				MbzID:          id,
+				Name:           r.Title,
+				ArtistName:     creditString(r.ArtistCredit),
+				Score:          r.Score,
+				Matches:        namesMatch(item.Name, r.Title) && (item.VariousArtists || creditIncludes(r.ArtistCredit, item.ArtistName)),
+				ReleaseGroupID: rgID,
+				ArtistCredit:   r.ArtistCredit,
+			})
+		}
+	case db.ItemTypeTrack:
+		results, err := mbzc.SearchRecordings(ctx, mbz.SearchRecordingsOpts{
+			Title:    item.Name,
+			Artist:   item.ArtistName,
+			Release:  item.AlbumTitle,
+			Duration: item.Duration,
+			MinScore: EnrichMinSuggestScore,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("searchEnrichCandidates: %w", err)
+		}
+		for _, r := range results {
+			id, err := uuid.Parse(r.ID)
+			if err != nil {
+				continue
+			}
+			duration := int32(r.LengthMs / 1000)
+			durationMatches := item.Duration == 0 || duration == 0 || abs(item.Duration-duration) <= enrichMaxDurationDiff
+			onAlbum := false
+			for _, release := range r.Releases {
+				onAlbum = onAlbum || namesMatch(item.AlbumTitle, release.Title)
+			}
+			candidates = append(candidates, enrichCandidate{
+				MbzID:        id,
+				Name:         r.Title,
+				ArtistName:   creditString(r.ArtistCredit),
+				Score:        r.Score,
+				Matches:      namesMatch(item.Name, r.Title) && creditIncludes(r.ArtistCredit, item.ArtistName) && durationMatches,
+				OnAlbum:      onAlbum,
+				Duration:     duration,
+				ArtistCredit: r.ArtistCredit,
+				Releases:     r.Releases,
+			})
+		}
+	}
+	return candidates, nil
+}
+
+// Returns the candidate that can be applied without review, if there is one, and otherwise the candidates
+// that should be suggested to the user.
+func selectEnrichMatch(t db.ItemType, candidates []enrichCandidate) (*enrichCandidate, []enrichCandidate) {
+	var confident []enrichCandidate
+	for _, c := range candidates {
+		if c.Matches && c.Score >= EnrichMinScore {
+			confident = append(confident, c)
+		}
+	}
+	switch {
+	case len(confident) == 1:
+		return &confident[0], nil
+	case len(confident) > 1 && t == db.ItemTypeAlbum:
+		// different editions of the same album, any of them will do
+		return &confident[0], nil
+	case len(confident) > 1 && t == db.ItemTypeTrack:
+		for _, c := range confident {
+			if c.OnAlbum {
+				return &c, nil
+			}
+		}
+		return &confident[0], nil
+	}
+	// artists that share a name are ambiguous, so they are left for the user to decide
+	var suggestions []enrichCandidate
+	for _, c := range candidates {
+		if c.Score >= EnrichMinSuggestScore && len(suggestions) < enrichMaxSuggestions {
+			suggestions = append(suggestions, c)
+		}
+	}
+	return nil, suggestions
+}
+
+func applyEnrichMatch(ctx context.Context, store db.DB, mbzc mbz.MusicBrainzCaller, t db.ItemType, item db.UnmatchedItem, match *enrichCandidate) error {
+	l := logger.FromContext(ctx)
+	switch t {
+	case db.ItemTypeArtist:
+		err := store.UpdateArtist(ctx, db.UpdateArtistOpts{ID: item.ID, MusicBrainzID: match.MbzID})
+		if err != nil {
+			return fmt.Errorf("applyEnrichMatch: %w", err)
+		}
+		aliases, err := mbzc.GetArtistPrimaryAliases(ctx, match.MbzID)
+		if err != nil {
+			l.Info().AnErr("err", err).Msg("applyEnrichMatch: Failed to get artist aliases from MusicBrainz")
+		} else if err = store.SaveArtistAliases(ctx, item.ID, aliases, "MusicBrainz"); err != nil {
+			l.Err(err).Msg("applyEnrichMatch: Failed to save artist aliases")
+		}
+	case db.ItemTypeAlbum:
+		err := store.UpdateAlbum(ctx, db.UpdateAlbumOpts{ID: item.ID, MusicBrainzID: match.MbzID, ReleaseGroupMbzID: match.ReleaseGroupID})
+		if err != nil {
+			return fmt.Errorf("applyEnrichMatch: %w", err)
+		}
+		aliases := []string{match.Name}
+		if match.ReleaseGroupID != uuid.Nil {
+			titles, err := mbzc.GetReleaseTitles(ctx, match.ReleaseGroupID)
+			if err != nil {
+				l.Info().AnErr("err", err).Msg("applyEnrichMatch: Failed to get release titles from MusicBrainz")
+			} else {
+				aliases = titles
+			}
+		}
+		if err = store.SaveAlbumAliases(ctx, item.ID, aliases, "MusicBrainz"); err != nil {
+			l.Err(err).Msg("applyEnrichMatch: Failed to save album aliases")
+		}
+	case db.ItemTypeTrack:
+		opts := db.UpdateTrackOpts{ID: item.ID, MusicBrainzID: match.MbzID}
+		if item.Duration == 0 {
+			opts.Duration = match.Duration
+			if opts.Duration == 0 {
+				track, err := mbzc.GetTrack(ctx, match.MbzID)
+				if err != nil {
+					l.Info().AnErr("err", err).Msg("applyEnrichMatch: Failed to get recording from MusicBrainz")
+				} else {
+					opts.Duration = int32(track.LengthMs / 1000)
+				}
+			}
+		}
+		err := store.UpdateTrack(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("applyEnrichMatch: %w", err)
+		}
+		if !strings.EqualFold(strings.TrimSpace(item.Name), strings.TrimSpace(match.Name)) && match.Name != "" {
+			if err = store.SaveTrackAliases(ctx, item.ID, []string{match.Name}, "MusicBrainz"); err != nil {
+				l.Err(err).Msg("applyEnrichMatch: Failed to save track aliases")
+			}
+		}
+	default:
+		return fmt.Errorf("applyEnrichMatch: unknown item type '%s'", t)
+	}
+	err := store.DeleteMbzMatchSuggestions(ctx, t, item.ID)
+	if err != nil {
+		return fmt.Errorf("applyEnrichMatch: %w", err)
+	}
+	return nil
+}
+
+// MusicBrainz IDs are unique, so an ID that is already used can't be applied to another item
+func mbzIDInUse(ctx context.Context, store db.DB, t db.ItemType, id uuid.UUID) bool {
+	var err error
+	switch t {
+	case db.ItemTypeArtist:
+		_, err = store.GetArtist(ctx, db.GetArtistOpts{MusicBrainzID: id})
+	case db.ItemTypeAlbum:
+		_, err = store.GetAlbum(ctx, db.GetAlbumOpts{MusicBrainzID: id})
+	case db.ItemTypeTrack:
+		_, err = store.GetTrack(ctx, db.GetTrackOpts{MusicBrainzID: id})
+	}
+	return !errors.Is(err, pgx.ErrNoRows)
+}
+
+func namesMatch(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
+
+func creditIncludes(credits []mbz.MusicBrainzArtistCredit, artist string) bool {
+	for _, c := range credits {
+		if namesMatch(c.Name, artist) || namesMatch(c.Artist.Name, artist) {
+			return true
+		}
+	}
+	return false
+}
+
+func creditString(credits []mbz.MusicBrainzArtistCredit) string {
+	var sb strings.Builder
+	for i, c := range credits {
+		sb.WriteString(c.Name)
+		if c.JoinPhrase != "" {
+			sb.WriteString(c.JoinPhrase)
+		} else if i < len(credits)-1 {
+			sb.WriteString(", ")
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+func abs(n int32) int32 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,63 @@
+package catalog_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabehf/koito/internal/catalog"
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/mbz"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnrichMetadata(t *testing.T) {
+	ctx := context.Background()
+	setupTestDataSansMbzIDs(t)
+	mbzc := &mbz.MbzMockCaller{
+		Artists:  mbzArtistData,
+		Releases: mbzReleaseData,
+		Tracks:   mbzTrackData,
+	}
+
+	err := catalog.EnrichMetadata(ctx, store, mbzc)
+	require.NoError(t, err)
+
+	artist, err := store.GetArtist(ctx, db.GetArtistOpts{ID: 1})
+	require.NoError(t, err)
+	require.NotNil(t, artist.MbzID)
+	assert.Equal(t, uuid.MustParse("00000000-0000-0000-0000-000000000001"), *artist.MbzID)
+	assert.Contains(t, artist.Aliases, "新しい学校のリーダーズ")
+
+	album, err := store.GetAlbum(ctx, db.GetAlbumOpts{ID: 1})
+	require.NoError(t, err)
+	require.NotNil(t, album.MbzID)
+	assert.Equal(t, uuid.MustParse("00000000-0000-0000-0000-000000000101"), *album.MbzID)
+
+	// the mock recording has no artist credit, so the track match needs to be reviewed
+	track, err := store.GetTrack(ctx, db.GetTrackOpts{ID: 1})
+	require.NoError(t, err)
+	assert.Nil(t, track.MbzID)
+	suggestions, err := store.GetMbzMatchSuggestions(ctx, db.GetMbzMatchSuggestionsOpts{})
+	require.NoError(t, err)
+	require.Len(t, suggestions.Items, 1)
+	assert.Equal(t, "track", suggestions.Items[0].Type)
+	assert.Equal(t, "Tokyo Calling", suggestions.Items[0].ItemName)
+
+	// items are not searched for again right away
+	err = catalog.EnrichMetadata(ctx, store, &mbz.MbzErrorCaller{})
+	require.NoError(t, err)
+
+	err = catalog.AcceptMbzMatchSuggestion(ctx, store, mbzc, suggestions.Items[0])
+	require.NoError(t, err)
+	track, err = store.GetTrack(ctx, db.GetTrackOpts{ID: 1})
+	require.NoError(t, err)
+	require.NotNil(t, track.MbzID)
+	assert.Equal(t, uuid.MustParse("00000000-0000-0000-0000-000000001001"), *track.MbzID)
+	assert.EqualValues(t, 191, track.Duration)
+
+	suggestions, err = store.GetMbzMatchSuggestions(ctx, db.GetMbzMatchSuggestionsOpts{})
+	require.NoError(t, err)
+	assert.Len(t, suggestions.Items, 0)
+}
@@ -0,0 +1,91 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gabehf/koito/internal/cfg"
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// Fuzzy matching catches names that are misspelled by scrobblers ("Radiohaed"), which the exact and
+// normalized lookups miss. When it is enabled, a name that could not be found is compared to existing
+// aliases by trigram similarity before a new item is created. Matches at or above the auto-accept
+// threshold are used as is. Matches between the review and auto-accept thresholds are used provisionally
+// and queued for review, so that the user can confirm them, or reject them to split the listens back out.
+
+// fuzzyMatch returns the id of the item a name was fuzzily matched to, or pgx.ErrNoRows. The ids of
+// provisional matches are appended to provisional, so that the listen can be linked to them once it is
+// saved. When provisional is nil, only matches that can be accepted automatically are made, as there would
+// be no listens to split out if the match were rejected.
+func fuzzyMatch(ctx context.Context, d db.DB, opts db.GetFuzzyMatchCandidateOpts, provisional *[]int32) (int32, error) {
+	l := logger.FromContext(ctx)
+	if !cfg.FuzzyMatchingEnabled() || strings.TrimSpace(opts.Name) == "" {
+		return 0, pgx.ErrNoRows
+	}
+	accept, review := cfg.FuzzyMatchThresholds()
+	opts.MinScore = review
+	if provisional == nil {
+		opts.MinScore = accept
+	}
+	c, err := d.GetFuzzyMatchCandidate(ctx, opts)
+	if err != nil {
+		return 0, fmt.Errorf("fuzzyMatch: %w", err)
+	}
+	if c.Score >= accept {
+		l.Info().Msgf("Matched %s '%s' to existing %s '%s' with similarity %.2f", opts.Type, opts.Name, opts.Type, c.Name, c.Score)
+		return c.ID, nil
+	}
+	id, err := d.SaveFuzzyMatch(ctx, db.SaveFuzzyMatchOpts{
+		Type:   opts.Type,
+		ItemID: c.ID,
+		Name:   opts.Name,
+		Score:  c.Score,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("fuzzyMatch: %w", err)
+	}
+	*provisional = append(*provisional, id)
+	l.Info().Msgf("Provisionally matched %s '%s' to existing %s '%s' with similarity %.2f, pending review", opts.Type, opts.Name, opts.Type, c.Name, c.Score)
+	return c.ID, nil
+}
+
+func fuzzyFindArtist(ctx context.Context, d db.DB, name string, provisional *[]int32) (*models.Artist, error) {
+	id, err := fuzzyMatch(ctx, d, db.GetFuzzyMatchCandidateOpts{
+		Type: db.ItemTypeArtist,
+		Name: name,
+	}, provisional)
+	if err != nil {
+		return nil, err
+	}
+	return d.GetArtist(ctx, db.GetArtistOpts{ID: id})
+}
+
+func fuzzyFindAlbum(ctx context.Context, d db.DB, title string, artistID int32, provisional *[]int32) (*models.Album, error) {
+	id, err := fuzzyMatch(ctx, d, db.GetFuzzyMatchCandidateOpts{
+		Type:     db.ItemTypeAlbum,
+		Name:     title,
+		ArtistID: artistID,
+	}, provisional)
+	if err != nil {
+		return nil, err
+	}
+	return d.GetAlbum(ctx, db.GetAlbumOpts{ID: id})
+}
+
+func fuzzyFindTrack(ctx context.Context, d db.DB, title string, albumID int32, artistIDs []int32, provisional *[]int32) (*models.Track, error) {
+	id, err := fuzzyMatch(ctx, d, db.GetFuzzyMatchCandidateOpts{
+		Type:      db.ItemTypeTrack,
+		Name:      title,
+		AlbumID:   albumID,
+		ArtistIDs: artistIDs,
+	}, provisional)
+	if err != nil {
+		return nil, err
+	}
+	return d.GetTrack(ctx, db.GetTrackOpts{ID: id})
+}
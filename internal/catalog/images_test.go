@@ -10,6 +10,7 @@ import (
 
 	"github.com/gabehf/koito/internal/catalog"
 	"github.com/gabehf/koito/internal/cfg"
+	"github.com/gabehf/koito/internal/db"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -72,3 +73,39 @@ func TestImageLifecycle(t *testing.T) {
 	_, err = os.Stat(imagePath)
 	assert.Error(t, err)
 }
+
+func TestCheckIntegrity_OrphanedImages(t *testing.T) {
+	truncateTestData(t)
+	ctx := context.Background()
+
+	imgID := uuid.New()
+	dir := filepath.Join(cfg.ConfigDir(), catalog.ImageCacheDir, "small")
+	require.NoError(t, os.MkdirAll(dir, 0744))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, imgID.String()), []byte("image"), 0644))
+
+	report, err := catalog.CheckIntegrity(ctx, store, catalog.CheckIntegrityOpts{
+		Checks: []db.IntegrityCheck{db.IntegrityCheckOrphanedImages},
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Checks, 1)
+	require.Len(t, report.Checks[0].Issues, 1)
+	assert.Equal(t, imgID.String(), report.Checks[0].Issues[0].Name)
+	assert.EqualValues(t, 0, report.Repaired)
+	// reporting does not delete anything
+	_, err = os.Stat(filepath.Join(dir, imgID.String()))
+	require.NoError(t, err)
+
+	report, err = catalog.CheckIntegrity(ctx, store, catalog.CheckIntegrityOpts{
+		Checks: []db.IntegrityCheck{db.IntegrityCheckOrphanedImages},
+		Repair: true,
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, report.Repaired)
+	_, err = os.Stat(filepath.Join(dir, imgID.String()))
+	assert.Error(t, err)
+
+	_, err = catalog.CheckIntegrity(ctx, store, catalog.CheckIntegrityOpts{
+		Checks: []db.IntegrityCheck{"other"},
+	})
+	assert.Error(t, err)
+}
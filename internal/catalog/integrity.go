@@ -0,0 +1,137 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/gabehf/koito/internal/cfg"
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/google/uuid"
+)
+
+// IntegrityChecks are run in this order, so that repairs of one check can't leave behind issues for
+// a check that already ran, e.g. deleting artists without tracks can orphan their images.
+var IntegrityChecks = []db.IntegrityCheck{
+	db.IntegrityCheckMissingPrimaryAliases,
+	db.IntegrityCheckAlbumsWithoutArtists,
+	db.IntegrityCheckArtistsWithoutTracks,
+	db.IntegrityCheckOrphanedImages,
+}
+
+type CheckIntegrityOpts struct {
+	// Checks to run; every check is run when empty
+	Checks []db.IntegrityCheck
+	// Repair the issues that can be repaired safely, after finding them
+	Repair bool
+}
+
+type IntegrityReport struct {
+	Checks []IntegrityCheckResult `json:"checks"`
+	// issues found, including the ones that were repaired
+	IssueCount int   `json:"issue_count"`
+	Repaired   int64 `json:"repaired"`
+}
+
+type IntegrityCheckResult struct {
+	Check    db.IntegrityCheck   `json:"check"`
+	Issues   []db.IntegrityIssue `json:"issues"`
+	Repaired int64               `json:"repaired"`
+}
+
+// CheckIntegrity looks for inconsistencies in the catalog, such as albums without artists or items
+// without a primary alias, and reports the items that have them. When opts.Repair is set, each class
+// of issue is repaired right after it is found.
+func CheckIntegrity(ctx context.Context, store db.DB, opts CheckIntegrityOpts) (*IntegrityReport, error) {
+	l := logger.FromContext(ctx)
+	for _, c := range opts.Checks {
+		if !slices.Contains(IntegrityChecks, c) {
+			return nil, fmt.Errorf("CheckIntegrity: unknown integrity check '%s'", c)
+		}
+	}
+	report := &IntegrityReport{Checks: make([]IntegrityCheckResult, 0)}
+	for _, check := range IntegrityChecks {
+		if len(opts.Checks) > 0 && !slices.Contains(opts.Checks, check) {
+			continue
+		}
+		var issues []db.IntegrityIssue
+		var err error
+		if check == db.IntegrityCheckOrphanedImages {
+			issues, err = findOrphanedImages(ctx, store)
+		} else {
+			issues, err = store.GetIntegrityIssues(ctx, check)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("CheckIntegrity: %w", err)
+		}
+		result := IntegrityCheckResult{Check: check, Issues: issues}
+		if opts.Repair && len(issues) > 0 {
+			if check == db.IntegrityCheckOrphanedImages {
+				result.Repaired, err = deleteOrphanedImages(issues)
+			} else {
+				result.Repaired, err = store.RepairIntegrityIssues(ctx, check)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("CheckIntegrity: %w", err)
+			}
+		}
+		l.Info().Msgf("CheckIntegrity: Found %d issues for check '%s'", len(issues), check)
+		report.Checks = append(report.Checks, result)
+		report.IssueCount += len(issues)
+		report.Repaired += result.Repaired
+	}
+	return report, nil
+}
+
+// findOrphanedImages returns the images in the image cache that no artist or album uses
+func findOrphanedImages(ctx context.Context, store db.DB) ([]db.IntegrityIssue, error) {
+	cacheDir := filepath.Join(cfg.ConfigDir(), ImageCacheDir)
+	seen := make(map[uuid.UUID]bool)
+	issues := make([]db.IntegrityIssue, 0)
+	for _, dir := range []string{"large", "medium", "small", "full"} {
+		files, err := os.ReadDir(filepath.Join(cacheDir, dir))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("findOrphanedImages: %w", err)
+		}
+		for _, file := range files {
+			id, err := uuid.Parse(file.Name())
+			if err != nil || seen[id] {
+				continue
+			}
+			seen[id] = true
+			used, err := store.ImageHasAssociation(ctx, id)
+			if err != nil {
+				return nil, fmt.Errorf("findOrphanedImages: %w", err)
+			}
+			if !used {
+				issues = append(issues, db.IntegrityIssue{
+					Check:      db.IntegrityCheckOrphanedImages,
+					Name:       id.String(),
+					Repairable: true,
+				})
+			}
+		}
+	}
+	return issues, nil
+}
+
+func deleteOrphanedImages(issues []db.IntegrityIssue) (int64, error) {
+	var deleted int64
+	for _, issue := range issues {
+		id, err := uuid.Parse(issue.Name)
+		if err != nil {
+			continue
+		}
+		err = DeleteImage(id)
+		if err != nil {
+			return deleted, fmt.Errorf("deleteOrphanedImages: %w", err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
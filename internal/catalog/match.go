@@ -0,0 +1,51 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// The find functions look up artists, albums, and tracks by exact name first, then by normalized name,
+// so that names that only differ in case, accents, or punctuation ("Beyonce" and "Beyoncé") are not
+// created as separate items.
+
+func findArtist(ctx context.Context, d db.DB, opts db.GetArtistOpts) (*models.Artist, error) {
+	a, err := d.GetArtist(ctx, opts)
+	if errors.Is(err, pgx.ErrNoRows) {
+		opts.Normalized = true
+		a, err = d.GetArtist(ctx, opts)
+		if err == nil {
+			logger.FromContext(ctx).Debug().Msgf("Artist '%s' matched existing artist '%s' by normalized name", opts.Name, a.Name)
+		}
+	}
+	return a, err
+}
+
+func findAlbum(ctx context.Context, d db.DB, opts db.GetAlbumOpts) (*models.Album, error) {
+	a, err := d.GetAlbum(ctx, opts)
+	if errors.Is(err, pgx.ErrNoRows) {
+		opts.Normalized = true
+		a, err = d.GetAlbum(ctx, opts)
+		if err == nil {
+			logger.FromContext(ctx).Debug().Msgf("Album matched existing album '%s' by normalized title", a.Title)
+		}
+	}
+	return a, err
+}
+
+func findTrack(ctx context.Context, d db.DB, opts db.GetTrackOpts) (*models.Track, error) {
+	t, err := d.GetTrack(ctx, opts)
+	if errors.Is(err, pgx.ErrNoRows) {
+		opts.Normalized = true
+		t, err = d.GetTrack(ctx, opts)
+		if err == nil {
+			logger.FromContext(ctx).Debug().Msgf("Track '%s' matched existing track '%s' by normalized title", opts.Title, t.Title)
+		}
+	}
+	return t, err
+}
@@ -0,0 +1,77 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/google/uuid"
+)
+
+// resolveMbzIDs searches MusicBrainz for the recording of a listen that was submitted without a
+// recording MBID, and fills in the recording, release, and artist MBIDs when a high confidence match is
+// found. IDs that were submitted are never replaced.
+func resolveMbzIDs(ctx context.Context, store db.DB, opts *SubmitListenOpts) {
+	l := logger.FromContext(ctx)
+	if opts.RecordingMbzID != uuid.Nil || opts.MbzCaller == nil {
+		return
+	}
+
+	artist := opts.Artist
+	if len(opts.ArtistNames) > 0 {
+		artist = opts.ArtistNames[0]
+	}
+
+	// tracks that are already known are matched by the enrichment job instead, so that MusicBrainz
+	// isn't searched every time they are listened to
+	if a, err := store.GetArtist(ctx, db.GetArtistOpts{Name: artist}); err == nil {
+		if _, err := store.GetTrack(ctx, db.GetTrackOpts{Title: opts.TrackTitle, ArtistIDs: []int32{a.ID}}); err == nil {
+			l.Debug().Msgf("resolveMbzIDs: Track '%s' by %s already exists, skipping search", opts.TrackTitle, artist)
+			return
+		}
+	}
+
+	candidates, err := searchEnrichCandidates(ctx, opts.MbzCaller, db.ItemTypeTrack, db.UnmatchedItem{
+		Name:       opts.TrackTitle,
+		ArtistName: artist,
+		AlbumTitle: opts.ReleaseTitle,
+		Duration:   opts.Duration,
+	})
+	if err != nil {
+		l.Warn().Err(err).Msg("resolveMbzIDs: Failed to search MusicBrainz for recording")
+		return
+	}
+	match, _ := selectEnrichMatch(db.ItemTypeTrack, candidates)
+	if match == nil {
+		l.Debug().Msgf("resolveMbzIDs: No confident MusicBrainz match for '%s' by %s", opts.TrackTitle, artist)
+		return
+	}
+	l.Debug().Msgf("resolveMbzIDs: Resolved '%s' by %s to recording %s", opts.TrackTitle, artist, match.MbzID)
+	opts.RecordingMbzID = match.MbzID
+
+	if opts.ReleaseMbzID == uuid.Nil && opts.ReleaseTitle != "" {
+		for _, r := range match.Releases {
+			if !namesMatch(r.Title, opts.ReleaseTitle) {
+				continue
+			}
+			if id, err := uuid.Parse(r.ID); err == nil {
+				opts.ReleaseMbzID = id
+			}
+			if id, err := uuid.Parse(r.ReleaseGroup.ID); err == nil && opts.ReleaseGroupMbzID == uuid.Nil {
+				opts.ReleaseGroupMbzID = id
+			}
+			break
+		}
+	}
+
+	if len(opts.ArtistMbzIDs) == 0 && len(opts.ArtistMbidMappings) == 0 {
+		for _, c := range match.ArtistCredit {
+			id, err := uuid.Parse(c.Artist.ID)
+			if err != nil {
+				continue
+			}
+			opts.ArtistMbzIDs = append(opts.ArtistMbzIDs, id)
+			opts.ArtistMbidMappings = append(opts.ArtistMbidMappings, ArtistMbidMap{Artist: c.Name, Mbid: id})
+		}
+	}
+}
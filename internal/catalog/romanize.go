@@ -0,0 +1,80 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/romanizer"
+)
+
+const (
+	// Source of aliases that are romanized versions of a non-Latin name
+	RomanizedAliasSource = "Romanized"
+	romanizeBatchSize    = 500
+)
+
+// saveRomanizedAlias adds a romanized alias to an artist, album, or track whose name is not in Latin script,
+// so that it can be found by typing Latin letters. Names that are already in Latin script are left alone.
+func saveRomanizedAlias(ctx context.Context, d db.DB, t db.ItemType, id int32, name string) error {
+	romanized := romanizer.Romanize(name)
+	if romanized == "" || romanized == name {
+		return nil
+	}
+	l := logger.FromContext(ctx)
+	l.Debug().Msgf("Saving romanized alias '%s' for %s '%s'", romanized, t, name)
+	var err error
+	switch t {
+	case db.ItemTypeArtist:
+		err = d.SaveArtistAliases(ctx, id, []string{romanized}, RomanizedAliasSource)
+	case db.ItemTypeAlbum:
+		err = d.SaveAlbumAliases(ctx, id, []string{romanized}, RomanizedAliasSource)
+	case db.ItemTypeTrack:
+		err = d.SaveTrackAliases(ctx, id, []string{romanized}, RomanizedAliasSource)
+	default:
+		err = fmt.Errorf("unknown item type '%s'", t)
+	}
+	if err != nil {
+		return fmt.Errorf("saveRomanizedAlias: %w", err)
+	}
+	return nil
+}
+
+// RomanizeAliases adds romanized aliases to all existing artists, albums, and tracks with names that
+// are not in Latin script and do not have one yet.
+func RomanizeAliases(ctx context.Context, store db.DB) error {
+	l := logger.FromContext(ctx)
+	for _, t := range []db.ItemType{db.ItemTypeArtist, db.ItemTypeAlbum, db.ItemTypeTrack} {
+		var afterId int32
+		var romanized int
+		for {
+			items, err := store.GetItemsWithoutRomanizedAlias(ctx, db.GetItemsWithoutRomanizedAliasOpts{
+				Type:    t,
+				AfterID: afterId,
+				Limit:   romanizeBatchSize,
+			})
+			if err != nil {
+				return fmt.Errorf("RomanizeAliases: %w", err)
+			}
+			for _, item := range items {
+				if romanizer.Romanize(item.Name) == "" {
+					continue
+				}
+				err = saveRomanizedAlias(ctx, store, t, item.ID, item.Name)
+				if err != nil {
+					return fmt.Errorf("RomanizeAliases: %w", err)
+				}
+				romanized++
+			}
+			if len(items) < romanizeBatchSize {
+				break
+			}
+			afterId = items[len(items)-1].ID
+		}
+		if romanized > 0 {
+			l.Info().Msgf("RomanizeAliases: Added romanized aliases to %d %ss", romanized, t)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package catalog_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabehf/koito/internal/catalog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRomanizeAliases(t *testing.T) {
+	ctx := context.Background()
+	truncateTestData(t)
+
+	err := store.Exec(ctx,
+		`INSERT INTO artists (musicbrainz_id) 
+			VALUES (NULL), (NULL)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx,
+		`INSERT INTO artist_aliases (artist_id, alias, source, is_primary) 
+			VALUES (1, 'ヨルシカ', 'Testing', true),
+				   (2, 'Sigur Rós', 'Testing', true)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx,
+		`INSERT INTO releases (musicbrainz_id) 
+			VALUES (NULL)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx,
+		`INSERT INTO release_aliases (release_id, alias, source, is_primary) 
+			VALUES (1, 'Кино', 'Testing', true)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx,
+		`INSERT INTO tracks (release_id)
+			VALUES (1)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx,
+		`INSERT INTO track_aliases (track_id, alias, source, is_primary)
+			VALUES (1, 'Tokyo Calling', 'Testing', true)`)
+	require.NoError(t, err)
+
+	err = catalog.RomanizeAliases(ctx, store)
+	require.NoError(t, err)
+
+	aliases, err := store.GetAllArtistAliases(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, aliases, 2)
+	assert.Equal(t, "ヨルシカ", aliases[0].Alias)
+	assert.Equal(t, "yorushika", aliases[1].Alias)
+	assert.Equal(t, catalog.RomanizedAliasSource, aliases[1].Source)
+	assert.False(t, aliases[1].Primary)
+
+	aliases, err = store.GetAllAlbumAliases(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, aliases, 2)
+	assert.Equal(t, "Kino", aliases[1].Alias)
+
+	// names that are already in Latin script are left alone
+	aliases, err = store.GetAllArtistAliases(ctx, 2)
+	require.NoError(t, err)
+	assert.Len(t, aliases, 1)
+	aliases, err = store.GetAllTrackAliases(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, aliases, 1)
+
+	// romanized aliases can be searched for
+	results, err := store.SearchArtists(ctx, "yorushika")
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	assert.Equal(t, "ヨルシカ", results[0].Name)
+}
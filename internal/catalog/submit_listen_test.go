@@ -2,12 +2,14 @@ package catalog_test
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/gabehf/koito/internal/catalog"
 	"github.com/gabehf/koito/internal/db"
 	"github.com/gabehf/koito/internal/mbz"
+	"github.com/gabehf/koito/internal/models"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -68,6 +70,35 @@ func TestSubmitListen_CreateAllMbzIDs(t *testing.T) {
 	require.Len(t, p.Items, 1)
 	l := p.Items[0]
 	EqualTime(t, opts.Time.Truncate(time.Second), l.Time)
+
+	// Verify that the release info was saved from the release group
+	album, err := store.GetAlbum(ctx, db.GetAlbumOpts{ID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "2024-01-26", album.ReleaseDate)
+	assert.Equal(t, "Album", album.ReleaseType)
+
+	// Verify that the tracklist was saved, and the listened track linked to it
+	tracklist, err := store.GetAlbumTracklist(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, tracklist, 2)
+	require.NotNil(t, tracklist[0].TrackID)
+	assert.EqualValues(t, 1, *tracklist[0].TrackID)
+	assert.EqualValues(t, 1, tracklist[0].ListenCount)
+	assert.Nil(t, tracklist[1].TrackID)
+	assert.Equal(t, "Otona Blue", tracklist[1].Title)
+	assert.EqualValues(t, 218, tracklist[1].Duration)
+	require.NotNil(t, album.Completion)
+	assert.EqualValues(t, 2, album.Completion.TrackCount)
+	assert.EqualValues(t, 1, album.Completion.HeardTrackCount)
+	assert.Equal(t, 50.0, album.Completion.Percent)
+
+	// Verify that the artist info was saved
+	artist, err := store.GetArtist(ctx, db.GetArtistOpts{MusicBrainzID: artistMbzID})
+	require.NoError(t, err)
+	assert.Equal(t, "Atarashii Gakko", artist.SortName)
+	assert.Equal(t, "Group", artist.Type)
+	assert.Equal(t, "JP", artist.Country)
+	assert.Equal(t, "2015", artist.BeginDate)
 }
 
 func TestSubmitListen_CreateAllMbzIDsNoReleaseGroupID(t *testing.T) {
@@ -519,6 +550,36 @@ func TestSubmitListen_MatchNoMbzIDs(t *testing.T) {
 	assert.Equal(t, 1, count, "duplicate track created or has been associated with fake musicbrainz id")
 }
 
+func TestSubmitListen_MatchNormalizedNames(t *testing.T) {
+	setupTestDataSansMbzIDs(t)
+
+	ctx := context.Background()
+	mbzc := &mbz.MbzMockCaller{}
+	opts := catalog.SubmitListenOpts{
+		MbzCaller:    mbzc,
+		ArtistNames:  []string{"Atarashii Gakko"},
+		Artist:       "Atarashii Gakko",
+		TrackTitle:   "tokyo calling",
+		ReleaseTitle: "AG Calling",
+		Time:         time.Now(),
+		UserID:       1,
+	}
+
+	err := catalog.SubmitListen(ctx, store, opts)
+	require.NoError(t, err)
+
+	// names that only differ in case and punctuation match the existing items
+	count, err := store.Count(ctx, `SELECT COUNT(*) FROM artists`)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "duplicate artist created")
+	count, err = store.Count(ctx, `SELECT COUNT(*) FROM releases`)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "duplicate release created")
+	count, err = store.Count(ctx, `SELECT COUNT(*) FROM tracks`)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "duplicate track created")
+}
+
 func TestSubmitListen_UpdateTrackDuration(t *testing.T) {
 	setupTestDataSansMbzIDs(t)
 
@@ -854,6 +915,165 @@ func TestSubmitListen_AssociateReleaseAliases(t *testing.T) {
 	assert.Equal(t, 1, count, "expected release alias to exist")
 }
 
+func TestSubmitListen_MatchReleaseGroup(t *testing.T) {
+	truncateTestData(t)
+
+	// a different release of an existing album is matched to it by release group
+
+	ctx := context.Background()
+	mbzc := &mbz.MbzMockCaller{
+		Artists:       mbzArtistData,
+		Releases:      mbzReleaseData,
+		Tracks:        mbzTrackData,
+		ReleaseGroups: mbzReleaseGroupData,
+	}
+	artistMbzID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	releaseGroupMbzID := uuid.MustParse("00000000-0000-0000-0000-000000000011")
+	opts := catalog.SubmitListenOpts{
+		MbzCaller:   mbzc,
+		ArtistNames: []string{"ATARASHII GAKKO!"},
+		Artist:      "ATARASHII GAKKO!",
+		ArtistMbzIDs: []uuid.UUID{
+			artistMbzID,
+		},
+		TrackTitle:        "Tokyo Calling",
+		ReleaseTitle:      "AG! Calling",
+		ReleaseMbzID:      uuid.MustParse("00000000-0000-0000-0000-000000000101"),
+		ReleaseGroupMbzID: releaseGroupMbzID,
+		Time:              time.Now().Add(-1 * time.Hour),
+		UserID:            1,
+	}
+	err := catalog.SubmitListen(ctx, store, opts)
+	require.NoError(t, err)
+
+	opts.ReleaseTitle = "AG! Calling (Japan Edition)"
+	opts.ReleaseMbzID = uuid.MustParse("00000000-0000-0000-0000-000000000103")
+	opts.Time = time.Now()
+	err = catalog.SubmitListen(ctx, store, opts)
+	require.NoError(t, err)
+
+	count, err := store.Count(ctx, `SELECT COUNT(*) FROM releases`)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "expected release to be matched by release group")
+
+	album, err := store.GetAlbum(ctx, db.GetAlbumOpts{ID: 1})
+	require.NoError(t, err)
+	require.NotNil(t, album.ReleaseGroupMbzID)
+	assert.Equal(t, releaseGroupMbzID, *album.ReleaseGroupMbzID)
+	assert.EqualValues(t, 2, album.ListenCount)
+
+	// listens with only a release group are matched too
+	opts.ReleaseMbzID = uuid.Nil
+	opts.ReleaseTitle = "AG! Calling (Remastered)"
+	opts.Time = time.Now().Add(-2 * time.Hour)
+	err = catalog.SubmitListen(ctx, store, opts)
+	require.NoError(t, err)
+	count, err = store.Count(ctx, `SELECT COUNT(*) FROM releases`)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "expected release to be matched by release group")
+}
+
+func TestSubmitListen_AlbumArtist(t *testing.T) {
+	truncateTestData(t)
+
+	// tracks on a compilation are grouped under the album artist, not the track artists
+
+	ctx := context.Background()
+	mbzc := &mbz.MbzMockCaller{}
+	opts := catalog.SubmitListenOpts{
+		MbzCaller:    mbzc,
+		Artist:       "Artist One",
+		TrackTitle:   "Track One",
+		ReleaseTitle: "Greatest Compilation",
+		AlbumArtist:  "Various Artists",
+		Time:         time.Now().Add(-1 * time.Hour),
+		UserID:       1,
+	}
+	err := catalog.SubmitListen(ctx, store, opts)
+	require.NoError(t, err)
+
+	opts.Artist = "Artist Two"
+	opts.TrackTitle = "Track Two"
+	opts.Time = time.Now()
+	err = catalog.SubmitListen(ctx, store, opts)
+	require.NoError(t, err)
+
+	count, err := store.Count(ctx, `SELECT COUNT(*) FROM releases`)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "expected both tracks to be on the same album")
+
+	albumArtist, err := store.GetArtist(ctx, db.GetArtistOpts{Name: "Various Artists"})
+	require.NoError(t, err)
+	album, err := store.GetAlbum(ctx, db.GetAlbumOpts{Title: "Greatest Compilation", ArtistID: albumArtist.ID})
+	require.NoError(t, err)
+	album, err = store.GetAlbum(ctx, db.GetAlbumOpts{ID: album.ID})
+	require.NoError(t, err)
+	require.Len(t, album.Artists, 1)
+	assert.Equal(t, "Various Artists", album.Artists[0].Name)
+
+	// track artists are credited on the album, but it is not one of their albums
+	trackArtist, err := store.GetArtist(ctx, db.GetArtistOpts{Name: "Artist One"})
+	require.NoError(t, err)
+	exists, err := store.RowExists(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM artist_releases
+			WHERE artist_id = $1 AND release_id = $2 AND NOT is_album_artist
+		)`, trackArtist.ID, album.ID)
+	require.NoError(t, err)
+	assert.True(t, exists, "expected track artist to be credited on the album")
+	resp, err := store.GetTopAlbumsPaginated(ctx, db.GetItemsOpts{ArtistID: int(trackArtist.ID), Period: db.PeriodAllTime})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Items)
+
+	resp, err = store.GetTopAlbumsPaginated(ctx, db.GetItemsOpts{ArtistID: int(albumArtist.ID), Period: db.PeriodAllTime})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 1)
+	assert.EqualValues(t, 2, resp.Items[0].ListenCount)
+}
+
+func TestSubmitListen_ArtistCredit(t *testing.T) {
+	truncateTestData(t)
+
+	// the artist credit is stored with the phrases joining the artists
+
+	ctx := context.Background()
+	mbzc := &mbz.MbzMockCaller{}
+	opts := catalog.SubmitListenOpts{
+		MbzCaller:    mbzc,
+		Artist:       "Artist One x Artist Two",
+		ArtistNames:  []string{"Artist One", "Artist Two"},
+		TrackTitle:   "Collaboration",
+		ReleaseTitle: "Collaboration",
+		Time:         time.Now(),
+		UserID:       1,
+	}
+	err := catalog.SubmitListen(ctx, store, opts)
+	require.NoError(t, err)
+
+	track, err := store.GetTrack(ctx, db.GetTrackOpts{ID: 1})
+	require.NoError(t, err)
+	require.Len(t, track.Artists, 2)
+	assert.Equal(t, "Artist One", track.Artists[0].CreditedName)
+	assert.Equal(t, " x ", track.Artists[0].JoinPhrase)
+	assert.Equal(t, "Artist One x Artist Two", models.ArtistCreditString(track.Artists))
+
+	raw, err := json.Marshal(track)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), `"artist_credit":"Artist One x Artist Two"`)
+
+	album, err := store.GetAlbum(ctx, db.GetAlbumOpts{ID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "Artist One x Artist Two", models.ArtistCreditString(album.Artists))
+
+	// merging an artist of the credit keeps the credit intact
+	artist, err := store.SaveArtist(ctx, db.SaveArtistOpts{Name: "Artist 2"})
+	require.NoError(t, err)
+	require.NoError(t, store.MergeArtists(ctx, track.Artists[1].ID, artist.ID, false))
+	track, err = store.GetTrack(ctx, db.GetTrackOpts{ID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "Artist One x Artist Two", models.ArtistCreditString(track.Artists))
+}
+
 func TestSubmitListen_MusicBrainzUnreachable(t *testing.T) {
 	truncateTestData(t)
 
@@ -954,3 +1174,67 @@ func TestSubmitListen_MusicBrainzUnreachableMBIDMappings(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, exists, "expected artist to have correct musicbrainz id")
 }
+
+func TestSubmitListen_ResolveMbzIDs(t *testing.T) {
+	truncateTestData(t)
+
+	// recording, release, and artist mbz ids are found by searching musicbrainz
+
+	ctx := context.Background()
+	artistMbzID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	releaseMbzID := uuid.MustParse("00000000-0000-0000-0000-000000000101")
+	trackMbzID := uuid.MustParse("00000000-0000-0000-0000-000000001001")
+	mbzc := &mbz.MbzMockCaller{
+		Artists:       mbzArtistData,
+		ReleaseGroups: mbzReleaseGroupData,
+		Releases:      mbzReleaseData,
+		Tracks: map[uuid.UUID]*mbz.MusicBrainzTrack{
+			trackMbzID: {
+				Title:    "Tokyo Calling",
+				LengthMs: 191000,
+				ArtistCredit: []mbz.MusicBrainzArtistCredit{
+					{
+						Artist: mbz.MusicBrainzArtist{ID: artistMbzID.String(), Name: "ATARASHII GAKKO!"},
+						Name:   "ATARASHII GAKKO!",
+					},
+				},
+				Releases: []mbz.MusicBrainzRelease{
+					{ID: releaseMbzID.String(), Title: "AG! Calling"},
+				},
+			},
+		},
+	}
+	opts := catalog.SubmitListenOpts{
+		MbzCaller:     mbzc,
+		ArtistNames:   []string{"ATARASHII GAKKO!"},
+		Artist:        "ATARASHII GAKKO!",
+		TrackTitle:    "Tokyo Calling",
+		ReleaseTitle:  "AG! Calling",
+		Duration:      190,
+		ResolveMbzIDs: true,
+		Time:          time.Now(),
+		UserID:        1,
+	}
+
+	err := catalog.SubmitListen(ctx, store, opts)
+	require.NoError(t, err)
+
+	track, err := store.GetTrack(ctx, db.GetTrackOpts{MusicBrainzID: trackMbzID})
+	require.NoError(t, err)
+	assert.Equal(t, "Tokyo Calling", track.Title)
+	album, err := store.GetAlbum(ctx, db.GetAlbumOpts{MusicBrainzID: releaseMbzID})
+	require.NoError(t, err)
+	assert.Equal(t, track.AlbumID, album.ID)
+	artist, err := store.GetArtist(ctx, db.GetArtistOpts{MusicBrainzID: artistMbzID})
+	require.NoError(t, err)
+	assert.Equal(t, "ATARASHII GAKKO!", artist.Name)
+
+	// recordings that don't match the submitted length are not used
+	truncateTestData(t)
+	opts.Duration = 300
+	err = catalog.SubmitListen(ctx, store, opts)
+	require.NoError(t, err)
+	track, err = store.GetTrack(ctx, db.GetTrackOpts{ID: 1})
+	require.NoError(t, err)
+	assert.Nil(t, track.MbzID)
+}
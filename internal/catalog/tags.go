@@ -0,0 +1,119 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/mbz"
+	"github.com/google/uuid"
+)
+
+const (
+	// MusicBrainz tags are fetched again after this long, to pick up new votes
+	TagFetchInterval  = 30 * 24 * time.Hour
+	tagFetchBatchSize = 250
+)
+
+// FetchMbzTags saves the MusicBrainz genres and tags of artists, albums, and tracks that have a MusicBrainz ID.
+// Albums use the genres and tags of their release group, since those of individual releases are rarely filled in,
+// and the release date and types of the release group and the tracklists of albums are updated along the way, as are the
+// info and related artists of artists.
+// Items that fail to be fetched are tried again on the next run.
+func FetchMbzTags(ctx context.Context, store db.DB, mbzc mbz.MusicBrainzCaller) error {
+	l := logger.FromContext(ctx)
+	for _, t := range []db.ItemType{db.ItemTypeArtist, db.ItemTypeAlbum, db.ItemTypeTrack} {
+		items, err := store.GetItemsForMbzTagFetch(ctx, db.GetItemsForMbzTagFetchOpts{
+			Type:         t,
+			FetchedAfter: time.Now().Add(-TagFetchInterval),
+			Limit:        tagFetchBatchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("FetchMbzTags: %w", err)
+		}
+		var tagged int
+		for _, item := range items {
+			tags, err := getMbzTags(ctx, store, mbzc, t, item)
+			if err != nil {
+				l.Err(err).Msgf("FetchMbzTags: Failed to fetch MusicBrainz tags for %s %d", t, item.ID)
+				continue
+			}
+			if len(tags) > 0 {
+				err = saveTags(ctx, store, t, item.ID, tags, "MusicBrainz")
+				if err != nil {
+					return fmt.Errorf("FetchMbzTags: %w", err)
+				}
+				tagged++
+			}
+			err = store.SaveMbzTagFetch(ctx, t, item.ID)
+			if err != nil {
+				return fmt.Errorf("FetchMbzTags: %w", err)
+			}
+		}
+		l.Info().Msgf("FetchMbzTags: Found MusicBrainz tags for %d of %d %ss", tagged, len(items), t)
+	}
+	return nil
+}
+
+func getMbzTags(ctx context.Context, store db.DB, mbzc mbz.MusicBrainzCaller, t db.ItemType, item db.MbzItem) ([]string, error) {
+	switch t {
+	case db.ItemTypeArtist:
+		artist, err := mbzc.GetArtist(ctx, item.MbzID)
+		if err != nil {
+			return nil, fmt.Errorf("getMbzTags: %w", err)
+		}
+		err = updateArtistInfo(ctx, store, item.ID, artist)
+		if err != nil {
+			return nil, fmt.Errorf("getMbzTags: %w", err)
+		}
+		err = saveMbzArtistRelations(ctx, store, item.ID, artist)
+		if err != nil {
+			return nil, fmt.Errorf("getMbzTags: %w", err)
+		}
+		return mbz.TagNames(artist.Genres, artist.Tags), nil
+	case db.ItemTypeAlbum:
+		release, err := mbzc.GetRelease(ctx, item.MbzID)
+		if err != nil {
+			return nil, fmt.Errorf("getMbzTags: %w", err)
+		}
+		err = saveMbzTracklist(ctx, store, item.ID, release)
+		if err != nil {
+			return nil, fmt.Errorf("getMbzTags: %w", err)
+		}
+		rgID, err := uuid.Parse(release.ReleaseGroup.ID)
+		if err != nil {
+			// release group was not included in the response
+			return nil, nil
+		}
+		rg, err := mbzc.GetReleaseGroup(ctx, rgID)
+		if err != nil {
+			return nil, fmt.Errorf("getMbzTags: %w", err)
+		}
+		err = updateReleaseInfo(ctx, store, item.ID, rgID, rg)
+		if err != nil {
+			return nil, fmt.Errorf("getMbzTags: %w", err)
+		}
+		return mbz.TagNames(rg.Genres, rg.Tags), nil
+	case db.ItemTypeTrack:
+		track, err := mbzc.GetTrack(ctx, item.MbzID)
+		if err != nil {
+			return nil, fmt.Errorf("getMbzTags: %w", err)
+		}
+		return mbz.TagNames(track.Genres, track.Tags), nil
+	}
+	return nil, fmt.Errorf("getMbzTags: unknown item type '%s'", t)
+}
+
+func saveTags(ctx context.Context, store db.DB, t db.ItemType, id int32, tags []string, source string) error {
+	switch t {
+	case db.ItemTypeArtist:
+		return store.SaveArtistTags(ctx, id, tags, source)
+	case db.ItemTypeAlbum:
+		return store.SaveAlbumTags(ctx, id, tags, source)
+	case db.ItemTypeTrack:
+		return store.SaveTrackTags(ctx, id, tags, source)
+	}
+	return fmt.Errorf("saveTags: unknown item type '%s'", t)
+}
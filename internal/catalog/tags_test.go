@@ -0,0 +1,123 @@
+package catalog_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabehf/koito/internal/catalog"
+	"github.com/gabehf/koito/internal/mbz"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchMbzTags(t *testing.T) {
+	ctx := context.Background()
+	setupTestDataWithMbzIDs(t)
+	mbzc := &mbz.MbzMockCaller{
+		Artists: map[uuid.UUID]*mbz.MusicBrainzArtist{
+			uuid.MustParse("00000000-0000-0000-0000-000000000001"): {
+				Name:   "ATARASHII GAKKO!",
+				Genres: []mbz.MusicBrainzTag{{Name: "J-Pop", Count: 3}},
+				Tags:   []mbz.MusicBrainzTag{{Name: "japanese", Count: 2}, {Name: "bad tag", Count: -1}},
+			},
+		},
+		Tracks: map[uuid.UUID]*mbz.MusicBrainzTrack{
+			uuid.MustParse("00000000-0000-0000-0000-000000001001"): {
+				Title: "Tokyo Calling",
+				Tags:  []mbz.MusicBrainzTag{{Name: "Dance", Count: 1}},
+			},
+		},
+	}
+
+	// the album is missing from the mock, which should not stop the other items from being tagged
+	err := catalog.FetchMbzTags(ctx, store, mbzc)
+	require.NoError(t, err)
+
+	tags, err := store.GetArtistTags(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+	assert.Equal(t, "j-pop", tags[0].Name)
+	assert.Equal(t, "japanese", tags[1].Name)
+	assert.Equal(t, "MusicBrainz", tags[0].Source)
+
+	tags, err = store.GetTrackTags(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, "dance", tags[0].Name)
+
+	// tags that were fetched are not fetched again right away, so changes on MusicBrainz are not picked up yet
+	mbzc.Artists[uuid.MustParse("00000000-0000-0000-0000-000000000001")].Genres = []mbz.MusicBrainzTag{{Name: "Rock", Count: 5}}
+	mbzc.Tracks[uuid.MustParse("00000000-0000-0000-0000-000000001001")].Tags = []mbz.MusicBrainzTag{{Name: "Funk", Count: 1}}
+	err = catalog.FetchMbzTags(ctx, store, mbzc)
+	require.NoError(t, err)
+
+	tags, err = store.GetArtistTags(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+	assert.Equal(t, "j-pop", tags[0].Name)
+	assert.Equal(t, "japanese", tags[1].Name)
+	tags, err = store.GetTrackTags(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, "dance", tags[0].Name)
+}
+
+func TestFetchMbzTags_ArtistRelations(t *testing.T) {
+	ctx := context.Background()
+	setupTestDataWithMbzIDs(t)
+	err := store.Exec(ctx,
+		`INSERT INTO artists (musicbrainz_id) 
+			VALUES ('00000000-0000-0000-0000-000000000002'), ('00000000-0000-0000-0000-000000000003')`)
+	require.NoError(t, err)
+	err = store.Exec(ctx,
+		`INSERT INTO artist_aliases (artist_id, alias, source, is_primary) 
+			VALUES (2, 'SUZUKA', 'Testing', true), (3, 'RIN', 'Testing', true)`)
+	require.NoError(t, err)
+
+	band := mbz.MusicBrainzArtist{ID: "00000000-0000-0000-0000-000000000001", Name: "ATARASHII GAKKO!"}
+	mbzc := &mbz.MbzMockCaller{
+		Artists: map[uuid.UUID]*mbz.MusicBrainzArtist{
+			// the relationships of a group point backward to its members
+			uuid.MustParse("00000000-0000-0000-0000-000000000001"): {
+				Name: "ATARASHII GAKKO!",
+				Relations: []mbz.MusicBrainzArtistRelation{
+					{Type: "member of band", Direction: "backward", Artist: mbz.MusicBrainzArtist{ID: "00000000-0000-0000-0000-000000000002", Name: "SUZUKA"}},
+					// artists that are not in the catalog are skipped
+					{Type: "member of band", Direction: "backward", Artist: mbz.MusicBrainzArtist{ID: "00000000-0000-0000-0000-000000000009", Name: "KANON"}},
+				},
+			},
+			uuid.MustParse("00000000-0000-0000-0000-000000000002"): {Name: "SUZUKA"},
+			// while the relationships of a member point forward to its groups
+			uuid.MustParse("00000000-0000-0000-0000-000000000003"): {
+				Name: "RIN",
+				Relations: []mbz.MusicBrainzArtistRelation{
+					{Type: "member of band", Direction: "forward", Artist: band},
+				},
+			},
+		},
+	}
+
+	err = catalog.FetchMbzTags(ctx, store, mbzc)
+	require.NoError(t, err)
+
+	// both members are stored as the artist, with the group as the related artist
+	for _, member := range []int32{2, 3} {
+		exists, err := store.RowExists(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM artist_relations
+			WHERE artist_id = $1 AND related_artist_id = $2 AND relation = 'member_of' AND source = 'MusicBrainz'
+		)`, member, 1)
+		require.NoError(t, err)
+		assert.True(t, exists, "expected artist %d to be a member of artist 1", member)
+	}
+	exists, err := store.RowExists(ctx, `
+	SELECT EXISTS (
+		SELECT 1 FROM artist_relations WHERE artist_id = 1
+	)`)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	related, err := store.GetRelatedArtists(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, related, 2)
+}
@@ -0,0 +1,83 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/mbz"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAlbumWithoutMbzID       = errors.New("album has no MusicBrainz release ID")
+	ErrReleaseWithoutTracklist = errors.New("MusicBrainz release has no tracklist")
+)
+
+// mbzTracklist returns the tracklist of a MusicBrainz release, with the discs numbered by their position
+func mbzTracklist(release *mbz.MusicBrainzRelease) []db.TracklistTrack {
+	var ret []db.TracklistTrack
+	for _, medium := range release.Media {
+		for _, t := range medium.Tracks {
+			length := t.LengthMs
+			if length == 0 {
+				length = t.Recording.LengthMs
+			}
+			title := t.Title
+			if title == "" {
+				title = t.Recording.Title
+			}
+			recordingID, _ := uuid.Parse(t.Recording.ID)
+			ret = append(ret, db.TracklistTrack{
+				DiscNumber: int32(medium.Position),
+				Position:   int32(t.Position),
+				Title:      title,
+				Duration:   int32(length / 1000),
+				MbzID:      recordingID,
+			})
+		}
+	}
+	return ret
+}
+
+// saveMbzTracklist stores the tracklist of an album from its MusicBrainz release. Releases without
+// any tracks (such as releases from a data dump without media) leave the tracklist as it is.
+func saveMbzTracklist(ctx context.Context, d db.DB, albumID int32, release *mbz.MusicBrainzRelease) error {
+	tracklist := mbzTracklist(release)
+	if len(tracklist) == 0 {
+		return nil
+	}
+	logger.FromContext(ctx).Debug().Msgf("Saving tracklist of %d tracks for album %d", len(tracklist), albumID)
+	err := d.SetAlbumTracklist(ctx, albumID, tracklist)
+	if err != nil {
+		return fmt.Errorf("saveMbzTracklist: %w", err)
+	}
+	return nil
+}
+
+// FetchMbzTracklist fetches the tracklist of an album from its MusicBrainz release and stores it.
+// Returns ErrAlbumWithoutMbzID if the album has no MusicBrainz release ID, and ErrReleaseWithoutTracklist
+// if the release was returned without any tracks.
+func FetchMbzTracklist(ctx context.Context, d db.DB, mbzc mbz.MusicBrainzCaller, albumID int32) error {
+	album, err := d.GetAlbum(ctx, db.GetAlbumOpts{ID: albumID})
+	if err != nil {
+		return fmt.Errorf("FetchMbzTracklist: %w", err)
+	}
+	if album.MbzID == nil || *album.MbzID == uuid.Nil {
+		return fmt.Errorf("FetchMbzTracklist: %w", ErrAlbumWithoutMbzID)
+	}
+	release, err := mbzc.GetRelease(ctx, *album.MbzID)
+	if err != nil {
+		return fmt.Errorf("FetchMbzTracklist: %w", err)
+	}
+	if len(mbzTracklist(release)) == 0 {
+		return fmt.Errorf("FetchMbzTracklist: %w", ErrReleaseWithoutTracklist)
+	}
+	err = saveMbzTracklist(ctx, d, albumID, release)
+	if err != nil {
+		return fmt.Errorf("FetchMbzTracklist: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package catalog_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabehf/koito/internal/catalog"
+	"github.com/gabehf/koito/internal/mbz"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchMbzTracklist(t *testing.T) {
+	ctx := context.Background()
+	setupTestDataWithMbzIDs(t)
+
+	// a release without media does not replace the tracklist
+	err := catalog.FetchMbzTracklist(ctx, store, &mbz.MbzMockCaller{
+		Releases: map[uuid.UUID]*mbz.MusicBrainzRelease{
+			uuid.MustParse("00000000-0000-0000-0000-000000000101"): {Title: "AG! Calling"},
+		},
+	}, 1)
+	assert.ErrorIs(t, err, catalog.ErrReleaseWithoutTracklist)
+	tracklist, err := store.GetAlbumTracklist(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, tracklist)
+
+	err = catalog.FetchMbzTracklist(ctx, store, &mbz.MbzMockCaller{Releases: mbzReleaseData}, 1)
+	require.NoError(t, err)
+	tracklist, err = store.GetAlbumTracklist(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, tracklist, 2)
+}
@@ -0,0 +1,30 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gabehf/koito/internal/cfg"
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+)
+
+// PurgeTrash permanently deletes the artists, albums and tracks that have been in the trash for longer
+// than the configured retention, then prunes the images that only they were using.
+func PurgeTrash(ctx context.Context, store db.DB) error {
+	l := logger.FromContext(ctx)
+	purged, err := store.PurgeTrash(ctx, time.Now().Add(-cfg.TrashRetention()))
+	if err != nil {
+		return fmt.Errorf("PurgeTrash: %w", err)
+	}
+	l.Info().Msgf("Purged %d items from the trash", purged)
+	if purged == 0 {
+		return nil
+	}
+	err = PruneOrphanedImages(ctx, store)
+	if err != nil {
+		return fmt.Errorf("PurgeTrash: %w", err)
+	}
+	return nil
+}
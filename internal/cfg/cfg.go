@@ -3,6 +3,7 @@ package cfg
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -11,37 +12,65 @@ import (
 
 const (
 	// defaultBaseUrl        = "http://127.0.0.1"
-	defaultListenPort     = 4110
-	defaultMusicBrainzUrl = "https://musicbrainz.org"
+	defaultListenPort      = 4110
+	defaultMusicBrainzUrl  = "https://musicbrainz.org"
+	defaultMbzCacheTTLDays = 30
+	defaultTrashRetention  = 30
+
+	defaultFuzzyMatchAcceptThreshold = 0.8
+	defaultFuzzyMatchReviewThreshold = 0.5
 )
 
+// suffixes that mark an album title as an edition of another album, e.g. "Album (Deluxe Edition)"
+var defaultEditionPatterns = []string{
+	`(super |super-)?deluxe( edition| version)?`,
+	`(\d{4} )?remaster(ed)?( \d{4})?( edition| version)?`,
+	`\d+(st|nd|rd|th) anniversary( edition| deluxe edition)?`,
+	`anniversary edition`,
+	`expanded( edition)?`,
+	`special edition`,
+	`collector'?s edition`,
+	`bonus tracks?( edition| version)?`,
+	`(\d{4} )?reissue`,
+}
+
 const (
 	// BASE_URL_ENV                  = "KOITO_BASE_URL"
-	DATABASE_URL_ENV               = "KOITO_DATABASE_URL"
-	BIND_ADDR_ENV                  = "KOITO_BIND_ADDR"
-	LISTEN_PORT_ENV                = "KOITO_LISTEN_PORT"
-	ENABLE_STRUCTURED_LOGGING_ENV  = "KOITO_ENABLE_STRUCTURED_LOGGING"
-	ENABLE_FULL_IMAGE_CACHE_ENV    = "KOITO_ENABLE_FULL_IMAGE_CACHE"
-	LOG_LEVEL_ENV                  = "KOITO_LOG_LEVEL"
-	MUSICBRAINZ_URL_ENV            = "KOITO_MUSICBRAINZ_URL"
-	MUSICBRAINZ_RATE_LIMIT_ENV     = "KOITO_MUSICBRAINZ_RATE_LIMIT"
-	ENABLE_LBZ_RELAY_ENV           = "KOITO_ENABLE_LBZ_RELAY"
-	LBZ_RELAY_URL_ENV              = "KOITO_LBZ_RELAY_URL"
-	LBZ_RELAY_TOKEN_ENV            = "KOITO_LBZ_RELAY_TOKEN"
-	CONFIG_DIR_ENV                 = "KOITO_CONFIG_DIR"
-	DEFAULT_USERNAME_ENV           = "KOITO_DEFAULT_USERNAME"
-	DEFAULT_PASSWORD_ENV           = "KOITO_DEFAULT_PASSWORD"
-	DISABLE_DEEZER_ENV             = "KOITO_DISABLE_DEEZER"
-	DISABLE_COVER_ART_ARCHIVE_ENV  = "KOITO_DISABLE_COVER_ART_ARCHIVE"
-	DISABLE_MUSICBRAINZ_ENV        = "KOITO_DISABLE_MUSICBRAINZ"
-	SKIP_IMPORT_ENV                = "KOITO_SKIP_IMPORT"
-	ALLOWED_HOSTS_ENV              = "KOITO_ALLOWED_HOSTS"
-	CORS_ORIGINS_ENV               = "KOITO_CORS_ALLOWED_ORIGINS"
-	DISABLE_RATE_LIMIT_ENV         = "KOITO_DISABLE_RATE_LIMIT"
-	THROTTLE_IMPORTS_MS            = "KOITO_THROTTLE_IMPORTS_MS"
-	IMPORT_BEFORE_UNIX_ENV         = "KOITO_IMPORT_BEFORE_UNIX"
-	IMPORT_AFTER_UNIX_ENV          = "KOITO_IMPORT_AFTER_UNIX"
-	FETCH_IMAGES_DURING_IMPORT_ENV = "KOITO_FETCH_IMAGES_DURING_IMPORT"
+	DATABASE_URL_ENV                = "KOITO_DATABASE_URL"
+	BIND_ADDR_ENV                   = "KOITO_BIND_ADDR"
+	LISTEN_PORT_ENV                 = "KOITO_LISTEN_PORT"
+	ENABLE_STRUCTURED_LOGGING_ENV   = "KOITO_ENABLE_STRUCTURED_LOGGING"
+	ENABLE_FULL_IMAGE_CACHE_ENV     = "KOITO_ENABLE_FULL_IMAGE_CACHE"
+	LOG_LEVEL_ENV                   = "KOITO_LOG_LEVEL"
+	MUSICBRAINZ_URL_ENV             = "KOITO_MUSICBRAINZ_URL"
+	MUSICBRAINZ_RATE_LIMIT_ENV      = "KOITO_MUSICBRAINZ_RATE_LIMIT"
+	ENABLE_LBZ_RELAY_ENV            = "KOITO_ENABLE_LBZ_RELAY"
+	LBZ_RELAY_URL_ENV               = "KOITO_LBZ_RELAY_URL"
+	LBZ_RELAY_TOKEN_ENV             = "KOITO_LBZ_RELAY_TOKEN"
+	CONFIG_DIR_ENV                  = "KOITO_CONFIG_DIR"
+	DEFAULT_USERNAME_ENV            = "KOITO_DEFAULT_USERNAME"
+	DEFAULT_PASSWORD_ENV            = "KOITO_DEFAULT_PASSWORD"
+	DISABLE_DEEZER_ENV              = "KOITO_DISABLE_DEEZER"
+	DISABLE_COVER_ART_ARCHIVE_ENV   = "KOITO_DISABLE_COVER_ART_ARCHIVE"
+	DISABLE_MUSICBRAINZ_ENV         = "KOITO_DISABLE_MUSICBRAINZ"
+	SKIP_IMPORT_ENV                 = "KOITO_SKIP_IMPORT"
+	ALLOWED_HOSTS_ENV               = "KOITO_ALLOWED_HOSTS"
+	CORS_ORIGINS_ENV                = "KOITO_CORS_ALLOWED_ORIGINS"
+	DISABLE_RATE_LIMIT_ENV          = "KOITO_DISABLE_RATE_LIMIT"
+	THROTTLE_IMPORTS_MS             = "KOITO_THROTTLE_IMPORTS_MS"
+	IMPORT_BEFORE_UNIX_ENV          = "KOITO_IMPORT_BEFORE_UNIX"
+	IMPORT_AFTER_UNIX_ENV           = "KOITO_IMPORT_AFTER_UNIX"
+	FETCH_IMAGES_DURING_IMPORT_ENV  = "KOITO_FETCH_IMAGES_DURING_IMPORT"
+	DISABLE_DUPLICATE_DETECTION_ENV = "KOITO_DISABLE_DUPLICATE_DETECTION"
+	DISABLE_MBZ_ENRICHMENT_ENV      = "KOITO_DISABLE_MUSICBRAINZ_ENRICHMENT"
+	ENABLE_MBZ_SEARCH_ENV           = "KOITO_ENABLE_MUSICBRAINZ_SEARCH"
+	MBZ_CACHE_TTL_DAYS_ENV          = "KOITO_MUSICBRAINZ_CACHE_TTL_DAYS"
+	USE_LOCAL_MBZ_ENV               = "KOITO_USE_LOCAL_MUSICBRAINZ"
+	ENABLE_FUZZY_MATCHING_ENV       = "KOITO_ENABLE_FUZZY_MATCHING"
+	FUZZY_MATCH_ACCEPT_ENV          = "KOITO_FUZZY_MATCH_ACCEPT_THRESHOLD"
+	FUZZY_MATCH_REVIEW_ENV          = "KOITO_FUZZY_MATCH_REVIEW_THRESHOLD"
+	EDITION_PATTERNS_ENV            = "KOITO_EDITION_PATTERNS"
+	TRASH_RETENTION_DAYS_ENV        = "KOITO_TRASH_RETENTION_DAYS"
 )
 
 type config struct {
@@ -49,30 +78,40 @@ type config struct {
 	listenPort int
 	configDir  string
 	// baseUrl              string
-	databaseUrl            string
-	musicBrainzUrl         string
-	musicBrainzRateLimit   int
-	logLevel               int
-	structuredLogging      bool
-	enableFullImageCache   bool
-	lbzRelayEnabled        bool
-	lbzRelayUrl            string
-	lbzRelayToken          string
-	defaultPw              string
-	defaultUsername        string
-	disableDeezer          bool
-	disableCAA             bool
-	disableMusicBrainz     bool
-	skipImport             bool
-	fetchImageDuringImport bool
-	allowedHosts           []string
-	allowAllHosts          bool
-	allowedOrigins         []string
-	disableRateLimit       bool
-	importThrottleMs       int
-	userAgent              string
-	importBefore           time.Time
-	importAfter            time.Time
+	databaseUrl               string
+	musicBrainzUrl            string
+	musicBrainzRateLimit      int
+	logLevel                  int
+	structuredLogging         bool
+	enableFullImageCache      bool
+	lbzRelayEnabled           bool
+	lbzRelayUrl               string
+	lbzRelayToken             string
+	defaultPw                 string
+	defaultUsername           string
+	disableDeezer             bool
+	disableCAA                bool
+	disableMusicBrainz        bool
+	skipImport                bool
+	fetchImageDuringImport    bool
+	allowedHosts              []string
+	allowAllHosts             bool
+	allowedOrigins            []string
+	disableRateLimit          bool
+	importThrottleMs          int
+	userAgent                 string
+	importBefore              time.Time
+	importAfter               time.Time
+	disableDuplicateDetection bool
+	disableMbzEnrichment      bool
+	enableMbzSearch           bool
+	mbzCacheTTL               time.Duration
+	useLocalMbz               bool
+	enableFuzzyMatching       bool
+	fuzzyMatchAccept          float32
+	fuzzyMatchReview          float32
+	editionPatterns           []*regexp.Regexp
+	trashRetention            time.Duration
 }
 
 var (
@@ -148,6 +187,46 @@ func loadConfig(getenv func(string) string, version string) (*config, error) {
 	cfg.disableCAA = parseBool(getenv(DISABLE_COVER_ART_ARCHIVE_ENV))
 	cfg.disableMusicBrainz = parseBool(getenv(DISABLE_MUSICBRAINZ_ENV))
 	cfg.skipImport = parseBool(getenv(SKIP_IMPORT_ENV))
+	cfg.disableDuplicateDetection = parseBool(getenv(DISABLE_DUPLICATE_DETECTION_ENV))
+	cfg.disableMbzEnrichment = parseBool(getenv(DISABLE_MBZ_ENRICHMENT_ENV))
+	cfg.enableMbzSearch = parseBool(getenv(ENABLE_MBZ_SEARCH_ENV))
+	cfg.useLocalMbz = parseBool(getenv(USE_LOCAL_MBZ_ENV))
+
+	cacheTTLDays, err := strconv.Atoi(getenv(MBZ_CACHE_TTL_DAYS_ENV))
+	if err != nil || cacheTTLDays < 0 {
+		cacheTTLDays = defaultMbzCacheTTLDays
+	}
+	cfg.mbzCacheTTL = time.Duration(cacheTTLDays) * 24 * time.Hour
+
+	cfg.enableFuzzyMatching = parseBool(getenv(ENABLE_FUZZY_MATCHING_ENV))
+	cfg.fuzzyMatchAccept = parseThreshold(getenv(FUZZY_MATCH_ACCEPT_ENV), defaultFuzzyMatchAcceptThreshold)
+	cfg.fuzzyMatchReview = parseThreshold(getenv(FUZZY_MATCH_REVIEW_ENV), defaultFuzzyMatchReviewThreshold)
+	if cfg.fuzzyMatchReview > cfg.fuzzyMatchAccept {
+		return nil, fmt.Errorf("loadConfig: invalid configuration: %s cannot be greater than %s", FUZZY_MATCH_REVIEW_ENV, FUZZY_MATCH_ACCEPT_ENV)
+	}
+
+	rawEditionPatterns := defaultEditionPatterns
+	if getenv(EDITION_PATTERNS_ENV) != "" {
+		rawEditionPatterns = strings.Split(getenv(EDITION_PATTERNS_ENV), ",")
+	}
+	for _, p := range rawEditionPatterns {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		// patterns must match the whole suffix, so that "Deluxe" does not match "Deluxe Dreams"
+		re, err := regexp.Compile(`(?i)^(?:` + p + `)$`)
+		if err != nil {
+			return nil, fmt.Errorf("loadConfig: invalid configuration: %s contains invalid pattern '%s': %w", EDITION_PATTERNS_ENV, p, err)
+		}
+		cfg.editionPatterns = append(cfg.editionPatterns, re)
+	}
+
+	trashRetentionDays, err := strconv.Atoi(getenv(TRASH_RETENTION_DAYS_ENV))
+	if err != nil || trashRetentionDays < 0 {
+		trashRetentionDays = defaultTrashRetention
+	}
+	cfg.trashRetention = time.Duration(trashRetentionDays) * 24 * time.Hour
 
 	cfg.userAgent = fmt.Sprintf("Koito %s (contact@koito.io)", version)
 
@@ -199,6 +278,15 @@ func parseBool(s string) bool {
 
 // Global accessors for configuration values
 
+// parseThreshold parses a similarity threshold between 0 and 1, returning def if s is empty or invalid.
+func parseThreshold(s string, def float32) float32 {
+	v, err := strconv.ParseFloat(s, 32)
+	if err != nil || v < 0 || v > 1 {
+		return def
+	}
+	return float32(v)
+}
+
 func UserAgent() string {
 	lock.RLock()
 	defer lock.RUnlock()
@@ -349,3 +437,64 @@ func FetchImagesDuringImport() bool {
 	defer lock.RUnlock()
 	return globalConfig.fetchImageDuringImport
 }
+
+func DuplicateDetectionDisabled() bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	return globalConfig.disableDuplicateDetection
+}
+
+func MusicBrainzEnrichmentDisabled() bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	return globalConfig.disableMbzEnrichment
+}
+
+func MusicBrainzSearchEnabled() bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	return globalConfig.enableMbzSearch && !globalConfig.disableMusicBrainz && !globalConfig.useLocalMbz
+}
+
+// MusicBrainzCacheTTL returns how long MusicBrainz responses are cached for. A TTL of 0 disables the cache.
+func MusicBrainzCacheTTL() time.Duration {
+	lock.RLock()
+	defer lock.RUnlock()
+	return globalConfig.mbzCacheTTL
+}
+
+// LocalMusicBrainzEnabled reports whether MusicBrainz data should be read from an imported data dump
+// instead of the MusicBrainz API.
+func LocalMusicBrainzEnabled() bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	return globalConfig.useLocalMbz
+}
+
+func FuzzyMatchingEnabled() bool {
+	lock.RLock()
+	defer lock.RUnlock()
+	return globalConfig.enableFuzzyMatching
+}
+
+// FuzzyMatchThresholds returns the trigram similarity at or above which fuzzy matches are accepted
+// automatically, and the similarity at or above which they are accepted provisionally and queued for review.
+func FuzzyMatchThresholds() (accept float32, review float32) {
+	lock.RLock()
+	defer lock.RUnlock()
+	return globalConfig.fuzzyMatchAccept, globalConfig.fuzzyMatchReview
+}
+
+func EditionPatterns() []*regexp.Regexp {
+	lock.RLock()
+	defer lock.RUnlock()
+	return globalConfig.editionPatterns
+}
+
+// TrashRetention returns how long deleted artists, albums and tracks are kept in the trash before they are
+// deleted permanently.
+func TrashRetention() time.Duration {
+	lock.RLock()
+	defer lock.RUnlock()
+	return globalConfig.trashRetention
+}
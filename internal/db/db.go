@@ -15,6 +15,7 @@ type DB interface {
 	GetAlbum(ctx context.Context, opts GetAlbumOpts) (*models.Album, error)
 	GetTrack(ctx context.Context, opts GetTrackOpts) (*models.Track, error)
 	GetArtistsForAlbum(ctx context.Context, id int32) ([]*models.Artist, error)
+	GetAlbumsInReleaseGroup(ctx context.Context, releaseGroupMbzID uuid.UUID) ([]*models.Album, error)
 	GetArtistsForTrack(ctx context.Context, id int32) ([]*models.Artist, error)
 	GetTopTracksPaginated(ctx context.Context, opts GetItemsOpts) (*PaginatedResponse[*models.Track], error)
 	GetTopArtistsPaginated(ctx context.Context, opts GetItemsOpts) (*PaginatedResponse[*models.Artist], error)
@@ -24,10 +25,38 @@ type DB interface {
 	GetAllArtistAliases(ctx context.Context, id int32) ([]models.Alias, error)
 	GetAllAlbumAliases(ctx context.Context, id int32) ([]models.Alias, error)
 	GetAllTrackAliases(ctx context.Context, id int32) ([]models.Alias, error)
+	GetTopTagsPaginated(ctx context.Context, opts GetItemsOpts) (*PaginatedResponse[*models.Tag], error)
+	GetArtistTags(ctx context.Context, id int32) ([]models.Tag, error)
+	GetAlbumTags(ctx context.Context, id int32) ([]models.Tag, error)
+	GetTrackTags(ctx context.Context, id int32) ([]models.Tag, error)
+	GetItemsForMbzTagFetch(ctx context.Context, opts GetItemsForMbzTagFetchOpts) ([]MbzItem, error)
+	GetItemsWithoutRomanizedAlias(ctx context.Context, opts GetItemsWithoutRomanizedAliasOpts) ([]NamedItem, error)
 	GetApiKeysByUserID(ctx context.Context, id int32) ([]models.ApiKey, error)
 	GetUserBySession(ctx context.Context, sessionId uuid.UUID) (*models.User, error)
 	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
 	GetUserByApiKey(ctx context.Context, key string) (*models.User, error)
+	GetDuplicatePairs(ctx context.Context, opts GetDuplicatePairsOpts) ([]DuplicatePair, error)
+	GetMergeCandidates(ctx context.Context, opts GetMergeCandidatesOpts) (*PaginatedResponse[*models.MergeCandidate], error)
+	GetMergeCandidate(ctx context.Context, id int32) (*models.MergeCandidate, error)
+	GetItemsWithoutMbzID(ctx context.Context, opts GetItemsWithoutMbzIDOpts) ([]UnmatchedItem, error)
+	GetMbzMatchSuggestions(ctx context.Context, opts GetMbzMatchSuggestionsOpts) (*PaginatedResponse[*models.MbzMatchSuggestion], error)
+	GetMbzMatchSuggestion(ctx context.Context, id int32) (*models.MbzMatchSuggestion, error)
+	GetFuzzyMatchCandidate(ctx context.Context, opts GetFuzzyMatchCandidateOpts) (*FuzzyMatchCandidate, error)
+	GetFuzzyMatches(ctx context.Context, opts GetFuzzyMatchesOpts) (*PaginatedResponse[*models.FuzzyMatch], error)
+	GetFuzzyMatch(ctx context.Context, id int32) (*models.FuzzyMatch, error)
+	GetAlbumsWithoutEdition(ctx context.Context, opts GetAlbumsWithoutEditionOpts) ([]UngroupedAlbum, error)
+	GetMbzCacheEntry(ctx context.Context, entity string, id uuid.UUID, includes string, fetchedAfter time.Time) ([]byte, error)
+	GetMbzLocalEntity(ctx context.Context, entity string, id uuid.UUID) ([]byte, error)
+	GetMbzLocalReleases(ctx context.Context, releaseGroupID uuid.UUID) ([][]byte, error)
+	GetArtistSplitRules(ctx context.Context) ([]models.ArtistSplitRule, error)
+	GetRelatedArtists(ctx context.Context, id int32) ([]models.RelatedArtist, error)
+	GetAlbumTracklist(ctx context.Context, id int32) ([]models.TracklistTrack, error)
+	GetIntegrityIssues(ctx context.Context, check IntegrityCheck) ([]IntegrityIssue, error)
+	GetHiddenItems(ctx context.Context) ([]HiddenItem, error)
+	GetHiddenClients(ctx context.Context) ([]string, error)
+	GetTrash(ctx context.Context) ([]TrashItem, error)
+	PreviewDelete(ctx context.Context, t ItemType, id int32) (*ImpactPreview, error)
+	PreviewMerge(ctx context.Context, t ItemType, fromId, toId int32, replaceImage bool) (*ImpactPreview, error)
 	// Save
 	SaveArtist(ctx context.Context, opts SaveArtistOpts) (*models.Artist, error)
 	SaveArtistAliases(ctx context.Context, id int32, aliases []string, source string) error
@@ -35,10 +64,22 @@ type DB interface {
 	SaveAlbumAliases(ctx context.Context, id int32, aliases []string, source string) error
 	SaveTrack(ctx context.Context, opts SaveTrackOpts) (*models.Track, error)
 	SaveTrackAliases(ctx context.Context, id int32, aliases []string, source string) error
+	SaveArtistTags(ctx context.Context, id int32, tags []string, source string) error
+	SaveAlbumTags(ctx context.Context, id int32, tags []string, source string) error
+	SaveTrackTags(ctx context.Context, id int32, tags []string, source string) error
+	SaveMbzTagFetch(ctx context.Context, t ItemType, id int32) error
 	SaveListen(ctx context.Context, opts SaveListenOpts) error
 	SaveUser(ctx context.Context, opts SaveUserOpts) (*models.User, error)
 	SaveApiKey(ctx context.Context, opts SaveApiKeyOpts) (*models.ApiKey, error)
 	SaveSession(ctx context.Context, userId int32, expiresAt time.Time, persistent bool) (*models.Session, error)
+	SaveMergeCandidates(ctx context.Context, t ItemType, candidates []SaveMergeCandidateOpts) error
+	SaveMbzEnrichmentAttempt(ctx context.Context, t ItemType, id int32) error
+	SaveMbzMatchSuggestions(ctx context.Context, t ItemType, id int32, suggestions []SaveMbzMatchSuggestionOpts) error
+	SaveFuzzyMatch(ctx context.Context, opts SaveFuzzyMatchOpts) (int32, error)
+	SaveMbzCacheEntry(ctx context.Context, entity string, id uuid.UUID, includes string, body []byte) error
+	SaveMbzLocalEntities(ctx context.Context, entity string, entities []SaveMbzLocalEntityOpts) error
+	SaveArtistSplitRule(ctx context.Context, opts SaveArtistSplitRuleOpts) (*models.ArtistSplitRule, error)
+	SaveArtistRelation(ctx context.Context, opts SaveArtistRelationOpts) error
 	// Update
 	UpdateArtist(ctx context.Context, opts UpdateArtistOpts) error
 	UpdateTrack(ctx context.Context, opts UpdateTrackOpts) error
@@ -52,6 +93,18 @@ type DB interface {
 	SetPrimaryTrackAlias(ctx context.Context, id int32, alias string) error
 	SetPrimaryAlbumArtist(ctx context.Context, id int32, artistId int32, value bool) error
 	SetPrimaryTrackArtist(ctx context.Context, id int32, artistId int32, value bool) error
+	SetAlbumArtistCredit(ctx context.Context, id int32, credit []ArtistCredit) error
+	SetTrackArtistCredit(ctx context.Context, id int32, credit []ArtistCredit) error
+	SetAlbumTracklist(ctx context.Context, id int32, tracklist []TracklistTrack) error
+	RepairIntegrityIssues(ctx context.Context, check IntegrityCheck) (int64, error)
+	SetItemHidden(ctx context.Context, t ItemType, id int32, hidden bool) error
+	SetClientHidden(ctx context.Context, client string, hidden bool) error
+	RestoreTrash(ctx context.Context, id int32) error
+	DismissMergeCandidate(ctx context.Context, id int32) error
+	DismissMbzMatchSuggestion(ctx context.Context, id int32) error
+	ConfirmFuzzyMatch(ctx context.Context, id int32) error
+	RejectFuzzyMatch(ctx context.Context, id int32) error
+	SetAlbumEdition(ctx context.Context, opts SetAlbumEditionOpts) error
 	// Delete
 	DeleteArtist(ctx context.Context, id int32) error
 	DeleteAlbum(ctx context.Context, id int32) error
@@ -60,25 +113,47 @@ type DB interface {
 	DeleteArtistAlias(ctx context.Context, id int32, alias string) error
 	DeleteAlbumAlias(ctx context.Context, id int32, alias string) error
 	DeleteTrackAlias(ctx context.Context, id int32, alias string) error
+	DeleteArtistTag(ctx context.Context, id int32, tag string) error
+	DeleteAlbumTag(ctx context.Context, id int32, tag string) error
+	DeleteTrackTag(ctx context.Context, id int32, tag string) error
 	DeleteSession(ctx context.Context, sessionId uuid.UUID) error
 	DeleteApiKey(ctx context.Context, id int32) error
+	DeleteMergeCandidate(ctx context.Context, id int32) error
+	DeleteMbzMatchSuggestions(ctx context.Context, t ItemType, id int32) error
+	DeleteMbzCacheEntries(ctx context.Context, entity string) (int64, error)
+	DeleteAlbumEdition(ctx context.Context, id int32) error
+	DeleteArtistSplitRule(ctx context.Context, id int32) error
+	DeleteArtistRelation(ctx context.Context, opts DeleteArtistRelationOpts) error
+	DeleteTrash(ctx context.Context, id int32) error
+	PurgeTrash(ctx context.Context, before time.Time) (int64, error)
 	// Count
-	CountListens(ctx context.Context, period Period) (int64, error)
-	CountTracks(ctx context.Context, period Period) (int64, error)
-	CountAlbums(ctx context.Context, period Period) (int64, error)
-	CountArtists(ctx context.Context, period Period) (int64, error)
-	CountTimeListened(ctx context.Context, period Period) (int64, error)
+	CountListens(ctx context.Context, period Period, opts ...CountOpts) (int64, error)
+	CountTracks(ctx context.Context, period Period, opts ...CountOpts) (int64, error)
+	CountAlbums(ctx context.Context, period Period, opts ...CountOpts) (int64, error)
+	CountArtists(ctx context.Context, period Period, opts ...CountOpts) (int64, error)
+	CountTimeListened(ctx context.Context, period Period, opts ...CountOpts) (int64, error)
 	CountTimeListenedToItem(ctx context.Context, opts TimeListenedOpts) (int64, error)
+	CountListensByReleaseYear(ctx context.Context, period Period, groupByDecade bool, opts ...CountOpts) ([]ReleaseYearCount, error)
+	CountListensByReleaseType(ctx context.Context, period Period, opts ...CountOpts) ([]ReleaseTypeCount, error)
+	CountListensByCountry(ctx context.Context, period Period, opts ...CountOpts) ([]CountryCount, error)
+	CountCompletedAlbums(ctx context.Context, period Period, opts ...CountOpts) (int64, error)
 	CountUsers(ctx context.Context) (int64, error)
+	CountMbzCacheEntries(ctx context.Context) (int64, error)
 	// Search
-	SearchArtists(ctx context.Context, q string) ([]*models.Artist, error)
-	SearchAlbums(ctx context.Context, q string) ([]*models.Album, error)
-	SearchTracks(ctx context.Context, q string) ([]*models.Track, error)
+	SearchArtists(ctx context.Context, q string, opts ...SearchOpts) ([]*models.Artist, error)
+	SearchAlbums(ctx context.Context, q string, opts ...SearchOpts) ([]*models.Album, error)
+	SearchTracks(ctx context.Context, q string, opts ...SearchOpts) ([]*models.Track, error)
 	// Merge
 	MergeTracks(ctx context.Context, fromId, toId int32) error
 	MergeAlbums(ctx context.Context, fromId, toId int32, replaceImage bool) error
 	MergeArtists(ctx context.Context, fromId, toId int32, replaceImage bool) error
+	// Split
+	SplitArtist(ctx context.Context, opts SplitArtistOpts) (*models.Artist, error)
+	SplitAlbum(ctx context.Context, opts SplitAlbumOpts) (*models.Album, error)
 	// Etc
+	// RunInTx runs fn with a store whose changes are all committed if fn returns nil, and all rolled
+	// back otherwise
+	RunInTx(ctx context.Context, fn func(tx DB) error) error
 	ImageHasAssociation(ctx context.Context, image uuid.UUID) (bool, error)
 	GetImageSource(ctx context.Context, image uuid.UUID) (string, error)
 	AlbumsWithoutImages(ctx context.Context, from int32) ([]*models.Album, error)
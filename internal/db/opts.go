@@ -1,6 +1,7 @@
 package db
 
 import (
+	"errors"
 	"time"
 
 	"github.com/gabehf/koito/internal/models"
@@ -8,12 +9,19 @@ import (
 )
 
 type GetAlbumOpts struct {
-	ID            int32
-	MusicBrainzID uuid.UUID
-	ArtistID      int32
-	Title         string
-	Titles        []string
-	Image         uuid.UUID
+	ID                int32
+	MusicBrainzID     uuid.UUID
+	ReleaseGroupMbzID uuid.UUID
+	ArtistID          int32
+	Title             string
+	Titles            []string
+	Image             uuid.UUID
+	// When true, titles are matched ignoring case, accents, punctuation, and spacing
+	Normalized bool
+	// When true, the listen count and time listened include listens to editions of the album
+	GroupEditions bool
+	// When true, the listen count and time listened include listens to hidden items and from hidden clients
+	IncludeHidden bool
 }
 
 type GetArtistOpts struct {
@@ -21,6 +29,10 @@ type GetArtistOpts struct {
 	MusicBrainzID uuid.UUID
 	Name          string
 	Image         uuid.UUID
+	// When true, the name is matched ignoring case, accents, punctuation, and spacing
+	Normalized bool
+	// When true, the listen count and time listened include listens to hidden items and from hidden clients
+	IncludeHidden bool
 }
 
 type GetTrackOpts struct {
@@ -28,6 +40,10 @@ type GetTrackOpts struct {
 	MusicBrainzID uuid.UUID
 	Title         string
 	ArtistIDs     []int32
+	// When true, the title is matched ignoring case, accents, punctuation, and spacing
+	Normalized bool
+	// When true, the listen count and time listened include listens to hidden items and from hidden clients
+	IncludeHidden bool
 }
 
 type SaveTrackOpts struct {
@@ -39,14 +55,17 @@ type SaveTrackOpts struct {
 }
 
 type SaveAlbumOpts struct {
-	Title          string
-	MusicBrainzID  uuid.UUID
-	Type           string
-	ArtistIDs      []int32
-	VariousArtists bool
-	Image          uuid.UUID
-	ImageSrc       string
-	Aliases        []string
+	Title             string
+	MusicBrainzID     uuid.UUID
+	ReleaseGroupMbzID uuid.UUID
+	Type              string
+	SecondaryTypes    []string
+	ReleaseDate       string // YYYY, YYYY-MM, or YYYY-MM-DD
+	ArtistIDs         []int32
+	VariousArtists    bool
+	Image             uuid.UUID
+	ImageSrc          string
+	Aliases           []string
 }
 
 type SaveArtistOpts struct {
@@ -80,12 +99,24 @@ type SaveListenOpts struct {
 	Time    time.Time
 	UserID  int32
 	Client  string
+	// provisional fuzzy matches the listen was associated through, see SaveFuzzyMatch
+	FuzzyMatchIDs []int32
 }
 
+// ErrInvalidTrackUpdate is returned by UpdateTrack when the update is rejected, e.g. because the
+// album or an artist it refers to doesn't exist. The track is left unchanged.
+var ErrInvalidTrackUpdate = errors.New("invalid track update")
+
 type UpdateTrackOpts struct {
 	ID            int32
 	MusicBrainzID uuid.UUID
 	Duration      int32
+	// Moves the track, along with its listens, to another album
+	AlbumID int32
+	// When set, replaces the artists of the track
+	ArtistIDs []int32
+	// Must be one of the artists of the track after the update
+	PrimaryArtistID int32
 }
 
 type UpdateArtistOpts struct {
@@ -93,15 +124,23 @@ type UpdateArtistOpts struct {
 	MusicBrainzID uuid.UUID
 	Image         uuid.UUID
 	ImageSrc      string
+	// When set, replaces the sort name, type, country, and other info of the artist
+	Info *models.ArtistInfo
 }
 
 type UpdateAlbumOpts struct {
 	ID                   int32
 	MusicBrainzID        uuid.UUID
+	ReleaseGroupMbzID    uuid.UUID
 	Image                uuid.UUID
 	ImageSrc             string
 	VariousArtistsUpdate bool
 	VariousArtistsValue  bool
+	// When true, replaces the release date and types with the values below
+	ReleaseInfoUpdate bool
+	ReleaseDate       string // YYYY, YYYY-MM, or YYYY-MM-DD
+	ReleaseType       string
+	SecondaryTypes    []string
 }
 
 type UpdateUserOpts struct {
@@ -110,9 +149,27 @@ type UpdateUserOpts struct {
 	Password string
 }
 
+// Unless AlbumArtists is set, the artists are only credited through the album's tracks
 type AddArtistsToAlbumOpts struct {
-	AlbumID   int32
-	ArtistIDs []int32
+	AlbumID      int32
+	ArtistIDs    []int32
+	AlbumArtists bool
+}
+
+// If ToID is 0, a new artist named NewName is created to receive the tracks
+type SplitArtistOpts struct {
+	FromID   int32
+	ToID     int32
+	NewName  string
+	TrackIDs []int32
+}
+
+// If ToID is 0, a new album titled NewTitle is created to receive the tracks
+type SplitAlbumOpts struct {
+	FromID   int32
+	ToID     int32
+	NewTitle string
+	TrackIDs []int32
 }
 
 type GetItemsOpts struct {
@@ -129,6 +186,20 @@ type GetItemsOpts struct {
 
 	// Used for getting listens
 	TrackID int
+
+	// Used for getting top artists, albums, and tracks
+	Tag string
+
+	// Used only for getting top albums. When true, listens to editions of an album are
+	// counted towards the album they are an edition of
+	GroupEditions bool
+
+	// Used with ArtistID. When true, the items of the artists related to the artist (its
+	// members and projects) are included
+	IncludeRelated bool
+
+	// When true, hidden artists, albums, tracks and clients are counted
+	IncludeHidden bool
 }
 
 type ListenActivityOpts struct {
@@ -139,6 +210,13 @@ type ListenActivityOpts struct {
 	AlbumID  int32
 	ArtistID int32
 	TrackID  int32
+	Tag      string
+
+	// Used with ArtistID. When true, listens to the artists related to the artist are included
+	IncludeRelated bool
+
+	// When true, listens to hidden items and from hidden clients are included
+	IncludeHidden bool
 }
 
 type TimeListenedOpts struct {
@@ -146,6 +224,19 @@ type TimeListenedOpts struct {
 	AlbumID  int32
 	ArtistID int32
 	TrackID  int32
+
+	// When true, listens to hidden items and from hidden clients are included
+	IncludeHidden bool
+}
+
+type CountOpts struct {
+	// When true, hidden artists, albums, tracks and clients are counted
+	IncludeHidden bool
+}
+
+type SearchOpts struct {
+	// When true, hidden artists, albums and tracks are included in the results
+	IncludeHidden bool
 }
 
 type GetExportPageOpts struct {
@@ -154,3 +245,112 @@ type GetExportPageOpts struct {
 	TrackID    int32
 	Limit      int32
 }
+
+type GetDuplicatePairsOpts struct {
+	Type          ItemType
+	MinSimilarity float32
+}
+
+type SaveMergeCandidateOpts struct {
+	FromID  int32
+	ToID    int32
+	Score   float32
+	Reasons []string
+}
+
+type GetMergeCandidatesOpts struct {
+	Type  ItemType
+	Limit int
+	Page  int
+}
+
+type GetItemsWithoutMbzIDOpts struct {
+	Type ItemType
+	// items that were already searched for after this time are skipped
+	AttemptedAfter time.Time
+	Limit          int
+}
+
+type GetItemsForMbzTagFetchOpts struct {
+	Type ItemType
+	// items with tags fetched after this time are skipped
+	FetchedAfter time.Time
+	Limit        int
+}
+
+type GetItemsWithoutRomanizedAliasOpts struct {
+	Type    ItemType
+	AfterID int32
+	Limit   int
+}
+
+type SaveMbzMatchSuggestionOpts struct {
+	MusicBrainzID uuid.UUID
+	Name          string
+	ArtistName    string
+	Score         int32
+}
+
+type SaveMbzLocalEntityOpts struct {
+	MusicBrainzID  uuid.UUID
+	ReleaseGroupID *uuid.UUID
+	Body           []byte
+}
+
+type GetMbzMatchSuggestionsOpts struct {
+	Limit int
+	Page  int
+}
+
+// Artists are matched by name, albums by title among the albums of ArtistID, and tracks by title among
+// the tracks of AlbumID credited to any of ArtistIDs
+type GetFuzzyMatchCandidateOpts struct {
+	Type      ItemType
+	Name      string
+	ArtistID  int32
+	ArtistIDs []int32
+	AlbumID   int32
+	MinScore  float32
+}
+
+type SaveFuzzyMatchOpts struct {
+	Type   ItemType
+	ItemID int32
+	Name   string
+	Score  float32
+}
+
+type SaveArtistRelationOpts struct {
+	ArtistID        int32
+	RelatedArtistID int32
+	Relation        ArtistRelationType
+	Source          string
+}
+
+type DeleteArtistRelationOpts struct {
+	ArtistID        int32
+	RelatedArtistID int32
+	Relation        ArtistRelationType
+}
+
+type SaveArtistSplitRuleOpts struct {
+	Kind  ArtistSplitRuleKind
+	Value string
+}
+
+type GetFuzzyMatchesOpts struct {
+	Limit int
+	Page  int
+}
+
+type GetAlbumsWithoutEditionOpts struct {
+	AfterID int32
+	Limit   int
+}
+
+type SetAlbumEditionOpts struct {
+	ID int32
+	// The album that this album is an edition of, or 0 if it is not an edition
+	EditionOf int32
+	Source    InformationSource
+}
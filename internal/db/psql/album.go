@@ -34,10 +34,22 @@ func (d *Psql) GetAlbum(ctx context.Context, opts db.GetAlbumOpts) (*models.Albu
 		ret.Title = row.Title
 		ret.Image = row.Image
 		ret.VariousArtists = row.VariousArtists
+		ret.ReleaseDate = row.ReleaseDate.String
+		ret.ReleaseType = row.ReleaseType.String
+		ret.SecondaryTypes = row.SecondaryTypes
+		ret.ReleaseGroupMbzID = row.ReleaseGroupMbzID
 		err = json.Unmarshal(row.Artists, &ret.Artists)
 		if err != nil {
 			return nil, fmt.Errorf("GetAlbum: json.Unmarshal: %w", err)
 		}
+		err = d.getAlbumEditions(ctx, ret)
+		if err != nil {
+			return nil, fmt.Errorf("GetAlbum: %w", err)
+		}
+		err = d.getAlbumCompletion(ctx, ret)
+		if err != nil {
+			return nil, fmt.Errorf("GetAlbum: %w", err)
+		}
 	} else if opts.MusicBrainzID != uuid.Nil {
 		l.Debug().Msgf("Fetching album from DB with MusicBrainz Release ID %s", opts.MusicBrainzID)
 		row, err := d.q.GetReleaseByMbzID(ctx, &opts.MusicBrainzID)
@@ -49,6 +61,45 @@ func (d *Psql) GetAlbum(ctx context.Context, opts db.GetAlbumOpts) (*models.Albu
 		ret.Title = row.Title
 		ret.Image = row.Image
 		ret.VariousArtists = row.VariousArtists
+		ret.ReleaseDate = row.ReleaseDate.String
+		ret.ReleaseType = row.ReleaseType.String
+		ret.SecondaryTypes = row.SecondaryTypes
+	} else if opts.ReleaseGroupMbzID != uuid.Nil {
+		l.Debug().Msgf("Fetching album from DB with MusicBrainz Release Group ID %s", opts.ReleaseGroupMbzID)
+		row, err := d.q.GetReleaseByReleaseGroupMbzID(ctx, &opts.ReleaseGroupMbzID)
+		if err != nil {
+			return nil, fmt.Errorf("GetAlbum: %w", err)
+		}
+		ret.ID = row.ID
+		ret.MbzID = row.MusicBrainzID
+		ret.ReleaseGroupMbzID = &opts.ReleaseGroupMbzID
+		ret.Title = row.Title
+		ret.Image = row.Image
+		ret.VariousArtists = row.VariousArtists
+		ret.ReleaseDate = row.ReleaseDate.String
+		ret.ReleaseType = row.ReleaseType.String
+		ret.SecondaryTypes = row.SecondaryTypes
+	} else if opts.ArtistID != 0 && (opts.Title != "" || len(opts.Titles) > 0) && opts.Normalized {
+		titles := opts.Titles
+		if opts.Title != "" {
+			titles = append([]string{opts.Title}, titles...)
+		}
+		l.Debug().Msgf("Fetching album from DB with artist_id %d and normalized titles %v", opts.ArtistID, titles)
+		row, err := d.q.GetReleaseByArtistAndMatchKeys(ctx, repository.GetReleaseByArtistAndMatchKeysParams{
+			ArtistID: opts.ArtistID,
+			Column1:  titles,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetAlbum: %w", err)
+		}
+		ret.ID = row.ID
+		ret.MbzID = row.MusicBrainzID
+		ret.Title = row.Title
+		ret.Image = row.Image
+		ret.VariousArtists = row.VariousArtists
+		ret.ReleaseDate = row.ReleaseDate.String
+		ret.ReleaseType = row.ReleaseType.String
+		ret.SecondaryTypes = row.SecondaryTypes
 	} else if opts.ArtistID != 0 && opts.Title != "" {
 		l.Debug().Msgf("Fetching album from DB with artist_id %d and title %s", opts.ArtistID, opts.Title)
 		row, err := d.q.GetReleaseByArtistAndTitle(ctx, repository.GetReleaseByArtistAndTitleParams{
@@ -63,6 +114,9 @@ func (d *Psql) GetAlbum(ctx context.Context, opts db.GetAlbumOpts) (*models.Albu
 		ret.Title = row.Title
 		ret.Image = row.Image
 		ret.VariousArtists = row.VariousArtists
+		ret.ReleaseDate = row.ReleaseDate.String
+		ret.ReleaseType = row.ReleaseType.String
+		ret.SecondaryTypes = row.SecondaryTypes
 	} else if opts.ArtistID != 0 && len(opts.Titles) > 0 {
 		l.Debug().Msgf("Fetching release group from DB with artist_id %d and titles %v", opts.ArtistID, opts.Titles)
 		row, err := d.q.GetReleaseByArtistAndTitles(ctx, repository.GetReleaseByArtistAndTitlesParams{
@@ -77,22 +131,42 @@ func (d *Psql) GetAlbum(ctx context.Context, opts db.GetAlbumOpts) (*models.Albu
 		ret.Title = row.Title
 		ret.Image = row.Image
 		ret.VariousArtists = row.VariousArtists
+		ret.ReleaseDate = row.ReleaseDate.String
+		ret.ReleaseType = row.ReleaseType.String
+		ret.SecondaryTypes = row.SecondaryTypes
 	} else {
 		return nil, errors.New("GetAlbum: insufficient information to get album")
 	}
 
+	if opts.GroupEditions {
+		row, err := d.q.CountListensFromReleaseEditions(ctx, repository.CountListensFromReleaseEditionsParams{
+			ListenedAt:    time.Unix(0, 0),
+			ListenedAt_2:  time.Now(),
+			ReleaseID:     ret.ID,
+			IncludeHidden: opts.IncludeHidden,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetAlbum: CountListensFromReleaseEditions: %w", err)
+		}
+		ret.ListenCount = row.ListenCount
+		ret.TimeListened = row.SecondsListened
+		return ret, nil
+	}
+
 	count, err := d.q.CountListensFromRelease(ctx, repository.CountListensFromReleaseParams{
-		ListenedAt:   time.Unix(0, 0),
-		ListenedAt_2: time.Now(),
-		ReleaseID:    ret.ID,
+		ListenedAt:    time.Unix(0, 0),
+		ListenedAt_2:  time.Now(),
+		ReleaseID:     ret.ID,
+		IncludeHidden: opts.IncludeHidden,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("GetAlbum: CountListensFromRelease: %w", err)
 	}
 
 	seconds, err := d.CountTimeListenedToItem(ctx, db.TimeListenedOpts{
-		Period:  db.PeriodAllTime,
-		AlbumID: ret.ID,
+		Period:        db.PeriodAllTime,
+		AlbumID:       ret.ID,
+		IncludeHidden: opts.IncludeHidden,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("GetAlbum: CountTimeListenedToItem: %w", err)
@@ -107,13 +181,20 @@ func (d *Psql) GetAlbum(ctx context.Context, opts db.GetAlbumOpts) (*models.Albu
 func (d *Psql) SaveAlbum(ctx context.Context, opts db.SaveAlbumOpts) (*models.Album, error) {
 	l := logger.FromContext(ctx)
 	var insertMbzID *uuid.UUID
+	var insertRgID *uuid.UUID
 	var insertImage *uuid.UUID
 	if opts.MusicBrainzID != uuid.Nil {
 		insertMbzID = &opts.MusicBrainzID
 	}
+	if opts.ReleaseGroupMbzID != uuid.Nil {
+		insertRgID = &opts.ReleaseGroupMbzID
+	}
 	if opts.Image != uuid.Nil {
 		insertImage = &opts.Image
 	}
+	if opts.SecondaryTypes == nil {
+		opts.SecondaryTypes = []string{}
+	}
 	if len(opts.ArtistIDs) < 1 {
 		return nil, errors.New("SaveAlbum: required parameter 'ArtistIDs' missing")
 	}
@@ -131,10 +212,14 @@ func (d *Psql) SaveAlbum(ctx context.Context, opts db.SaveAlbumOpts) (*models.Al
 	qtx := d.q.WithTx(tx)
 	l.Debug().Msgf("Inserting release '%s' into DB", opts.Title)
 	r, err := qtx.InsertRelease(ctx, repository.InsertReleaseParams{
-		MusicBrainzID:  insertMbzID,
-		VariousArtists: opts.VariousArtists,
-		Image:          insertImage,
-		ImageSource:    pgtype.Text{String: opts.ImageSrc, Valid: opts.ImageSrc != ""},
+		MusicBrainzID:     insertMbzID,
+		VariousArtists:    opts.VariousArtists,
+		Image:             insertImage,
+		ImageSource:       pgtype.Text{String: opts.ImageSrc, Valid: opts.ImageSrc != ""},
+		ReleaseDate:       pgtype.Text{String: opts.ReleaseDate, Valid: opts.ReleaseDate != ""},
+		ReleaseType:       pgtype.Text{String: opts.Type, Valid: opts.Type != ""},
+		SecondaryTypes:    opts.SecondaryTypes,
+		ReleaseGroupMbzID: insertRgID,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("SaveAlbum: InsertRelease: %w", err)
@@ -142,8 +227,9 @@ func (d *Psql) SaveAlbum(ctx context.Context, opts db.SaveAlbumOpts) (*models.Al
 	for _, artistId := range opts.ArtistIDs {
 		l.Debug().Msgf("Associating release '%s' to artist with ID %d", opts.Title, artistId)
 		err = qtx.AssociateArtistToRelease(ctx, repository.AssociateArtistToReleaseParams{
-			ArtistID:  artistId,
-			ReleaseID: r.ID,
+			ArtistID:      artistId,
+			ReleaseID:     r.ID,
+			IsAlbumArtist: true,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("SaveAlbum: AssociateArtistToRelease: %w", err)
@@ -172,11 +258,15 @@ func (d *Psql) SaveAlbum(ctx context.Context, opts db.SaveAlbumOpts) (*models.Al
 	}
 
 	return &models.Album{
-		ID:             r.ID,
-		MbzID:          r.MusicBrainzID,
-		Title:          opts.Title,
-		Image:          r.Image,
-		VariousArtists: r.VariousArtists,
+		ID:                r.ID,
+		MbzID:             r.MusicBrainzID,
+		ReleaseGroupMbzID: r.ReleaseGroupMbzID,
+		Title:             opts.Title,
+		Image:             r.Image,
+		VariousArtists:    r.VariousArtists,
+		ReleaseDate:       r.ReleaseDate.String,
+		ReleaseType:       r.ReleaseType.String,
+		SecondaryTypes:    r.SecondaryTypes,
 	}, nil
 }
 
@@ -191,8 +281,9 @@ func (d *Psql) AddArtistsToAlbum(ctx context.Context, opts db.AddArtistsToAlbumO
 	qtx := d.q.WithTx(tx)
 	for _, id := range opts.ArtistIDs {
 		err := qtx.AssociateArtistToRelease(ctx, repository.AssociateArtistToReleaseParams{
-			ReleaseID: opts.AlbumID,
-			ArtistID:  id,
+			ReleaseID:     opts.AlbumID,
+			ArtistID:      id,
+			IsAlbumArtist: opts.AlbumArtists,
 		})
 		if err != nil {
 			l.Error().Err(err).Msgf("Failed to associate release %d with artist %d", opts.AlbumID, id)
@@ -224,6 +315,16 @@ func (d *Psql) UpdateAlbum(ctx context.Context, opts db.UpdateAlbumOpts) error {
 			return fmt.Errorf("UpdateAlbum: UpdateReleaseMbzID: %w", err)
 		}
 	}
+	if opts.ReleaseGroupMbzID != uuid.Nil {
+		l.Debug().Msgf("Updating release with ID %d with MusicBrainz Release Group ID %s", opts.ID, opts.ReleaseGroupMbzID)
+		err := qtx.UpdateReleaseGroupMbzID(ctx, repository.UpdateReleaseGroupMbzIDParams{
+			ID:                opts.ID,
+			ReleaseGroupMbzID: &opts.ReleaseGroupMbzID,
+		})
+		if err != nil {
+			return fmt.Errorf("UpdateAlbum: UpdateReleaseGroupMbzID: %w", err)
+		}
+	}
 	if opts.Image != uuid.Nil {
 		l.Debug().Msgf("Updating release with ID %d with image %s", opts.ID, opts.Image)
 		err := qtx.UpdateReleaseImage(ctx, repository.UpdateReleaseImageParams{
@@ -245,6 +346,21 @@ func (d *Psql) UpdateAlbum(ctx context.Context, opts db.UpdateAlbumOpts) error {
 			return fmt.Errorf("UpdateAlbum: UpdateReleaseVariousArtists: %w", err)
 		}
 	}
+	if opts.ReleaseInfoUpdate {
+		l.Debug().Msgf("Updating release with ID %d with release date '%s' and type '%s'", opts.ID, opts.ReleaseDate, opts.ReleaseType)
+		if opts.SecondaryTypes == nil {
+			opts.SecondaryTypes = []string{}
+		}
+		err := qtx.UpdateReleaseInfo(ctx, repository.UpdateReleaseInfoParams{
+			ID:             opts.ID,
+			ReleaseDate:    pgtype.Text{String: opts.ReleaseDate, Valid: opts.ReleaseDate != ""},
+			ReleaseType:    pgtype.Text{String: opts.ReleaseType, Valid: opts.ReleaseType != ""},
+			SecondaryTypes: opts.SecondaryTypes,
+		})
+		if err != nil {
+			return fmt.Errorf("UpdateAlbum: UpdateReleaseInfo: %w", err)
+		}
+	}
 	return tx.Commit(ctx)
 }
 
@@ -285,9 +401,11 @@ func (d *Psql) SaveAlbumAliases(ctx context.Context, id int32, aliases []string,
 	return tx.Commit(ctx)
 }
 
+// DeleteAlbum moves an album to the trash, along with its tracks and their listens.
 func (d *Psql) DeleteAlbum(ctx context.Context, id int32) error {
-	return d.q.DeleteRelease(ctx, id)
+	return d.moveToTrash(ctx, db.ItemTypeAlbum, id)
 }
+
 func (d *Psql) DeleteAlbumAlias(ctx context.Context, id int32, alias string) error {
 	return d.q.DeleteReleaseAlias(ctx, repository.DeleteReleaseAliasParams{
 		ReleaseID: id,
@@ -418,3 +536,36 @@ func (d *Psql) SetPrimaryAlbumArtist(ctx context.Context, id int32, artistId int
 	}
 	return tx.Commit(ctx)
 }
+
+// GetAlbumsInReleaseGroup returns the albums that are releases of a MusicBrainz release group, ordered by
+// release date.
+func (d *Psql) GetAlbumsInReleaseGroup(ctx context.Context, releaseGroupMbzID uuid.UUID) ([]*models.Album, error) {
+	l := logger.FromContext(ctx)
+	l.Debug().Msgf("Fetching albums in MusicBrainz release group %s", releaseGroupMbzID)
+	rows, err := d.q.GetReleasesInReleaseGroup(ctx, &releaseGroupMbzID)
+	if err != nil {
+		return nil, fmt.Errorf("GetAlbumsInReleaseGroup: %w", err)
+	}
+	albums := make([]*models.Album, len(rows))
+	for i, row := range rows {
+		artists := make([]models.SimpleArtist, 0)
+		err = json.Unmarshal(row.Artists, &artists)
+		if err != nil {
+			return nil, fmt.Errorf("GetAlbumsInReleaseGroup: Unmarshal: %w", err)
+		}
+		albums[i] = &models.Album{
+			ID:                row.ID,
+			MbzID:             row.MusicBrainzID,
+			ReleaseGroupMbzID: &releaseGroupMbzID,
+			Title:             row.Title,
+			Image:             row.Image,
+			Artists:           artists,
+			VariousArtists:    row.VariousArtists,
+			ReleaseDate:       row.ReleaseDate.String,
+			ReleaseType:       row.ReleaseType.String,
+			SecondaryTypes:    row.SecondaryTypes,
+			ListenCount:       row.ListenCount,
+		}
+	}
+	return albums, nil
+}
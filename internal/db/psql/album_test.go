@@ -21,7 +21,17 @@ func truncateTestData(t *testing.T) {
 		releases, 
 		artist_releases, 
 		release_aliases,
-		listens 
+		listens,
+		merge_candidates,
+		mbz_enrichment_attempts,
+		mbz_match_suggestions,
+		fuzzy_matches,
+		fuzzy_match_listens,
+		release_editions,
+		tags,
+		mbz_tag_fetches,
+		hidden_clients,
+		trash
 		RESTART IDENTITY CASCADE`)
 	require.NoError(t, err)
 }
@@ -133,6 +143,59 @@ func TestUpdateAlbum(t *testing.T) {
 
 	truncateTestData(t)
 }
+
+func TestGetAlbumsInReleaseGroup(t *testing.T) {
+	testDataForRelease(t)
+	ctx := context.Background()
+
+	rgID := uuid.New()
+	first, err := store.SaveAlbum(ctx, db.SaveAlbumOpts{
+		Title:             "Release Group",
+		MusicBrainzID:     uuid.New(),
+		ReleaseGroupMbzID: rgID,
+		ReleaseDate:       "2020",
+		ArtistIDs:         []int32{1},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, first.ReleaseGroupMbzID)
+	assert.Equal(t, rgID, *first.ReleaseGroupMbzID)
+	second, err := store.SaveAlbum(ctx, db.SaveAlbumOpts{
+		Title:       "Release Group (Japan Edition)",
+		ReleaseDate: "2019",
+		ArtistIDs:   []int32{1},
+	})
+	require.NoError(t, err)
+	err = store.UpdateAlbum(ctx, db.UpdateAlbumOpts{ID: second.ID, ReleaseGroupMbzID: rgID})
+	require.NoError(t, err)
+	_, err = store.SaveAlbum(ctx, db.SaveAlbumOpts{
+		Title:     "Other Release Group",
+		ArtistIDs: []int32{1},
+	})
+	require.NoError(t, err)
+
+	// albums are found by release group
+	result, err := store.GetAlbum(ctx, db.GetAlbumOpts{ReleaseGroupMbzID: rgID})
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, result.ID)
+	result, err = store.GetAlbum(ctx, db.GetAlbumOpts{ID: second.ID})
+	require.NoError(t, err)
+	require.NotNil(t, result.ReleaseGroupMbzID)
+	assert.Equal(t, rgID, *result.ReleaseGroupMbzID)
+
+	albums, err := store.GetAlbumsInReleaseGroup(ctx, rgID)
+	require.NoError(t, err)
+	require.Len(t, albums, 2)
+	assert.Equal(t, "Release Group (Japan Edition)", albums[0].Title)
+	assert.Equal(t, "Release Group", albums[1].Title)
+	require.Len(t, albums[0].Artists, 1)
+
+	albums, err = store.GetAlbumsInReleaseGroup(ctx, uuid.New())
+	require.NoError(t, err)
+	assert.Empty(t, albums)
+
+	truncateTestData(t)
+}
+
 func TestAddArtistsToAlbum(t *testing.T) {
 	testDataForRelease(t)
 	ctx := context.Background()
@@ -27,16 +27,18 @@ func (d *Psql) GetArtist(ctx context.Context, opts db.GetArtistOpts) (*models.Ar
 			return nil, fmt.Errorf("GetArtist: GetArtist by ID: %w", err)
 		}
 		count, err := d.q.CountListensFromArtist(ctx, repository.CountListensFromArtistParams{
-			ListenedAt:   time.Unix(0, 0),
-			ListenedAt_2: time.Now(),
-			ArtistID:     row.ID,
+			ListenedAt:    time.Unix(0, 0),
+			ListenedAt_2:  time.Now(),
+			ArtistID:      row.ID,
+			IncludeHidden: opts.IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("GetArtist: CountListensFromArtist: %w", err)
 		}
 		seconds, err := d.CountTimeListenedToItem(ctx, db.TimeListenedOpts{
-			Period:   db.PeriodAllTime,
-			ArtistID: row.ID,
+			Period:        db.PeriodAllTime,
+			ArtistID:      row.ID,
+			IncludeHidden: opts.IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("GetArtist: CountTimeListenedToItem: %w", err)
@@ -49,6 +51,15 @@ func (d *Psql) GetArtist(ctx context.Context, opts db.GetArtistOpts) (*models.Ar
 			Image:        row.Image,
 			ListenCount:  count,
 			TimeListened: seconds,
+			ArtistInfo: models.ArtistInfo{
+				SortName:  row.SortName.String,
+				Type:      row.ArtistType.String,
+				Gender:    row.Gender.String,
+				Area:      row.Area.String,
+				Country:   row.Country.String,
+				BeginDate: row.BeginDate.String,
+				EndDate:   row.EndDate.String,
+			},
 		}, nil
 	} else if opts.MusicBrainzID != uuid.Nil {
 		l.Debug().Msgf("Fetching artist from DB with MusicBrainz ID %s", opts.MusicBrainzID)
@@ -57,16 +68,18 @@ func (d *Psql) GetArtist(ctx context.Context, opts db.GetArtistOpts) (*models.Ar
 			return nil, fmt.Errorf("GetArtist: GetArtistByMbzID: %w", err)
 		}
 		count, err := d.q.CountListensFromArtist(ctx, repository.CountListensFromArtistParams{
-			ListenedAt:   time.Unix(0, 0),
-			ListenedAt_2: time.Now(),
-			ArtistID:     row.ID,
+			ListenedAt:    time.Unix(0, 0),
+			ListenedAt_2:  time.Now(),
+			ArtistID:      row.ID,
+			IncludeHidden: opts.IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("GetArtist: CountListensFromArtist: %w", err)
 		}
 		seconds, err := d.CountTimeListenedToItem(ctx, db.TimeListenedOpts{
-			Period:   db.PeriodAllTime,
-			ArtistID: row.ID,
+			Period:        db.PeriodAllTime,
+			ArtistID:      row.ID,
+			IncludeHidden: opts.IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("GetArtist: CountTimeListenedToItem: %w", err)
@@ -79,6 +92,56 @@ func (d *Psql) GetArtist(ctx context.Context, opts db.GetArtistOpts) (*models.Ar
 			Image:        row.Image,
 			TimeListened: seconds,
 			ListenCount:  count,
+			ArtistInfo: models.ArtistInfo{
+				SortName:  row.SortName.String,
+				Type:      row.ArtistType.String,
+				Gender:    row.Gender.String,
+				Area:      row.Area.String,
+				Country:   row.Country.String,
+				BeginDate: row.BeginDate.String,
+				EndDate:   row.EndDate.String,
+			},
+		}, nil
+	} else if opts.Name != "" && opts.Normalized {
+		l.Debug().Msgf("Fetching artist from DB with normalized name '%s'", opts.Name)
+		row, err := d.q.GetArtistByMatchKey(ctx, opts.Name)
+		if err != nil {
+			return nil, fmt.Errorf("GetArtist: GetArtistByMatchKey: %w", err)
+		}
+		count, err := d.q.CountListensFromArtist(ctx, repository.CountListensFromArtistParams{
+			ListenedAt:    time.Unix(0, 0),
+			ListenedAt_2:  time.Now(),
+			ArtistID:      row.ID,
+			IncludeHidden: opts.IncludeHidden,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetArtist: CountListensFromArtist: %w", err)
+		}
+		seconds, err := d.CountTimeListenedToItem(ctx, db.TimeListenedOpts{
+			Period:        db.PeriodAllTime,
+			ArtistID:      row.ID,
+			IncludeHidden: opts.IncludeHidden,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetArtist: CountTimeListenedToItem: %w", err)
+		}
+		return &models.Artist{
+			ID:           row.ID,
+			MbzID:        row.MusicBrainzID,
+			Name:         row.Name,
+			Aliases:      row.Aliases,
+			Image:        row.Image,
+			ListenCount:  count,
+			TimeListened: seconds,
+			ArtistInfo: models.ArtistInfo{
+				SortName:  row.SortName.String,
+				Type:      row.ArtistType.String,
+				Gender:    row.Gender.String,
+				Area:      row.Area.String,
+				Country:   row.Country.String,
+				BeginDate: row.BeginDate.String,
+				EndDate:   row.EndDate.String,
+			},
 		}, nil
 	} else if opts.Name != "" {
 		l.Debug().Msgf("Fetching artist from DB with name '%s'", opts.Name)
@@ -87,16 +150,18 @@ func (d *Psql) GetArtist(ctx context.Context, opts db.GetArtistOpts) (*models.Ar
 			return nil, fmt.Errorf("GetArtist: GetArtistByName: %w", err)
 		}
 		count, err := d.q.CountListensFromArtist(ctx, repository.CountListensFromArtistParams{
-			ListenedAt:   time.Unix(0, 0),
-			ListenedAt_2: time.Now(),
-			ArtistID:     row.ID,
+			ListenedAt:    time.Unix(0, 0),
+			ListenedAt_2:  time.Now(),
+			ArtistID:      row.ID,
+			IncludeHidden: opts.IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("GetArtist: CountListensFromArtist: %w", err)
 		}
 		seconds, err := d.CountTimeListenedToItem(ctx, db.TimeListenedOpts{
-			Period:   db.PeriodAllTime,
-			ArtistID: row.ID,
+			Period:        db.PeriodAllTime,
+			ArtistID:      row.ID,
+			IncludeHidden: opts.IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("GetArtist: CountTimeListenedToItem: %w", err)
@@ -109,6 +174,15 @@ func (d *Psql) GetArtist(ctx context.Context, opts db.GetArtistOpts) (*models.Ar
 			Image:        row.Image,
 			ListenCount:  count,
 			TimeListened: seconds,
+			ArtistInfo: models.ArtistInfo{
+				SortName:  row.SortName.String,
+				Type:      row.ArtistType.String,
+				Gender:    row.Gender.String,
+				Area:      row.Area.String,
+				Country:   row.Country.String,
+				BeginDate: row.BeginDate.String,
+				EndDate:   row.EndDate.String,
+			},
 		}, nil
 	} else {
 		return nil, errors.New("insufficient information to get artist")
@@ -157,8 +231,10 @@ func (d *Psql) SaveArtistAliases(ctx context.Context, id int32, aliases []string
 	return tx.Commit(ctx)
 }
 
+// DeleteArtist moves an artist to the trash, along with its albums and tracks that have no other
+// artists, and their listens.
 func (d *Psql) DeleteArtist(ctx context.Context, id int32) error {
-	return d.q.DeleteArtist(ctx, id)
+	return d.moveToTrash(ctx, db.ItemTypeArtist, id)
 }
 
 // Equivalent to Psql.SaveArtist, then Psql.SaveMbzAliases
@@ -259,6 +335,22 @@ func (d *Psql) UpdateArtist(ctx context.Context, opts db.UpdateArtistOpts) error
 			return fmt.Errorf("UpdateArtist: UpdateArtistImage: %w", err)
 		}
 	}
+	if opts.Info != nil {
+		l.Debug().Msgf("Updating artist with id %d with info %+v", opts.ID, *opts.Info)
+		err = qtx.UpdateArtistInfo(ctx, repository.UpdateArtistInfoParams{
+			ID:         opts.ID,
+			SortName:   pgtype.Text{String: opts.Info.SortName, Valid: opts.Info.SortName != ""},
+			ArtistType: pgtype.Text{String: opts.Info.Type, Valid: opts.Info.Type != ""},
+			Gender:     pgtype.Text{String: opts.Info.Gender, Valid: opts.Info.Gender != ""},
+			Area:       pgtype.Text{String: opts.Info.Area, Valid: opts.Info.Area != ""},
+			Country:    pgtype.Text{String: opts.Info.Country, Valid: opts.Info.Country != ""},
+			BeginDate:  pgtype.Text{String: opts.Info.BeginDate, Valid: opts.Info.BeginDate != ""},
+			EndDate:    pgtype.Text{String: opts.Info.EndDate, Valid: opts.Info.EndDate != ""},
+		})
+		if err != nil {
+			return fmt.Errorf("UpdateArtist: UpdateArtistInfo: %w", err)
+		}
+	}
 	err = tx.Commit(ctx)
 	if err != nil {
 		l.Err(err).Msg("Failed to commit update artist transaction")
@@ -0,0 +1,78 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// SetTrackArtistCredit replaces the artist credit of a track. Every artist of the credit must
+// already be an artist of the track.
+func (d *Psql) SetTrackArtistCredit(ctx context.Context, id int32, credit []db.ArtistCredit) error {
+	l := logger.FromContext(ctx)
+	tx, err := d.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		l.Err(err).Msg("Failed to begin transaction")
+		return fmt.Errorf("SetTrackArtistCredit: BeginTx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := d.q.WithTx(tx)
+	err = qtx.ClearTrackArtistCredit(ctx, id)
+	if err != nil {
+		return fmt.Errorf("SetTrackArtistCredit: ClearTrackArtistCredit: %w", err)
+	}
+	for i, c := range credit {
+		rows, err := qtx.UpdateTrackArtistCredit(ctx, repository.UpdateTrackArtistCreditParams{
+			ArtistID:       c.ArtistID,
+			TrackID:        id,
+			CreditPosition: pgtype.Int4{Int32: int32(i), Valid: true},
+			CreditedName:   pgtype.Text{String: c.Name, Valid: c.Name != ""},
+			JoinPhrase:     c.JoinPhrase,
+		})
+		if err != nil {
+			return fmt.Errorf("SetTrackArtistCredit: UpdateTrackArtistCredit: %w", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("SetTrackArtistCredit: artist %d is not an artist of track %d", c.ArtistID, id)
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// SetAlbumArtistCredit replaces the artist credit of an album. Every artist of the credit must
+// already be credited on the album, and the credit should only contain its album artists.
+func (d *Psql) SetAlbumArtistCredit(ctx context.Context, id int32, credit []db.ArtistCredit) error {
+	l := logger.FromContext(ctx)
+	tx, err := d.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		l.Err(err).Msg("Failed to begin transaction")
+		return fmt.Errorf("SetAlbumArtistCredit: BeginTx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := d.q.WithTx(tx)
+	err = qtx.ClearReleaseArtistCredit(ctx, id)
+	if err != nil {
+		return fmt.Errorf("SetAlbumArtistCredit: ClearReleaseArtistCredit: %w", err)
+	}
+	for i, c := range credit {
+		rows, err := qtx.UpdateReleaseArtistCredit(ctx, repository.UpdateReleaseArtistCreditParams{
+			ArtistID:       c.ArtistID,
+			ReleaseID:      id,
+			CreditPosition: pgtype.Int4{Int32: int32(i), Valid: true},
+			CreditedName:   pgtype.Text{String: c.Name, Valid: c.Name != ""},
+			JoinPhrase:     c.JoinPhrase,
+		})
+		if err != nil {
+			return fmt.Errorf("SetAlbumArtistCredit: UpdateReleaseArtistCredit: %w", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("SetAlbumArtistCredit: artist %d is not an artist of album %d", c.ArtistID, id)
+		}
+	}
+	return tx.Commit(ctx)
+}
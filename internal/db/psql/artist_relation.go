@@ -0,0 +1,81 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/models"
+	"github.com/gabehf/koito/internal/repository"
+	"github.com/jackc/pgx/v5"
+)
+
+// relations as read from the related artist
+var reverseArtistRelations = map[string]string{
+	string(db.ArtistRelationMemberOf):   "has_member",
+	string(db.ArtistRelationPerformsAs): "performed_by",
+}
+
+// GetRelatedArtists returns the artists related to an artist in either direction, with the relation
+// read from the artist, e.g. a group has_member a person that is a member_of the group.
+func (d *Psql) GetRelatedArtists(ctx context.Context, id int32) ([]models.RelatedArtist, error) {
+	rows, err := d.q.GetRelatedArtists(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("GetRelatedArtists: %w", err)
+	}
+	ret := make([]models.RelatedArtist, len(rows))
+	for i, row := range rows {
+		relation := row.Relation
+		if row.Reverse {
+			relation = reverseArtistRelations[row.Relation]
+		}
+		ret[i] = models.RelatedArtist{
+			ID:       row.ID,
+			Name:     row.Name,
+			Image:    row.Image,
+			Relation: relation,
+			Source:   row.Source,
+		}
+	}
+	return ret, nil
+}
+
+func (d *Psql) SaveArtistRelation(ctx context.Context, opts db.SaveArtistRelationOpts) error {
+	if opts.ArtistID == 0 || opts.RelatedArtistID == 0 {
+		return errors.New("SaveArtistRelation: artist ids must be specified")
+	}
+	if opts.ArtistID == opts.RelatedArtistID {
+		return errors.New("SaveArtistRelation: an artist cannot be related to itself")
+	}
+	if opts.Relation != db.ArtistRelationMemberOf && opts.Relation != db.ArtistRelationPerformsAs {
+		return fmt.Errorf("SaveArtistRelation: unknown relation '%s'", opts.Relation)
+	}
+	err := d.q.InsertArtistRelation(ctx, repository.InsertArtistRelationParams{
+		ArtistID:        opts.ArtistID,
+		RelatedArtistID: opts.RelatedArtistID,
+		Relation:        string(opts.Relation),
+		Source:          opts.Source,
+	})
+	if err != nil {
+		return fmt.Errorf("SaveArtistRelation: InsertArtistRelation: %w", err)
+	}
+	return nil
+}
+
+// DeleteArtistRelation deletes a relation between two artists. Returns pgx.ErrNoRows if the artists
+// are not related.
+func (d *Psql) DeleteArtistRelation(ctx context.Context, opts db.DeleteArtistRelationOpts) error {
+	rows, err := d.q.DeleteArtistRelation(ctx, repository.DeleteArtistRelationParams{
+		ArtistID:        opts.ArtistID,
+		RelatedArtistID: opts.RelatedArtistID,
+		Relation:        string(opts.Relation),
+	})
+	if err != nil {
+		return fmt.Errorf("DeleteArtistRelation: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("DeleteArtistRelation: %w", pgx.ErrNoRows)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package psql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtistRelations(t *testing.T) {
+	testDataForTopItems(t)
+	ctx := context.Background()
+
+	// artist 2 is a member of artist 1
+	require.NoError(t, store.SaveArtistRelation(ctx, db.SaveArtistRelationOpts{
+		ArtistID:        2,
+		RelatedArtistID: 1,
+		Relation:        db.ArtistRelationMemberOf,
+		Source:          "Testing",
+	}))
+	// saving the same relation again does nothing
+	require.NoError(t, store.SaveArtistRelation(ctx, db.SaveArtistRelationOpts{
+		ArtistID:        2,
+		RelatedArtistID: 1,
+		Relation:        db.ArtistRelationMemberOf,
+		Source:          "Testing",
+	}))
+	assert.Error(t, store.SaveArtistRelation(ctx, db.SaveArtistRelationOpts{
+		ArtistID:        1,
+		RelatedArtistID: 1,
+		Relation:        db.ArtistRelationMemberOf,
+	}))
+	assert.Error(t, store.SaveArtistRelation(ctx, db.SaveArtistRelationOpts{
+		ArtistID:        1,
+		RelatedArtistID: 2,
+		Relation:        "other",
+	}))
+
+	related, err := store.GetRelatedArtists(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, related, 1)
+	assert.EqualValues(t, 2, related[0].ID)
+	assert.Equal(t, "Artist Two", related[0].Name)
+	assert.Equal(t, "has_member", related[0].Relation)
+
+	related, err = store.GetRelatedArtists(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, related, 1)
+	assert.EqualValues(t, 1, related[0].ID)
+	assert.Equal(t, "member_of", related[0].Relation)
+
+	// stats of a group include its members when rolled up
+	resp, err := store.GetTopTracksPaginated(ctx, db.GetItemsOpts{Period: db.PeriodAllTime, ArtistID: 1})
+	require.NoError(t, err)
+	assert.Len(t, resp.Items, 1)
+	resp, err = store.GetTopTracksPaginated(ctx, db.GetItemsOpts{Period: db.PeriodAllTime, ArtistID: 1, IncludeRelated: true})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 2)
+	assert.Equal(t, int64(2), resp.TotalCount)
+	assert.Equal(t, "Track One", resp.Items[0].Title)
+	assert.Equal(t, "Track Two", resp.Items[1].Title)
+
+	albums, err := store.GetTopAlbumsPaginated(ctx, db.GetItemsOpts{Period: db.PeriodAllTime, ArtistID: 1, IncludeRelated: true})
+	require.NoError(t, err)
+	assert.Len(t, albums.Items, 2)
+
+	// relations are moved to the artist that is merged into
+	require.NoError(t, store.MergeArtists(ctx, 2, 3, false))
+	related, err = store.GetRelatedArtists(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, related, 1)
+	assert.EqualValues(t, 3, related[0].ID)
+
+	require.NoError(t, store.DeleteArtistRelation(ctx, db.DeleteArtistRelationOpts{
+		ArtistID:        3,
+		RelatedArtistID: 1,
+		Relation:        db.ArtistRelationMemberOf,
+	}))
+	assert.ErrorIs(t, store.DeleteArtistRelation(ctx, db.DeleteArtistRelationOpts{
+		ArtistID:        3,
+		RelatedArtistID: 1,
+		Relation:        db.ArtistRelationMemberOf,
+	}), pgx.ErrNoRows)
+
+	related, err = store.GetRelatedArtists(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, related)
+}
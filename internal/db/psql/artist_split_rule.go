@@ -0,0 +1,70 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/models"
+	"github.com/gabehf/koito/internal/repository"
+	"github.com/jackc/pgx/v5"
+)
+
+func (d *Psql) GetArtistSplitRules(ctx context.Context) ([]models.ArtistSplitRule, error) {
+	rows, err := d.q.GetArtistSplitRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetArtistSplitRules: %w", err)
+	}
+	rules := make([]models.ArtistSplitRule, len(rows))
+	for i, row := range rows {
+		rules[i] = models.ArtistSplitRule{
+			ID:    row.ID,
+			Kind:  row.Kind,
+			Value: row.Value,
+		}
+	}
+	return rules, nil
+}
+
+// SaveArtistSplitRule saves a separator or protected artist name. Saving a rule that already exists,
+// ignoring case, replaces its value.
+func (d *Psql) SaveArtistSplitRule(ctx context.Context, opts db.SaveArtistSplitRuleOpts) (*models.ArtistSplitRule, error) {
+	if opts.Kind != db.ArtistSplitRuleSeparator && opts.Kind != db.ArtistSplitRuleProtected {
+		return nil, fmt.Errorf("SaveArtistSplitRule: unknown rule kind '%s'", opts.Kind)
+	}
+	// separators keep their surrounding whitespace, so that e.g. " x " does not split "Alex"
+	if strings.TrimSpace(opts.Value) == "" {
+		return nil, errors.New("SaveArtistSplitRule: value must not be empty")
+	}
+	value := opts.Value
+	if opts.Kind == db.ArtistSplitRuleProtected {
+		value = strings.TrimSpace(value)
+	}
+	row, err := d.q.InsertArtistSplitRule(ctx, repository.InsertArtistSplitRuleParams{
+		Kind:  string(opts.Kind),
+		Value: value,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("SaveArtistSplitRule: InsertArtistSplitRule: %w", err)
+	}
+	return &models.ArtistSplitRule{
+		ID:    row.ID,
+		Kind:  row.Kind,
+		Value: row.Value,
+	}, nil
+}
+
+// DeleteArtistSplitRule deletes a separator or protected artist name. Returns pgx.ErrNoRows if no
+// rule has the given id.
+func (d *Psql) DeleteArtistSplitRule(ctx context.Context, id int32) error {
+	rows, err := d.q.DeleteArtistSplitRule(ctx, id)
+	if err != nil {
+		return fmt.Errorf("DeleteArtistSplitRule: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("DeleteArtistSplitRule: %w", pgx.ErrNoRows)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package psql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtistSplitRules(t *testing.T) {
+	ctx := context.Background()
+
+	sep, err := store.SaveArtistSplitRule(ctx, db.SaveArtistSplitRuleOpts{Kind: db.ArtistSplitRuleSeparator, Value: " / "})
+	require.NoError(t, err)
+	assert.Equal(t, " / ", sep.Value)
+	prot, err := store.SaveArtistSplitRule(ctx, db.SaveArtistSplitRuleOpts{Kind: db.ArtistSplitRuleProtected, Value: " simon & garfunkel "})
+	require.NoError(t, err)
+	assert.Equal(t, "simon & garfunkel", prot.Value)
+
+	// saving a rule again, ignoring case, replaces its value
+	again, err := store.SaveArtistSplitRule(ctx, db.SaveArtistSplitRuleOpts{Kind: db.ArtistSplitRuleProtected, Value: "Simon & Garfunkel"})
+	require.NoError(t, err)
+	assert.Equal(t, prot.ID, again.ID)
+
+	_, err = store.SaveArtistSplitRule(ctx, db.SaveArtistSplitRuleOpts{Kind: db.ArtistSplitRuleSeparator, Value: "  "})
+	assert.Error(t, err)
+	_, err = store.SaveArtistSplitRule(ctx, db.SaveArtistSplitRuleOpts{Kind: "other", Value: ";"})
+	assert.Error(t, err)
+
+	rules, err := store.GetArtistSplitRules(ctx)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "protected", rules[0].Kind)
+	assert.Equal(t, "Simon & Garfunkel", rules[0].Value)
+	assert.Equal(t, "separator", rules[1].Kind)
+
+	require.NoError(t, store.DeleteArtistSplitRule(ctx, sep.ID))
+	require.NoError(t, store.DeleteArtistSplitRule(ctx, prot.ID))
+	assert.ErrorIs(t, store.DeleteArtistSplitRule(ctx, prot.ID), pgx.ErrNoRows)
+
+	rules, err = store.GetArtistSplitRules(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+}
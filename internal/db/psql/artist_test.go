@@ -8,6 +8,7 @@ import (
 	"github.com/gabehf/koito/internal/catalog"
 	"github.com/gabehf/koito/internal/db"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -48,6 +49,48 @@ func TestGetArtist(t *testing.T) {
 	truncateTestData(t)
 }
 
+func TestGetByNormalizedName(t *testing.T) {
+	ctx := context.Background()
+	truncateTestData(t)
+
+	artist, err := store.SaveArtist(ctx, db.SaveArtistOpts{Name: "Beyoncé"})
+	require.NoError(t, err)
+	acdc, err := store.SaveArtist(ctx, db.SaveArtistOpts{Name: "AC/DC"})
+	require.NoError(t, err)
+
+	// exact matching is unchanged
+	_, err = store.GetArtist(ctx, db.GetArtistOpts{Name: "Beyonce"})
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	for _, name := range []string{"Beyonce", "BEYONCÉ", "  beyoncé "} {
+		result, err := store.GetArtist(ctx, db.GetArtistOpts{Name: name, Normalized: true})
+		require.NoError(t, err, name)
+		assert.Equal(t, artist.ID, result.ID, name)
+	}
+	result, err := store.GetArtist(ctx, db.GetArtistOpts{Name: "ACDC", Normalized: true})
+	require.NoError(t, err)
+	assert.Equal(t, acdc.ID, result.ID)
+	_, err = store.GetArtist(ctx, db.GetArtistOpts{Name: "AC DC Tribute", Normalized: true})
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	album, err := store.SaveAlbum(ctx, db.SaveAlbumOpts{Title: "Don’t Stop", ArtistIDs: []int32{artist.ID}})
+	require.NoError(t, err)
+	resultAlbum, err := store.GetAlbum(ctx, db.GetAlbumOpts{Title: "Don't Stop", ArtistID: artist.ID, Normalized: true})
+	require.NoError(t, err)
+	assert.Equal(t, album.ID, resultAlbum.ID)
+	// other artists' albums are not matched
+	_, err = store.GetAlbum(ctx, db.GetAlbumOpts{Title: "Don't Stop", ArtistID: acdc.ID, Normalized: true})
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	track, err := store.SaveTrack(ctx, db.SaveTrackOpts{Title: "Café del Mar", AlbumID: album.ID, ArtistIDs: []int32{artist.ID}})
+	require.NoError(t, err)
+	resultTrack, err := store.GetTrack(ctx, db.GetTrackOpts{Title: "cafe del  mar", ArtistIDs: []int32{artist.ID}, Normalized: true})
+	require.NoError(t, err)
+	assert.Equal(t, track.ID, resultTrack.ID)
+
+	truncateTestData(t)
+}
+
 func TestSaveAliases(t *testing.T) {
 	ctx := context.Background()
 
@@ -10,12 +10,21 @@ import (
 	"github.com/gabehf/koito/internal/repository"
 )
 
-func (p *Psql) CountListens(ctx context.Context, period db.Period) (int64, error) {
+// countOpts returns the options given to a count, which are optional
+func countOpts(opts []db.CountOpts) db.CountOpts {
+	if len(opts) > 0 {
+		return opts[0]
+	}
+	return db.CountOpts{}
+}
+
+func (p *Psql) CountListens(ctx context.Context, period db.Period, opts ...db.CountOpts) (int64, error) {
 	t2 := time.Now()
 	t1 := db.StartTimeFromPeriod(period)
 	count, err := p.q.CountListens(ctx, repository.CountListensParams{
-		ListenedAt:   t1,
-		ListenedAt_2: t2,
+		ListenedAt:    t1,
+		ListenedAt_2:  t2,
+		IncludeHidden: countOpts(opts).IncludeHidden,
 	})
 	if err != nil {
 		return 0, fmt.Errorf("CountListens: %w", err)
@@ -23,12 +32,13 @@ func (p *Psql) CountListens(ctx context.Context, period db.Period) (int64, error
 	return count, nil
 }
 
-func (p *Psql) CountTracks(ctx context.Context, period db.Period) (int64, error) {
+func (p *Psql) CountTracks(ctx context.Context, period db.Period, opts ...db.CountOpts) (int64, error) {
 	t2 := time.Now()
 	t1 := db.StartTimeFromPeriod(period)
 	count, err := p.q.CountTopTracks(ctx, repository.CountTopTracksParams{
-		ListenedAt:   t1,
-		ListenedAt_2: t2,
+		ListenedAt:    t1,
+		ListenedAt_2:  t2,
+		IncludeHidden: countOpts(opts).IncludeHidden,
 	})
 	if err != nil {
 		return 0, fmt.Errorf("CountTracks: %w", err)
@@ -36,12 +46,13 @@ func (p *Psql) CountTracks(ctx context.Context, period db.Period) (int64, error)
 	return count, nil
 }
 
-func (p *Psql) CountAlbums(ctx context.Context, period db.Period) (int64, error) {
+func (p *Psql) CountAlbums(ctx context.Context, period db.Period, opts ...db.CountOpts) (int64, error) {
 	t2 := time.Now()
 	t1 := db.StartTimeFromPeriod(period)
 	count, err := p.q.CountTopReleases(ctx, repository.CountTopReleasesParams{
-		ListenedAt:   t1,
-		ListenedAt_2: t2,
+		ListenedAt:    t1,
+		ListenedAt_2:  t2,
+		IncludeHidden: countOpts(opts).IncludeHidden,
 	})
 	if err != nil {
 		return 0, fmt.Errorf("CountAlbums: %w", err)
@@ -49,12 +60,13 @@ func (p *Psql) CountAlbums(ctx context.Context, period db.Period) (int64, error)
 	return count, nil
 }
 
-func (p *Psql) CountArtists(ctx context.Context, period db.Period) (int64, error) {
+func (p *Psql) CountArtists(ctx context.Context, period db.Period, opts ...db.CountOpts) (int64, error) {
 	t2 := time.Now()
 	t1 := db.StartTimeFromPeriod(period)
 	count, err := p.q.CountTopArtists(ctx, repository.CountTopArtistsParams{
-		ListenedAt:   t1,
-		ListenedAt_2: t2,
+		ListenedAt:    t1,
+		ListenedAt_2:  t2,
+		IncludeHidden: countOpts(opts).IncludeHidden,
 	})
 	if err != nil {
 		return 0, fmt.Errorf("CountArtists: %w", err)
@@ -62,12 +74,13 @@ func (p *Psql) CountArtists(ctx context.Context, period db.Period) (int64, error
 	return count, nil
 }
 
-func (p *Psql) CountTimeListened(ctx context.Context, period db.Period) (int64, error) {
+func (p *Psql) CountTimeListened(ctx context.Context, period db.Period, opts ...db.CountOpts) (int64, error) {
 	t2 := time.Now()
 	t1 := db.StartTimeFromPeriod(period)
 	count, err := p.q.CountTimeListened(ctx, repository.CountTimeListenedParams{
-		ListenedAt:   t1,
-		ListenedAt_2: t2,
+		ListenedAt:    t1,
+		ListenedAt_2:  t2,
+		IncludeHidden: countOpts(opts).IncludeHidden,
 	})
 	if err != nil {
 		return 0, fmt.Errorf("CountTimeListened: %w", err)
@@ -81,9 +94,10 @@ func (p *Psql) CountTimeListenedToItem(ctx context.Context, opts db.TimeListened
 
 	if opts.ArtistID > 0 {
 		count, err := p.q.CountTimeListenedToArtist(ctx, repository.CountTimeListenedToArtistParams{
-			ListenedAt:   t1,
-			ListenedAt_2: t2,
-			ArtistID:     opts.ArtistID,
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			ArtistID:      opts.ArtistID,
+			IncludeHidden: opts.IncludeHidden,
 		})
 		if err != nil {
 			return 0, fmt.Errorf("CountTimeListenedToItem (Artist): %w", err)
@@ -91,9 +105,10 @@ func (p *Psql) CountTimeListenedToItem(ctx context.Context, opts db.TimeListened
 		return count, nil
 	} else if opts.AlbumID > 0 {
 		count, err := p.q.CountTimeListenedToRelease(ctx, repository.CountTimeListenedToReleaseParams{
-			ListenedAt:   t1,
-			ListenedAt_2: t2,
-			ReleaseID:    opts.AlbumID,
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			ReleaseID:     opts.AlbumID,
+			IncludeHidden: opts.IncludeHidden,
 		})
 		if err != nil {
 			return 0, fmt.Errorf("CountTimeListenedToItem (Album): %w", err)
@@ -101,9 +116,10 @@ func (p *Psql) CountTimeListenedToItem(ctx context.Context, opts db.TimeListened
 		return count, nil
 	} else if opts.TrackID > 0 {
 		count, err := p.q.CountTimeListenedToTrack(ctx, repository.CountTimeListenedToTrackParams{
-			ListenedAt:   t1,
-			ListenedAt_2: t2,
-			ID:           opts.TrackID,
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			ID:            opts.TrackID,
+			IncludeHidden: opts.IncludeHidden,
 		})
 		if err != nil {
 			return 0, fmt.Errorf("CountTimeListenedToItem (Track): %w", err)
@@ -112,3 +128,72 @@ func (p *Psql) CountTimeListenedToItem(ctx context.Context, opts db.TimeListened
 	}
 	return 0, errors.New("CountTimeListenedToItem: an id must be provided")
 }
+
+func (p *Psql) CountListensByReleaseYear(ctx context.Context, period db.Period, groupByDecade bool, opts ...db.CountOpts) ([]db.ReleaseYearCount, error) {
+	t2 := time.Now()
+	t1 := db.StartTimeFromPeriod(period)
+	var bucketSize int32 = 1
+	if groupByDecade {
+		bucketSize = 10
+	}
+	rows, err := p.q.CountListensByReleaseYear(ctx, repository.CountListensByReleaseYearParams{
+		ListenedAt:    t1,
+		ListenedAt_2:  t2,
+		Column3:       bucketSize,
+		IncludeHidden: countOpts(opts).IncludeHidden,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("CountListensByReleaseYear: %w", err)
+	}
+	ret := make([]db.ReleaseYearCount, len(rows))
+	for i, row := range rows {
+		ret[i] = db.ReleaseYearCount{
+			Year:        row.Year,
+			ListenCount: row.ListenCount,
+		}
+	}
+	return ret, nil
+}
+
+func (p *Psql) CountListensByReleaseType(ctx context.Context, period db.Period, opts ...db.CountOpts) ([]db.ReleaseTypeCount, error) {
+	t2 := time.Now()
+	t1 := db.StartTimeFromPeriod(period)
+	rows, err := p.q.CountListensByReleaseType(ctx, repository.CountListensByReleaseTypeParams{
+		ListenedAt:    t1,
+		ListenedAt_2:  t2,
+		IncludeHidden: countOpts(opts).IncludeHidden,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("CountListensByReleaseType: %w", err)
+	}
+	ret := make([]db.ReleaseTypeCount, len(rows))
+	for i, row := range rows {
+		ret[i] = db.ReleaseTypeCount{
+			Type:        row.ReleaseType,
+			ListenCount: row.ListenCount,
+		}
+	}
+	return ret, nil
+}
+
+func (p *Psql) CountListensByCountry(ctx context.Context, period db.Period, opts ...db.CountOpts) ([]db.CountryCount, error) {
+	t2 := time.Now()
+	t1 := db.StartTimeFromPeriod(period)
+	rows, err := p.q.CountListensByArtistCountry(ctx, repository.CountListensByArtistCountryParams{
+		ListenedAt:    t1,
+		ListenedAt_2:  t2,
+		IncludeHidden: countOpts(opts).IncludeHidden,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("CountListensByCountry: %w", err)
+	}
+	ret := make([]db.CountryCount, len(rows))
+	for i, row := range rows {
+		ret[i] = db.CountryCount{
+			Country:     row.Country,
+			ListenCount: row.ListenCount,
+			ArtistCount: row.ArtistCount,
+		}
+	}
+	return ret, nil
+}
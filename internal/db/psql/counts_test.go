@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -104,3 +105,74 @@ func TestCountTimeListenedToTrack(t *testing.T) {
 	assert.EqualValues(t, 200, count)
 	truncateTestData(t)
 }
+
+func TestCountListensByReleaseInfo(t *testing.T) {
+	ctx := context.Background()
+	testDataForTopItems(t)
+
+	require.NoError(t, store.UpdateAlbum(ctx, db.UpdateAlbumOpts{ID: 1, ReleaseInfoUpdate: true, ReleaseDate: "1998-05", ReleaseType: "Album"}))
+	require.NoError(t, store.UpdateAlbum(ctx, db.UpdateAlbumOpts{ID: 2, ReleaseInfoUpdate: true, ReleaseDate: "2003", ReleaseType: "EP"}))
+	require.NoError(t, store.UpdateAlbum(ctx, db.UpdateAlbumOpts{ID: 3, ReleaseInfoUpdate: true, ReleaseDate: "2009-10-01", ReleaseType: "Album", SecondaryTypes: []string{"Compilation"}}))
+
+	album, err := store.GetAlbum(ctx, db.GetAlbumOpts{ID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "1998-05", album.ReleaseDate)
+	assert.Equal(t, "Album", album.ReleaseType)
+
+	// album 4 has no release info, so its listen is left out
+	years, err := store.CountListensByReleaseYear(ctx, db.PeriodAllTime, false)
+	require.NoError(t, err)
+	assert.Equal(t, []db.ReleaseYearCount{{Year: 1998, ListenCount: 4}, {Year: 2003, ListenCount: 3}, {Year: 2009, ListenCount: 2}}, years)
+
+	decades, err := store.CountListensByReleaseYear(ctx, db.PeriodAllTime, true)
+	require.NoError(t, err)
+	assert.Equal(t, []db.ReleaseYearCount{{Year: 1990, ListenCount: 4}, {Year: 2000, ListenCount: 5}}, decades)
+
+	types, err := store.CountListensByReleaseType(ctx, db.PeriodAllTime)
+	require.NoError(t, err)
+	assert.Equal(t, []db.ReleaseTypeCount{{Type: "Album", ListenCount: 4}, {Type: "EP", ListenCount: 3}, {Type: "Compilation", ListenCount: 2}}, types)
+
+	// invalid release dates are rejected
+	err = store.UpdateAlbum(ctx, db.UpdateAlbumOpts{ID: 4, ReleaseInfoUpdate: true, ReleaseDate: "May 2001"})
+	assert.Error(t, err)
+
+	truncateTestData(t)
+}
+
+func TestCountListensByCountry(t *testing.T) {
+	ctx := context.Background()
+	testDataForTopItems(t)
+
+	require.NoError(t, store.UpdateArtist(ctx, db.UpdateArtistOpts{ID: 1, Info: &models.ArtistInfo{
+		SortName:  "One, Artist",
+		Type:      "Group",
+		Area:      "Japan",
+		Country:   "JP",
+		BeginDate: "2015",
+	}}))
+	require.NoError(t, store.UpdateArtist(ctx, db.UpdateArtistOpts{ID: 2, Info: &models.ArtistInfo{Country: "JP"}}))
+	require.NoError(t, store.UpdateArtist(ctx, db.UpdateArtistOpts{ID: 3, Info: &models.ArtistInfo{Country: "US"}}))
+
+	artist, err := store.GetArtist(ctx, db.GetArtistOpts{ID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "One, Artist", artist.SortName)
+	assert.Equal(t, "Group", artist.Type)
+	assert.Equal(t, "JP", artist.Country)
+	assert.Equal(t, "2015", artist.BeginDate)
+	assert.Empty(t, artist.EndDate)
+
+	// artist 4 has no country, so its listen is left out
+	counts, err := store.CountListensByCountry(ctx, db.PeriodAllTime)
+	require.NoError(t, err)
+	assert.Equal(t, []db.CountryCount{{Country: "JP", ListenCount: 7, ArtistCount: 2}, {Country: "US", ListenCount: 2, ArtistCount: 1}}, counts)
+
+	counts, err = store.CountListensByCountry(ctx, db.PeriodMonth)
+	require.NoError(t, err)
+	assert.Equal(t, []db.CountryCount{{Country: "US", ListenCount: 2, ArtistCount: 1}}, counts)
+
+	// countries must be ISO 3166-1 codes
+	err = store.UpdateArtist(ctx, db.UpdateArtistOpts{ID: 4, Info: &models.ArtistInfo{Country: "Japan"}})
+	assert.Error(t, err)
+
+	truncateTestData(t)
+}
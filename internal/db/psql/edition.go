@@ -0,0 +1,139 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/models"
+	"github.com/gabehf/koito/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// getAlbumEditions fills in the album that an album is an edition of, and the editions of the album.
+func (d *Psql) getAlbumEditions(ctx context.Context, album *models.Album) error {
+	e, err := d.q.GetReleaseEdition(ctx, album.ID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("getAlbumEditions: GetReleaseEdition: %w", err)
+	}
+	if err == nil && e.EditionOf.Valid {
+		album.EditionOf = &e.EditionOf.Int32
+	}
+	rows, err := d.q.GetReleaseEditions(ctx, album.ID)
+	if err != nil {
+		return fmt.Errorf("getAlbumEditions: GetReleaseEditions: %w", err)
+	}
+	for _, row := range rows {
+		album.Editions = append(album.Editions, models.AlbumEdition{ID: row.ID, Title: row.Title})
+	}
+	return nil
+}
+
+// GetAlbumsWithoutEdition returns albums that have not been grouped with other editions yet, ordered by id.
+func (d *Psql) GetAlbumsWithoutEdition(ctx context.Context, opts db.GetAlbumsWithoutEditionOpts) ([]db.UngroupedAlbum, error) {
+	l := logger.FromContext(ctx)
+	if opts.Limit == 0 {
+		opts.Limit = DefaultItemsPerPage
+	}
+	l.Debug().Msgf("Fetching %d albums without edition after id %d", opts.Limit, opts.AfterID)
+	rows, err := d.q.GetReleasesWithoutEdition(ctx, repository.GetReleasesWithoutEditionParams{
+		ID:    opts.AfterID,
+		Limit: int32(opts.Limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetAlbumsWithoutEdition: %w", err)
+	}
+	ret := make([]db.UngroupedAlbum, len(rows))
+	for i, row := range rows {
+		ret[i] = db.UngroupedAlbum{ID: row.ID, Title: row.Title, ArtistID: row.ArtistID}
+	}
+	return ret, nil
+}
+
+// SetAlbumEdition marks an album as an edition of another album, or as not being an edition when
+// EditionOf is 0. Editions are always grouped under a single album, so an album cannot be an edition
+// of an edition: if the target is an edition itself, the album is grouped under the album the target
+// is an edition of, and the editions of the album are moved along with it. Marking an album as an
+// edition of one of its own editions swaps the two around.
+// Inferred editions never override editions set by the user.
+func (d *Psql) SetAlbumEdition(ctx context.Context, opts db.SetAlbumEditionOpts) error {
+	l := logger.FromContext(ctx)
+	if opts.ID == 0 {
+		return errors.New("SetAlbumEdition: required parameter 'ID' missing")
+	}
+	if opts.ID == opts.EditionOf {
+		return errors.New("SetAlbumEdition: an album cannot be an edition of itself")
+	}
+	if opts.Source == "" {
+		opts.Source = db.InformationSourceUserProvided
+	}
+	tx, err := d.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		l.Err(err).Msg("Failed to begin transaction")
+		return fmt.Errorf("SetAlbumEdition: BeginTx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := d.q.WithTx(tx)
+
+	if opts.Source != db.InformationSourceUserProvided {
+		e, err := qtx.GetReleaseEdition(ctx, opts.ID)
+		if err == nil && e.Source == string(db.InformationSourceUserProvided) {
+			l.Debug().Msgf("Keeping edition of album %d set by user", opts.ID)
+			return nil
+		} else if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("SetAlbumEdition: GetReleaseEdition: %w", err)
+		}
+	}
+
+	var editionOf pgtype.Int4
+	if opts.EditionOf != 0 {
+		root := opts.EditionOf
+		e, err := qtx.GetReleaseEdition(ctx, opts.EditionOf)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("SetAlbumEdition: GetReleaseEdition: %w", err)
+		}
+		if err == nil && e.EditionOf.Valid {
+			if e.EditionOf.Int32 == opts.ID {
+				if opts.Source != db.InformationSourceUserProvided {
+					return nil
+				}
+				// the target becomes the album the others are editions of
+				err = qtx.DeleteReleaseEdition(ctx, opts.EditionOf)
+				if err != nil {
+					return fmt.Errorf("SetAlbumEdition: DeleteReleaseEdition: %w", err)
+				}
+			} else {
+				root = e.EditionOf.Int32
+			}
+		}
+		err = qtx.UpdateReleaseEditionsOf(ctx, repository.UpdateReleaseEditionsOfParams{
+			NewEditionOf: root,
+			EditionOf:    opts.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("SetAlbumEdition: UpdateReleaseEditionsOf: %w", err)
+		}
+		editionOf = pgtype.Int4{Int32: root, Valid: true}
+	}
+	err = qtx.InsertReleaseEdition(ctx, repository.InsertReleaseEditionParams{
+		ReleaseID: opts.ID,
+		EditionOf: editionOf,
+		Source:    string(opts.Source),
+	})
+	if err != nil {
+		return fmt.Errorf("SetAlbumEdition: InsertReleaseEdition: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// DeleteAlbumEdition removes the edition information of an album, so that it is grouped automatically again.
+func (d *Psql) DeleteAlbumEdition(ctx context.Context, id int32) error {
+	err := d.q.DeleteReleaseEdition(ctx, id)
+	if err != nil {
+		return fmt.Errorf("DeleteAlbumEdition: %w", err)
+	}
+	return nil
+}
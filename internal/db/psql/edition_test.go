@@ -0,0 +1,165 @@
+package psql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDataForEditions(t *testing.T) {
+	truncateTestData(t)
+	ctx := context.Background()
+	err := store.Exec(ctx,
+		`INSERT INTO artists (musicbrainz_id) VALUES (NULL)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx,
+		`INSERT INTO artist_aliases (artist_id, alias, source, is_primary)
+			VALUES (1, 'Artist One', 'Testing', true)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx,
+		`INSERT INTO releases (musicbrainz_id) VALUES (NULL), (NULL), (NULL), (NULL)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx,
+		`INSERT INTO release_aliases (release_id, alias, source, is_primary)
+			VALUES (1, 'Album', 'Testing', true),
+				   (2, 'Album (Deluxe Edition)', 'Testing', true),
+				   (3, 'Album (2015 Remaster)', 'Testing', true),
+				   (4, 'Other Album', 'Testing', true)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx,
+		`INSERT INTO artist_releases (artist_id, release_id, is_primary)
+			VALUES (1, 1, true), (1, 2, true), (1, 3, true), (1, 4, true)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx,
+		`INSERT INTO tracks (musicbrainz_id, release_id, duration)
+			VALUES (NULL, 1, 100), (NULL, 2, 100), (NULL, 3, 100), (NULL, 4, 100)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx,
+		`INSERT INTO track_aliases (track_id, alias, source, is_primary)
+			VALUES (1, 'Track One', 'Testing', true),
+				   (2, 'Track Two', 'Testing', true),
+				   (3, 'Track Three', 'Testing', true),
+				   (4, 'Track Four', 'Testing', true)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx,
+		`INSERT INTO artist_tracks (artist_id, track_id, is_primary)
+			VALUES (1, 1, true), (1, 2, true), (1, 3, true), (1, 4, true)`)
+	require.NoError(t, err)
+	// album 1 has 1 listen, album 2 has 2, album 3 has 1, album 4 has 3
+	err = store.Exec(ctx,
+		`INSERT INTO listens (user_id, track_id, listened_at)
+			VALUES (1, 1, NOW() - INTERVAL '1 hour'),
+				   (1, 2, NOW() - INTERVAL '2 hours'),
+				   (1, 2, NOW() - INTERVAL '3 hours'),
+				   (1, 3, NOW() - INTERVAL '4 hours'),
+				   (1, 4, NOW() - INTERVAL '5 hours'),
+				   (1, 4, NOW() - INTERVAL '6 hours'),
+				   (1, 4, NOW() - INTERVAL '7 hours')`)
+	require.NoError(t, err)
+}
+
+func TestSetAlbumEdition(t *testing.T) {
+	testDataForEditions(t)
+	ctx := context.Background()
+
+	err := store.SetAlbumEdition(ctx, db.SetAlbumEditionOpts{ID: 2, EditionOf: 1, Source: db.InformationSourceInferred})
+	require.NoError(t, err)
+	// editions of editions are grouped under the same album
+	err = store.SetAlbumEdition(ctx, db.SetAlbumEditionOpts{ID: 3, EditionOf: 2, Source: db.InformationSourceInferred})
+	require.NoError(t, err)
+
+	album, err := store.GetAlbum(ctx, db.GetAlbumOpts{ID: 1})
+	require.NoError(t, err)
+	assert.Nil(t, album.EditionOf)
+	require.Len(t, album.Editions, 2)
+	assert.Equal(t, "Album (Deluxe Edition)", album.Editions[0].Title)
+	assert.Equal(t, "Album (2015 Remaster)", album.Editions[1].Title)
+	assert.EqualValues(t, 1, album.ListenCount)
+
+	album, err = store.GetAlbum(ctx, db.GetAlbumOpts{ID: 1, GroupEditions: true})
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, album.ListenCount)
+	assert.EqualValues(t, 400, album.TimeListened)
+
+	album, err = store.GetAlbum(ctx, db.GetAlbumOpts{ID: 3})
+	require.NoError(t, err)
+	require.NotNil(t, album.EditionOf)
+	assert.EqualValues(t, 1, *album.EditionOf)
+
+	// an album cannot be an edition of itself
+	err = store.SetAlbumEdition(ctx, db.SetAlbumEditionOpts{ID: 1, EditionOf: 1})
+	assert.Error(t, err)
+
+	// the user can make an edition the album the others are editions of
+	err = store.SetAlbumEdition(ctx, db.SetAlbumEditionOpts{ID: 1, EditionOf: 2, Source: db.InformationSourceUserProvided})
+	require.NoError(t, err)
+	album, err = store.GetAlbum(ctx, db.GetAlbumOpts{ID: 2})
+	require.NoError(t, err)
+	assert.Nil(t, album.EditionOf)
+	require.Len(t, album.Editions, 2)
+
+	// inferred editions do not override the user
+	err = store.SetAlbumEdition(ctx, db.SetAlbumEditionOpts{ID: 1, EditionOf: 4, Source: db.InformationSourceInferred})
+	require.NoError(t, err)
+	album, err = store.GetAlbum(ctx, db.GetAlbumOpts{ID: 1})
+	require.NoError(t, err)
+	require.NotNil(t, album.EditionOf)
+	assert.EqualValues(t, 2, *album.EditionOf)
+
+	// albums can be marked as not being an edition
+	err = store.SetAlbumEdition(ctx, db.SetAlbumEditionOpts{ID: 3, Source: db.InformationSourceUserProvided})
+	require.NoError(t, err)
+	album, err = store.GetAlbum(ctx, db.GetAlbumOpts{ID: 3})
+	require.NoError(t, err)
+	assert.Nil(t, album.EditionOf)
+
+	ungrouped, err := store.GetAlbumsWithoutEdition(ctx, db.GetAlbumsWithoutEditionOpts{})
+	require.NoError(t, err)
+	require.Len(t, ungrouped, 2)
+	assert.EqualValues(t, 2, ungrouped[0].ID)
+	assert.EqualValues(t, 4, ungrouped[1].ID)
+	assert.EqualValues(t, 1, ungrouped[1].ArtistID)
+
+	err = store.DeleteAlbumEdition(ctx, 1)
+	require.NoError(t, err)
+	album, err = store.GetAlbum(ctx, db.GetAlbumOpts{ID: 1})
+	require.NoError(t, err)
+	assert.Nil(t, album.EditionOf)
+}
+
+func TestGetTopAlbumsPaginated_GroupEditions(t *testing.T) {
+	testDataForEditions(t)
+	ctx := context.Background()
+
+	err := store.SetAlbumEdition(ctx, db.SetAlbumEditionOpts{ID: 2, EditionOf: 1, Source: db.InformationSourceInferred})
+	require.NoError(t, err)
+	err = store.SetAlbumEdition(ctx, db.SetAlbumEditionOpts{ID: 3, EditionOf: 1, Source: db.InformationSourceInferred})
+	require.NoError(t, err)
+
+	resp, err := store.GetTopAlbumsPaginated(ctx, db.GetItemsOpts{Period: db.PeriodAllTime})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 4)
+	assert.Equal(t, "Other Album", resp.Items[0].Title)
+
+	resp, err = store.GetTopAlbumsPaginated(ctx, db.GetItemsOpts{Period: db.PeriodAllTime, GroupEditions: true})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 2)
+	assert.EqualValues(t, 2, resp.TotalCount)
+	assert.Equal(t, "Album", resp.Items[0].Title)
+	assert.EqualValues(t, 4, resp.Items[0].ListenCount)
+	assert.Equal(t, "Other Album", resp.Items[1].Title)
+
+	resp, err = store.GetTopAlbumsPaginated(ctx, db.GetItemsOpts{Period: db.PeriodAllTime, ArtistID: 1, GroupEditions: true})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 2)
+	assert.EqualValues(t, 2, resp.TotalCount)
+	assert.Equal(t, "Album", resp.Items[0].Title)
+
+	// listens stay attached to the edition
+	count, err := store.CountListens(ctx, db.PeriodAllTime)
+	require.NoError(t, err)
+	assert.EqualValues(t, 7, count)
+}
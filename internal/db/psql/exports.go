@@ -38,6 +38,13 @@ func (d *Psql) GetExportPage(ctx context.Context, opts db.GetExportPageOpts) ([]
 		if err != nil {
 			return nil, fmt.Errorf("GetExportPage: json.Unmarshal artists: %w", err)
 		}
+		var releaseArtists []models.ArtistWithFullAliases
+		if row.ReleaseArtists != nil {
+			err = json.Unmarshal(row.ReleaseArtists, &releaseArtists)
+			if err != nil {
+				return nil, fmt.Errorf("GetExportPage: json.Unmarshal releaseArtists: %w", err)
+			}
+		}
 
 		ret[i] = &db.ExportItem{
 			TrackID:            row.TrackID,
@@ -53,6 +60,7 @@ func (d *Psql) GetExportPage(ctx context.Context, opts db.GetExportPageOpts) ([]
 			VariousArtists:     row.VariousArtists,
 			ReleaseAliases:     albumAliases,
 			Artists:            artists,
+			ReleaseArtists:     releaseArtists,
 		}
 	}
 	return ret, nil
@@ -0,0 +1,403 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/models"
+	"github.com/gabehf/koito/internal/repository"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetFuzzyMatchCandidate returns the item with the alias most similar to the given name, skipping items
+// that a match for the same name was already rejected for. Returns pgx.ErrNoRows if no item is at least
+// as similar as opts.MinScore.
+func (d *Psql) GetFuzzyMatchCandidate(ctx context.Context, opts db.GetFuzzyMatchCandidateOpts) (*db.FuzzyMatchCandidate, error) {
+	l := logger.FromContext(ctx)
+	l.Debug().Msgf("Searching for %s similar to '%s' with minimum score %.2f", opts.Type, opts.Name, opts.MinScore)
+	switch opts.Type {
+	case db.ItemTypeArtist:
+		row, err := d.q.GetArtistFuzzyMatch(ctx, repository.GetArtistFuzzyMatchParams{
+			Name:     opts.Name,
+			MinScore: opts.MinScore,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetFuzzyMatchCandidate: GetArtistFuzzyMatch: %w", err)
+		}
+		return &db.FuzzyMatchCandidate{ID: row.ID, Name: row.Name, Score: row.Score}, nil
+	case db.ItemTypeAlbum:
+		if opts.ArtistID == 0 {
+			return nil, errors.New("GetFuzzyMatchCandidate: artist id not specified")
+		}
+		row, err := d.q.GetAlbumFuzzyMatch(ctx, repository.GetAlbumFuzzyMatchParams{
+			Name:     opts.Name,
+			ArtistID: opts.ArtistID,
+			MinScore: opts.MinScore,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetFuzzyMatchCandidate: GetAlbumFuzzyMatch: %w", err)
+		}
+		return &db.FuzzyMatchCandidate{ID: row.ID, Name: row.Name, Score: row.Score}, nil
+	case db.ItemTypeTrack:
+		if opts.AlbumID == 0 || len(opts.ArtistIDs) < 1 {
+			return nil, errors.New("GetFuzzyMatchCandidate: album id and artist ids must be specified")
+		}
+		row, err := d.q.GetTrackFuzzyMatch(ctx, repository.GetTrackFuzzyMatchParams{
+			Name:      opts.Name,
+			ReleaseID: opts.AlbumID,
+			ArtistIds: opts.ArtistIDs,
+			MinScore:  opts.MinScore,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetFuzzyMatchCandidate: GetTrackFuzzyMatch: %w", err)
+		}
+		return &db.FuzzyMatchCandidate{ID: row.ID, Name: row.Name, Score: row.Score}, nil
+	default:
+		return nil, fmt.Errorf("GetFuzzyMatchCandidate: unknown item type '%s'", opts.Type)
+	}
+}
+
+// SaveFuzzyMatch queues a provisional match of a name to an item for review, and returns its id so that
+// listens can be linked to it. Matching the same name to the same item again reuses the existing match.
+func (d *Psql) SaveFuzzyMatch(ctx context.Context, opts db.SaveFuzzyMatchOpts) (int32, error) {
+	if opts.ItemID == 0 || opts.Name == "" {
+		return 0, errors.New("SaveFuzzyMatch: item id and name must be specified")
+	}
+	id, err := d.q.InsertFuzzyMatch(ctx, repository.InsertFuzzyMatchParams{
+		ItemType: string(opts.Type),
+		ItemID:   opts.ItemID,
+		Name:     opts.Name,
+		Score:    opts.Score,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("SaveFuzzyMatch: InsertFuzzyMatch: %w", err)
+	}
+	return id, nil
+}
+
+func (d *Psql) GetFuzzyMatches(ctx context.Context, opts db.GetFuzzyMatchesOpts) (*db.PaginatedResponse[*models.FuzzyMatch], error) {
+	l := logger.FromContext(ctx)
+	if opts.Limit == 0 {
+		opts.Limit = DefaultItemsPerPage
+	}
+	if opts.Page < 1 {
+		opts.Page = 1
+	}
+	offset := (opts.Page - 1) * opts.Limit
+	l.Debug().Msgf("Fetching %d fuzzy matches on page %d", opts.Limit, opts.Page)
+	rows, err := d.q.GetFuzzyMatchesPaginated(ctx, repository.GetFuzzyMatchesPaginatedParams{
+		Limit:  int32(opts.Limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetFuzzyMatches: GetFuzzyMatchesPaginated: %w", err)
+	}
+	items := make([]*models.FuzzyMatch, len(rows))
+	for i, row := range rows {
+		items[i] = &models.FuzzyMatch{
+			ID:          row.ID,
+			Type:        row.ItemType,
+			ItemID:      row.ItemID,
+			ItemName:    row.ItemName,
+			Name:        row.Name,
+			Score:       row.Score,
+			ListenCount: row.ListenCount,
+		}
+	}
+	count, err := d.q.CountFuzzyMatches(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetFuzzyMatches: CountFuzzyMatches: %w", err)
+	}
+	return &db.PaginatedResponse[*models.FuzzyMatch]{
+		Items:        items,
+		TotalCount:   count,
+		ItemsPerPage: int32(opts.Limit),
+		HasNextPage:  int64(offset+len(items)) < count,
+		CurrentPage:  int32(opts.Page),
+	}, nil
+}
+
+func (d *Psql) GetFuzzyMatch(ctx context.Context, id int32) (*models.FuzzyMatch, error) {
+	row, err := d.q.GetFuzzyMatch(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("GetFuzzyMatch: %w", err)
+	}
+	return &models.FuzzyMatch{
+		ID:     row.ID,
+		Type:   row.ItemType,
+		ItemID: row.ItemID,
+		Name:   row.Name,
+		Score:  row.Score,
+	}, nil
+}
+
+// ConfirmFuzzyMatch saves the matched name as an alias of the item, so that future listens are matched
+// to it exactly, and removes the match from the review queue.
+func (d *Psql) ConfirmFuzzyMatch(ctx context.Context, id int32) error {
+	l := logger.FromContext(ctx)
+	tx, err := d.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		l.Err(err).Msg("Failed to begin transaction")
+		return fmt.Errorf("ConfirmFuzzyMatch: BeginTx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := d.q.WithTx(tx)
+
+	m, err := qtx.GetFuzzyMatch(ctx, id)
+	if err != nil {
+		return fmt.Errorf("ConfirmFuzzyMatch: GetFuzzyMatch: %w", err)
+	}
+	if m.Rejected {
+		return errors.New("ConfirmFuzzyMatch: match has already been rejected")
+	}
+	l.Info().Msgf("Confirming fuzzy match of '%s' to %s %d", m.Name, m.ItemType, m.ItemID)
+	switch db.ItemType(m.ItemType) {
+	case db.ItemTypeArtist:
+		err = qtx.InsertArtistAlias(ctx, repository.InsertArtistAliasParams{
+			ArtistID: m.ItemID,
+			Alias:    m.Name,
+			Source:   "Fuzzy Match",
+		})
+	case db.ItemTypeAlbum:
+		err = qtx.InsertReleaseAlias(ctx, repository.InsertReleaseAliasParams{
+			ReleaseID: m.ItemID,
+			Alias:     m.Name,
+			Source:    "Fuzzy Match",
+		})
+	case db.ItemTypeTrack:
+		err = qtx.InsertTrackAlias(ctx, repository.InsertTrackAliasParams{
+			TrackID: m.ItemID,
+			Alias:   m.Name,
+			Source:  "Fuzzy Match",
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("ConfirmFuzzyMatch: InsertAlias: %w", err)
+	}
+	err = qtx.DeleteFuzzyMatch(ctx, id)
+	if err != nil {
+		return fmt.Errorf("ConfirmFuzzyMatch: DeleteFuzzyMatch: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// RejectFuzzyMatch splits the listens linked through a match back out of the matched item, into a new
+// artist, album, or track with the submitted name. The listens move to copies of their tracks that are
+// credited to the new artist, are on the new album, or have the submitted title. The rejected match is
+// kept, so that the name is not matched to the same item again.
+func (d *Psql) RejectFuzzyMatch(ctx context.Context, id int32) error {
+	l := logger.FromContext(ctx)
+	tx, err := d.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		l.Err(err).Msg("Failed to begin transaction")
+		return fmt.Errorf("RejectFuzzyMatch: BeginTx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := d.q.WithTx(tx)
+
+	m, err := qtx.GetFuzzyMatch(ctx, id)
+	if err != nil {
+		return fmt.Errorf("RejectFuzzyMatch: GetFuzzyMatch: %w", err)
+	}
+	if m.Rejected {
+		return errors.New("RejectFuzzyMatch: match has already been rejected")
+	}
+	trackIds, err := qtx.GetFuzzyMatchTrackIDs(ctx, id)
+	if err != nil {
+		return fmt.Errorf("RejectFuzzyMatch: GetFuzzyMatchTrackIDs: %w", err)
+	}
+	l.Info().Msgf("Rejecting fuzzy match of '%s' to %s %d, splitting out listens from %d tracks", m.Name, m.ItemType, m.ItemID, len(trackIds))
+
+	// an item is only created for the name if there are listens to move to it, as it would
+	// be cleaned up as an orphan otherwise
+	var newId int32
+	if len(trackIds) > 0 {
+		switch db.ItemType(m.ItemType) {
+		case db.ItemTypeArtist:
+			a, err := qtx.InsertArtist(ctx, repository.InsertArtistParams{})
+			if err != nil {
+				return fmt.Errorf("RejectFuzzyMatch: InsertArtist: %w", err)
+			}
+			newId = a.ID
+			err = qtx.InsertArtistAlias(ctx, repository.InsertArtistAliasParams{
+				ArtistID:  newId,
+				Alias:     m.Name,
+				Source:    "Canonical",
+				IsPrimary: true,
+			})
+			if err != nil {
+				return fmt.Errorf("RejectFuzzyMatch: InsertArtistAlias: %w", err)
+			}
+		case db.ItemTypeAlbum:
+			from, err := qtx.GetRelease(ctx, m.ItemID)
+			if err != nil {
+				return fmt.Errorf("RejectFuzzyMatch: GetRelease: %w", err)
+			}
+			r, err := qtx.InsertRelease(ctx, repository.InsertReleaseParams{
+				VariousArtists: from.VariousArtists,
+			})
+			if err != nil {
+				return fmt.Errorf("RejectFuzzyMatch: InsertRelease: %w", err)
+			}
+			newId = r.ID
+			err = qtx.InsertReleaseAlias(ctx, repository.InsertReleaseAliasParams{
+				ReleaseID: newId,
+				Alias:     m.Name,
+				Source:    "Canonical",
+				IsPrimary: true,
+			})
+			if err != nil {
+				return fmt.Errorf("RejectFuzzyMatch: InsertReleaseAlias: %w", err)
+			}
+		}
+	}
+
+	releases := make([]int32, 0)
+	for _, trackId := range trackIds {
+		track, err := qtx.GetTrack(ctx, trackId)
+		if err != nil {
+			return fmt.Errorf("RejectFuzzyMatch: GetTrack: %w", err)
+		}
+		artists, err := qtx.GetTrackArtists(ctx, trackId)
+		if err != nil {
+			return fmt.Errorf("RejectFuzzyMatch: GetTrackArtists: %w", err)
+		}
+		releaseId, title := track.ReleaseID, track.Title
+		if !slices.Contains(releases, releaseId) {
+			releases = append(releases, releaseId)
+		}
+		switch db.ItemType(m.ItemType) {
+		case db.ItemTypeArtist:
+			for i := range artists {
+				if artists[i].ID == m.ItemID {
+					artists[i].ID = newId
+				}
+			}
+		case db.ItemTypeAlbum:
+			releaseId = newId
+		case db.ItemTypeTrack:
+			title = m.Name
+		}
+
+		newTrack, err := qtx.InsertTrack(ctx, repository.InsertTrackParams{
+			ReleaseID: releaseId,
+			Duration:  track.Duration,
+		})
+		if err != nil {
+			return fmt.Errorf("RejectFuzzyMatch: InsertTrack: %w", err)
+		}
+		err = qtx.InsertTrackAlias(ctx, repository.InsertTrackAliasParams{
+			TrackID:   newTrack.ID,
+			Alias:     title,
+			Source:    "Canonical",
+			IsPrimary: true,
+		})
+		if err != nil {
+			return fmt.Errorf("RejectFuzzyMatch: InsertTrackAlias: %w", err)
+		}
+		for _, a := range artists {
+			err = qtx.AssociateArtistToTrack(ctx, repository.AssociateArtistToTrackParams{
+				ArtistID: a.ID,
+				TrackID:  newTrack.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("RejectFuzzyMatch: AssociateArtistToTrack: %w", err)
+			}
+			if a.IsPrimary.Valid && a.IsPrimary.Bool {
+				err = qtx.UpdateTrackPrimaryArtist(ctx, repository.UpdateTrackPrimaryArtistParams{
+					ArtistID:  a.ID,
+					TrackID:   newTrack.ID,
+					IsPrimary: true,
+				})
+				if err != nil {
+					return fmt.Errorf("RejectFuzzyMatch: UpdateTrackPrimaryArtist: %w", err)
+				}
+			}
+			// a new album split out of a rejected match has no other artists than those of its tracks
+			err = qtx.AssociateArtistToRelease(ctx, repository.AssociateArtistToReleaseParams{
+				ArtistID:      a.ID,
+				ReleaseID:     releaseId,
+				IsAlbumArtist: db.ItemType(m.ItemType) == db.ItemTypeAlbum,
+			})
+			if err != nil {
+				return fmt.Errorf("RejectFuzzyMatch: AssociateArtistToRelease: %w", err)
+			}
+		}
+		err = qtx.MoveFuzzyMatchListens(ctx, repository.MoveFuzzyMatchListensParams{
+			NewTrackID:   newTrack.ID,
+			FuzzyMatchID: id,
+			TrackID:      trackId,
+		})
+		if err != nil {
+			return fmt.Errorf("RejectFuzzyMatch: MoveFuzzyMatchListens: %w", err)
+		}
+	}
+
+	err = qtx.DeleteFuzzyMatchListens(ctx, id)
+	if err != nil {
+		return fmt.Errorf("RejectFuzzyMatch: DeleteFuzzyMatchListens: %w", err)
+	}
+	err = qtx.RejectFuzzyMatch(ctx, id)
+	if err != nil {
+		return fmt.Errorf("RejectFuzzyMatch: RejectFuzzyMatch: %w", err)
+	}
+	err = qtx.CleanOrphanedEntries(ctx)
+	if err != nil {
+		l.Err(err).Msg("Failed to clean orphaned entries")
+		return fmt.Errorf("RejectFuzzyMatch: CleanOrphanedEntries: %w", err)
+	}
+	if db.ItemType(m.ItemType) == db.ItemTypeArtist {
+		// same as when splitting an artist, the album credit is handed over to the new artist
+		// on albums the matched artist no longer has any tracks on
+		for _, releaseId := range releases {
+			remaining, err := qtx.CountArtistTracksInRelease(ctx, repository.CountArtistTracksInReleaseParams{
+				ArtistID:  m.ItemID,
+				ReleaseID: releaseId,
+			})
+			if err != nil {
+				return fmt.Errorf("RejectFuzzyMatch: CountArtistTracksInRelease: %w", err)
+			}
+			if remaining > 0 {
+				continue
+			}
+			releaseArtists, err := qtx.GetReleaseArtists(ctx, releaseId)
+			if err != nil {
+				return fmt.Errorf("RejectFuzzyMatch: GetReleaseArtists: %w", err)
+			}
+			for _, a := range releaseArtists {
+				if a.ID == m.ItemID && a.IsAlbumArtist.Valid && a.IsAlbumArtist.Bool {
+					err = qtx.AssociateArtistToRelease(ctx, repository.AssociateArtistToReleaseParams{
+						ArtistID:      newId,
+						ReleaseID:     releaseId,
+						IsAlbumArtist: true,
+					})
+					if err != nil {
+						return fmt.Errorf("RejectFuzzyMatch: AssociateArtistToRelease: %w", err)
+					}
+				}
+				if a.ID == m.ItemID && a.IsPrimary.Valid && a.IsPrimary.Bool {
+					err = qtx.UpdateReleasePrimaryArtist(ctx, repository.UpdateReleasePrimaryArtistParams{
+						ArtistID:  newId,
+						ReleaseID: releaseId,
+						IsPrimary: true,
+					})
+					if err != nil {
+						return fmt.Errorf("RejectFuzzyMatch: UpdateReleasePrimaryArtist: %w", err)
+					}
+				}
+			}
+			err = qtx.DeleteArtistRelease(ctx, repository.DeleteArtistReleaseParams{
+				ArtistID:  m.ItemID,
+				ReleaseID: releaseId,
+			})
+			if err != nil {
+				return fmt.Errorf("RejectFuzzyMatch: DeleteArtistRelease: %w", err)
+			}
+		}
+	}
+	return tx.Commit(ctx)
+}
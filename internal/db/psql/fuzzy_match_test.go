@@ -0,0 +1,205 @@
+package psql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFuzzyMatchCandidate(t *testing.T) {
+	ctx := context.Background()
+	setupTestDataForDuplicates(t)
+
+	c, err := store.GetFuzzyMatchCandidate(ctx, db.GetFuzzyMatchCandidateOpts{
+		Type:     db.ItemTypeArtist,
+		Name:     "Radiohaed",
+		MinScore: 0.4,
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, c.ID)
+	assert.Equal(t, "Radiohead", c.Name)
+	assert.Less(t, c.Score, float32(1))
+
+	_, err = store.GetFuzzyMatchCandidate(ctx, db.GetFuzzyMatchCandidateOpts{
+		Type:     db.ItemTypeArtist,
+		Name:     "Radiohaed",
+		MinScore: 0.9,
+	})
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// albums are only matched among the albums of the artist
+	c, err = store.GetFuzzyMatchCandidate(ctx, db.GetFuzzyMatchCandidateOpts{
+		Type:     db.ItemTypeAlbum,
+		Name:     "Abbey Rd",
+		ArtistID: 1,
+		MinScore: 0.4,
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, c.ID)
+
+	// tracks are only matched among the tracks of the album credited to the artists
+	c, err = store.GetFuzzyMatchCandidate(ctx, db.GetFuzzyMatchCandidateOpts{
+		Type:      db.ItemTypeTrack,
+		Name:      "Paranoid Androd",
+		AlbumID:   4,
+		ArtistIDs: []int32{3},
+		MinScore:  0.4,
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 6, c.ID)
+	_, err = store.GetFuzzyMatchCandidate(ctx, db.GetFuzzyMatchCandidateOpts{
+		Type:      db.ItemTypeTrack,
+		Name:      "Paranoid Androd",
+		AlbumID:   4,
+		ArtistIDs: []int32{1},
+		MinScore:  0.4,
+	})
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	truncateTestData(t)
+}
+
+func TestConfirmFuzzyMatch(t *testing.T) {
+	ctx := context.Background()
+	setupTestDataForDuplicates(t)
+
+	id, err := store.SaveFuzzyMatch(ctx, db.SaveFuzzyMatchOpts{
+		Type:   db.ItemTypeAlbum,
+		ItemID: 1,
+		Name:   "Abbey Rd",
+		Score:  0.6,
+	})
+	require.NoError(t, err)
+	err = store.SaveListen(ctx, db.SaveListenOpts{
+		TrackID:       1,
+		Time:          time.Now().Add(-time.Hour),
+		UserID:        1,
+		FuzzyMatchIDs: []int32{id},
+	})
+	require.NoError(t, err)
+
+	resp, err := store.GetFuzzyMatches(ctx, db.GetFuzzyMatchesOpts{})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, "album", resp.Items[0].Type)
+	assert.Equal(t, "Abbey Road", resp.Items[0].ItemName)
+	assert.Equal(t, "Abbey Rd", resp.Items[0].Name)
+	assert.EqualValues(t, 1, resp.Items[0].ListenCount)
+
+	require.NoError(t, store.ConfirmFuzzyMatch(ctx, id))
+
+	exists, err := store.RowExists(ctx, `
+	SELECT EXISTS (
+		SELECT 1 FROM release_aliases
+		WHERE release_id = $1 AND alias = $2
+	)`, 1, "Abbey Rd")
+	require.NoError(t, err)
+	assert.True(t, exists, "expected matched name to be saved as an alias")
+
+	resp, err = store.GetFuzzyMatches(ctx, db.GetFuzzyMatchesOpts{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Items)
+
+	count, err := store.Count(ctx, `SELECT COUNT(*) FROM listens WHERE track_id = 1`)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count, "expected listens to stay with the matched album")
+
+	truncateTestData(t)
+}
+
+func TestRejectFuzzyMatch_Track(t *testing.T) {
+	ctx := context.Background()
+	setupTestDataForDuplicates(t)
+
+	id, err := store.SaveFuzzyMatch(ctx, db.SaveFuzzyMatchOpts{
+		Type:   db.ItemTypeTrack,
+		ItemID: 6,
+		Name:   "Paranoid Androd",
+		Score:  0.7,
+	})
+	require.NoError(t, err)
+	err = store.SaveListen(ctx, db.SaveListenOpts{
+		TrackID:       6,
+		Time:          time.Now().Add(-time.Hour),
+		UserID:        1,
+		FuzzyMatchIDs: []int32{id},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, store.RejectFuzzyMatch(ctx, id))
+
+	count, err := store.Count(ctx, `SELECT COUNT(*) FROM listens WHERE track_id = 6`)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "expected the matched track to keep its other listens")
+
+	track, err := store.GetTrack(ctx, db.GetTrackOpts{Title: "Paranoid Androd", ArtistIDs: []int32{3}})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, track.ListenCount)
+	assert.EqualValues(t, 4, track.AlbumID, "expected new track to be on the same album")
+
+	resp, err := store.GetFuzzyMatches(ctx, db.GetFuzzyMatchesOpts{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Items)
+
+	// a rejected match is not made again
+	c, err := store.GetFuzzyMatchCandidate(ctx, db.GetFuzzyMatchCandidateOpts{
+		Type:      db.ItemTypeTrack,
+		Name:      "Paranoid Androd",
+		AlbumID:   4,
+		ArtistIDs: []int32{3},
+		MinScore:  0.4,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, track.ID, c.ID)
+
+	assert.Error(t, store.RejectFuzzyMatch(ctx, id), "expected a match to only be rejected once")
+
+	truncateTestData(t)
+}
+
+func TestRejectFuzzyMatch_Artist(t *testing.T) {
+	ctx := context.Background()
+	setupTestDataForDuplicates(t)
+
+	id, err := store.SaveFuzzyMatch(ctx, db.SaveFuzzyMatchOpts{
+		Type:   db.ItemTypeArtist,
+		ItemID: 3,
+		Name:   "Radiohaed",
+		Score:  0.6,
+	})
+	require.NoError(t, err)
+	err = store.SaveListen(ctx, db.SaveListenOpts{
+		TrackID:       4,
+		Time:          time.Now().Add(-time.Hour),
+		UserID:        1,
+		FuzzyMatchIDs: []int32{id},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, store.RejectFuzzyMatch(ctx, id))
+
+	artist, err := store.GetArtist(ctx, db.GetArtistOpts{Name: "Radiohaed"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, artist.ListenCount)
+
+	track, err := store.GetTrack(ctx, db.GetTrackOpts{Title: "Airbag", ArtistIDs: []int32{artist.ID}})
+	require.NoError(t, err)
+	assert.NotEqualValues(t, 4, track.ID)
+	assert.EqualValues(t, 3, track.AlbumID, "expected new track to be on the same album")
+
+	count, err := store.Count(ctx, `SELECT COUNT(*) FROM listens WHERE track_id = 4`)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "expected the matched artist to keep its other listens")
+
+	// Radiohead still has Airbag on the album, so both artists are credited
+	count, err = store.Count(ctx, `SELECT COUNT(*) FROM artist_releases WHERE release_id = 3`)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	truncateTestData(t)
+}
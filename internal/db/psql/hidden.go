@@ -0,0 +1,82 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gabehf/koito/internal/db"
+)
+
+// GetHiddenItems returns the artists, albums and tracks that are hidden from stats, ordered by type and name.
+func (d *Psql) GetHiddenItems(ctx context.Context) ([]db.HiddenItem, error) {
+	rows, err := d.q.GetHiddenItems(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetHiddenItems: %w", err)
+	}
+	ret := make([]db.HiddenItem, len(rows))
+	for i, row := range rows {
+		ret[i] = db.HiddenItem{
+			Type: db.ItemType(row.ItemType),
+			ID:   row.ID,
+			Name: row.Name,
+		}
+	}
+	return ret, nil
+}
+
+func (d *Psql) GetHiddenClients(ctx context.Context) ([]string, error) {
+	clients, err := d.q.GetHiddenClients(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetHiddenClients: %w", err)
+	}
+	if clients == nil {
+		clients = []string{}
+	}
+	return clients, nil
+}
+
+// SetItemHidden hides an artist, album or track from stats, or shows it again. Hiding an item that is
+// already hidden, or showing one that is not, does nothing.
+func (d *Psql) SetItemHidden(ctx context.Context, t db.ItemType, id int32, hidden bool) error {
+	var err error
+	switch t {
+	case db.ItemTypeArtist:
+		if hidden {
+			err = d.q.InsertHiddenArtist(ctx, id)
+		} else {
+			_, err = d.q.DeleteHiddenArtist(ctx, id)
+		}
+	case db.ItemTypeAlbum:
+		if hidden {
+			err = d.q.InsertHiddenRelease(ctx, id)
+		} else {
+			_, err = d.q.DeleteHiddenRelease(ctx, id)
+		}
+	case db.ItemTypeTrack:
+		if hidden {
+			err = d.q.InsertHiddenTrack(ctx, id)
+		} else {
+			_, err = d.q.DeleteHiddenTrack(ctx, id)
+		}
+	default:
+		return fmt.Errorf("SetItemHidden: unknown item type '%s'", t)
+	}
+	if err != nil {
+		return fmt.Errorf("SetItemHidden: %w", err)
+	}
+	return nil
+}
+
+// SetClientHidden hides the listens submitted from a client from stats, or shows them again.
+func (d *Psql) SetClientHidden(ctx context.Context, client string, hidden bool) error {
+	var err error
+	if hidden {
+		err = d.q.InsertHiddenClient(ctx, client)
+	} else {
+		_, err = d.q.DeleteHiddenClient(ctx, client)
+	}
+	if err != nil {
+		return fmt.Errorf("SetClientHidden: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package psql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHiddenItems(t *testing.T) {
+	testDataForTopItems(t)
+	ctx := context.Background()
+
+	require.NoError(t, store.SetItemHidden(ctx, db.ItemTypeTrack, 1, true))
+	// hiding an item twice does nothing
+	require.NoError(t, store.SetItemHidden(ctx, db.ItemTypeTrack, 1, true))
+	require.NoError(t, store.SetItemHidden(ctx, db.ItemTypeArtist, 2, true))
+	assert.Error(t, store.SetItemHidden(ctx, "other", 3, true))
+
+	hidden, err := store.GetHiddenItems(ctx)
+	require.NoError(t, err)
+	require.Len(t, hidden, 2)
+	assert.Equal(t, db.HiddenItem{Type: db.ItemTypeArtist, ID: 2, Name: "Artist Two"}, hidden[0])
+	assert.Equal(t, db.HiddenItem{Type: db.ItemTypeTrack, ID: 1, Name: "Track One"}, hidden[1])
+
+	// the hidden track and the tracks of the hidden artist are left out of charts, counts and search
+	tracks, err := store.GetTopTracksPaginated(ctx, db.GetItemsOpts{Period: db.PeriodAllTime})
+	require.NoError(t, err)
+	require.Len(t, tracks.Items, 2)
+	assert.EqualValues(t, 3, tracks.Items[0].ID)
+	assert.EqualValues(t, 2, tracks.TotalCount)
+	artists, err := store.GetTopArtistsPaginated(ctx, db.GetItemsOpts{Period: db.PeriodAllTime})
+	require.NoError(t, err)
+	assert.Len(t, artists.Items, 2)
+	albums, err := store.GetTopAlbumsPaginated(ctx, db.GetItemsOpts{Period: db.PeriodAllTime})
+	require.NoError(t, err)
+	assert.Len(t, albums.Items, 2)
+
+	count, err := store.CountListens(ctx, db.PeriodAllTime)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, count)
+	count, err = store.CountListens(ctx, db.PeriodAllTime, db.CountOpts{IncludeHidden: true})
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, count)
+	count, err = store.CountArtists(ctx, db.PeriodAllTime)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+
+	activity, err := store.GetListenActivity(ctx, db.ListenActivityOpts{Step: db.StepYear, Range: 3})
+	require.NoError(t, err)
+	var total int64
+	for _, item := range activity {
+		total += item.Listens
+	}
+	assert.EqualValues(t, 3, total)
+
+	results, err := store.SearchTracks(ctx, "Track One")
+	require.NoError(t, err)
+	for _, track := range results {
+		assert.NotEqualValues(t, 1, track.ID)
+	}
+	results, err = store.SearchTracks(ctx, "Track One", db.SearchOpts{IncludeHidden: true})
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	assert.EqualValues(t, 1, results[0].ID)
+	// hiding an artist hides its albums too
+	albumResults, err := store.SearchAlbums(ctx, "Release Two")
+	require.NoError(t, err)
+	for _, album := range albumResults {
+		assert.NotEqualValues(t, 2, album.ID)
+	}
+
+	// and from the listen counts of the items themselves
+	artist, err := store.GetArtist(ctx, db.GetArtistOpts{ID: 2})
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, artist.ListenCount)
+	assert.EqualValues(t, 0, artist.TimeListened)
+	track, err := store.GetTrack(ctx, db.GetTrackOpts{ID: 1})
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, track.ListenCount)
+
+	// include_hidden restores the full charts and counts
+	tracks, err = store.GetTopTracksPaginated(ctx, db.GetItemsOpts{Period: db.PeriodAllTime, IncludeHidden: true})
+	require.NoError(t, err)
+	assert.Len(t, tracks.Items, 4)
+	artist, err = store.GetArtist(ctx, db.GetArtistOpts{ID: 2, IncludeHidden: true})
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, artist.ListenCount)
+	assert.EqualValues(t, 300, artist.TimeListened)
+	track, err = store.GetTrack(ctx, db.GetTrackOpts{ID: 1, IncludeHidden: true})
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, track.ListenCount)
+
+	require.NoError(t, store.SetItemHidden(ctx, db.ItemTypeTrack, 1, false))
+	require.NoError(t, store.SetItemHidden(ctx, db.ItemTypeArtist, 2, false))
+	count, err = store.CountListens(ctx, db.PeriodAllTime)
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, count)
+	hidden, err = store.GetHiddenItems(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, hidden)
+}
+
+func TestHiddenClients(t *testing.T) {
+	testDataForTopItems(t)
+	ctx := context.Background()
+
+	err := store.Exec(ctx, `UPDATE listens SET client = 'Sleep App' WHERE track_id IN (1, 2)`)
+	require.NoError(t, err)
+
+	require.NoError(t, store.SetClientHidden(ctx, "Sleep App", true))
+	clients, err := store.GetHiddenClients(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Sleep App"}, clients)
+
+	count, err := store.CountListens(ctx, db.PeriodAllTime)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, count)
+	tracks, err := store.GetTopTracksPaginated(ctx, db.GetItemsOpts{Period: db.PeriodAllTime})
+	require.NoError(t, err)
+	assert.Len(t, tracks.Items, 2)
+	// only charts are affected, the listen history is kept whole
+	listens, err := store.GetListensPaginated(ctx, db.GetItemsOpts{Period: db.PeriodAllTime})
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, listens.TotalCount)
+
+	require.NoError(t, store.SetClientHidden(ctx, "Sleep App", false))
+	count, err = store.CountListens(ctx, db.PeriodAllTime)
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, count)
+}
+
+func TestHiddenItems_FeaturedArtist(t *testing.T) {
+	testDataForTopItems(t)
+	ctx := context.Background()
+
+	// release 1 is a compilation that artist 2 is only featured on
+	err := store.Exec(ctx, `INSERT INTO artist_releases (artist_id, release_id, is_album_artist) VALUES (2, 1, false)`)
+	require.NoError(t, err)
+
+	require.NoError(t, store.SetItemHidden(ctx, db.ItemTypeArtist, 2, true))
+
+	// the album and its tracks stay in stats, only the hidden artist's own album is left out
+	albums, err := store.GetTopAlbumsPaginated(ctx, db.GetItemsOpts{Period: db.PeriodAllTime})
+	require.NoError(t, err)
+	var albumIDs []int32
+	for _, album := range albums.Items {
+		albumIDs = append(albumIDs, album.ID)
+	}
+	assert.Contains(t, albumIDs, int32(1))
+	assert.NotContains(t, albumIDs, int32(2))
+
+	tracks, err := store.GetTopTracksPaginated(ctx, db.GetItemsOpts{Period: db.PeriodAllTime})
+	require.NoError(t, err)
+	var trackIDs []int32
+	for _, track := range tracks.Items {
+		trackIDs = append(trackIDs, track.ID)
+	}
+	assert.Contains(t, trackIDs, int32(1))
+	assert.NotContains(t, trackIDs, int32(2))
+}
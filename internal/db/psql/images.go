@@ -26,7 +26,12 @@ func (d *Psql) ImageHasAssociation(ctx context.Context, image uuid.UUID) (bool,
 	} else if !errors.Is(err, pgx.ErrNoRows) {
 		return false, fmt.Errorf("ImageHasAssociation: GetArtistByImage: %w", err)
 	}
-	return false, nil
+	// images of deleted artists and albums are kept until they are purged from the trash
+	inTrash, err := d.q.TrashHasImage(ctx, image.String())
+	if err != nil {
+		return false, fmt.Errorf("ImageHasAssociation: TrashHasImage: %w", err)
+	}
+	return inTrash, nil
 }
 
 func (d *Psql) GetImageSource(ctx context.Context, image uuid.UUID) (string, error) {
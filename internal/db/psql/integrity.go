@@ -0,0 +1,132 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/repository"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetIntegrityIssues returns the items in the database that fail an integrity check. Orphaned images
+// are not stored in the database, and are found by the catalog instead.
+func (d *Psql) GetIntegrityIssues(ctx context.Context, check db.IntegrityCheck) ([]db.IntegrityIssue, error) {
+	issues, err := getIntegrityIssues(ctx, d.q, check)
+	if err != nil {
+		return nil, fmt.Errorf("GetIntegrityIssues: %w", err)
+	}
+	return issues, nil
+}
+
+func getIntegrityIssues(ctx context.Context, q *repository.Queries, check db.IntegrityCheck) ([]db.IntegrityIssue, error) {
+	ret := make([]db.IntegrityIssue, 0)
+	switch check {
+	case db.IntegrityCheckMissingPrimaryAliases:
+		rows, err := q.GetItemsWithoutPrimaryAlias(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("getIntegrityIssues: GetItemsWithoutPrimaryAlias: %w", err)
+		}
+		for _, row := range rows {
+			ret = append(ret, db.IntegrityIssue{
+				Check:      check,
+				Type:       db.ItemType(row.ItemType),
+				ID:         row.ID,
+				Name:       row.Name,
+				Repairable: row.Repairable,
+			})
+		}
+	case db.IntegrityCheckAlbumsWithoutArtists:
+		rows, err := q.GetReleasesWithoutArtists(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("getIntegrityIssues: GetReleasesWithoutArtists: %w", err)
+		}
+		for _, row := range rows {
+			ret = append(ret, db.IntegrityIssue{
+				Check:      check,
+				Type:       db.ItemTypeAlbum,
+				ID:         row.ID,
+				Name:       row.Title,
+				Repairable: row.Repairable,
+			})
+		}
+	case db.IntegrityCheckArtistsWithoutTracks:
+		rows, err := q.GetArtistsWithoutTracks(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("getIntegrityIssues: GetArtistsWithoutTracks: %w", err)
+		}
+		for _, row := range rows {
+			ret = append(ret, db.IntegrityIssue{
+				Check:      check,
+				Type:       db.ItemTypeArtist,
+				ID:         row.ID,
+				Name:       row.Name,
+				Repairable: true,
+			})
+		}
+	default:
+		return nil, fmt.Errorf("getIntegrityIssues: unknown integrity check '%s'", check)
+	}
+	return ret, nil
+}
+
+// RepairIntegrityIssues repairs the items that fail an integrity check where it is safe to do so, and
+// returns the number of items that were repaired:
+//   - items without a primary alias get their canonical alias, or otherwise their first alias, made primary
+//   - albums without artists get the artists of their tracks credited as album artists
+//   - artists without tracks or albums are deleted
+func (d *Psql) RepairIntegrityIssues(ctx context.Context, check db.IntegrityCheck) (int64, error) {
+	l := logger.FromContext(ctx)
+	tx, err := d.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		l.Err(err).Msg("Failed to begin transaction")
+		return 0, fmt.Errorf("RepairIntegrityIssues: BeginTx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := d.q.WithTx(tx)
+
+	before, err := getIntegrityIssues(ctx, qtx, check)
+	if err != nil {
+		return 0, fmt.Errorf("RepairIntegrityIssues: %w", err)
+	}
+	if len(before) == 0 {
+		return 0, nil
+	}
+	switch check {
+	case db.IntegrityCheckMissingPrimaryAliases:
+		err = qtx.SetMissingPrimaryArtistAliases(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("RepairIntegrityIssues: SetMissingPrimaryArtistAliases: %w", err)
+		}
+		err = qtx.SetMissingPrimaryReleaseAliases(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("RepairIntegrityIssues: SetMissingPrimaryReleaseAliases: %w", err)
+		}
+		err = qtx.SetMissingPrimaryTrackAliases(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("RepairIntegrityIssues: SetMissingPrimaryTrackAliases: %w", err)
+		}
+	case db.IntegrityCheckAlbumsWithoutArtists:
+		err = qtx.CreditTrackArtistsOnReleasesWithoutArtists(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("RepairIntegrityIssues: CreditTrackArtistsOnReleasesWithoutArtists: %w", err)
+		}
+	case db.IntegrityCheckArtistsWithoutTracks:
+		err = qtx.DeleteArtistsWithoutTracks(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("RepairIntegrityIssues: DeleteArtistsWithoutTracks: %w", err)
+		}
+	}
+	after, err := getIntegrityIssues(ctx, qtx, check)
+	if err != nil {
+		return 0, fmt.Errorf("RepairIntegrityIssues: %w", err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("RepairIntegrityIssues: Commit: %w", err)
+	}
+	repaired := int64(len(before) - len(after))
+	l.Info().Msgf("Repaired %d of %d items failing integrity check '%s'", repaired, len(before), check)
+	return repaired, nil
+}
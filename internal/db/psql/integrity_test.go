@@ -0,0 +1,124 @@
+package psql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrity_MissingPrimaryAliases(t *testing.T) {
+	testDataForTopItems(t)
+	ctx := context.Background()
+
+	issues, err := store.GetIntegrityIssues(ctx, db.IntegrityCheckMissingPrimaryAliases)
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+
+	// track 1 has an alias, but none of them are primary. track 2 has no aliases at all
+	err = store.Exec(ctx, `INSERT INTO track_aliases (track_id, alias, source, is_primary) VALUES (1, 'Alt Title', 'Testing', false)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx, `UPDATE track_aliases SET is_primary = false WHERE track_id = 1`)
+	require.NoError(t, err)
+	err = store.Exec(ctx, `DELETE FROM track_aliases WHERE track_id = 2`)
+	require.NoError(t, err)
+
+	issues, err = store.GetIntegrityIssues(ctx, db.IntegrityCheckMissingPrimaryAliases)
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+	assert.Equal(t, db.ItemTypeTrack, issues[0].Type)
+	assert.EqualValues(t, 1, issues[0].ID)
+	assert.True(t, issues[0].Repairable)
+	assert.EqualValues(t, 2, issues[1].ID)
+	assert.False(t, issues[1].Repairable)
+
+	repaired, err := store.RepairIntegrityIssues(ctx, db.IntegrityCheckMissingPrimaryAliases)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, repaired)
+
+	// the canonical alias is preferred
+	track, err := store.GetTrack(ctx, db.GetTrackOpts{ID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "Track One", track.Title)
+
+	issues, err = store.GetIntegrityIssues(ctx, db.IntegrityCheckMissingPrimaryAliases)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.EqualValues(t, 2, issues[0].ID)
+}
+
+func TestIntegrity_AlbumsWithoutArtists(t *testing.T) {
+	testDataForTopItems(t)
+	ctx := context.Background()
+
+	// deleting the credit directly would trigger the orphan cleanup, so the trigger is bypassed
+	err := store.Exec(ctx, `ALTER TABLE artist_releases DISABLE TRIGGER trg_delete_orphan_releases`)
+	require.NoError(t, err)
+	err = store.Exec(ctx, `DELETE FROM artist_releases WHERE release_id = 1`)
+	require.NoError(t, err)
+	err = store.Exec(ctx, `ALTER TABLE artist_releases ENABLE TRIGGER trg_delete_orphan_releases`)
+	require.NoError(t, err)
+
+	issues, err := store.GetIntegrityIssues(ctx, db.IntegrityCheckAlbumsWithoutArtists)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, db.ItemTypeAlbum, issues[0].Type)
+	assert.EqualValues(t, 1, issues[0].ID)
+	assert.Equal(t, "Release One", issues[0].Name)
+	assert.True(t, issues[0].Repairable)
+
+	repaired, err := store.RepairIntegrityIssues(ctx, db.IntegrityCheckAlbumsWithoutArtists)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, repaired)
+
+	// the track artist is now credited as album artist
+	album, err := store.GetAlbum(ctx, db.GetAlbumOpts{ID: 1})
+	require.NoError(t, err)
+	require.Len(t, album.Artists, 1)
+	assert.Equal(t, "Artist One", album.Artists[0].Name)
+
+	issues, err = store.GetIntegrityIssues(ctx, db.IntegrityCheckAlbumsWithoutArtists)
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+}
+
+func TestIntegrity_ArtistsWithoutTracks(t *testing.T) {
+	testDataForTopItems(t)
+	ctx := context.Background()
+
+	// artist 5 is orphaned, artist 6 is only an album artist, which is not an issue
+	err := store.Exec(ctx, `INSERT INTO artists (musicbrainz_id) VALUES (NULL), (NULL)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx,
+		`INSERT INTO artist_aliases (artist_id, alias, source, is_primary) 
+			VALUES (5, 'Artist Five', 'Testing', true),
+				   (6, 'Artist Six', 'Testing', true)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx, `INSERT INTO artist_releases (artist_id, release_id) VALUES (6, 1)`)
+	require.NoError(t, err)
+
+	issues, err := store.GetIntegrityIssues(ctx, db.IntegrityCheckArtistsWithoutTracks)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, db.ItemTypeArtist, issues[0].Type)
+	assert.EqualValues(t, 5, issues[0].ID)
+	assert.Equal(t, "Artist Five", issues[0].Name)
+
+	repaired, err := store.RepairIntegrityIssues(ctx, db.IntegrityCheckArtistsWithoutTracks)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, repaired)
+
+	_, err = store.GetArtist(ctx, db.GetArtistOpts{ID: 5})
+	assert.Error(t, err)
+	_, err = store.GetArtist(ctx, db.GetArtistOpts{ID: 6})
+	assert.NoError(t, err)
+
+	issues, err = store.GetIntegrityIssues(ctx, db.IntegrityCheckArtistsWithoutTracks)
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+
+	_, err = store.GetIntegrityIssues(ctx, "other")
+	assert.Error(t, err)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/gabehf/koito/internal/models"
 	"github.com/gabehf/koito/internal/repository"
 	"github.com/gabehf/koito/internal/utils"
+	"github.com/jackc/pgx/v5"
 )
 
 func (d *Psql) GetListensPaginated(ctx context.Context, opts db.GetItemsOpts) (*db.PaginatedResponse[*models.Listen], error) {
@@ -60,9 +61,10 @@ func (d *Psql) GetListensPaginated(ctx context.Context, opts db.GetItemsOpts) (*
 			listens[i] = t
 		}
 		count, err = d.q.CountListensFromTrack(ctx, repository.CountListensFromTrackParams{
-			ListenedAt:   t1,
-			ListenedAt_2: t2,
-			TrackID:      int32(opts.TrackID),
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			TrackID:       int32(opts.TrackID),
+			IncludeHidden: true,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("GetListensPaginated: CountListensFromTrack: %w", err)
@@ -96,9 +98,10 @@ func (d *Psql) GetListensPaginated(ctx context.Context, opts db.GetItemsOpts) (*
 			listens[i] = t
 		}
 		count, err = d.q.CountListensFromRelease(ctx, repository.CountListensFromReleaseParams{
-			ListenedAt:   t1,
-			ListenedAt_2: t2,
-			ReleaseID:    int32(opts.AlbumID),
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			ReleaseID:     int32(opts.AlbumID),
+			IncludeHidden: true,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("GetListensPaginated: CountListensFromRelease: %w", err)
@@ -132,9 +135,10 @@ func (d *Psql) GetListensPaginated(ctx context.Context, opts db.GetItemsOpts) (*
 			listens[i] = t
 		}
 		count, err = d.q.CountListensFromArtist(ctx, repository.CountListensFromArtistParams{
-			ListenedAt:   t1,
-			ListenedAt_2: t2,
-			ArtistID:     int32(opts.ArtistID),
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			ArtistID:      int32(opts.ArtistID),
+			IncludeHidden: true,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("GetListensPaginated: CountListensFromArtist: %w", err)
@@ -166,9 +170,11 @@ func (d *Psql) GetListensPaginated(ctx context.Context, opts db.GetItemsOpts) (*
 			}
 			listens[i] = t
 		}
+		// hidden items are only left out of stats, not the listen history
 		count, err = d.q.CountListens(ctx, repository.CountListensParams{
-			ListenedAt:   t1,
-			ListenedAt_2: t2,
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			IncludeHidden: true,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("GetListensPaginated: CountListens: %w", err)
@@ -198,12 +204,41 @@ func (d *Psql) SaveListen(ctx context.Context, opts db.SaveListenOpts) error {
 		client = &opts.Client
 	}
 	l.Debug().Msgf("Inserting listen for track with id %d at time %v into DB", opts.TrackID, opts.Time)
-	return d.q.InsertListen(ctx, repository.InsertListenParams{
+	if len(opts.FuzzyMatchIDs) == 0 {
+		return d.q.InsertListen(ctx, repository.InsertListenParams{
+			TrackID:    opts.TrackID,
+			ListenedAt: opts.Time,
+			UserID:     opts.UserID,
+			Client:     client,
+		})
+	}
+	tx, err := d.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		l.Err(err).Msg("Failed to begin transaction")
+		return fmt.Errorf("SaveListen: BeginTx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := d.q.WithTx(tx)
+	err = qtx.InsertListen(ctx, repository.InsertListenParams{
 		TrackID:    opts.TrackID,
 		ListenedAt: opts.Time,
 		UserID:     opts.UserID,
 		Client:     client,
 	})
+	if err != nil {
+		return fmt.Errorf("SaveListen: InsertListen: %w", err)
+	}
+	for _, id := range opts.FuzzyMatchIDs {
+		err = qtx.InsertFuzzyMatchListen(ctx, repository.InsertFuzzyMatchListenParams{
+			FuzzyMatchID: id,
+			TrackID:      opts.TrackID,
+			ListenedAt:   opts.Time,
+		})
+		if err != nil {
+			return fmt.Errorf("SaveListen: InsertFuzzyMatchListen: %w", err)
+		}
+	}
+	return tx.Commit(ctx)
 }
 
 func (d *Psql) DeleteListen(ctx context.Context, trackId int32, listenedAt time.Time) error {
@@ -25,10 +25,11 @@ func (d *Psql) GetListenActivity(ctx context.Context, opts db.ListenActivityOpts
 		l.Debug().Msgf("Fetching listen activity for %d %s(s) from %v to %v for release group %d",
 			opts.Range, opts.Step, t1.Format("Jan 02, 2006 15:04:05"), t2.Format("Jan 02, 2006 15:04:05"), opts.AlbumID)
 		rows, err := d.q.ListenActivityForRelease(ctx, repository.ListenActivityForReleaseParams{
-			Column1:   t1,
-			Column2:   t2,
-			Column3:   stepToInterval(opts.Step),
-			ReleaseID: opts.AlbumID,
+			Column1:       t1,
+			Column2:       t2,
+			Column3:       stepToInterval(opts.Step),
+			ReleaseID:     opts.AlbumID,
+			IncludeHidden: opts.IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("GetListenActivity: ListenActivityForRelease: %w", err)
@@ -46,10 +47,12 @@ func (d *Psql) GetListenActivity(ctx context.Context, opts db.ListenActivityOpts
 		l.Debug().Msgf("Fetching listen activity for %d %s(s) from %v to %v for artist %d",
 			opts.Range, opts.Step, t1.Format("Jan 02, 2006 15:04:05"), t2.Format("Jan 02, 2006 15:04:05"), opts.ArtistID)
 		rows, err := d.q.ListenActivityForArtist(ctx, repository.ListenActivityForArtistParams{
-			Column1:  t1,
-			Column2:  t2,
-			Column3:  stepToInterval(opts.Step),
-			ArtistID: opts.ArtistID,
+			Column1:        t1,
+			Column2:        t2,
+			Column3:        stepToInterval(opts.Step),
+			ArtistID:       opts.ArtistID,
+			IncludeRelated: opts.IncludeRelated,
+			IncludeHidden:  opts.IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("GetListenActivity: ListenActivityForArtist: %w", err)
@@ -67,10 +70,11 @@ func (d *Psql) GetListenActivity(ctx context.Context, opts db.ListenActivityOpts
 		l.Debug().Msgf("Fetching listen activity for %d %s(s) from %v to %v for track %d",
 			opts.Range, opts.Step, t1.Format("Jan 02, 2006 15:04:05"), t2.Format("Jan 02, 2006 15:04:05"), opts.TrackID)
 		rows, err := d.q.ListenActivityForTrack(ctx, repository.ListenActivityForTrackParams{
-			Column1: t1,
-			Column2: t2,
-			Column3: stepToInterval(opts.Step),
-			ID:      opts.TrackID,
+			Column1:       t1,
+			Column2:       t2,
+			Column3:       stepToInterval(opts.Step),
+			ID:            opts.TrackID,
+			IncludeHidden: opts.IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("GetListenActivity: ListenActivityForTrack: %w", err)
@@ -84,13 +88,36 @@ func (d *Psql) GetListenActivity(ctx context.Context, opts db.ListenActivityOpts
 			listenActivity[i] = t
 		}
 		l.Debug().Msgf("Database responded with %d steps", len(rows))
+	} else if opts.Tag != "" {
+		l.Debug().Msgf("Fetching listen activity for %d %s(s) from %v to %v for tag '%s'",
+			opts.Range, opts.Step, t1.Format("Jan 02, 2006 15:04:05"), t2.Format("Jan 02, 2006 15:04:05"), opts.Tag)
+		rows, err := d.q.ListenActivityForTag(ctx, repository.ListenActivityForTagParams{
+			Column1:       t1,
+			Column2:       t2,
+			Column3:       stepToInterval(opts.Step),
+			Name:          opts.Tag,
+			IncludeHidden: opts.IncludeHidden,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetListenActivity: ListenActivityForTag: %w", err)
+		}
+		listenActivity = make([]db.ListenActivityItem, len(rows))
+		for i, row := range rows {
+			t := db.ListenActivityItem{
+				Start:   row.BucketStart,
+				Listens: row.ListenCount,
+			}
+			listenActivity[i] = t
+		}
+		l.Debug().Msgf("Database responded with %d steps", len(rows))
 	} else {
 		l.Debug().Msgf("Fetching listen activity for %d %s(s) from %v to %v",
 			opts.Range, opts.Step, t1.Format("Jan 02, 2006 15:04:05"), t2.Format("Jan 02, 2006 15:04:05"))
 		rows, err := d.q.ListenActivity(ctx, repository.ListenActivityParams{
-			Column1: t1,
-			Column2: t2,
-			Column3: stepToInterval(opts.Step),
+			Column1:       t1,
+			Column2:       t2,
+			Column3:       stepToInterval(opts.Step),
+			IncludeHidden: opts.IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("GetListenActivity: ListenActivity: %w", err)
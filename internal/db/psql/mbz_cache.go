@@ -0,0 +1,61 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gabehf/koito/internal/repository"
+	"github.com/google/uuid"
+)
+
+func (d *Psql) GetMbzCacheEntry(ctx context.Context, entity string, id uuid.UUID, includes string, fetchedAfter time.Time) ([]byte, error) {
+	body, err := d.q.GetMbzCacheEntry(ctx, repository.GetMbzCacheEntryParams{
+		EntityType:    entity,
+		MusicBrainzID: id,
+		Includes:      includes,
+		FetchedAt:     fetchedAfter,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetMbzCacheEntry: %w", err)
+	}
+	return body, nil
+}
+
+func (d *Psql) SaveMbzCacheEntry(ctx context.Context, entity string, id uuid.UUID, includes string, body []byte) error {
+	err := d.q.SaveMbzCacheEntry(ctx, repository.SaveMbzCacheEntryParams{
+		EntityType:    entity,
+		MusicBrainzID: id,
+		Includes:      includes,
+		Body:          body,
+	})
+	if err != nil {
+		return fmt.Errorf("SaveMbzCacheEntry: %w", err)
+	}
+	return nil
+}
+
+// DeleteMbzCacheEntries removes cached MusicBrainz responses for the given entity type, or all of
+// them when entity is empty. It returns the number of entries removed.
+func (d *Psql) DeleteMbzCacheEntries(ctx context.Context, entity string) (int64, error) {
+	if entity == "" {
+		count, err := d.q.DeleteMbzCacheEntries(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("DeleteMbzCacheEntries: %w", err)
+		}
+		return count, nil
+	}
+	count, err := d.q.DeleteMbzCacheEntriesForEntity(ctx, entity)
+	if err != nil {
+		return 0, fmt.Errorf("DeleteMbzCacheEntries: DeleteMbzCacheEntriesForEntity: %w", err)
+	}
+	return count, nil
+}
+
+func (d *Psql) CountMbzCacheEntries(ctx context.Context) (int64, error) {
+	count, err := d.q.CountMbzCacheEntries(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("CountMbzCacheEntries: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,56 @@
+package psql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMbzCache(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+	_, err := store.GetMbzCacheEntry(ctx, "artist", id, "aliases", time.Time{})
+	assert.Error(t, err)
+
+	require.NoError(t, store.SaveMbzCacheEntry(ctx, "artist", id, "aliases", []byte(`{"name":"ATARASHII GAKKO!"}`)))
+	require.NoError(t, store.SaveMbzCacheEntry(ctx, "release", id, "", []byte(`{"title":"AG! Calling"}`)))
+
+	body, err := store.GetMbzCacheEntry(ctx, "artist", id, "aliases", time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"ATARASHII GAKKO!"}`, string(body))
+
+	// entries fetched before the cutoff are stale
+	_, err = store.GetMbzCacheEntry(ctx, "artist", id, "aliases", time.Now().Add(time.Hour))
+	assert.Error(t, err)
+	// entries requested with other includes are a miss
+	_, err = store.GetMbzCacheEntry(ctx, "artist", id, "aliases genres", time.Time{})
+	assert.Error(t, err)
+
+	// saving again replaces the entry
+	require.NoError(t, store.SaveMbzCacheEntry(ctx, "artist", id, "aliases", []byte(`{"name":"新しい学校のリーダーズ"}`)))
+	body, err = store.GetMbzCacheEntry(ctx, "artist", id, "aliases", time.Time{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"新しい学校のリーダーズ"}`, string(body))
+
+	count, err := store.CountMbzCacheEntries(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+
+	deleted, err := store.DeleteMbzCacheEntries(ctx, "release")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, deleted)
+	_, err = store.GetMbzCacheEntry(ctx, "release", id, "", time.Time{})
+	assert.Error(t, err)
+
+	deleted, err = store.DeleteMbzCacheEntries(ctx, "")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, deleted)
+	count, err = store.CountMbzCacheEntries(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, count)
+}
@@ -0,0 +1,200 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/models"
+	"github.com/gabehf/koito/internal/repository"
+	"github.com/jackc/pgx/v5"
+)
+
+func (d *Psql) GetItemsWithoutMbzID(ctx context.Context, opts db.GetItemsWithoutMbzIDOpts) ([]db.UnmatchedItem, error) {
+	l := logger.FromContext(ctx)
+	if opts.Limit == 0 {
+		opts.Limit = DefaultItemsPerPage
+	}
+	l.Debug().Msgf("Fetching %d %ss without MusicBrainz IDs not searched for since %v", opts.Limit, opts.Type, opts.AttemptedAfter)
+	var ret []db.UnmatchedItem
+	switch opts.Type {
+	case db.ItemTypeArtist:
+		rows, err := d.q.GetArtistsWithoutMbzID(ctx, repository.GetArtistsWithoutMbzIDParams{
+			AttemptedAt: opts.AttemptedAfter,
+			Limit:       int32(opts.Limit),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetItemsWithoutMbzID: GetArtistsWithoutMbzID: %w", err)
+		}
+		ret = make([]db.UnmatchedItem, len(rows))
+		for i, row := range rows {
+			ret[i] = db.UnmatchedItem{
+				ID:   row.ID,
+				Name: row.Name,
+			}
+		}
+	case db.ItemTypeAlbum:
+		rows, err := d.q.GetAlbumsWithoutMbzID(ctx, repository.GetAlbumsWithoutMbzIDParams{
+			AttemptedAt: opts.AttemptedAfter,
+			Limit:       int32(opts.Limit),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetItemsWithoutMbzID: GetAlbumsWithoutMbzID: %w", err)
+		}
+		ret = make([]db.UnmatchedItem, len(rows))
+		for i, row := range rows {
+			ret[i] = db.UnmatchedItem{
+				ID:             row.ID,
+				Name:           row.Title,
+				ArtistName:     row.ArtistName,
+				VariousArtists: row.VariousArtists,
+			}
+		}
+	case db.ItemTypeTrack:
+		rows, err := d.q.GetTracksWithoutMbzID(ctx, repository.GetTracksWithoutMbzIDParams{
+			AttemptedAt: opts.AttemptedAfter,
+			Limit:       int32(opts.Limit),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetItemsWithoutMbzID: GetTracksWithoutMbzID: %w", err)
+		}
+		ret = make([]db.UnmatchedItem, len(rows))
+		for i, row := range rows {
+			ret[i] = db.UnmatchedItem{
+				ID:         row.ID,
+				Name:       row.Title,
+				ArtistName: row.ArtistName,
+				AlbumTitle: row.AlbumTitle,
+				Duration:   row.Duration,
+			}
+		}
+	default:
+		return nil, fmt.Errorf("GetItemsWithoutMbzID: unknown item type '%s'", opts.Type)
+	}
+	return ret, nil
+}
+
+func (d *Psql) SaveMbzEnrichmentAttempt(ctx context.Context, t db.ItemType, id int32) error {
+	err := d.q.UpsertMbzEnrichmentAttempt(ctx, repository.UpsertMbzEnrichmentAttemptParams{
+		ItemType: string(t),
+		ItemID:   id,
+	})
+	if err != nil {
+		return fmt.Errorf("SaveMbzEnrichmentAttempt: %w", err)
+	}
+	return nil
+}
+
+// SaveMbzMatchSuggestions replaces the pending suggestions for an item. Suggestions that were dismissed
+// are kept, and are not suggested again.
+func (d *Psql) SaveMbzMatchSuggestions(ctx context.Context, t db.ItemType, id int32, suggestions []db.SaveMbzMatchSuggestionOpts) error {
+	l := logger.FromContext(ctx)
+	tx, err := d.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		l.Err(err).Msg("Failed to begin transaction")
+		return fmt.Errorf("SaveMbzMatchSuggestions: BeginTx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := d.q.WithTx(tx)
+	err = qtx.DeleteMbzMatchSuggestionsForItem(ctx, repository.DeleteMbzMatchSuggestionsForItemParams{
+		ItemType: string(t),
+		ItemID:   id,
+	})
+	if err != nil {
+		return fmt.Errorf("SaveMbzMatchSuggestions: DeleteMbzMatchSuggestionsForItem: %w", err)
+	}
+	for _, s := range suggestions {
+		err = qtx.InsertMbzMatchSuggestion(ctx, repository.InsertMbzMatchSuggestionParams{
+			ItemType:      string(t),
+			ItemID:        id,
+			MusicBrainzID: s.MusicBrainzID,
+			Name:          s.Name,
+			ArtistName:    s.ArtistName,
+			Score:         s.Score,
+		})
+		if err != nil {
+			return fmt.Errorf("SaveMbzMatchSuggestions: InsertMbzMatchSuggestion: %w", err)
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (d *Psql) GetMbzMatchSuggestions(ctx context.Context, opts db.GetMbzMatchSuggestionsOpts) (*db.PaginatedResponse[*models.MbzMatchSuggestion], error) {
+	l := logger.FromContext(ctx)
+	if opts.Limit == 0 {
+		opts.Limit = DefaultItemsPerPage
+	}
+	if opts.Page < 1 {
+		opts.Page = 1
+	}
+	offset := (opts.Page - 1) * opts.Limit
+	l.Debug().Msgf("Fetching %d MusicBrainz match suggestions on page %d", opts.Limit, opts.Page)
+	rows, err := d.q.GetMbzMatchSuggestionsPaginated(ctx, repository.GetMbzMatchSuggestionsPaginatedParams{
+		Limit:  int32(opts.Limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetMbzMatchSuggestions: GetMbzMatchSuggestionsPaginated: %w", err)
+	}
+	items := make([]*models.MbzMatchSuggestion, len(rows))
+	for i, row := range rows {
+		items[i] = &models.MbzMatchSuggestion{
+			ID:         row.ID,
+			Type:       row.ItemType,
+			ItemID:     row.ItemID,
+			ItemName:   row.ItemName,
+			MbzID:      row.MusicBrainzID,
+			Name:       row.Name,
+			ArtistName: row.ArtistName,
+			Score:      row.Score,
+		}
+	}
+	count, err := d.q.CountMbzMatchSuggestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetMbzMatchSuggestions: CountMbzMatchSuggestions: %w", err)
+	}
+	return &db.PaginatedResponse[*models.MbzMatchSuggestion]{
+		Items:        items,
+		TotalCount:   count,
+		ItemsPerPage: int32(opts.Limit),
+		HasNextPage:  int64(offset+len(items)) < count,
+		CurrentPage:  int32(opts.Page),
+	}, nil
+}
+
+func (d *Psql) GetMbzMatchSuggestion(ctx context.Context, id int32) (*models.MbzMatchSuggestion, error) {
+	row, err := d.q.GetMbzMatchSuggestion(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("GetMbzMatchSuggestion: %w", err)
+	}
+	return &models.MbzMatchSuggestion{
+		ID:         row.ID,
+		Type:       row.ItemType,
+		ItemID:     row.ItemID,
+		ItemName:   row.ItemName,
+		MbzID:      row.MusicBrainzID,
+		Name:       row.Name,
+		ArtistName: row.ArtistName,
+		Score:      row.Score,
+	}, nil
+}
+
+func (d *Psql) DismissMbzMatchSuggestion(ctx context.Context, id int32) error {
+	err := d.q.DismissMbzMatchSuggestion(ctx, id)
+	if err != nil {
+		return fmt.Errorf("DismissMbzMatchSuggestion: %w", err)
+	}
+	return nil
+}
+
+func (d *Psql) DeleteMbzMatchSuggestions(ctx context.Context, t db.ItemType, id int32) error {
+	err := d.q.DeleteMbzMatchSuggestionsForItem(ctx, repository.DeleteMbzMatchSuggestionsForItemParams{
+		ItemType: string(t),
+		ItemID:   id,
+	})
+	if err != nil {
+		return fmt.Errorf("DeleteMbzMatchSuggestions: %w", err)
+	}
+	return nil
+}
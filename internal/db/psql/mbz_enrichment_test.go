@@ -0,0 +1,108 @@
+package psql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetItemsWithoutMbzID(t *testing.T) {
+	ctx := context.Background()
+	setupTestDataForDuplicates(t)
+
+	items, err := store.GetItemsWithoutMbzID(ctx, db.GetItemsWithoutMbzIDOpts{Type: db.ItemTypeArtist})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "Beatles", items[0].Name)
+	assert.Equal(t, "Radiohead", items[1].Name)
+
+	// recently searched items are skipped
+	require.NoError(t, store.SaveMbzEnrichmentAttempt(ctx, db.ItemTypeArtist, 2))
+	items, err = store.GetItemsWithoutMbzID(ctx, db.GetItemsWithoutMbzIDOpts{
+		Type:           db.ItemTypeArtist,
+		AttemptedAfter: time.Now().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.EqualValues(t, 3, items[0].ID)
+	items, err = store.GetItemsWithoutMbzID(ctx, db.GetItemsWithoutMbzIDOpts{
+		Type:           db.ItemTypeArtist,
+		AttemptedAfter: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Len(t, items, 2)
+
+	items, err = store.GetItemsWithoutMbzID(ctx, db.GetItemsWithoutMbzIDOpts{Type: db.ItemTypeAlbum, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Abbey Road", items[0].Name)
+	assert.Equal(t, "The Beatles", items[0].ArtistName)
+
+	items, err = store.GetItemsWithoutMbzID(ctx, db.GetItemsWithoutMbzIDOpts{Type: db.ItemTypeTrack})
+	require.NoError(t, err)
+	require.Len(t, items, 6)
+	assert.Equal(t, "Come Together", items[0].Name)
+	assert.Equal(t, "Abbey Road", items[0].AlbumTitle)
+	assert.Equal(t, "The Beatles", items[0].ArtistName)
+
+	truncateTestData(t)
+}
+
+func TestMbzMatchSuggestions(t *testing.T) {
+	ctx := context.Background()
+	setupTestDataForDuplicates(t)
+	mbzID1 := uuid.MustParse("00000000-0000-0000-0000-000000000011")
+	mbzID2 := uuid.MustParse("00000000-0000-0000-0000-000000000012")
+
+	err := store.SaveMbzMatchSuggestions(ctx, db.ItemTypeArtist, 3, []db.SaveMbzMatchSuggestionOpts{
+		{MusicBrainzID: mbzID1, Name: "Radiohead", Score: 80},
+		{MusicBrainzID: mbzID2, Name: "Radiohead Tribute", Score: 90},
+	})
+	require.NoError(t, err)
+
+	resp, err := store.GetMbzMatchSuggestions(ctx, db.GetMbzMatchSuggestionsOpts{})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 2)
+	assert.EqualValues(t, 2, resp.TotalCount)
+	assert.Equal(t, mbzID2, resp.Items[0].MbzID, "expected suggestions to be ordered by score")
+	assert.Equal(t, "Radiohead", resp.Items[0].ItemName)
+	assert.Equal(t, "artist", resp.Items[0].Type)
+
+	// dismissed suggestions are not suggested again
+	require.NoError(t, store.DismissMbzMatchSuggestion(ctx, resp.Items[0].ID))
+	err = store.SaveMbzMatchSuggestions(ctx, db.ItemTypeArtist, 3, []db.SaveMbzMatchSuggestionOpts{
+		{MusicBrainzID: mbzID2, Name: "Radiohead Tribute", Score: 90},
+	})
+	require.NoError(t, err)
+	resp, err = store.GetMbzMatchSuggestions(ctx, db.GetMbzMatchSuggestionsOpts{})
+	require.NoError(t, err)
+	assert.Len(t, resp.Items, 0)
+
+	// suggestions for items that have since been matched are hidden
+	err = store.SaveMbzMatchSuggestions(ctx, db.ItemTypeAlbum, 3, []db.SaveMbzMatchSuggestionOpts{
+		{MusicBrainzID: mbzID1, Name: "OK Computer", ArtistName: "Radiohead", Score: 80},
+	})
+	require.NoError(t, err)
+	resp, err = store.GetMbzMatchSuggestions(ctx, db.GetMbzMatchSuggestionsOpts{})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 1)
+	suggestion, err := store.GetMbzMatchSuggestion(ctx, resp.Items[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Radiohead", suggestion.ArtistName)
+	assert.Equal(t, resp.Items[0].ItemName, suggestion.ItemName)
+	require.NoError(t, store.UpdateAlbum(ctx, db.UpdateAlbumOpts{ID: 3, MusicBrainzID: mbzID2}))
+	resp, err = store.GetMbzMatchSuggestions(ctx, db.GetMbzMatchSuggestionsOpts{})
+	require.NoError(t, err)
+	assert.Len(t, resp.Items, 0)
+
+	require.NoError(t, store.DeleteMbzMatchSuggestions(ctx, db.ItemTypeAlbum, 3))
+	_, err = store.GetMbzMatchSuggestion(ctx, suggestion.ID)
+	assert.Error(t, err)
+
+	truncateTestData(t)
+}
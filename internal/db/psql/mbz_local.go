@@ -0,0 +1,54 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func (d *Psql) GetMbzLocalEntity(ctx context.Context, entity string, id uuid.UUID) ([]byte, error) {
+	body, err := d.q.GetMbzLocalEntity(ctx, repository.GetMbzLocalEntityParams{
+		EntityType:    entity,
+		MusicBrainzID: id,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetMbzLocalEntity: %w", err)
+	}
+	return body, nil
+}
+
+func (d *Psql) GetMbzLocalReleases(ctx context.Context, releaseGroupID uuid.UUID) ([][]byte, error) {
+	bodies, err := d.q.GetMbzLocalReleasesForReleaseGroup(ctx, &releaseGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("GetMbzLocalReleases: %w", err)
+	}
+	return bodies, nil
+}
+
+func (d *Psql) SaveMbzLocalEntities(ctx context.Context, entity string, entities []db.SaveMbzLocalEntityOpts) error {
+	l := logger.FromContext(ctx)
+	tx, err := d.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		l.Err(err).Msg("Failed to begin transaction")
+		return fmt.Errorf("SaveMbzLocalEntities: BeginTx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := d.q.WithTx(tx)
+	for _, e := range entities {
+		err = qtx.SaveMbzLocalEntity(ctx, repository.SaveMbzLocalEntityParams{
+			EntityType:     entity,
+			MusicBrainzID:  e.MusicBrainzID,
+			ReleaseGroupID: e.ReleaseGroupID,
+			Body:           e.Body,
+		})
+		if err != nil {
+			return fmt.Errorf("SaveMbzLocalEntities: SaveMbzLocalEntity: %w", err)
+		}
+	}
+	return tx.Commit(ctx)
+}
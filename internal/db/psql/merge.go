@@ -42,19 +42,31 @@ func (d *Psql) MergeTracks(ctx context.Context, fromId, toId int32) error {
 		}
 		for _, artist := range artists {
 			err = qtx.AssociateArtistToRelease(ctx, repository.AssociateArtistToReleaseParams{
-				ArtistID:  artist.ID,
-				ReleaseID: to.ReleaseID,
+				ArtistID:      artist.ID,
+				ReleaseID:     to.ReleaseID,
+				IsAlbumArtist: false,
 			})
 			if err != nil {
 				return fmt.Errorf("MergeTracks: AssociateArtistToRelease: %w", err)
 			}
 		}
 	}
+	err = qtx.CopyTrackTags(ctx, repository.CopyTrackTagsParams{
+		FromID: fromId,
+		ToID:   toId,
+	})
+	if err != nil {
+		return fmt.Errorf("MergeTracks: CopyTrackTags: %w", err)
+	}
 	err = qtx.CleanOrphanedEntries(ctx)
 	if err != nil {
 		l.Err(err).Msg("Failed to clean orphaned entries")
 		return err
 	}
+	err = qtx.LinkReleaseTracks(ctx, to.ReleaseID)
+	if err != nil {
+		return fmt.Errorf("MergeTracks: LinkReleaseTracks: %w", err)
+	}
 	return tx.Commit(ctx)
 }
 
@@ -98,19 +110,31 @@ func (d *Psql) MergeAlbums(ctx context.Context, fromId, toId int32, replaceImage
 
 	for _, artist := range fromArtists {
 		err = qtx.AssociateArtistToRelease(ctx, repository.AssociateArtistToReleaseParams{
-			ArtistID:  artist.ID,
-			ReleaseID: toId,
+			ArtistID:      artist.ID,
+			ReleaseID:     toId,
+			IsAlbumArtist: artist.IsAlbumArtist.Valid && artist.IsAlbumArtist.Bool,
 		})
 		if err != nil {
 			return fmt.Errorf("MergeAlbums: AssociateArtistToRelease: %w", err)
 		}
 	}
 
+	err = qtx.CopyReleaseTags(ctx, repository.CopyReleaseTagsParams{
+		FromID: fromId,
+		ToID:   toId,
+	})
+	if err != nil {
+		return fmt.Errorf("MergeAlbums: CopyReleaseTags: %w", err)
+	}
 	err = qtx.CleanOrphanedEntries(ctx)
 	if err != nil {
 		l.Err(err).Msg("Failed to clean orphaned entries")
 		return fmt.Errorf("MergeAlbums: CleanOrphanedEntries: %w", err)
 	}
+	err = qtx.LinkReleaseTracks(ctx, toId)
+	if err != nil {
+		return fmt.Errorf("MergeAlbums: LinkReleaseTracks: %w", err)
+	}
 	return tx.Commit(ctx)
 }
 
@@ -124,6 +148,16 @@ func (d *Psql) MergeArtists(ctx context.Context, fromId, toId int32, replaceImag
 	}
 	defer tx.Rollback(ctx)
 	qtx := d.q.WithTx(tx)
+	// tracks and albums both artists are credited on keep the position of the merged artist in
+	// their artist credit, so that the credit stays intact
+	err = qtx.UpdateConflictingArtistTracks(ctx, repository.UpdateConflictingArtistTracksParams{
+		ArtistID:   fromId,
+		ArtistID_2: toId,
+	})
+	if err != nil {
+		l.Err(err).Msg("Failed to update conflicting artist tracks")
+		return fmt.Errorf("MergeArtists: %w", err)
+	}
 	err = qtx.DeleteConflictingArtistTracks(ctx, repository.DeleteConflictingArtistTracksParams{
 		ArtistID:   fromId,
 		ArtistID_2: toId,
@@ -132,6 +166,15 @@ func (d *Psql) MergeArtists(ctx context.Context, fromId, toId int32, replaceImag
 		l.Err(err).Msg("Failed to delete conflicting artist tracks")
 		return fmt.Errorf("MergeArtists: %w", err)
 	}
+	// albums both artists are credited on also keep the album artist credit of the merged artist
+	err = qtx.UpdateConflictingArtistReleases(ctx, repository.UpdateConflictingArtistReleasesParams{
+		ArtistID:   fromId,
+		ArtistID_2: toId,
+	})
+	if err != nil {
+		l.Err(err).Msg("Failed to update conflicting artist releases")
+		return fmt.Errorf("MergeArtists: %w", err)
+	}
 	err = qtx.DeleteConflictingArtistReleases(ctx, repository.DeleteConflictingArtistReleasesParams{
 		ArtistID:   fromId,
 		ArtistID_2: toId,
@@ -170,6 +213,28 @@ func (d *Psql) MergeArtists(ctx context.Context, fromId, toId int32, replaceImag
 			return fmt.Errorf("MergeAlbums: %w", err)
 		}
 	}
+	err = qtx.CopyArtistTags(ctx, repository.CopyArtistTagsParams{
+		FromID: fromId,
+		ToID:   toId,
+	})
+	if err != nil {
+		return fmt.Errorf("MergeArtists: CopyArtistTags: %w", err)
+	}
+	// relations of the merged artist are kept, except the ones to the artist it is merged into
+	err = qtx.UpdateArtistRelations(ctx, repository.UpdateArtistRelationsParams{
+		FromID: fromId,
+		ToID:   toId,
+	})
+	if err != nil {
+		return fmt.Errorf("MergeArtists: UpdateArtistRelations: %w", err)
+	}
+	err = qtx.UpdateRelatedArtistRelations(ctx, repository.UpdateRelatedArtistRelationsParams{
+		FromID: fromId,
+		ToID:   toId,
+	})
+	if err != nil {
+		return fmt.Errorf("MergeArtists: UpdateRelatedArtistRelations: %w", err)
+	}
 	err = qtx.CleanOrphanedEntries(ctx)
 	if err != nil {
 		l.Err(err).Msg("Failed to clean orphaned entries")
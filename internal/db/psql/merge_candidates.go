@@ -0,0 +1,208 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/models"
+	"github.com/gabehf/koito/internal/repository"
+	"github.com/jackc/pgx/v5"
+)
+
+func (d *Psql) GetDuplicatePairs(ctx context.Context, opts db.GetDuplicatePairsOpts) ([]db.DuplicatePair, error) {
+	l := logger.FromContext(ctx)
+	l.Debug().Msgf("Fetching %s duplicate pairs with minimum similarity %.2f", opts.Type, opts.MinSimilarity)
+	var ret []db.DuplicatePair
+	switch opts.Type {
+	case db.ItemTypeArtist:
+		rows, err := d.q.GetArtistDuplicatePairs(ctx, opts.MinSimilarity)
+		if err != nil {
+			return nil, fmt.Errorf("GetDuplicatePairs: GetArtistDuplicatePairs: %w", err)
+		}
+		ret = make([]db.DuplicatePair, len(rows))
+		for i, row := range rows {
+			ret[i] = db.DuplicatePair(row)
+		}
+	case db.ItemTypeAlbum:
+		rows, err := d.q.GetAlbumDuplicatePairs(ctx, opts.MinSimilarity)
+		if err != nil {
+			return nil, fmt.Errorf("GetDuplicatePairs: GetAlbumDuplicatePairs: %w", err)
+		}
+		ret = make([]db.DuplicatePair, len(rows))
+		for i, row := range rows {
+			ret[i] = db.DuplicatePair(row)
+		}
+	case db.ItemTypeTrack:
+		rows, err := d.q.GetTrackDuplicatePairs(ctx, opts.MinSimilarity)
+		if err != nil {
+			return nil, fmt.Errorf("GetDuplicatePairs: GetTrackDuplicatePairs: %w", err)
+		}
+		ret = make([]db.DuplicatePair, len(rows))
+		for i, row := range rows {
+			ret[i] = db.DuplicatePair(row)
+		}
+	default:
+		return nil, fmt.Errorf("GetDuplicatePairs: unknown item type '%s'", opts.Type)
+	}
+	return ret, nil
+}
+
+// SaveMergeCandidates replaces all pending merge candidates of the given type. Dismissed candidates are
+// kept, and a candidate for a pair that has already been dismissed is ignored.
+func (d *Psql) SaveMergeCandidates(ctx context.Context, t db.ItemType, candidates []db.SaveMergeCandidateOpts) error {
+	l := logger.FromContext(ctx)
+	tx, err := d.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		l.Err(err).Msg("Failed to begin transaction")
+		return fmt.Errorf("SaveMergeCandidates: BeginTx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := d.q.WithTx(tx)
+	err = qtx.DeleteMergeCandidatesByType(ctx, string(t))
+	if err != nil {
+		return fmt.Errorf("SaveMergeCandidates: DeleteMergeCandidatesByType: %w", err)
+	}
+	for _, c := range candidates {
+		err = qtx.InsertMergeCandidate(ctx, repository.InsertMergeCandidateParams{
+			ItemType: string(t),
+			FromID:   c.FromID,
+			ToID:     c.ToID,
+			Score:    c.Score,
+			Reasons:  c.Reasons,
+		})
+		if err != nil {
+			return fmt.Errorf("SaveMergeCandidates: InsertMergeCandidate: %w", err)
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (d *Psql) GetMergeCandidates(ctx context.Context, opts db.GetMergeCandidatesOpts) (*db.PaginatedResponse[*models.MergeCandidate], error) {
+	l := logger.FromContext(ctx)
+	if opts.Limit == 0 {
+		opts.Limit = DefaultItemsPerPage
+	}
+	if opts.Page < 1 {
+		opts.Page = 1
+	}
+	offset := (opts.Page - 1) * opts.Limit
+	params := repository.GetArtistMergeCandidatesPaginatedParams{
+		Limit:  int32(opts.Limit),
+		Offset: int32(offset),
+	}
+	l.Debug().Msgf("Fetching %d %s merge candidates on page %d", opts.Limit, opts.Type, opts.Page)
+
+	var items []*models.MergeCandidate
+	var count int64
+	switch opts.Type {
+	case db.ItemTypeArtist:
+		rows, err := d.q.GetArtistMergeCandidatesPaginated(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("GetMergeCandidates: GetArtistMergeCandidatesPaginated: %w", err)
+		}
+		items = make([]*models.MergeCandidate, len(rows))
+		for i, row := range rows {
+			items[i] = &models.MergeCandidate{
+				ID:       row.ID,
+				Type:     string(opts.Type),
+				FromID:   row.FromID,
+				FromName: row.FromName,
+				ToID:     row.ToID,
+				ToName:   row.ToName,
+				Score:    row.Score,
+				Reasons:  row.Reasons,
+			}
+		}
+		count, err = d.q.CountArtistMergeCandidates(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("GetMergeCandidates: CountArtistMergeCandidates: %w", err)
+		}
+	case db.ItemTypeAlbum:
+		rows, err := d.q.GetAlbumMergeCandidatesPaginated(ctx, repository.GetAlbumMergeCandidatesPaginatedParams(params))
+		if err != nil {
+			return nil, fmt.Errorf("GetMergeCandidates: GetAlbumMergeCandidatesPaginated: %w", err)
+		}
+		items = make([]*models.MergeCandidate, len(rows))
+		for i, row := range rows {
+			items[i] = &models.MergeCandidate{
+				ID:       row.ID,
+				Type:     string(opts.Type),
+				FromID:   row.FromID,
+				FromName: row.FromName,
+				ToID:     row.ToID,
+				ToName:   row.ToName,
+				Score:    row.Score,
+				Reasons:  row.Reasons,
+			}
+		}
+		count, err = d.q.CountAlbumMergeCandidates(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("GetMergeCandidates: CountAlbumMergeCandidates: %w", err)
+		}
+	case db.ItemTypeTrack:
+		rows, err := d.q.GetTrackMergeCandidatesPaginated(ctx, repository.GetTrackMergeCandidatesPaginatedParams(params))
+		if err != nil {
+			return nil, fmt.Errorf("GetMergeCandidates: GetTrackMergeCandidatesPaginated: %w", err)
+		}
+		items = make([]*models.MergeCandidate, len(rows))
+		for i, row := range rows {
+			items[i] = &models.MergeCandidate{
+				ID:       row.ID,
+				Type:     string(opts.Type),
+				FromID:   row.FromID,
+				FromName: row.FromName,
+				ToID:     row.ToID,
+				ToName:   row.ToName,
+				Score:    row.Score,
+				Reasons:  row.Reasons,
+			}
+		}
+		count, err = d.q.CountTrackMergeCandidates(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("GetMergeCandidates: CountTrackMergeCandidates: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("GetMergeCandidates: unknown item type '%s'", opts.Type)
+	}
+
+	return &db.PaginatedResponse[*models.MergeCandidate]{
+		Items:        items,
+		TotalCount:   count,
+		ItemsPerPage: int32(opts.Limit),
+		HasNextPage:  int64(offset+len(items)) < count,
+		CurrentPage:  int32(opts.Page),
+	}, nil
+}
+
+func (d *Psql) GetMergeCandidate(ctx context.Context, id int32) (*models.MergeCandidate, error) {
+	row, err := d.q.GetMergeCandidate(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("GetMergeCandidate: %w", err)
+	}
+	return &models.MergeCandidate{
+		ID:      row.ID,
+		Type:    row.ItemType,
+		FromID:  row.FromID,
+		ToID:    row.ToID,
+		Score:   row.Score,
+		Reasons: row.Reasons,
+	}, nil
+}
+
+func (d *Psql) DismissMergeCandidate(ctx context.Context, id int32) error {
+	err := d.q.DismissMergeCandidate(ctx, id)
+	if err != nil {
+		return fmt.Errorf("DismissMergeCandidate: %w", err)
+	}
+	return nil
+}
+
+func (d *Psql) DeleteMergeCandidate(ctx context.Context, id int32) error {
+	err := d.q.DeleteMergeCandidate(ctx, id)
+	if err != nil {
+		return fmt.Errorf("DeleteMergeCandidate: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package psql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestDataForDuplicates(t *testing.T) {
+	truncateTestData(t)
+
+	err := store.Exec(context.Background(),
+		`INSERT INTO artists (musicbrainz_id)
+			VALUES ('00000000-0000-0000-0000-000000000001'),
+				   (NULL),
+				   (NULL)`)
+	require.NoError(t, err)
+
+	err = store.Exec(context.Background(),
+		`INSERT INTO artist_aliases (artist_id, alias, source, is_primary)
+			VALUES (1, 'The Beatles', 'Testing', true),
+				   (2, 'Beatles', 'Testing', true),
+				   (3, 'Radiohead', 'Testing', true)`)
+	require.NoError(t, err)
+
+	err = store.Exec(context.Background(),
+		`INSERT INTO releases (musicbrainz_id)
+			VALUES (NULL), (NULL), (NULL), (NULL)`)
+	require.NoError(t, err)
+
+	err = store.Exec(context.Background(),
+		`INSERT INTO release_aliases (release_id, alias, source, is_primary)
+			VALUES (1, 'Abbey Road', 'Testing', true),
+				   (2, 'Abbey Road', 'Testing', true),
+				   (3, 'OK Computer', 'Testing', true),
+				   (4, 'OK Computer (Remastered)', 'Testing', true)`)
+	require.NoError(t, err)
+
+	err = store.Exec(context.Background(),
+		`INSERT INTO artist_releases (artist_id, release_id)
+			VALUES (1, 1), (2, 2), (3, 3), (3, 4)`)
+	require.NoError(t, err)
+
+	err = store.Exec(context.Background(),
+		`INSERT INTO tracks (musicbrainz_id, release_id)
+			VALUES (NULL, 1), (NULL, 2), (NULL, 2), (NULL, 3), (NULL, 4), (NULL, 4)`)
+	require.NoError(t, err)
+
+	err = store.Exec(context.Background(),
+		`INSERT INTO track_aliases (track_id, alias, source, is_primary)
+			VALUES (1, 'Come Together', 'Testing', true),
+				   (2, 'Come Together', 'Testing', true),
+				   (3, 'Something', 'Testing', true),
+				   (4, 'Airbag', 'Testing', true),
+				   (5, 'Airbag', 'Testing', true),
+				   (6, 'Paranoid Android', 'Testing', true)`)
+	require.NoError(t, err)
+
+	err = store.Exec(context.Background(),
+		`INSERT INTO artist_tracks (artist_id, track_id)
+			VALUES (1, 1), (2, 2), (2, 3), (3, 4), (3, 5), (3, 6)`)
+	require.NoError(t, err)
+
+	err = store.Exec(context.Background(),
+		`INSERT INTO listens (user_id, track_id, listened_at)
+			VALUES (1, 1, NOW() - INTERVAL '1 day'),
+				   (1, 1, NOW() - INTERVAL '2 days'),
+				   (1, 2, NOW() - INTERVAL '3 days'),
+				   (1, 3, NOW() - INTERVAL '4 days'),
+				   (1, 4, NOW() - INTERVAL '5 days'),
+				   (1, 5, NOW() - INTERVAL '6 days'),
+				   (1, 6, NOW() - INTERVAL '7 days')`)
+	require.NoError(t, err)
+}
+
+func TestGetDuplicatePairs(t *testing.T) {
+	ctx := context.Background()
+	setupTestDataForDuplicates(t)
+
+	pairs, err := store.GetDuplicatePairs(ctx, db.GetDuplicatePairsOpts{
+		Type:          db.ItemTypeArtist,
+		MinSimilarity: 0.4,
+	})
+	require.NoError(t, err)
+	require.Len(t, pairs, 1)
+	assert.EqualValues(t, 1, pairs[0].ID1)
+	assert.EqualValues(t, 2, pairs[0].ID2)
+	assert.Equal(t, "The Beatles", pairs[0].Name1)
+	assert.NotNil(t, pairs[0].MbzID1)
+	assert.Nil(t, pairs[0].MbzID2)
+	assert.EqualValues(t, 1, pairs[0].SharedAlbums)
+	assert.EqualValues(t, 1, pairs[0].SharedTracks)
+	assert.EqualValues(t, 2, pairs[0].ListenCount1)
+	assert.EqualValues(t, 2, pairs[0].ListenCount2)
+
+	// the two Abbey Roads have different artists
+	pairs, err = store.GetDuplicatePairs(ctx, db.GetDuplicatePairsOpts{
+		Type:          db.ItemTypeAlbum,
+		MinSimilarity: 0.4,
+	})
+	require.NoError(t, err)
+	require.Len(t, pairs, 1)
+	assert.EqualValues(t, 3, pairs[0].ID1)
+	assert.EqualValues(t, 4, pairs[0].ID2)
+	assert.EqualValues(t, 1, pairs[0].SharedTracks)
+
+	pairs, err = store.GetDuplicatePairs(ctx, db.GetDuplicatePairsOpts{
+		Type:          db.ItemTypeTrack,
+		MinSimilarity: 0.4,
+	})
+	require.NoError(t, err)
+	require.Len(t, pairs, 1)
+	assert.EqualValues(t, 4, pairs[0].ID1)
+	assert.EqualValues(t, 5, pairs[0].ID2)
+	assert.EqualValues(t, 0, pairs[0].SharedAlbums)
+
+	truncateTestData(t)
+}
+
+func TestMergeCandidates(t *testing.T) {
+	ctx := context.Background()
+	setupTestDataForDuplicates(t)
+
+	err := store.SaveMergeCandidates(ctx, db.ItemTypeArtist, []db.SaveMergeCandidateOpts{
+		{FromID: 2, ToID: 1, Score: 0.9, Reasons: []string{"Testing"}},
+		{FromID: 3, ToID: 1, Score: 0.7, Reasons: []string{"Testing"}},
+	})
+	require.NoError(t, err)
+
+	resp, err := store.GetMergeCandidates(ctx, db.GetMergeCandidatesOpts{Type: db.ItemTypeArtist})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 2)
+	assert.EqualValues(t, 2, resp.TotalCount)
+	assert.EqualValues(t, 2, resp.Items[0].FromID, "expected candidates to be ordered by score")
+	assert.Equal(t, "Beatles", resp.Items[0].FromName)
+	assert.Equal(t, "The Beatles", resp.Items[0].ToName)
+	assert.Equal(t, []string{"Testing"}, resp.Items[0].Reasons)
+
+	// candidates of other types are not included
+	resp, err = store.GetMergeCandidates(ctx, db.GetMergeCandidatesOpts{Type: db.ItemTypeAlbum})
+	require.NoError(t, err)
+	assert.Len(t, resp.Items, 0)
+
+	// dismissed candidates stay dismissed, even when found again in the other direction
+	resp, err = store.GetMergeCandidates(ctx, db.GetMergeCandidatesOpts{Type: db.ItemTypeArtist})
+	require.NoError(t, err)
+	require.NoError(t, store.DismissMergeCandidate(ctx, resp.Items[0].ID))
+	err = store.SaveMergeCandidates(ctx, db.ItemTypeArtist, []db.SaveMergeCandidateOpts{
+		{FromID: 1, ToID: 2, Score: 0.9, Reasons: []string{"Testing"}},
+	})
+	require.NoError(t, err)
+	resp, err = store.GetMergeCandidates(ctx, db.GetMergeCandidatesOpts{Type: db.ItemTypeArtist})
+	require.NoError(t, err)
+	assert.Len(t, resp.Items, 0, "expected dismissed candidate to not be returned")
+	count, err := store.Count(ctx, `SELECT COUNT(*) FROM merge_candidates`)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "expected pending candidates to be replaced")
+
+	// candidates for items that no longer exist are not returned
+	err = store.SaveMergeCandidates(ctx, db.ItemTypeTrack, []db.SaveMergeCandidateOpts{
+		{FromID: 4, ToID: 5, Score: 0.9},
+	})
+	require.NoError(t, err)
+	require.NoError(t, store.MergeTracks(ctx, 4, 5))
+	resp, err = store.GetMergeCandidates(ctx, db.GetMergeCandidatesOpts{Type: db.ItemTypeTrack})
+	require.NoError(t, err)
+	assert.Len(t, resp.Items, 0)
+	assert.EqualValues(t, 0, resp.TotalCount)
+
+	// deleting a candidate
+	err = store.SaveMergeCandidates(ctx, db.ItemTypeAlbum, []db.SaveMergeCandidateOpts{
+		{FromID: 4, ToID: 3, Score: 0.9},
+	})
+	require.NoError(t, err)
+	resp, err = store.GetMergeCandidates(ctx, db.GetMergeCandidatesOpts{Type: db.ItemTypeAlbum})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 1)
+	candidate, err := store.GetMergeCandidate(ctx, resp.Items[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "album", candidate.Type)
+	assert.EqualValues(t, 4, candidate.FromID)
+	require.NoError(t, store.DeleteMergeCandidate(ctx, candidate.ID))
+	_, err = store.GetMergeCandidate(ctx, candidate.ID)
+	assert.Error(t, err)
+
+	truncateTestData(t)
+}
@@ -0,0 +1,165 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/repository"
+	"github.com/google/uuid"
+)
+
+// PreviewDelete returns what deleting an artist, album or track would change, by deleting it in a
+// transaction that is rolled back. Albums that are deleted for having no artists left are included.
+// Returns pgx.ErrNoRows if the item does not exist.
+func (d *Psql) PreviewDelete(ctx context.Context, t db.ItemType, id int32) (*db.ImpactPreview, error) {
+	var ret *db.ImpactPreview
+	err := d.inTx(ctx, false, func(tx *Psql) error {
+		var err error
+		ret, err = tx.previewImpact(ctx, func() error {
+			switch t {
+			case db.ItemTypeArtist:
+				return tx.DeleteArtist(ctx, id)
+			case db.ItemTypeAlbum:
+				return tx.DeleteAlbum(ctx, id)
+			case db.ItemTypeTrack:
+				return tx.DeleteTrack(ctx, id)
+			}
+			return fmt.Errorf("unknown item type '%s'", t)
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("PreviewDelete: %w", err)
+	}
+	return ret, nil
+}
+
+// PreviewMerge returns what merging an artist, album or track into another would change, by merging
+// them in a transaction that is rolled back. Returns pgx.ErrNoRows if either item does not exist.
+func (d *Psql) PreviewMerge(ctx context.Context, t db.ItemType, fromId, toId int32, replaceImage bool) (*db.ImpactPreview, error) {
+	var ret *db.ImpactPreview
+	err := d.inTx(ctx, false, func(tx *Psql) error {
+		for _, id := range []int32{fromId, toId} {
+			if err := tx.itemExists(ctx, t, id); err != nil {
+				return err
+			}
+		}
+		// the listens of the merged item are moved rather than deleted, so they are counted up front
+		var moved int64
+		var err error
+		allTime := time.Unix(0, 0)
+		switch t {
+		case db.ItemTypeArtist:
+			moved, err = tx.q.CountListensFromArtist(ctx, repository.CountListensFromArtistParams{
+				ListenedAt:    allTime,
+				ListenedAt_2:  time.Now(),
+				ArtistID:      fromId,
+				IncludeHidden: true,
+			})
+		case db.ItemTypeAlbum:
+			moved, err = tx.q.CountListensFromRelease(ctx, repository.CountListensFromReleaseParams{
+				ListenedAt:    allTime,
+				ListenedAt_2:  time.Now(),
+				ReleaseID:     fromId,
+				IncludeHidden: true,
+			})
+		case db.ItemTypeTrack:
+			moved, err = tx.q.CountListensFromTrack(ctx, repository.CountListensFromTrackParams{
+				ListenedAt:    allTime,
+				ListenedAt_2:  time.Now(),
+				TrackID:       fromId,
+				IncludeHidden: true,
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("count listens from %s: %w", t, err)
+		}
+
+		ret, err = tx.previewImpact(ctx, func() error {
+			switch t {
+			case db.ItemTypeArtist:
+				return tx.MergeArtists(ctx, fromId, toId, replaceImage)
+			case db.ItemTypeAlbum:
+				return tx.MergeAlbums(ctx, fromId, toId, replaceImage)
+			default:
+				return tx.MergeTracks(ctx, fromId, toId)
+			}
+		})
+		if err != nil {
+			return err
+		}
+		ret.ListenCount = moved
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("PreviewMerge: %w", err)
+	}
+	return ret, nil
+}
+
+// itemExists returns pgx.ErrNoRows if the artist, album or track does not exist
+func (d *Psql) itemExists(ctx context.Context, t db.ItemType, id int32) error {
+	var err error
+	switch t {
+	case db.ItemTypeArtist:
+		_, err = d.q.GetArtist(ctx, id)
+	case db.ItemTypeAlbum:
+		_, err = d.q.GetRelease(ctx, id)
+	case db.ItemTypeTrack:
+		_, err = d.q.GetTrack(ctx, id)
+	default:
+		return fmt.Errorf("unknown item type '%s'", t)
+	}
+	if err != nil {
+		return fmt.Errorf("%s %d: %w", t, id, err)
+	}
+	return nil
+}
+
+// previewImpact runs change and compares the catalog before and after it. Only meant to be called on a
+// Psql bound to a transaction that is rolled back afterwards.
+func (d *Psql) previewImpact(ctx context.Context, change func() error) (*db.ImpactPreview, error) {
+	before, err := d.q.CountCatalogItems(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("previewImpact: CountCatalogItems: %w", err)
+	}
+	imagesBefore, err := d.q.GetUsedImages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("previewImpact: GetUsedImages: %w", err)
+	}
+
+	err = change()
+	if err != nil {
+		return nil, fmt.Errorf("previewImpact: %w", err)
+	}
+
+	after, err := d.q.CountCatalogItems(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("previewImpact: CountCatalogItems: %w", err)
+	}
+	imagesAfter, err := d.q.GetUsedImages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("previewImpact: GetUsedImages: %w", err)
+	}
+
+	used := make(map[uuid.UUID]bool, len(imagesAfter))
+	for _, image := range imagesAfter {
+		used[*image] = true
+	}
+	orphaned := []uuid.UUID{}
+	for _, image := range imagesBefore {
+		if !used[*image] {
+			orphaned = append(orphaned, *image)
+		}
+	}
+
+	return &db.ImpactPreview{
+		ListenCount:    before.ListenCount - after.ListenCount,
+		TrackCount:     before.TrackCount - after.TrackCount,
+		AlbumCount:     before.AlbumCount - after.AlbumCount,
+		ArtistCount:    before.ArtistCount - after.ArtistCount,
+		OrphanedImages: orphaned,
+	}, nil
+}
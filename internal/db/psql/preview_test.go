@@ -0,0 +1,92 @@
+package psql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviewDelete(t *testing.T) {
+	testDataForTopItems(t)
+	ctx := context.Background()
+
+	image := uuid.MustParse("00000000-0000-0000-0000-0000000000aa")
+	require.NoError(t, store.Exec(ctx, `UPDATE artists SET image = $1 WHERE id = 1`, image))
+
+	preview, err := store.PreviewDelete(ctx, db.ItemTypeArtist, 1)
+	require.NoError(t, err)
+	// the album of the artist is deleted for having no artists left
+	assert.Equal(t, &db.ImpactPreview{
+		ListenCount:    4,
+		TrackCount:     1,
+		AlbumCount:     1,
+		ArtistCount:    1,
+		OrphanedImages: []uuid.UUID{image},
+	}, preview)
+
+	// nothing was deleted
+	_, err = store.GetArtist(ctx, db.GetArtistOpts{ID: 1})
+	require.NoError(t, err)
+	count, err := store.CountListens(ctx, db.PeriodAllTime, db.CountOpts{IncludeHidden: true})
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, count)
+	trash, err := store.GetTrash(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, trash)
+
+	preview, err = store.PreviewDelete(ctx, db.ItemTypeTrack, 2)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, preview.ListenCount)
+	assert.EqualValues(t, 1, preview.TrackCount)
+	assert.EqualValues(t, 0, preview.AlbumCount)
+	assert.Empty(t, preview.OrphanedImages)
+
+	_, err = store.PreviewDelete(ctx, db.ItemTypeAlbum, 999)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestPreviewDelete_ImageInTrash(t *testing.T) {
+	testDataForTopItems(t)
+	ctx := context.Background()
+
+	image := uuid.MustParse("00000000-0000-0000-0000-0000000000aa")
+	require.NoError(t, store.Exec(ctx, `UPDATE artists SET image = $1 WHERE id IN (1, 2)`, image))
+	require.NoError(t, store.DeleteArtist(ctx, 2))
+
+	// the image is kept for the artist in the trash, so deleting the other artist leaves it in use
+	preview, err := store.PreviewDelete(ctx, db.ItemTypeArtist, 1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, preview.ArtistCount)
+	assert.Empty(t, preview.OrphanedImages)
+}
+
+func TestPreviewMerge(t *testing.T) {
+	testDataForTopItems(t)
+	ctx := context.Background()
+
+	preview, err := store.PreviewMerge(ctx, db.ItemTypeTrack, 1, 2, false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, preview.ListenCount)
+	assert.EqualValues(t, 1, preview.TrackCount)
+	assert.EqualValues(t, 0, preview.ArtistCount)
+
+	preview, err = store.PreviewMerge(ctx, db.ItemTypeArtist, 3, 4, false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, preview.ListenCount)
+	assert.EqualValues(t, 1, preview.ArtistCount)
+
+	// nothing was merged
+	count, err := store.Count(ctx, `SELECT COUNT(*) FROM listens WHERE track_id = 1`)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, count)
+	_, err = store.GetArtist(ctx, db.GetArtistOpts{ID: 3})
+	require.NoError(t, err)
+
+	_, err = store.PreviewMerge(ctx, db.ItemTypeArtist, 1, 999, false)
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
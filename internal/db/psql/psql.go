@@ -12,6 +12,7 @@ import (
 	"github.com/gabehf/koito/internal/db"
 	"github.com/gabehf/koito/internal/repository"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -24,7 +25,16 @@ const (
 
 type Psql struct {
 	q    *repository.Queries
-	conn *pgxpool.Pool
+	conn conn
+	pool *pgxpool.Pool
+}
+
+// conn is the pool, or the transaction a Psql is bound to by inTx. Transactions begun on a
+// transaction are savepoints, so every method of a bound Psql runs inside its transaction.
+type conn interface {
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 }
 
 func New() (*Psql, error) {
@@ -63,6 +73,7 @@ func New() (*Psql, error) {
 	return &Psql{
 		q:    repository.New(pool),
 		conn: pool,
+		pool: pool,
 	}, nil
 }
 
@@ -90,11 +101,49 @@ func (p *Psql) QueryRow(ctx context.Context, query string, args ...any) pgx.Row
 }
 
 func (d *Psql) Close(ctx context.Context) {
-	d.conn.Close()
+	d.pool.Close()
 }
 
 func (d *Psql) Ping(ctx context.Context) error {
-	return d.conn.Ping(ctx)
+	return d.pool.Ping(ctx)
+}
+
+// RunInTx runs fn with a store bound to a single transaction, which is committed if fn succeeds.
+func (d *Psql) RunInTx(ctx context.Context, fn func(tx db.DB) error) error {
+	return d.inTx(ctx, true, func(tx *Psql) error {
+		return fn(tx)
+	})
+}
+
+// txConn begins savepoints on a transaction in place of transactions
+type txConn struct {
+	pgx.Tx
+}
+
+func (c txConn) BeginTx(ctx context.Context, _ pgx.TxOptions) (pgx.Tx, error) {
+	return c.Begin(ctx)
+}
+
+// inTx runs fn with a copy of the Psql that is bound to a single transaction. The transaction is
+// committed if fn succeeds and commit is set, and rolled back otherwise.
+func (d *Psql) inTx(ctx context.Context, commit bool, fn func(tx *Psql) error) error {
+	tx, err := d.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("inTx: BeginTx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	err = fn(&Psql{
+		q:    d.q.WithTx(tx),
+		conn: txConn{tx},
+		pool: d.pool,
+	})
+	if err != nil {
+		return err
+	}
+	if !commit {
+		return nil
+	}
+	return tx.Commit(ctx)
 }
 
 func stepToInterval(p db.StepInterval) pgtype.Interval {
@@ -0,0 +1,59 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/repository"
+)
+
+// GetItemsWithoutRomanizedAlias returns items with non-ASCII names that do not have a romanized alias yet,
+// ordered by id. Names in Latin script with accents are included, so the caller must decide what to romanize.
+func (d *Psql) GetItemsWithoutRomanizedAlias(ctx context.Context, opts db.GetItemsWithoutRomanizedAliasOpts) ([]db.NamedItem, error) {
+	l := logger.FromContext(ctx)
+	if opts.Limit == 0 {
+		opts.Limit = DefaultItemsPerPage
+	}
+	l.Debug().Msgf("Fetching %d %ss without romanized aliases after id %d", opts.Limit, opts.Type, opts.AfterID)
+	var ret []db.NamedItem
+	switch opts.Type {
+	case db.ItemTypeArtist:
+		rows, err := d.q.GetArtistsWithoutRomanizedAlias(ctx, repository.GetArtistsWithoutRomanizedAliasParams{
+			ID:    opts.AfterID,
+			Limit: int32(opts.Limit),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetItemsWithoutRomanizedAlias: GetArtistsWithoutRomanizedAlias: %w", err)
+		}
+		for _, row := range rows {
+			ret = append(ret, db.NamedItem{ID: row.ID, Name: row.Name})
+		}
+	case db.ItemTypeAlbum:
+		rows, err := d.q.GetReleasesWithoutRomanizedAlias(ctx, repository.GetReleasesWithoutRomanizedAliasParams{
+			ID:    opts.AfterID,
+			Limit: int32(opts.Limit),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetItemsWithoutRomanizedAlias: GetReleasesWithoutRomanizedAlias: %w", err)
+		}
+		for _, row := range rows {
+			ret = append(ret, db.NamedItem{ID: row.ID, Name: row.Title})
+		}
+	case db.ItemTypeTrack:
+		rows, err := d.q.GetTracksWithoutRomanizedAlias(ctx, repository.GetTracksWithoutRomanizedAliasParams{
+			ID:    opts.AfterID,
+			Limit: int32(opts.Limit),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetItemsWithoutRomanizedAlias: GetTracksWithoutRomanizedAlias: %w", err)
+		}
+		for _, row := range rows {
+			ret = append(ret, db.NamedItem{ID: row.ID, Name: row.Title})
+		}
+	default:
+		return nil, fmt.Errorf("GetItemsWithoutRomanizedAlias: unknown item type '%s'", opts.Type)
+	}
+	return ret, nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/gabehf/koito/internal/db"
 	"github.com/gabehf/koito/internal/models"
 	"github.com/gabehf/koito/internal/repository"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -13,11 +14,20 @@ import (
 const searchItemLimit = 8
 const substringSearchLength = 6
 
-func (d *Psql) SearchArtists(ctx context.Context, q string) ([]*models.Artist, error) {
+// searchOpts returns the options given to a search, which are optional
+func searchOpts(opts []db.SearchOpts) db.SearchOpts {
+	if len(opts) > 0 {
+		return opts[0]
+	}
+	return db.SearchOpts{}
+}
+
+func (d *Psql) SearchArtists(ctx context.Context, q string, opts ...db.SearchOpts) ([]*models.Artist, error) {
 	if len(q) < substringSearchLength {
 		rows, err := d.q.SearchArtistsBySubstring(ctx, repository.SearchArtistsBySubstringParams{
-			Column1: pgtype.Text{String: q, Valid: true},
-			Limit:   searchItemLimit,
+			Column1:       pgtype.Text{String: q, Valid: true},
+			Limit:         searchItemLimit,
+			IncludeHidden: searchOpts(opts).IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("SearchArtist: SearchArtistsBySubstring: %w", err)
@@ -34,8 +44,9 @@ func (d *Psql) SearchArtists(ctx context.Context, q string) ([]*models.Artist, e
 		return ret, nil
 	} else {
 		rows, err := d.q.SearchArtists(ctx, repository.SearchArtistsParams{
-			Similarity: q,
-			Limit:      searchItemLimit,
+			Similarity:    q,
+			Limit:         searchItemLimit,
+			IncludeHidden: searchOpts(opts).IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("SearchArtist: SearchArtists: %w", err)
@@ -53,11 +64,12 @@ func (d *Psql) SearchArtists(ctx context.Context, q string) ([]*models.Artist, e
 	}
 }
 
-func (d *Psql) SearchAlbums(ctx context.Context, q string) ([]*models.Album, error) {
+func (d *Psql) SearchAlbums(ctx context.Context, q string, opts ...db.SearchOpts) ([]*models.Album, error) {
 	if len(q) < substringSearchLength {
 		rows, err := d.q.SearchReleasesBySubstring(ctx, repository.SearchReleasesBySubstringParams{
-			Column1: pgtype.Text{String: q, Valid: true},
-			Limit:   searchItemLimit,
+			Column1:       pgtype.Text{String: q, Valid: true},
+			Limit:         searchItemLimit,
+			IncludeHidden: searchOpts(opts).IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("SearchAlbums: SearchReleasesBySubstring: %w", err)
@@ -79,8 +91,9 @@ func (d *Psql) SearchAlbums(ctx context.Context, q string) ([]*models.Album, err
 		return ret, nil
 	} else {
 		rows, err := d.q.SearchReleases(ctx, repository.SearchReleasesParams{
-			Similarity: q,
-			Limit:      searchItemLimit,
+			Similarity:    q,
+			Limit:         searchItemLimit,
+			IncludeHidden: searchOpts(opts).IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("SearchAlbums: SearchReleases: %w", err)
@@ -103,11 +116,12 @@ func (d *Psql) SearchAlbums(ctx context.Context, q string) ([]*models.Album, err
 	}
 }
 
-func (d *Psql) SearchTracks(ctx context.Context, q string) ([]*models.Track, error) {
+func (d *Psql) SearchTracks(ctx context.Context, q string, opts ...db.SearchOpts) ([]*models.Track, error) {
 	if len(q) < substringSearchLength {
 		rows, err := d.q.SearchTracksBySubstring(ctx, repository.SearchTracksBySubstringParams{
-			Column1: pgtype.Text{String: q, Valid: true},
-			Limit:   searchItemLimit,
+			Column1:       pgtype.Text{String: q, Valid: true},
+			Limit:         searchItemLimit,
+			IncludeHidden: searchOpts(opts).IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("SearchTracks: SearchTracksBySubstring: %w", err)
@@ -128,8 +142,9 @@ func (d *Psql) SearchTracks(ctx context.Context, q string) ([]*models.Track, err
 		return ret, nil
 	} else {
 		rows, err := d.q.SearchTracks(ctx, repository.SearchTracksParams{
-			Similarity: q,
-			Limit:      searchItemLimit,
+			Similarity:    q,
+			Limit:         searchItemLimit,
+			IncludeHidden: searchOpts(opts).IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("SearchTracks: SearchTracks: %w", err)
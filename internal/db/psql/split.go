@@ -0,0 +1,335 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/models"
+	"github.com/gabehf/koito/internal/repository"
+	"github.com/jackc/pgx/v5"
+)
+
+// SplitArtist moves the artist credit for the given tracks from one artist to another,
+// creating the receiving artist if needed. Album credits follow the tracks.
+func (d *Psql) SplitArtist(ctx context.Context, opts db.SplitArtistOpts) (*models.Artist, error) {
+	l := logger.FromContext(ctx)
+	opts.NewName = strings.TrimSpace(opts.NewName)
+	if opts.FromID == 0 {
+		return nil, errors.New("SplitArtist: from id not specified")
+	}
+	if len(opts.TrackIDs) < 1 {
+		return nil, errors.New("SplitArtist: no tracks specified")
+	}
+	if opts.ToID == 0 && opts.NewName == "" {
+		return nil, errors.New("SplitArtist: one of to id or new name must be specified")
+	}
+	if opts.ToID == opts.FromID {
+		return nil, errors.New("SplitArtist: cannot split an artist into itself")
+	}
+	l.Info().Msgf("Splitting %d tracks from artist %d", len(opts.TrackIDs), opts.FromID)
+	tx, err := d.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		l.Err(err).Msg("Failed to begin transaction")
+		return nil, fmt.Errorf("SplitArtist: BeginTx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := d.q.WithTx(tx)
+
+	_, err = qtx.GetArtist(ctx, opts.FromID)
+	if err != nil {
+		return nil, fmt.Errorf("SplitArtist: GetArtist: %w", err)
+	}
+
+	toId := opts.ToID
+	if toId == 0 {
+		l.Debug().Msgf("Creating new artist '%s' to split tracks into", opts.NewName)
+		a, err := qtx.InsertArtist(ctx, repository.InsertArtistParams{})
+		if err != nil {
+			return nil, fmt.Errorf("SplitArtist: InsertArtist: %w", err)
+		}
+		toId = a.ID
+		err = qtx.InsertArtistAlias(ctx, repository.InsertArtistAliasParams{
+			ArtistID:  toId,
+			Alias:     opts.NewName,
+			Source:    "Canonical",
+			IsPrimary: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("SplitArtist: InsertArtistAlias: %w", err)
+		}
+		// if the new name was an alias of the original artist, it belongs to the new artist now,
+		// otherwise future listens would keep being matched to the original
+		err = qtx.DeleteArtistAlias(ctx, repository.DeleteArtistAliasParams{
+			ArtistID: opts.FromID,
+			Alias:    opts.NewName,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("SplitArtist: DeleteArtistAlias: %w", err)
+		}
+	} else {
+		_, err = qtx.GetArtist(ctx, toId)
+		if err != nil {
+			return nil, fmt.Errorf("SplitArtist: GetArtist: %w", err)
+		}
+	}
+
+	releases := make([]int32, 0)
+	for _, trackId := range opts.TrackIDs {
+		track, err := qtx.GetTrack(ctx, trackId)
+		if err != nil {
+			return nil, fmt.Errorf("SplitArtist: GetTrack: %w", err)
+		}
+		artists, err := qtx.GetTrackArtists(ctx, trackId)
+		if err != nil {
+			return nil, fmt.Errorf("SplitArtist: GetTrackArtists: %w", err)
+		}
+		var found, primary bool
+		for _, a := range artists {
+			if a.ID == opts.FromID {
+				found = true
+				primary = a.IsPrimary.Valid && a.IsPrimary.Bool
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("SplitArtist: track %d is not associated with artist %d", trackId, opts.FromID)
+		}
+		err = qtx.AssociateArtistToTrack(ctx, repository.AssociateArtistToTrackParams{
+			ArtistID: toId,
+			TrackID:  trackId,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("SplitArtist: AssociateArtistToTrack: %w", err)
+		}
+		if primary {
+			err = qtx.UpdateTrackPrimaryArtist(ctx, repository.UpdateTrackPrimaryArtistParams{
+				ArtistID:  toId,
+				TrackID:   trackId,
+				IsPrimary: true,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("SplitArtist: UpdateTrackPrimaryArtist: %w", err)
+			}
+		}
+		err = qtx.DeleteArtistTrack(ctx, repository.DeleteArtistTrackParams{
+			ArtistID: opts.FromID,
+			TrackID:  trackId,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("SplitArtist: DeleteArtistTrack: %w", err)
+		}
+		if !slices.Contains(releases, track.ReleaseID) {
+			releases = append(releases, track.ReleaseID)
+		}
+	}
+
+	for _, releaseId := range releases {
+		err = qtx.AssociateArtistToRelease(ctx, repository.AssociateArtistToReleaseParams{
+			ArtistID:      toId,
+			ReleaseID:     releaseId,
+			IsAlbumArtist: false,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("SplitArtist: AssociateArtistToRelease: %w", err)
+		}
+		remaining, err := qtx.CountArtistTracksInRelease(ctx, repository.CountArtistTracksInReleaseParams{
+			ArtistID:  opts.FromID,
+			ReleaseID: releaseId,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("SplitArtist: CountArtistTracksInRelease: %w", err)
+		}
+		if remaining > 0 {
+			continue
+		}
+		// the original artist no longer has any tracks on this release, so the album credit
+		// (and primary and album artist status, if any) is handed over to the receiving artist
+		releaseArtists, err := qtx.GetReleaseArtists(ctx, releaseId)
+		if err != nil {
+			return nil, fmt.Errorf("SplitArtist: GetReleaseArtists: %w", err)
+		}
+		for _, a := range releaseArtists {
+			if a.ID == opts.FromID && a.IsAlbumArtist.Valid && a.IsAlbumArtist.Bool {
+				err = qtx.AssociateArtistToRelease(ctx, repository.AssociateArtistToReleaseParams{
+					ArtistID:      toId,
+					ReleaseID:     releaseId,
+					IsAlbumArtist: true,
+				})
+				if err != nil {
+					return nil, fmt.Errorf("SplitArtist: AssociateArtistToRelease: %w", err)
+				}
+			}
+			if a.ID == opts.FromID && a.IsPrimary.Valid && a.IsPrimary.Bool {
+				err = qtx.UpdateReleasePrimaryArtist(ctx, repository.UpdateReleasePrimaryArtistParams{
+					ArtistID:  toId,
+					ReleaseID: releaseId,
+					IsPrimary: true,
+				})
+				if err != nil {
+					return nil, fmt.Errorf("SplitArtist: UpdateReleasePrimaryArtist: %w", err)
+				}
+			}
+		}
+		err = qtx.DeleteArtistRelease(ctx, repository.DeleteArtistReleaseParams{
+			ArtistID:  opts.FromID,
+			ReleaseID: releaseId,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("SplitArtist: DeleteArtistRelease: %w", err)
+		}
+	}
+
+	err = qtx.CleanOrphanedEntries(ctx)
+	if err != nil {
+		l.Err(err).Msg("Failed to clean orphaned entries")
+		return nil, fmt.Errorf("SplitArtist: CleanOrphanedEntries: %w", err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("SplitArtist: Commit: %w", err)
+	}
+	return d.GetArtist(ctx, db.GetArtistOpts{ID: toId})
+}
+
+// SplitAlbum moves the given tracks, along with their listens, from one album to another,
+// creating the receiving album with the same artists if needed.
+func (d *Psql) SplitAlbum(ctx context.Context, opts db.SplitAlbumOpts) (*models.Album, error) {
+	l := logger.FromContext(ctx)
+	opts.NewTitle = strings.TrimSpace(opts.NewTitle)
+	if opts.FromID == 0 {
+		return nil, errors.New("SplitAlbum: from id not specified")
+	}
+	if len(opts.TrackIDs) < 1 {
+		return nil, errors.New("SplitAlbum: no tracks specified")
+	}
+	if opts.ToID == 0 && opts.NewTitle == "" {
+		return nil, errors.New("SplitAlbum: one of to id or new title must be specified")
+	}
+	if opts.ToID == opts.FromID {
+		return nil, errors.New("SplitAlbum: cannot split an album into itself")
+	}
+	l.Info().Msgf("Splitting %d tracks from album %d", len(opts.TrackIDs), opts.FromID)
+	tx, err := d.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		l.Err(err).Msg("Failed to begin transaction")
+		return nil, fmt.Errorf("SplitAlbum: BeginTx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := d.q.WithTx(tx)
+
+	from, err := qtx.GetRelease(ctx, opts.FromID)
+	if err != nil {
+		return nil, fmt.Errorf("SplitAlbum: GetRelease: %w", err)
+	}
+
+	toId := opts.ToID
+	if toId == 0 {
+		l.Debug().Msgf("Creating new album '%s' to split tracks into", opts.NewTitle)
+		// the image is left empty, as there is no way to know it is correct for the new album
+		r, err := qtx.InsertRelease(ctx, repository.InsertReleaseParams{
+			VariousArtists: from.VariousArtists,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("SplitAlbum: InsertRelease: %w", err)
+		}
+		toId = r.ID
+		err = qtx.InsertReleaseAlias(ctx, repository.InsertReleaseAliasParams{
+			ReleaseID: toId,
+			Alias:     opts.NewTitle,
+			Source:    "Canonical",
+			IsPrimary: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("SplitAlbum: InsertReleaseAlias: %w", err)
+		}
+		err = qtx.DeleteReleaseAlias(ctx, repository.DeleteReleaseAliasParams{
+			ReleaseID: opts.FromID,
+			Alias:     opts.NewTitle,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("SplitAlbum: DeleteReleaseAlias: %w", err)
+		}
+		fromArtists, err := qtx.GetReleaseArtists(ctx, opts.FromID)
+		if err != nil {
+			return nil, fmt.Errorf("SplitAlbum: GetReleaseArtists: %w", err)
+		}
+		for _, a := range fromArtists {
+			err = qtx.AssociateArtistToRelease(ctx, repository.AssociateArtistToReleaseParams{
+				ArtistID:      a.ID,
+				ReleaseID:     toId,
+				IsAlbumArtist: a.IsAlbumArtist.Valid && a.IsAlbumArtist.Bool,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("SplitAlbum: AssociateArtistToRelease: %w", err)
+			}
+			if a.IsPrimary.Valid && a.IsPrimary.Bool {
+				err = qtx.UpdateReleasePrimaryArtist(ctx, repository.UpdateReleasePrimaryArtistParams{
+					ArtistID:  a.ID,
+					ReleaseID: toId,
+					IsPrimary: true,
+				})
+				if err != nil {
+					return nil, fmt.Errorf("SplitAlbum: UpdateReleasePrimaryArtist: %w", err)
+				}
+			}
+		}
+	} else {
+		_, err = qtx.GetRelease(ctx, toId)
+		if err != nil {
+			return nil, fmt.Errorf("SplitAlbum: GetRelease: %w", err)
+		}
+	}
+
+	for _, trackId := range opts.TrackIDs {
+		track, err := qtx.GetTrack(ctx, trackId)
+		if err != nil {
+			return nil, fmt.Errorf("SplitAlbum: GetTrack: %w", err)
+		}
+		if track.ReleaseID != opts.FromID {
+			return nil, fmt.Errorf("SplitAlbum: track %d is not on album %d", trackId, opts.FromID)
+		}
+		err = qtx.UpdateTrackRelease(ctx, repository.UpdateTrackReleaseParams{
+			ID:        trackId,
+			ReleaseID: toId,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("SplitAlbum: UpdateTrackRelease: %w", err)
+		}
+		// same as when merging tracks, the track artists should be credited on the album they moved to
+		artists, err := qtx.GetTrackArtists(ctx, trackId)
+		if err != nil {
+			return nil, fmt.Errorf("SplitAlbum: GetTrackArtists: %w", err)
+		}
+		for _, a := range artists {
+			err = qtx.AssociateArtistToRelease(ctx, repository.AssociateArtistToReleaseParams{
+				ArtistID:      a.ID,
+				ReleaseID:     toId,
+				IsAlbumArtist: false,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("SplitAlbum: AssociateArtistToRelease: %w", err)
+			}
+		}
+	}
+
+	err = qtx.CleanOrphanedEntries(ctx)
+	if err != nil {
+		l.Err(err).Msg("Failed to clean orphaned entries")
+		return nil, fmt.Errorf("SplitAlbum: CleanOrphanedEntries: %w", err)
+	}
+	for _, id := range []int32{opts.FromID, toId} {
+		err = qtx.LinkReleaseTracks(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("SplitAlbum: LinkReleaseTracks: %w", err)
+		}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("SplitAlbum: Commit: %w", err)
+	}
+	return d.GetAlbum(ctx, db.GetAlbumOpts{ID: toId})
+}
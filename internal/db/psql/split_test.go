@@ -0,0 +1,202 @@
+package psql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitArtist_NewArtist(t *testing.T) {
+	ctx := context.Background()
+	setupTestDataForMerge(t)
+
+	// Split Track Four out of Artist One
+	artist, err := store.SplitArtist(ctx, db.SplitArtistOpts{
+		FromID:   1,
+		NewName:  "Artist Three",
+		TrackIDs: []int32{4},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Artist Three", artist.Name)
+	assert.Nil(t, artist.Image, "expected new artist to not inherit image")
+
+	// Verify track association was moved
+	exists, err := store.RowExists(ctx, `
+	SELECT EXISTS (
+		SELECT 1 FROM artist_tracks
+		WHERE artist_id = $1 AND track_id = $2
+	)`, artist.ID, 4)
+	require.NoError(t, err)
+	assert.True(t, exists, "expected track to be associated with new artist")
+
+	count, err := store.Count(ctx, `SELECT COUNT(*) FROM artist_tracks WHERE artist_id = 1`)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count, "expected original artist to keep remaining tracks")
+
+	// Album Three only had Track Four, so it belongs to the new artist now
+	exists, err = store.RowExists(ctx, `
+	SELECT EXISTS (
+		SELECT 1 FROM artist_releases
+		WHERE artist_id = $1 AND release_id = $2
+	)`, 1, 3)
+	require.NoError(t, err)
+	assert.False(t, exists, "expected original artist to be removed from album")
+	exists, err = store.RowExists(ctx, `
+	SELECT EXISTS (
+		SELECT 1 FROM artist_releases
+		WHERE artist_id = $1 AND release_id = $2
+	)`, artist.ID, 3)
+	require.NoError(t, err)
+	assert.True(t, exists, "expected new artist to be associated with album")
+
+	// Listens are untouched
+	count, err = store.Count(ctx, `SELECT COUNT(*) FROM listens`)
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+
+	truncateTestData(t)
+}
+
+func TestSplitArtist_ExistingArtist(t *testing.T) {
+	ctx := context.Background()
+	setupTestDataForMerge(t)
+
+	// Split Track Three from Artist One into Artist Two
+	artist, err := store.SplitArtist(ctx, db.SplitArtistOpts{
+		FromID:   1,
+		ToID:     2,
+		TrackIDs: []int32{3},
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, artist.ID)
+
+	count, err := store.Count(ctx, `SELECT COUNT(*) FROM artist_tracks WHERE artist_id = 2`)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count, "expected track to be associated with Artist Two")
+
+	// Artist One still has Track One on Album One
+	exists, err := store.RowExists(ctx, `
+	SELECT EXISTS (
+		SELECT 1 FROM artist_releases
+		WHERE artist_id = $1 AND release_id = $2
+	)`, 1, 1)
+	require.NoError(t, err)
+	assert.True(t, exists, "expected original artist to stay on album with remaining tracks")
+	exists, err = store.RowExists(ctx, `
+	SELECT EXISTS (
+		SELECT 1 FROM artist_releases
+		WHERE artist_id = $1 AND release_id = $2
+	)`, 2, 1)
+	require.NoError(t, err)
+	assert.True(t, exists, "expected Artist Two to be associated with album")
+
+	truncateTestData(t)
+}
+
+func TestSplitArtist_Invalid(t *testing.T) {
+	ctx := context.Background()
+	setupTestDataForMerge(t)
+
+	// Track Two is not by Artist One
+	_, err := store.SplitArtist(ctx, db.SplitArtistOpts{
+		FromID:   1,
+		NewName:  "Artist Three",
+		TrackIDs: []int32{4, 2},
+	})
+	require.Error(t, err)
+
+	// Nothing should have changed
+	count, err := store.Count(ctx, `SELECT COUNT(*) FROM artists`)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count, "expected no new artist to be created")
+	count, err = store.Count(ctx, `SELECT COUNT(*) FROM artist_tracks WHERE artist_id = 1`)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	_, err = store.SplitArtist(ctx, db.SplitArtistOpts{
+		FromID:   1,
+		TrackIDs: []int32{4},
+	})
+	assert.Error(t, err, "expected error when neither to id nor name is provided")
+
+	truncateTestData(t)
+}
+
+func TestSplitAlbum_NewAlbum(t *testing.T) {
+	ctx := context.Background()
+	setupTestDataForMerge(t)
+
+	// Split Track Three out of Album One
+	album, err := store.SplitAlbum(ctx, db.SplitAlbumOpts{
+		FromID:   1,
+		NewTitle: "Album One (Deluxe)",
+		TrackIDs: []int32{3},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Album One (Deluxe)", album.Title)
+	assert.Nil(t, album.Image, "expected new album to not inherit image")
+	assert.EqualValues(t, 1, album.ListenCount, "expected listens to move with the track")
+
+	count, err := store.Count(ctx, `SELECT COUNT(*) FROM tracks WHERE release_id = $1`, album.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	count, err = store.Count(ctx, `SELECT COUNT(*) FROM tracks WHERE release_id = 1`)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "expected original album to keep remaining tracks")
+
+	// Album artists are carried over
+	exists, err := store.RowExists(ctx, `
+	SELECT EXISTS (
+		SELECT 1 FROM artist_releases
+		WHERE artist_id = $1 AND release_id = $2
+	)`, 1, album.ID)
+	require.NoError(t, err)
+	assert.True(t, exists, "expected album artist to be associated with new album")
+
+	truncateTestData(t)
+}
+
+func TestSplitAlbum_ExistingAlbum(t *testing.T) {
+	ctx := context.Background()
+	setupTestDataForMerge(t)
+
+	// Move Track Four, the only track on Album Three, to Album Two
+	album, err := store.SplitAlbum(ctx, db.SplitAlbumOpts{
+		FromID:   3,
+		ToID:     2,
+		TrackIDs: []int32{4},
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, album.ID)
+
+	count, err := store.Count(ctx, `SELECT COUNT(*) FROM tracks WHERE release_id = 2`)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// Track artist is credited on the album
+	exists, err := store.RowExists(ctx, `
+	SELECT EXISTS (
+		SELECT 1 FROM artist_releases
+		WHERE artist_id = $1 AND release_id = $2
+	)`, 1, 2)
+	require.NoError(t, err)
+	assert.True(t, exists, "expected track artist to be associated with album")
+
+	// Album Three is now empty
+	exists, err = store.RowExists(ctx, `SELECT EXISTS (SELECT 1 FROM releases WHERE id = $1)`, 3)
+	require.NoError(t, err)
+	assert.False(t, exists, "expected empty album to be removed")
+
+	// Track must be on the source album
+	_, err = store.SplitAlbum(ctx, db.SplitAlbumOpts{
+		FromID:   1,
+		ToID:     2,
+		TrackIDs: []int32{4},
+	})
+	assert.Error(t, err)
+
+	truncateTestData(t)
+}
@@ -0,0 +1,315 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/models"
+	"github.com/gabehf/koito/internal/repository"
+	"github.com/gabehf/koito/internal/utils"
+	"github.com/jackc/pgx/v5"
+)
+
+func (d *Psql) GetTopTagsPaginated(ctx context.Context, opts db.GetItemsOpts) (*db.PaginatedResponse[*models.Tag], error) {
+	l := logger.FromContext(ctx)
+	offset := (opts.Page - 1) * opts.Limit
+	t1, t2, err := utils.DateRange(opts.Week, opts.Month, opts.Year)
+	if err != nil {
+		return nil, fmt.Errorf("GetTopTagsPaginated: %w", err)
+	}
+	if opts.Month == 0 && opts.Year == 0 {
+		// use period, not date range
+		t2 = time.Now()
+		t1 = db.StartTimeFromPeriod(opts.Period)
+	}
+	if opts.Limit == 0 {
+		opts.Limit = DefaultItemsPerPage
+	}
+	l.Debug().Msgf("Fetching top %d tags with period %s on page %d from range %v to %v",
+		opts.Limit, opts.Period, opts.Page, t1.Format("Jan 02, 2006"), t2.Format("Jan 02, 2006"))
+	rows, err := d.q.GetTopTagsPaginated(ctx, repository.GetTopTagsPaginatedParams{
+		ListenedAt:    t1,
+		ListenedAt_2:  t2,
+		Limit:         int32(opts.Limit),
+		Offset:        int32(offset),
+		IncludeHidden: opts.IncludeHidden,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetTopTagsPaginated: GetTopTagsPaginated: %w", err)
+	}
+	tags := make([]*models.Tag, len(rows))
+	for i, row := range rows {
+		tags[i] = &models.Tag{
+			ID:          row.ID,
+			Name:        row.Name,
+			ListenCount: row.ListenCount,
+		}
+	}
+	count, err := d.q.CountTopTags(ctx, repository.CountTopTagsParams{
+		ListenedAt:    t1,
+		ListenedAt_2:  t2,
+		IncludeHidden: opts.IncludeHidden,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetTopTagsPaginated: CountTopTags: %w", err)
+	}
+	l.Debug().Msgf("Database responded with %d tags out of a total %d", len(rows), count)
+
+	return &db.PaginatedResponse[*models.Tag]{
+		Items:        tags,
+		TotalCount:   count,
+		ItemsPerPage: int32(opts.Limit),
+		HasNextPage:  int64(offset+len(tags)) < count,
+		CurrentPage:  int32(opts.Page),
+	}, nil
+}
+
+func (d *Psql) GetArtistTags(ctx context.Context, id int32) ([]models.Tag, error) {
+	rows, err := d.q.GetArtistTags(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("GetArtistTags: %w", err)
+	}
+	tags := make([]models.Tag, len(rows))
+	for i, row := range rows {
+		tags[i] = models.Tag{
+			ID:     row.ID,
+			Name:   row.Name,
+			Source: row.Source,
+		}
+	}
+	return tags, nil
+}
+
+func (d *Psql) GetAlbumTags(ctx context.Context, id int32) ([]models.Tag, error) {
+	rows, err := d.q.GetReleaseTags(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("GetAlbumTags: %w", err)
+	}
+	tags := make([]models.Tag, len(rows))
+	for i, row := range rows {
+		tags[i] = models.Tag{
+			ID:     row.ID,
+			Name:   row.Name,
+			Source: row.Source,
+		}
+	}
+	return tags, nil
+}
+
+func (d *Psql) GetTrackTags(ctx context.Context, id int32) ([]models.Tag, error) {
+	rows, err := d.q.GetTrackTags(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("GetTrackTags: %w", err)
+	}
+	tags := make([]models.Tag, len(rows))
+	for i, row := range rows {
+		tags[i] = models.Tag{
+			ID:     row.ID,
+			Name:   row.Name,
+			Source: row.Source,
+		}
+	}
+	return tags, nil
+}
+
+// saveTags inserts each of the tags and links it to an item with insertFn, in one transaction.
+// Tags are normalized first, and blank tags are skipped.
+func (d *Psql) saveTags(ctx context.Context, tags []string, insertFn func(qtx *repository.Queries, tagID int32) error) error {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = utils.NormalizeTag(tag)
+		if tag != "" {
+			normalized = append(normalized, tag)
+		}
+	}
+	utils.Unique(&normalized)
+	if len(normalized) == 0 {
+		return nil
+	}
+	tx, err := d.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("BeginTx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := d.q.WithTx(tx)
+	for _, tag := range normalized {
+		tagID, err := qtx.InsertTag(ctx, tag)
+		if err != nil {
+			return fmt.Errorf("InsertTag: %w", err)
+		}
+		err = insertFn(qtx, tagID)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (d *Psql) SaveArtistTags(ctx context.Context, id int32, tags []string, source string) error {
+	if id == 0 {
+		return errors.New("SaveArtistTags: artist id not specified")
+	}
+	logger.FromContext(ctx).Debug().Msgf("Saving %d tags for artist %d from source %s", len(tags), id, source)
+	err := d.saveTags(ctx, tags, func(qtx *repository.Queries, tagID int32) error {
+		err := qtx.InsertArtistTag(ctx, repository.InsertArtistTagParams{
+			ArtistID: id,
+			TagID:    tagID,
+			Source:   source,
+		})
+		if err != nil {
+			return fmt.Errorf("InsertArtistTag: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("SaveArtistTags: %w", err)
+	}
+	return nil
+}
+
+func (d *Psql) SaveAlbumTags(ctx context.Context, id int32, tags []string, source string) error {
+	if id == 0 {
+		return errors.New("SaveAlbumTags: album id not specified")
+	}
+	logger.FromContext(ctx).Debug().Msgf("Saving %d tags for album %d from source %s", len(tags), id, source)
+	err := d.saveTags(ctx, tags, func(qtx *repository.Queries, tagID int32) error {
+		err := qtx.InsertReleaseTag(ctx, repository.InsertReleaseTagParams{
+			ReleaseID: id,
+			TagID:     tagID,
+			Source:    source,
+		})
+		if err != nil {
+			return fmt.Errorf("InsertReleaseTag: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("SaveAlbumTags: %w", err)
+	}
+	return nil
+}
+
+func (d *Psql) SaveTrackTags(ctx context.Context, id int32, tags []string, source string) error {
+	if id == 0 {
+		return errors.New("SaveTrackTags: track id not specified")
+	}
+	logger.FromContext(ctx).Debug().Msgf("Saving %d tags for track %d from source %s", len(tags), id, source)
+	err := d.saveTags(ctx, tags, func(qtx *repository.Queries, tagID int32) error {
+		err := qtx.InsertTrackTag(ctx, repository.InsertTrackTagParams{
+			TrackID: id,
+			TagID:   tagID,
+			Source:  source,
+		})
+		if err != nil {
+			return fmt.Errorf("InsertTrackTag: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("SaveTrackTags: %w", err)
+	}
+	return nil
+}
+
+func (d *Psql) DeleteArtistTag(ctx context.Context, id int32, tag string) error {
+	err := d.q.DeleteArtistTag(ctx, repository.DeleteArtistTagParams{
+		ArtistID: id,
+		Name:     utils.NormalizeTag(tag),
+	})
+	if err != nil {
+		return fmt.Errorf("DeleteArtistTag: %w", err)
+	}
+	return d.deleteOrphanedTags(ctx)
+}
+
+func (d *Psql) DeleteAlbumTag(ctx context.Context, id int32, tag string) error {
+	err := d.q.DeleteReleaseTag(ctx, repository.DeleteReleaseTagParams{
+		ReleaseID: id,
+		Name:      utils.NormalizeTag(tag),
+	})
+	if err != nil {
+		return fmt.Errorf("DeleteAlbumTag: %w", err)
+	}
+	return d.deleteOrphanedTags(ctx)
+}
+
+func (d *Psql) DeleteTrackTag(ctx context.Context, id int32, tag string) error {
+	err := d.q.DeleteTrackTag(ctx, repository.DeleteTrackTagParams{
+		TrackID: id,
+		Name:    utils.NormalizeTag(tag),
+	})
+	if err != nil {
+		return fmt.Errorf("DeleteTrackTag: %w", err)
+	}
+	return d.deleteOrphanedTags(ctx)
+}
+
+func (d *Psql) deleteOrphanedTags(ctx context.Context) error {
+	err := d.q.DeleteOrphanedTags(ctx)
+	if err != nil {
+		return fmt.Errorf("DeleteOrphanedTags: %w", err)
+	}
+	return nil
+}
+
+func (d *Psql) GetItemsForMbzTagFetch(ctx context.Context, opts db.GetItemsForMbzTagFetchOpts) ([]db.MbzItem, error) {
+	l := logger.FromContext(ctx)
+	if opts.Limit == 0 {
+		opts.Limit = DefaultItemsPerPage
+	}
+	l.Debug().Msgf("Fetching %d %ss with MusicBrainz tags not fetched since %v", opts.Limit, opts.Type, opts.FetchedAfter)
+	var ret []db.MbzItem
+	switch opts.Type {
+	case db.ItemTypeArtist:
+		rows, err := d.q.GetArtistsForMbzTagFetch(ctx, repository.GetArtistsForMbzTagFetchParams{
+			FetchedAt: opts.FetchedAfter,
+			Limit:     int32(opts.Limit),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetItemsForMbzTagFetch: GetArtistsForMbzTagFetch: %w", err)
+		}
+		for _, row := range rows {
+			ret = append(ret, db.MbzItem{ID: row.ID, MbzID: *row.MusicBrainzID})
+		}
+	case db.ItemTypeAlbum:
+		rows, err := d.q.GetAlbumsForMbzTagFetch(ctx, repository.GetAlbumsForMbzTagFetchParams{
+			FetchedAt: opts.FetchedAfter,
+			Limit:     int32(opts.Limit),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetItemsForMbzTagFetch: GetAlbumsForMbzTagFetch: %w", err)
+		}
+		for _, row := range rows {
+			ret = append(ret, db.MbzItem{ID: row.ID, MbzID: *row.MusicBrainzID})
+		}
+	case db.ItemTypeTrack:
+		rows, err := d.q.GetTracksForMbzTagFetch(ctx, repository.GetTracksForMbzTagFetchParams{
+			FetchedAt: opts.FetchedAfter,
+			Limit:     int32(opts.Limit),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetItemsForMbzTagFetch: GetTracksForMbzTagFetch: %w", err)
+		}
+		for _, row := range rows {
+			ret = append(ret, db.MbzItem{ID: row.ID, MbzID: *row.MusicBrainzID})
+		}
+	default:
+		return nil, fmt.Errorf("GetItemsForMbzTagFetch: unknown item type '%s'", opts.Type)
+	}
+	return ret, nil
+}
+
+func (d *Psql) SaveMbzTagFetch(ctx context.Context, t db.ItemType, id int32) error {
+	err := d.q.UpsertMbzTagFetch(ctx, repository.UpsertMbzTagFetchParams{
+		ItemType: string(t),
+		ItemID:   id,
+	})
+	if err != nil {
+		return fmt.Errorf("SaveMbzTagFetch: %w", err)
+	}
+	return nil
+}
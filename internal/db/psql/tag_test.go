@@ -0,0 +1,122 @@
+package psql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveAndDeleteTags(t *testing.T) {
+	testDataForTopItems(t)
+	ctx := context.Background()
+
+	err := store.SaveArtistTags(ctx, 1, []string{"Rock", " rock ", "J-Pop", ""}, "Testing")
+	require.NoError(t, err)
+	tags, err := store.GetArtistTags(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, tags, 2)
+	assert.Equal(t, "j-pop", tags[0].Name)
+	assert.Equal(t, "rock", tags[1].Name)
+	assert.Equal(t, "Testing", tags[0].Source)
+
+	// tags are shared between items
+	require.NoError(t, store.SaveAlbumTags(ctx, 2, []string{"rock"}, "Testing"))
+	require.NoError(t, store.SaveTrackTags(ctx, 3, []string{"Rock"}, "Testing"))
+	albumTags, err := store.GetAlbumTags(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, albumTags, 1)
+	trackTags, err := store.GetTrackTags(ctx, 3)
+	require.NoError(t, err)
+	require.Len(t, trackTags, 1)
+	assert.Equal(t, tags[1].ID, albumTags[0].ID)
+	assert.Equal(t, tags[1].ID, trackTags[0].ID)
+
+	require.NoError(t, store.DeleteArtistTag(ctx, 1, "J-POP"))
+	tags, err = store.GetArtistTags(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, "rock", tags[0].Name)
+
+	// unused tags are removed
+	count, err := store.Count(ctx, `SELECT COUNT(*) FROM tags`)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	truncateTestData(t)
+}
+
+func TestGetTopTagsPaginated(t *testing.T) {
+	testDataForTopItems(t)
+	ctx := context.Background()
+
+	require.NoError(t, store.SaveArtistTags(ctx, 1, []string{"rock"}, "Testing"))
+	require.NoError(t, store.SaveAlbumTags(ctx, 2, []string{"rock", "pop"}, "Testing"))
+	require.NoError(t, store.SaveTrackTags(ctx, 4, []string{"pop"}, "Testing"))
+	// a tag on both a track and its album only counts each listen once
+	require.NoError(t, store.SaveTrackTags(ctx, 2, []string{"pop"}, "Testing"))
+
+	resp, err := store.GetTopTagsPaginated(ctx, db.GetItemsOpts{Period: db.PeriodAllTime})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 2)
+	assert.EqualValues(t, 2, resp.TotalCount)
+	assert.Equal(t, "rock", resp.Items[0].Name)
+	assert.EqualValues(t, 7, resp.Items[0].ListenCount)
+	assert.Equal(t, "pop", resp.Items[1].Name)
+	assert.EqualValues(t, 4, resp.Items[1].ListenCount)
+
+	resp, err = store.GetTopTagsPaginated(ctx, db.GetItemsOpts{Period: db.PeriodWeek})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, "pop", resp.Items[0].Name)
+
+	// tag filtered charts
+	artists, err := store.GetTopArtistsPaginated(ctx, db.GetItemsOpts{Period: db.PeriodAllTime, Tag: "pop"})
+	require.NoError(t, err)
+	require.Len(t, artists.Items, 2)
+	assert.EqualValues(t, 2, artists.TotalCount)
+	assert.Equal(t, "Artist Two", artists.Items[0].Name)
+	assert.Equal(t, "Artist Four", artists.Items[1].Name)
+
+	albums, err := store.GetTopAlbumsPaginated(ctx, db.GetItemsOpts{Period: db.PeriodAllTime, Tag: "rock"})
+	require.NoError(t, err)
+	require.Len(t, albums.Items, 2)
+	assert.Equal(t, "Release One", albums.Items[0].Title)
+	assert.Equal(t, "Release Two", albums.Items[1].Title)
+
+	tracks, err := store.GetTopTracksPaginated(ctx, db.GetItemsOpts{Period: db.PeriodAllTime, Tag: "pop"})
+	require.NoError(t, err)
+	require.Len(t, tracks.Items, 2)
+	assert.Equal(t, "Track Two", tracks.Items[0].Title)
+	assert.Equal(t, "Track Four", tracks.Items[1].Title)
+
+	truncateTestData(t)
+}
+
+func TestGetItemsForMbzTagFetch(t *testing.T) {
+	testDataForTopItems(t)
+	ctx := context.Background()
+
+	items, err := store.GetItemsForMbzTagFetch(ctx, db.GetItemsForMbzTagFetchOpts{Type: db.ItemTypeArtist})
+	require.NoError(t, err)
+	require.Len(t, items, 4)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000001", items[0].MbzID.String())
+
+	require.NoError(t, store.SaveMbzTagFetch(ctx, db.ItemTypeArtist, 1))
+	items, err = store.GetItemsForMbzTagFetch(ctx, db.GetItemsForMbzTagFetchOpts{
+		Type:         db.ItemTypeArtist,
+		FetchedAfter: time.Now().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.EqualValues(t, 2, items[0].ID)
+
+	items, err = store.GetItemsForMbzTagFetch(ctx, db.GetItemsForMbzTagFetchOpts{Type: db.ItemTypeTrack, Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, items, 2)
+
+	truncateTestData(t)
+}
@@ -32,16 +32,64 @@ func (d *Psql) GetTopAlbumsPaginated(ctx context.Context, opts db.GetItemsOpts)
 	var rgs []*models.Album
 	var count int64
 
-	if opts.ArtistID != 0 {
+	if opts.ArtistID != 0 && opts.GroupEditions {
+		l.Debug().Msgf("Fetching top %d albums with editions grouped from artist id %d with period %s on page %d from range %v to %v",
+			opts.Limit, opts.ArtistID, opts.Period, opts.Page, t1.Format("Jan 02, 2006"), t2.Format("Jan 02, 2006"))
+
+		rows, err := d.q.GetTopReleaseGroupsFromArtist(ctx, repository.GetTopReleaseGroupsFromArtistParams{
+			ArtistID:       int32(opts.ArtistID),
+			IncludeRelated: opts.IncludeRelated,
+			Limit:          int32(opts.Limit),
+			Offset:         int32(offset),
+			ListenedAt:     t1,
+			ListenedAt_2:   t2,
+			IncludeHidden:  opts.IncludeHidden,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetTopAlbumsPaginated: GetTopReleaseGroupsFromArtist: %w", err)
+		}
+		rgs = make([]*models.Album, len(rows))
+		l.Debug().Msgf("Database responded with %d items", len(rows))
+		for i, row := range rows {
+			artists := make([]models.SimpleArtist, 0)
+			err = json.Unmarshal(row.Artists, &artists)
+			if err != nil {
+				l.Err(err).Msgf("Error unmarshalling artists for release group with id %d", row.ID)
+				return nil, fmt.Errorf("GetTopAlbumsPaginated: Unmarshal: %w", err)
+			}
+			rgs[i] = &models.Album{
+				ID:             row.ID,
+				MbzID:          row.MusicBrainzID,
+				Title:          row.Title,
+				Image:          row.Image,
+				Artists:        artists,
+				VariousArtists: row.VariousArtists,
+				ReleaseDate:    row.ReleaseDate.String,
+				ReleaseType:    row.ReleaseType.String,
+				SecondaryTypes: row.SecondaryTypes,
+				ListenCount:    row.ListenCount,
+			}
+		}
+		count, err = d.q.CountReleaseGroupsFromArtist(ctx, repository.CountReleaseGroupsFromArtistParams{
+			ArtistID:       int32(opts.ArtistID),
+			IncludeRelated: opts.IncludeRelated,
+			IncludeHidden:  opts.IncludeHidden,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetTopAlbumsPaginated: CountReleaseGroupsFromArtist: %w", err)
+		}
+	} else if opts.ArtistID != 0 {
 		l.Debug().Msgf("Fetching top %d albums from artist id %d with period %s on page %d from range %v to %v",
 			opts.Limit, opts.ArtistID, opts.Period, opts.Page, t1.Format("Jan 02, 2006"), t2.Format("Jan 02, 2006"))
 
 		rows, err := d.q.GetTopReleasesFromArtist(ctx, repository.GetTopReleasesFromArtistParams{
-			ArtistID:     int32(opts.ArtistID),
-			Limit:        int32(opts.Limit),
-			Offset:       int32(offset),
-			ListenedAt:   t1,
-			ListenedAt_2: t2,
+			ArtistID:       int32(opts.ArtistID),
+			IncludeRelated: opts.IncludeRelated,
+			Limit:          int32(opts.Limit),
+			Offset:         int32(offset),
+			ListenedAt:     t1,
+			ListenedAt_2:   t2,
+			IncludeHidden:  opts.IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("GetTopAlbumsPaginated: GetTopReleasesFromArtist: %w", err)
@@ -62,21 +110,118 @@ func (d *Psql) GetTopAlbumsPaginated(ctx context.Context, opts db.GetItemsOpts)
 				Image:          v.Image,
 				Artists:        artists,
 				VariousArtists: v.VariousArtists,
+				ReleaseDate:    v.ReleaseDate.String,
+				ReleaseType:    v.ReleaseType.String,
+				SecondaryTypes: v.SecondaryTypes,
 				ListenCount:    v.ListenCount,
 			}
 		}
-		count, err = d.q.CountReleasesFromArtist(ctx, int32(opts.ArtistID))
+		count, err = d.q.CountReleasesFromArtist(ctx, repository.CountReleasesFromArtistParams{
+			ArtistID:       int32(opts.ArtistID),
+			IncludeRelated: opts.IncludeRelated,
+			IncludeHidden:  opts.IncludeHidden,
+		})
 		if err != nil {
 			return nil, fmt.Errorf("GetTopAlbumsPaginated: CountReleasesFromArtist: %w", err)
 		}
+	} else if opts.Tag != "" {
+		l.Debug().Msgf("Fetching top %d albums tagged '%s' with period %s on page %d from range %v to %v",
+			opts.Limit, opts.Tag, opts.Period, opts.Page, t1.Format("Jan 02, 2006"), t2.Format("Jan 02, 2006"))
+		rows, err := d.q.GetTopReleasesByTagPaginated(ctx, repository.GetTopReleasesByTagPaginatedParams{
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			Name:          opts.Tag,
+			Limit:         int32(opts.Limit),
+			Offset:        int32(offset),
+			IncludeHidden: opts.IncludeHidden,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetTopAlbumsPaginated: GetTopReleasesByTagPaginated: %w", err)
+		}
+		rgs = make([]*models.Album, len(rows))
+		l.Debug().Msgf("Database responded with %d items", len(rows))
+		for i, row := range rows {
+			artists := make([]models.SimpleArtist, 0)
+			err = json.Unmarshal(row.Artists, &artists)
+			if err != nil {
+				l.Err(err).Msgf("Error unmarshalling artists for release group with id %d", row.ID)
+				return nil, fmt.Errorf("GetTopAlbumsPaginated: Unmarshal: %w", err)
+			}
+			rgs[i] = &models.Album{
+				Title:          row.Title,
+				MbzID:          row.MusicBrainzID,
+				ID:             row.ID,
+				Image:          row.Image,
+				Artists:        artists,
+				VariousArtists: row.VariousArtists,
+				ReleaseDate:    row.ReleaseDate.String,
+				ReleaseType:    row.ReleaseType.String,
+				SecondaryTypes: row.SecondaryTypes,
+				ListenCount:    row.ListenCount,
+			}
+		}
+		count, err = d.q.CountTopReleasesByTag(ctx, repository.CountTopReleasesByTagParams{
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			Name:          opts.Tag,
+			IncludeHidden: opts.IncludeHidden,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetTopAlbumsPaginated: CountTopReleasesByTag: %w", err)
+		}
+	} else if opts.GroupEditions {
+		l.Debug().Msgf("Fetching top %d albums with editions grouped with period %s on page %d from range %v to %v",
+			opts.Limit, opts.Period, opts.Page, t1.Format("Jan 02, 2006"), t2.Format("Jan 02, 2006"))
+		rows, err := d.q.GetTopReleaseGroupsPaginated(ctx, repository.GetTopReleaseGroupsPaginatedParams{
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			Limit:         int32(opts.Limit),
+			Offset:        int32(offset),
+			IncludeHidden: opts.IncludeHidden,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetTopAlbumsPaginated: GetTopReleaseGroupsPaginated: %w", err)
+		}
+		rgs = make([]*models.Album, len(rows))
+		l.Debug().Msgf("Database responded with %d items", len(rows))
+		for i, row := range rows {
+			artists := make([]models.SimpleArtist, 0)
+			err = json.Unmarshal(row.Artists, &artists)
+			if err != nil {
+				l.Err(err).Msgf("Error unmarshalling artists for release group with id %d", row.ID)
+				return nil, fmt.Errorf("GetTopAlbumsPaginated: Unmarshal: %w", err)
+			}
+			rgs[i] = &models.Album{
+				ID:             row.ID,
+				MbzID:          row.MusicBrainzID,
+				Title:          row.Title,
+				Image:          row.Image,
+				Artists:        artists,
+				VariousArtists: row.VariousArtists,
+				ReleaseDate:    row.ReleaseDate.String,
+				ReleaseType:    row.ReleaseType.String,
+				SecondaryTypes: row.SecondaryTypes,
+				ListenCount:    row.ListenCount,
+			}
+		}
+		count, err = d.q.CountTopReleaseGroups(ctx, repository.CountTopReleaseGroupsParams{
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			IncludeHidden: opts.IncludeHidden,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetTopAlbumsPaginated: CountTopReleaseGroups: %w", err)
+		}
+		l.Debug().Msgf("Database responded with %d albums out of a total %d", len(rows), count)
 	} else {
 		l.Debug().Msgf("Fetching top %d albums with period %s on page %d from range %v to %v",
 			opts.Limit, opts.Period, opts.Page, t1.Format("Jan 02, 2006"), t2.Format("Jan 02, 2006"))
 		rows, err := d.q.GetTopReleasesPaginated(ctx, repository.GetTopReleasesPaginatedParams{
-			ListenedAt:   t1,
-			ListenedAt_2: t2,
-			Limit:        int32(opts.Limit),
-			Offset:       int32(offset),
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			Limit:         int32(opts.Limit),
+			Offset:        int32(offset),
+			IncludeHidden: opts.IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("GetTopAlbumsPaginated: GetTopReleasesPaginated: %w", err)
@@ -97,13 +242,17 @@ func (d *Psql) GetTopAlbumsPaginated(ctx context.Context, opts db.GetItemsOpts)
 				Image:          row.Image,
 				Artists:        artists,
 				VariousArtists: row.VariousArtists,
+				ReleaseDate:    row.ReleaseDate.String,
+				ReleaseType:    row.ReleaseType.String,
+				SecondaryTypes: row.SecondaryTypes,
 				ListenCount:    row.ListenCount,
 			}
 			rgs[i] = t
 		}
 		count, err = d.q.CountTopReleases(ctx, repository.CountTopReleasesParams{
-			ListenedAt:   t1,
-			ListenedAt_2: t2,
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			IncludeHidden: opts.IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("GetTopAlbumsPaginated: CountTopReleases: %w", err)
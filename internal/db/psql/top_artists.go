@@ -27,36 +27,76 @@ func (d *Psql) GetTopArtistsPaginated(ctx context.Context, opts db.GetItemsOpts)
 	if opts.Limit == 0 {
 		opts.Limit = DefaultItemsPerPage
 	}
-	l.Debug().Msgf("Fetching top %d artists with period %s on page %d from range %v to %v",
-		opts.Limit, opts.Period, opts.Page, t1.Format("Jan 02, 2006"), t2.Format("Jan 02, 2006"))
-	rows, err := d.q.GetTopArtistsPaginated(ctx, repository.GetTopArtistsPaginatedParams{
-		ListenedAt:   t1,
-		ListenedAt_2: t2,
-		Limit:        int32(opts.Limit),
-		Offset:       int32(offset),
-	})
-	if err != nil {
-		return nil, fmt.Errorf("GetTopArtistsPaginated: GetTopArtistsPaginated: %w", err)
-	}
-	rgs := make([]*models.Artist, len(rows))
-	for i, row := range rows {
-		t := &models.Artist{
-			Name:        row.Name,
-			MbzID:       row.MusicBrainzID,
-			ID:          row.ID,
-			Image:       row.Image,
-			ListenCount: row.ListenCount,
+	var rgs []*models.Artist
+	var count int64
+	if opts.Tag != "" {
+		l.Debug().Msgf("Fetching top %d artists tagged '%s' with period %s on page %d from range %v to %v",
+			opts.Limit, opts.Tag, opts.Period, opts.Page, t1.Format("Jan 02, 2006"), t2.Format("Jan 02, 2006"))
+		rows, err := d.q.GetTopArtistsByTagPaginated(ctx, repository.GetTopArtistsByTagPaginatedParams{
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			Name:          opts.Tag,
+			Limit:         int32(opts.Limit),
+			Offset:        int32(offset),
+			IncludeHidden: opts.IncludeHidden,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetTopArtistsPaginated: GetTopArtistsByTagPaginated: %w", err)
 		}
-		rgs[i] = t
-	}
-	count, err := d.q.CountTopArtists(ctx, repository.CountTopArtistsParams{
-		ListenedAt:   t1,
-		ListenedAt_2: t2,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("GetTopArtistsPaginated: CountTopArtists: %w", err)
+		rgs = make([]*models.Artist, len(rows))
+		for i, row := range rows {
+			rgs[i] = &models.Artist{
+				Name:        row.Name,
+				MbzID:       row.MusicBrainzID,
+				ID:          row.ID,
+				Image:       row.Image,
+				ListenCount: row.ListenCount,
+			}
+		}
+		count, err = d.q.CountTopArtistsByTag(ctx, repository.CountTopArtistsByTagParams{
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			Name:          opts.Tag,
+			IncludeHidden: opts.IncludeHidden,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetTopArtistsPaginated: CountTopArtistsByTag: %w", err)
+		}
+		l.Debug().Msgf("Database responded with %d artists out of a total %d", len(rows), count)
+	} else {
+		l.Debug().Msgf("Fetching top %d artists with period %s on page %d from range %v to %v",
+			opts.Limit, opts.Period, opts.Page, t1.Format("Jan 02, 2006"), t2.Format("Jan 02, 2006"))
+		rows, err := d.q.GetTopArtistsPaginated(ctx, repository.GetTopArtistsPaginatedParams{
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			Limit:         int32(opts.Limit),
+			Offset:        int32(offset),
+			IncludeHidden: opts.IncludeHidden,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetTopArtistsPaginated: GetTopArtistsPaginated: %w", err)
+		}
+		rgs = make([]*models.Artist, len(rows))
+		for i, row := range rows {
+			t := &models.Artist{
+				Name:        row.Name,
+				MbzID:       row.MusicBrainzID,
+				ID:          row.ID,
+				Image:       row.Image,
+				ListenCount: row.ListenCount,
+			}
+			rgs[i] = t
+		}
+		count, err = d.q.CountTopArtists(ctx, repository.CountTopArtistsParams{
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			IncludeHidden: opts.IncludeHidden,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetTopArtistsPaginated: CountTopArtists: %w", err)
+		}
+		l.Debug().Msgf("Database responded with %d artists out of a total %d", len(rows), count)
 	}
-	l.Debug().Msgf("Database responded with %d artists out of a total %d", len(rows), count)
 
 	return &db.PaginatedResponse[*models.Artist]{
 		Items:        rgs,
@@ -34,11 +34,12 @@ func (d *Psql) GetTopTracksPaginated(ctx context.Context, opts db.GetItemsOpts)
 		l.Debug().Msgf("Fetching top %d tracks with period %s on page %d from range %v to %v",
 			opts.Limit, opts.Period, opts.Page, t1.Format("Jan 02, 2006"), t2.Format("Jan 02, 2006"))
 		rows, err := d.q.GetTopTracksInReleasePaginated(ctx, repository.GetTopTracksInReleasePaginatedParams{
-			ListenedAt:   t1,
-			ListenedAt_2: t2,
-			Limit:        int32(opts.Limit),
-			Offset:       int32(offset),
-			ReleaseID:    int32(opts.AlbumID),
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			Limit:         int32(opts.Limit),
+			Offset:        int32(offset),
+			ReleaseID:     int32(opts.AlbumID),
+			IncludeHidden: opts.IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("GetTopTracksPaginated: GetTopTracksInReleasePaginated: %w", err)
@@ -63,9 +64,10 @@ func (d *Psql) GetTopTracksPaginated(ctx context.Context, opts db.GetItemsOpts)
 			tracks[i] = t
 		}
 		count, err = d.q.CountTopTracksByRelease(ctx, repository.CountTopTracksByReleaseParams{
-			ListenedAt:   t1,
-			ListenedAt_2: t2,
-			ReleaseID:    int32(opts.AlbumID),
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			ReleaseID:     int32(opts.AlbumID),
+			IncludeHidden: opts.IncludeHidden,
 		})
 		if err != nil {
 			return nil, err
@@ -74,11 +76,13 @@ func (d *Psql) GetTopTracksPaginated(ctx context.Context, opts db.GetItemsOpts)
 		l.Debug().Msgf("Fetching top %d tracks with period %s on page %d from range %v to %v",
 			opts.Limit, opts.Period, opts.Page, t1.Format("Jan 02, 2006"), t2.Format("Jan 02, 2006"))
 		rows, err := d.q.GetTopTracksByArtistPaginated(ctx, repository.GetTopTracksByArtistPaginatedParams{
-			ListenedAt:   t1,
-			ListenedAt_2: t2,
-			Limit:        int32(opts.Limit),
-			Offset:       int32(offset),
-			ArtistID:     int32(opts.ArtistID),
+			ListenedAt:     t1,
+			ListenedAt_2:   t2,
+			Limit:          int32(opts.Limit),
+			Offset:         int32(offset),
+			ArtistID:       int32(opts.ArtistID),
+			IncludeRelated: opts.IncludeRelated,
+			IncludeHidden:  opts.IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("GetTopTracksPaginated: GetTopTracksByArtistPaginated: %w", err)
@@ -103,21 +107,66 @@ func (d *Psql) GetTopTracksPaginated(ctx context.Context, opts db.GetItemsOpts)
 			tracks[i] = t
 		}
 		count, err = d.q.CountTopTracksByArtist(ctx, repository.CountTopTracksByArtistParams{
-			ListenedAt:   t1,
-			ListenedAt_2: t2,
-			ArtistID:     int32(opts.ArtistID),
+			ListenedAt:     t1,
+			ListenedAt_2:   t2,
+			ArtistID:       int32(opts.ArtistID),
+			IncludeRelated: opts.IncludeRelated,
+			IncludeHidden:  opts.IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("GetTopTracksPaginated: CountTopTracksByArtist: %w", err)
 		}
+	} else if opts.Tag != "" {
+		l.Debug().Msgf("Fetching top %d tracks tagged '%s' with period %s on page %d from range %v to %v",
+			opts.Limit, opts.Tag, opts.Period, opts.Page, t1.Format("Jan 02, 2006"), t2.Format("Jan 02, 2006"))
+		rows, err := d.q.GetTopTracksByTagPaginated(ctx, repository.GetTopTracksByTagPaginatedParams{
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			Name:          opts.Tag,
+			Limit:         int32(opts.Limit),
+			Offset:        int32(offset),
+			IncludeHidden: opts.IncludeHidden,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetTopTracksPaginated: GetTopTracksByTagPaginated: %w", err)
+		}
+		tracks = make([]*models.Track, len(rows))
+		for i, row := range rows {
+			artists := make([]models.SimpleArtist, 0)
+			err = json.Unmarshal(row.Artists, &artists)
+			if err != nil {
+				l.Err(err).Msgf("Error unmarshalling artists for track with id %d", row.ID)
+				return nil, fmt.Errorf("GetTopTracksPaginated: Unmarshal: %w", err)
+			}
+			t := &models.Track{
+				Title:       row.Title,
+				MbzID:       row.MusicBrainzID,
+				ID:          row.ID,
+				Image:       row.Image,
+				ListenCount: row.ListenCount,
+				AlbumID:     row.ReleaseID,
+				Artists:     artists,
+			}
+			tracks[i] = t
+		}
+		count, err = d.q.CountTopTracksByTag(ctx, repository.CountTopTracksByTagParams{
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			Name:          opts.Tag,
+			IncludeHidden: opts.IncludeHidden,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetTopTracksPaginated: CountTopTracksByTag: %w", err)
+		}
 	} else {
 		l.Debug().Msgf("Fetching top %d tracks with period %s on page %d from range %v to %v",
 			opts.Limit, opts.Period, opts.Page, t1.Format("Jan 02, 2006"), t2.Format("Jan 02, 2006"))
 		rows, err := d.q.GetTopTracksPaginated(ctx, repository.GetTopTracksPaginatedParams{
-			ListenedAt:   t1,
-			ListenedAt_2: t2,
-			Limit:        int32(opts.Limit),
-			Offset:       int32(offset),
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			Limit:         int32(opts.Limit),
+			Offset:        int32(offset),
+			IncludeHidden: opts.IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("GetTopTracksPaginated: GetTopTracksPaginated: %w", err)
@@ -142,8 +191,9 @@ func (d *Psql) GetTopTracksPaginated(ctx context.Context, opts db.GetItemsOpts)
 			tracks[i] = t
 		}
 		count, err = d.q.CountTopTracks(ctx, repository.CountTopTracksParams{
-			ListenedAt:   t1,
-			ListenedAt_2: t2,
+			ListenedAt:    t1,
+			ListenedAt_2:  t2,
+			IncludeHidden: opts.IncludeHidden,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("GetTopTracksPaginated: CountTopTracks: %w", err)
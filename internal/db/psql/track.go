@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -52,6 +53,22 @@ func (d *Psql) GetTrack(ctx context.Context, opts db.GetTrackOpts) (*models.Trac
 			AlbumID:  t.ReleaseID,
 			Duration: t.Duration,
 		}
+	} else if len(opts.ArtistIDs) > 0 && opts.Normalized {
+		l.Debug().Msgf("Fetching track from DB with normalized title '%s' and artist id(s) '%v'", opts.Title, opts.ArtistIDs)
+		t, err := d.q.GetTrackByMatchKeyAndArtists(ctx, repository.GetTrackByMatchKeyAndArtistsParams{
+			Name:    opts.Title,
+			Column2: opts.ArtistIDs,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("GetTrack: GetTrackByMatchKeyAndArtists: %w", err)
+		}
+		track = models.Track{
+			ID:       t.ID,
+			MbzID:    t.MusicBrainzID,
+			Title:    t.Title,
+			AlbumID:  t.ReleaseID,
+			Duration: t.Duration,
+		}
 	} else if len(opts.ArtistIDs) > 0 {
 		l.Debug().Msgf("Fetching track from DB with title '%s' and artist id(s) '%v'", opts.Title, opts.ArtistIDs)
 		t, err := d.q.GetTrackByTitleAndArtists(ctx, repository.GetTrackByTitleAndArtistsParams{
@@ -73,17 +90,19 @@ func (d *Psql) GetTrack(ctx context.Context, opts db.GetTrackOpts) (*models.Trac
 	}
 
 	count, err := d.q.CountListensFromTrack(ctx, repository.CountListensFromTrackParams{
-		ListenedAt:   time.Unix(0, 0),
-		ListenedAt_2: time.Now(),
-		TrackID:      track.ID,
+		ListenedAt:    time.Unix(0, 0),
+		ListenedAt_2:  time.Now(),
+		TrackID:       track.ID,
+		IncludeHidden: opts.IncludeHidden,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("GetTrack: CountListensFromTrack: %w", err)
 	}
 
 	seconds, err := d.CountTimeListenedToItem(ctx, db.TimeListenedOpts{
-		Period:  db.PeriodAllTime,
-		TrackID: track.ID,
+		Period:        db.PeriodAllTime,
+		TrackID:       track.ID,
+		IncludeHidden: opts.IncludeHidden,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("GetTrack: CountTimeListenedToItem: %w", err)
@@ -149,6 +168,10 @@ func (d *Psql) SaveTrack(ctx context.Context, opts db.SaveTrackOpts) (*models.Tr
 	if err != nil {
 		return nil, fmt.Errorf("SaveTrack: InsertTrackAlias: %w", err)
 	}
+	err = qtx.LinkReleaseTracks(ctx, opts.AlbumID)
+	if err != nil {
+		return nil, fmt.Errorf("SaveTrack: LinkReleaseTracks: %w", err)
+	}
 	err = tx.Commit(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("SaveTrack: Commit: %w", err)
@@ -161,10 +184,18 @@ func (d *Psql) SaveTrack(ctx context.Context, opts db.SaveTrackOpts) (*models.Tr
 	}, nil
 }
 
+// UpdateTrack updates the given fields of a track. When the album or artists of the track change,
+// album credits are updated to match, and anything left without tracks is removed.
 func (d *Psql) UpdateTrack(ctx context.Context, opts db.UpdateTrackOpts) error {
 	l := logger.FromContext(ctx)
 	if opts.ID == 0 {
-		return errors.New("UpdateTrack: track id not specified")
+		return fmt.Errorf("UpdateTrack: %w: track id not specified", db.ErrInvalidTrackUpdate)
+	}
+	if opts.Duration < 0 {
+		return fmt.Errorf("UpdateTrack: %w: duration cannot be negative", db.ErrInvalidTrackUpdate)
+	}
+	if opts.ArtistIDs != nil && len(opts.ArtistIDs) < 1 {
+		return fmt.Errorf("UpdateTrack: %w: a track must have at least one artist", db.ErrInvalidTrackUpdate)
 	}
 	tx, err := d.conn.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
@@ -173,9 +204,19 @@ func (d *Psql) UpdateTrack(ctx context.Context, opts db.UpdateTrackOpts) error {
 	}
 	defer tx.Rollback(ctx)
 	qtx := d.q.WithTx(tx)
+	track, err := qtx.GetTrack(ctx, opts.ID)
+	if err != nil {
+		return fmt.Errorf("UpdateTrack: GetTrack: %w", err)
+	}
 	if opts.MusicBrainzID != uuid.Nil {
+		existing, err := qtx.GetTrackByMbzID(ctx, &opts.MusicBrainzID)
+		if err == nil && existing.ID != opts.ID {
+			return fmt.Errorf("UpdateTrack: %w: MusicBrainz ID %s already belongs to track %d", db.ErrInvalidTrackUpdate, opts.MusicBrainzID, existing.ID)
+		} else if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("UpdateTrack: GetTrackByMbzID: %w", err)
+		}
 		l.Debug().Msgf("Updating MusicBrainz ID for track %d", opts.ID)
-		err := qtx.UpdateTrackMbzID(ctx, repository.UpdateTrackMbzIDParams{
+		err = qtx.UpdateTrackMbzID(ctx, repository.UpdateTrackMbzIDParams{
 			ID:            opts.ID,
 			MusicBrainzID: &opts.MusicBrainzID,
 		})
@@ -193,6 +234,159 @@ func (d *Psql) UpdateTrack(ctx context.Context, opts db.UpdateTrackOpts) error {
 			return fmt.Errorf("UpdateTrack: UpdateTrackDuration: %w", err)
 		}
 	}
+
+	releaseId := track.ReleaseID
+	if opts.AlbumID != 0 && opts.AlbumID != track.ReleaseID {
+		_, err = qtx.GetRelease(ctx, opts.AlbumID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("UpdateTrack: %w: album %d does not exist", db.ErrInvalidTrackUpdate, opts.AlbumID)
+		} else if err != nil {
+			return fmt.Errorf("UpdateTrack: GetRelease: %w", err)
+		}
+		l.Debug().Msgf("Moving track %d from album %d to album %d", opts.ID, track.ReleaseID, opts.AlbumID)
+		err = qtx.UpdateTrackRelease(ctx, repository.UpdateTrackReleaseParams{
+			ID:        opts.ID,
+			ReleaseID: opts.AlbumID,
+		})
+		if err != nil {
+			return fmt.Errorf("UpdateTrack: UpdateTrackRelease: %w", err)
+		}
+		releaseId = opts.AlbumID
+	}
+	// the track might now match a different entry of the tracklist, or none of the tracklist it left
+	relink := []int32{releaseId}
+	if releaseId != track.ReleaseID {
+		relink = append(relink, track.ReleaseID)
+	}
+	if releaseId != track.ReleaseID || opts.MusicBrainzID != uuid.Nil {
+		for _, id := range relink {
+			err = qtx.LinkReleaseTracks(ctx, id)
+			if err != nil {
+				return fmt.Errorf("UpdateTrack: LinkReleaseTracks: %w", err)
+			}
+		}
+	}
+
+	current, err := qtx.GetTrackArtists(ctx, opts.ID)
+	if err != nil {
+		return fmt.Errorf("UpdateTrack: GetTrackArtists: %w", err)
+	}
+	artistIds := make([]int32, 0, len(current))
+	var primaryId int32
+	for _, a := range current {
+		artistIds = append(artistIds, a.ID)
+		if a.IsPrimary.Valid && a.IsPrimary.Bool {
+			primaryId = a.ID
+		}
+	}
+	// artists that might no longer have any tracks on the original album
+	uncredited := make([]int32, 0)
+	if releaseId != track.ReleaseID {
+		uncredited = append(uncredited, artistIds...)
+	}
+
+	if opts.ArtistIDs != nil {
+		for _, id := range opts.ArtistIDs {
+			_, err = qtx.GetArtist(ctx, id)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("UpdateTrack: %w: artist %d does not exist", db.ErrInvalidTrackUpdate, id)
+			} else if err != nil {
+				return fmt.Errorf("UpdateTrack: GetArtist: %w", err)
+			}
+		}
+		l.Debug().Msgf("Replacing artists of track %d with %v", opts.ID, opts.ArtistIDs)
+		for _, id := range artistIds {
+			if slices.Contains(opts.ArtistIDs, id) {
+				continue
+			}
+			err = qtx.DeleteArtistTrack(ctx, repository.DeleteArtistTrackParams{
+				ArtistID: id,
+				TrackID:  opts.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("UpdateTrack: DeleteArtistTrack: %w", err)
+			}
+			if !slices.Contains(uncredited, id) {
+				uncredited = append(uncredited, id)
+			}
+		}
+		for _, id := range opts.ArtistIDs {
+			err = qtx.AssociateArtistToTrack(ctx, repository.AssociateArtistToTrackParams{
+				ArtistID: id,
+				TrackID:  opts.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("UpdateTrack: AssociateArtistToTrack: %w", err)
+			}
+		}
+		artistIds = opts.ArtistIDs
+		if !slices.Contains(artistIds, primaryId) {
+			// the primary artist was removed, so the first of the new artists takes its place
+			primaryId = 0
+			if opts.PrimaryArtistID == 0 {
+				opts.PrimaryArtistID = artistIds[0]
+			}
+		}
+	}
+
+	if opts.PrimaryArtistID != 0 && opts.PrimaryArtistID != primaryId {
+		if !slices.Contains(artistIds, opts.PrimaryArtistID) {
+			return fmt.Errorf("UpdateTrack: %w: artist %d is not an artist of track %d", db.ErrInvalidTrackUpdate, opts.PrimaryArtistID, opts.ID)
+		}
+		l.Debug().Msgf("Setting primary artist of track %d to %d", opts.ID, opts.PrimaryArtistID)
+		for _, id := range artistIds {
+			err = qtx.UpdateTrackPrimaryArtist(ctx, repository.UpdateTrackPrimaryArtistParams{
+				ArtistID:  id,
+				TrackID:   opts.ID,
+				IsPrimary: id == opts.PrimaryArtistID,
+			})
+			if err != nil {
+				return fmt.Errorf("UpdateTrack: UpdateTrackPrimaryArtist: %w", err)
+			}
+		}
+	}
+
+	if releaseId == track.ReleaseID && opts.ArtistIDs == nil {
+		return tx.Commit(ctx)
+	}
+
+	// same as when merging tracks, the track artists should be credited on the album of the track
+	for _, id := range artistIds {
+		err = qtx.AssociateArtistToRelease(ctx, repository.AssociateArtistToReleaseParams{
+			ArtistID:      id,
+			ReleaseID:     releaseId,
+			IsAlbumArtist: false,
+		})
+		if err != nil {
+			return fmt.Errorf("UpdateTrack: AssociateArtistToRelease: %w", err)
+		}
+	}
+	for _, id := range uncredited {
+		remaining, err := qtx.CountArtistTracksInRelease(ctx, repository.CountArtistTracksInReleaseParams{
+			ArtistID:  id,
+			ReleaseID: track.ReleaseID,
+		})
+		if err != nil {
+			return fmt.Errorf("UpdateTrack: CountArtistTracksInRelease: %w", err)
+		}
+		if remaining > 0 {
+			continue
+		}
+		// album artists stay credited on the album even when they no longer perform on any of its tracks
+		err = qtx.DeleteNonAlbumArtistRelease(ctx, repository.DeleteNonAlbumArtistReleaseParams{
+			ArtistID:  id,
+			ReleaseID: track.ReleaseID,
+		})
+		if err != nil {
+			return fmt.Errorf("UpdateTrack: DeleteNonAlbumArtistRelease: %w", err)
+		}
+	}
+
+	err = qtx.CleanOrphanedEntries(ctx)
+	if err != nil {
+		l.Err(err).Msg("Failed to clean orphaned entries")
+		return fmt.Errorf("UpdateTrack: CleanOrphanedEntries: %w", err)
+	}
 	return tx.Commit(ctx)
 }
 
@@ -233,8 +427,9 @@ func (d *Psql) SaveTrackAliases(ctx context.Context, id int32, aliases []string,
 	return tx.Commit(ctx)
 }
 
+// DeleteTrack moves a track to the trash, along with its listens.
 func (d *Psql) DeleteTrack(ctx context.Context, id int32) error {
-	return d.q.DeleteTrack(ctx, id)
+	return d.moveToTrash(ctx, db.ItemTypeTrack, id)
 }
 
 func (d *Psql) DeleteTrackAlias(ctx context.Context, id int32, alias string) error {
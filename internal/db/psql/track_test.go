@@ -186,6 +186,139 @@ func TestUpdateTrack(t *testing.T) {
 		Duration:      int32(newDuration),
 	})
 	assert.NoError(t, err) // No update should occur
+
+	// MusicBrainz IDs that belong to another track are rejected
+	err = store.UpdateTrack(ctx, db.UpdateTrackOpts{
+		ID:            1,
+		MusicBrainzID: uuid.MustParse("22222222-2222-2222-2222-222222222222"),
+	})
+	assert.ErrorIs(t, err, db.ErrInvalidTrackUpdate)
+}
+
+func TestUpdateTrack_MoveAlbum(t *testing.T) {
+	testDataForTracks(t)
+	ctx := context.Background()
+
+	err := store.Exec(ctx,
+		`INSERT INTO artist_releases (artist_id, release_id) 
+			VALUES (1, 1), (2, 2)`)
+	require.NoError(t, err)
+
+	// albums that don't exist are rejected
+	err = store.UpdateTrack(ctx, db.UpdateTrackOpts{ID: 1, AlbumID: 99})
+	assert.ErrorIs(t, err, db.ErrInvalidTrackUpdate)
+
+	err = store.UpdateTrack(ctx, db.UpdateTrackOpts{ID: 1, AlbumID: 2})
+	require.NoError(t, err)
+
+	track, err := store.GetTrack(ctx, db.GetTrackOpts{ID: 1})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, track.AlbumID)
+
+	// the artist of the track is now credited on the album it was moved to
+	exists, err := store.RowExists(ctx, `
+	SELECT EXISTS (
+		SELECT 1 FROM artist_releases
+		WHERE artist_id = $1 AND release_id = $2
+	)`, 1, 2)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	// the original album has no tracks left, so it is removed
+	_, err = store.GetAlbum(ctx, db.GetAlbumOpts{ID: 1})
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+	// listens follow the track
+	album, err := store.GetAlbum(ctx, db.GetAlbumOpts{ID: 2})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, album.ListenCount)
+}
+
+func TestUpdateTrack_Artists(t *testing.T) {
+	testDataForTracks(t)
+	ctx := context.Background()
+
+	err := store.Exec(ctx,
+		`INSERT INTO artist_releases (artist_id, release_id) 
+			VALUES (1, 1), (2, 2)`)
+	require.NoError(t, err)
+
+	err = store.UpdateTrack(ctx, db.UpdateTrackOpts{ID: 2, ArtistIDs: []int32{1, 2}, PrimaryArtistID: 1})
+	require.NoError(t, err)
+
+	track, err := store.GetTrack(ctx, db.GetTrackOpts{ID: 2})
+	require.NoError(t, err)
+	assert.Len(t, track.Artists, 2)
+	exists, err := store.RowExists(ctx, `
+	SELECT EXISTS (
+		SELECT 1 FROM artist_tracks
+		WHERE artist_id = $1 AND track_id = $2 AND is_primary = true
+	)`, 1, 2)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = store.RowExists(ctx, `
+	SELECT EXISTS (
+		SELECT 1 FROM artist_releases
+		WHERE artist_id = $1 AND release_id = $2
+	)`, 1, 2)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	// the primary artist must be one of the artists of the track
+	err = store.UpdateTrack(ctx, db.UpdateTrackOpts{ID: 1, PrimaryArtistID: 2})
+	assert.ErrorIs(t, err, db.ErrInvalidTrackUpdate)
+	// a track can't be left without artists
+	err = store.UpdateTrack(ctx, db.UpdateTrackOpts{ID: 1, ArtistIDs: []int32{}})
+	assert.ErrorIs(t, err, db.ErrInvalidTrackUpdate)
+	// artists that don't exist are rejected
+	err = store.UpdateTrack(ctx, db.UpdateTrackOpts{ID: 1, ArtistIDs: []int32{99}})
+	assert.ErrorIs(t, err, db.ErrInvalidTrackUpdate)
+
+	// artist 2 no longer has any tracks, so it is removed along with its album credit
+	err = store.UpdateTrack(ctx, db.UpdateTrackOpts{ID: 2, ArtistIDs: []int32{1}})
+	require.NoError(t, err)
+	_, err = store.GetArtist(ctx, db.GetArtistOpts{ID: 2})
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	exists, err = store.RowExists(ctx, `
+	SELECT EXISTS (
+		SELECT 1 FROM artist_releases
+		WHERE artist_id = $1 AND release_id = $2
+	)`, 2, 2)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestUpdateTrack_KeepsAlbumArtist(t *testing.T) {
+	testDataForTracks(t)
+	ctx := context.Background()
+
+	// artist 2 is the album artist of release 2, and also performs on track 1 of release 1
+	err := store.Exec(ctx,
+		`INSERT INTO artist_releases (artist_id, release_id, is_album_artist) 
+			VALUES (1, 1, true), (2, 2, true)`)
+	require.NoError(t, err)
+	err = store.Exec(ctx, `INSERT INTO artist_tracks (artist_id, track_id) VALUES (2, 1)`)
+	require.NoError(t, err)
+
+	// track 2 is the only track artist 2 performs on in release 2
+	err = store.UpdateTrack(ctx, db.UpdateTrackOpts{ID: 2, ArtistIDs: []int32{1}})
+	require.NoError(t, err)
+
+	// artist 2 is no longer credited on track 2, but is still the album artist of release 2
+	exists, err := store.RowExists(ctx, `
+	SELECT EXISTS (
+		SELECT 1 FROM artist_tracks
+		WHERE artist_id = $1 AND track_id = $2
+	)`, 2, 2)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	exists, err = store.RowExists(ctx, `
+	SELECT EXISTS (
+		SELECT 1 FROM artist_releases
+		WHERE artist_id = $1 AND release_id = $2 AND is_album_artist
+	)`, 2, 2)
+	require.NoError(t, err)
+	assert.True(t, exists)
 }
 
 func TestTrackAliases(t *testing.T) {
@@ -0,0 +1,110 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/models"
+	"github.com/gabehf/koito/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func (d *Psql) GetAlbumTracklist(ctx context.Context, id int32) ([]models.TracklistTrack, error) {
+	rows, err := d.q.GetReleaseTracklist(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("GetAlbumTracklist: %w", err)
+	}
+	ret := make([]models.TracklistTrack, len(rows))
+	for i, row := range rows {
+		ret[i] = models.TracklistTrack{
+			DiscNumber:  row.DiscNumber,
+			Position:    row.Position,
+			Title:       row.Title,
+			Duration:    row.Duration,
+			MbzID:       row.MusicBrainzID,
+			ListenCount: row.ListenCount,
+		}
+		if row.TrackID.Valid {
+			ret[i].TrackID = &row.TrackID.Int32
+		}
+	}
+	return ret, nil
+}
+
+// SetAlbumTracklist replaces the tracklist of an album, and links its entries to the tracks of the album.
+func (d *Psql) SetAlbumTracklist(ctx context.Context, id int32, tracklist []db.TracklistTrack) error {
+	l := logger.FromContext(ctx)
+	if id == 0 {
+		return errors.New("SetAlbumTracklist: album id not specified")
+	}
+	tx, err := d.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		l.Err(err).Msg("Failed to begin transaction")
+		return fmt.Errorf("SetAlbumTracklist: BeginTx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := d.q.WithTx(tx)
+	err = qtx.DeleteReleaseTracks(ctx, id)
+	if err != nil {
+		return fmt.Errorf("SetAlbumTracklist: DeleteReleaseTracks: %w", err)
+	}
+	for _, t := range tracklist {
+		var mbzID *uuid.UUID
+		if t.MbzID != uuid.Nil {
+			mbzID = &t.MbzID
+		}
+		err = qtx.InsertReleaseTrack(ctx, repository.InsertReleaseTrackParams{
+			ReleaseID:     id,
+			DiscNumber:    t.DiscNumber,
+			Position:      t.Position,
+			Title:         t.Title,
+			Duration:      t.Duration,
+			MusicBrainzID: mbzID,
+		})
+		if err != nil {
+			return fmt.Errorf("SetAlbumTracklist: InsertReleaseTrack: %w", err)
+		}
+	}
+	err = qtx.LinkReleaseTracks(ctx, id)
+	if err != nil {
+		return fmt.Errorf("SetAlbumTracklist: LinkReleaseTracks: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// getAlbumCompletion sets how much of the tracklist of an album has been listened to, if the album has a tracklist.
+func (d *Psql) getAlbumCompletion(ctx context.Context, album *models.Album) error {
+	row, err := d.q.GetReleaseCompletion(ctx, album.ID)
+	if err != nil {
+		return fmt.Errorf("getAlbumCompletion: %w", err)
+	}
+	if row.TrackCount == 0 {
+		return nil
+	}
+	album.Completion = &models.AlbumCompletion{
+		TrackCount:      row.TrackCount,
+		HeardTrackCount: row.HeardCount,
+		Percent:         float64(row.HeardCount) / float64(row.TrackCount) * 100,
+	}
+	return nil
+}
+
+// CountCompletedAlbums returns the number of albums that have had every track of their tracklist listened to in the period.
+func (d *Psql) CountCompletedAlbums(ctx context.Context, period db.Period, opts ...db.CountOpts) (int64, error) {
+	t2 := time.Now()
+	t1 := db.StartTimeFromPeriod(period)
+	count, err := d.q.CountCompletedReleases(ctx, repository.CountCompletedReleasesParams{
+		ListenedAt:    t1,
+		ListenedAt_2:  t2,
+		IncludeHidden: countOpts(opts).IncludeHidden,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("CountCompletedAlbums: %w", err)
+	}
+	return count, nil
+}
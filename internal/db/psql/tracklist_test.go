@@ -0,0 +1,74 @@
+package psql_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlbumTracklist(t *testing.T) {
+	testDataForTopItems(t)
+	ctx := context.Background()
+
+	// the first track is linked by title, the second by recording once it is on the album
+	require.NoError(t, store.SetAlbumTracklist(ctx, 1, []db.TracklistTrack{
+		{DiscNumber: 1, Position: 1, Title: "track one", Duration: 100},
+		{DiscNumber: 1, Position: 2, Title: "Another Title", Duration: 120, MbzID: uuid.MustParse("22222222-2222-2222-2222-222222222222")},
+	}))
+	require.NoError(t, store.SetAlbumTracklist(ctx, 4, []db.TracklistTrack{
+		{DiscNumber: 1, Position: 1, Title: "Track Four", Duration: 100},
+	}))
+
+	tracklist, err := store.GetAlbumTracklist(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, tracklist, 2)
+	require.NotNil(t, tracklist[0].TrackID)
+	assert.EqualValues(t, 1, *tracklist[0].TrackID)
+	assert.EqualValues(t, 4, tracklist[0].ListenCount)
+	assert.Nil(t, tracklist[1].TrackID)
+	assert.EqualValues(t, 0, tracklist[1].ListenCount)
+
+	album, err := store.GetAlbum(ctx, db.GetAlbumOpts{ID: 1})
+	require.NoError(t, err)
+	require.NotNil(t, album.Completion)
+	assert.EqualValues(t, 2, album.Completion.TrackCount)
+	assert.EqualValues(t, 1, album.Completion.HeardTrackCount)
+	assert.Equal(t, 50.0, album.Completion.Percent)
+
+	// albums without a tracklist have no completion
+	album, err = store.GetAlbum(ctx, db.GetAlbumOpts{ID: 2})
+	require.NoError(t, err)
+	assert.Nil(t, album.Completion)
+
+	count, err := store.CountCompletedAlbums(ctx, db.PeriodAllTime)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+	count, err = store.CountCompletedAlbums(ctx, db.PeriodWeek)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+	count, err = store.CountCompletedAlbums(ctx, db.PeriodDay)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, count)
+
+	// moving a track onto the album links it to its tracklist entry
+	require.NoError(t, store.UpdateTrack(ctx, db.UpdateTrackOpts{ID: 2, AlbumID: 1}))
+	tracklist, err = store.GetAlbumTracklist(ctx, 1)
+	require.NoError(t, err)
+	require.NotNil(t, tracklist[1].TrackID)
+	assert.EqualValues(t, 2, *tracklist[1].TrackID)
+	count, err = store.CountCompletedAlbums(ctx, db.PeriodAllTime)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+
+	// setting the tracklist again replaces it
+	require.NoError(t, store.SetAlbumTracklist(ctx, 1, []db.TracklistTrack{
+		{DiscNumber: 1, Position: 1, Title: "Track One", Duration: 100},
+	}))
+	tracklist, err = store.GetAlbumTracklist(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, tracklist, 1)
+}
@@ -0,0 +1,158 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/gabehf/koito/internal/repository"
+	"github.com/jackc/pgx/v5"
+)
+
+// moveToTrash deletes an artist, album or track, along with everything that is deleted with it, and
+// keeps the deleted rows in a trash entry so that they can be restored. Returns pgx.ErrNoRows if the
+// item does not exist.
+func (d *Psql) moveToTrash(ctx context.Context, t db.ItemType, id int32) error {
+	l := logger.FromContext(ctx)
+	tx, err := d.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		l.Err(err).Msg("Failed to begin transaction")
+		return fmt.Errorf("moveToTrash: BeginTx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := d.q.WithTx(tx)
+
+	var name string
+	switch t {
+	case db.ItemTypeArtist:
+		a, err := qtx.GetArtist(ctx, id)
+		if err != nil {
+			return fmt.Errorf("moveToTrash: GetArtist: %w", err)
+		}
+		name = a.Name
+	case db.ItemTypeAlbum:
+		r, err := qtx.GetRelease(ctx, id)
+		if err != nil {
+			return fmt.Errorf("moveToTrash: GetRelease: %w", err)
+		}
+		name = r.Title
+	case db.ItemTypeTrack:
+		tr, err := qtx.GetTrack(ctx, id)
+		if err != nil {
+			return fmt.Errorf("moveToTrash: GetTrack: %w", err)
+		}
+		name = tr.Title
+	default:
+		return fmt.Errorf("moveToTrash: unknown item type '%s'", t)
+	}
+
+	trashID, err := qtx.InsertTrash(ctx, repository.InsertTrashParams{
+		ItemType: string(t),
+		ItemID:   id,
+		Name:     name,
+	})
+	if err != nil {
+		return fmt.Errorf("moveToTrash: InsertTrash: %w", err)
+	}
+	// from here on, the rows removed by the delete and its cascades are copied to the trash entry
+	err = qtx.SetCurrentTrash(ctx, trashID)
+	if err != nil {
+		return fmt.Errorf("moveToTrash: SetCurrentTrash: %w", err)
+	}
+
+	switch t {
+	case db.ItemTypeArtist:
+		err = qtx.DeleteArtist(ctx, id)
+	case db.ItemTypeAlbum:
+		err = qtx.DeleteRelease(ctx, id)
+	case db.ItemTypeTrack:
+		err = qtx.DeleteTrack(ctx, id)
+	}
+	if err != nil {
+		return fmt.Errorf("moveToTrash: delete %s: %w", t, err)
+	}
+
+	err = qtx.InsertTrashTags(ctx, trashID)
+	if err != nil {
+		return fmt.Errorf("moveToTrash: InsertTrashTags: %w", err)
+	}
+
+	l.Debug().Msgf("Moved %s %d to trash entry %d", t, id, trashID)
+	return tx.Commit(ctx)
+}
+
+// GetTrash returns the deleted items in the trash, most recently deleted first.
+func (d *Psql) GetTrash(ctx context.Context) ([]db.TrashItem, error) {
+	rows, err := d.q.GetTrash(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetTrash: %w", err)
+	}
+	ret := make([]db.TrashItem, len(rows))
+	for i, row := range rows {
+		ret[i] = db.TrashItem{
+			ID:          row.ID,
+			Type:        db.ItemType(row.ItemType),
+			ItemID:      row.ItemID,
+			Name:        row.Name,
+			DeletedAt:   row.DeletedAt,
+			ListenCount: row.ListenCount,
+			TrackCount:  row.TrackCount,
+			AlbumCount:  row.AlbumCount,
+			ArtistCount: row.ArtistCount,
+		}
+	}
+	return ret, nil
+}
+
+// RestoreTrash puts a deleted item back as it was before it was deleted, with the same id, aliases,
+// images, artist links and listens, and removes it from the trash. Links to other items that have since
+// been deleted are left out. Returns pgx.ErrNoRows if the trash entry does not exist.
+func (d *Psql) RestoreTrash(ctx context.Context, id int32) error {
+	l := logger.FromContext(ctx)
+	tx, err := d.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		l.Err(err).Msg("Failed to begin transaction")
+		return fmt.Errorf("RestoreTrash: BeginTx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := d.q.WithTx(tx)
+
+	_, err = qtx.GetTrashEntry(ctx, id)
+	if err != nil {
+		return fmt.Errorf("RestoreTrash: GetTrashEntry: %w", err)
+	}
+	err = qtx.RestoreTrash(ctx, id)
+	if err != nil {
+		return fmt.Errorf("RestoreTrash: %w", err)
+	}
+	_, err = qtx.DeleteTrash(ctx, id)
+	if err != nil {
+		return fmt.Errorf("RestoreTrash: DeleteTrash: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// DeleteTrash permanently deletes an item in the trash. Returns pgx.ErrNoRows if the trash entry
+// does not exist.
+func (d *Psql) DeleteTrash(ctx context.Context, id int32) error {
+	rows, err := d.q.DeleteTrash(ctx, id)
+	if err != nil {
+		return fmt.Errorf("DeleteTrash: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("DeleteTrash: %w", pgx.ErrNoRows)
+	}
+	return nil
+}
+
+// PurgeTrash permanently deletes the items that were moved to the trash before the given time, and
+// returns the number of items deleted.
+func (d *Psql) PurgeTrash(ctx context.Context, before time.Time) (int64, error) {
+	rows, err := d.q.DeleteTrashBefore(ctx, before)
+	if err != nil {
+		return 0, fmt.Errorf("PurgeTrash: %w", err)
+	}
+	return rows, nil
+}
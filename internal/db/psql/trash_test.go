@@ -0,0 +1,137 @@
+package psql_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrashRestore(t *testing.T) {
+	testDataForTopItems(t)
+	ctx := context.Background()
+
+	image := uuid.MustParse("00000000-0000-0000-0000-0000000000aa")
+	require.NoError(t, store.Exec(ctx, `UPDATE artists SET image = $1 WHERE id = 1`, image))
+	require.NoError(t, store.Exec(ctx,
+		`INSERT INTO artist_aliases (artist_id, alias, source, is_primary) VALUES (1, 'Artist Uno', 'Testing', false)`))
+	require.NoError(t, store.SaveArtistTags(ctx, 1, []string{"rock"}, "Testing"))
+	require.NoError(t, store.SetItemHidden(ctx, db.ItemTypeAlbum, 1, true))
+
+	require.NoError(t, store.DeleteArtist(ctx, 1))
+
+	// the artist, its album, track and listens are gone from every query
+	_, err := store.GetArtist(ctx, db.GetArtistOpts{ID: 1})
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	count, err := store.CountListens(ctx, db.PeriodAllTime, db.CountOpts{IncludeHidden: true})
+	require.NoError(t, err)
+	assert.EqualValues(t, 6, count)
+	// but its image is kept until it is purged
+	used, err := store.ImageHasAssociation(ctx, image)
+	require.NoError(t, err)
+	assert.True(t, used)
+
+	trash, err := store.GetTrash(ctx)
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	assert.Equal(t, db.ItemTypeArtist, trash[0].Type)
+	assert.EqualValues(t, 1, trash[0].ItemID)
+	assert.Equal(t, "Artist One", trash[0].Name)
+	assert.EqualValues(t, 4, trash[0].ListenCount)
+	assert.EqualValues(t, 1, trash[0].TrackCount)
+	assert.EqualValues(t, 1, trash[0].AlbumCount)
+	assert.EqualValues(t, 1, trash[0].ArtistCount)
+
+	// the tag is cleaned up as an orphan while the artist is in the trash
+	require.NoError(t, store.Exec(ctx, `DELETE FROM tags`))
+
+	require.NoError(t, store.RestoreTrash(ctx, trash[0].ID))
+
+	artist, err := store.GetArtist(ctx, db.GetArtistOpts{ID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "Artist One", artist.Name)
+	require.NotNil(t, artist.Image)
+	assert.Equal(t, image, *artist.Image)
+	aliases, err := store.GetAllArtistAliases(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, aliases, 2)
+	tags, err := store.GetArtistTags(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, tags, 1)
+	album, err := store.GetAlbum(ctx, db.GetAlbumOpts{ID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "Release One", album.Title)
+	exists, err := store.RowExists(ctx, `SELECT EXISTS (SELECT 1 FROM artist_tracks WHERE artist_id = 1 AND track_id = 1)`)
+	require.NoError(t, err)
+	assert.True(t, exists, "expected artist to be linked to its track again")
+	exists, err = store.RowExists(ctx, `SELECT EXISTS (SELECT 1 FROM hidden_releases WHERE release_id = 1)`)
+	require.NoError(t, err)
+	assert.True(t, exists, "expected album to be hidden again")
+	count, err = store.CountListens(ctx, db.PeriodAllTime, db.CountOpts{IncludeHidden: true})
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, count)
+
+	trash, err = store.GetTrash(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, trash)
+
+	assert.ErrorIs(t, store.RestoreTrash(ctx, 999), pgx.ErrNoRows)
+	assert.ErrorIs(t, store.DeleteArtist(ctx, 999), pgx.ErrNoRows)
+}
+
+func TestTrashRestoreTrackOnTracklist(t *testing.T) {
+	testDataForTopItems(t)
+	ctx := context.Background()
+
+	require.NoError(t, store.Exec(ctx,
+		`INSERT INTO release_tracks (release_id, disc_number, position, title, track_id) VALUES (2, 1, 1, 'Track Two', 2)`))
+
+	require.NoError(t, store.DeleteTrack(ctx, 2))
+	exists, err := store.RowExists(ctx, `SELECT EXISTS (SELECT 1 FROM release_tracks WHERE track_id IS NULL)`)
+	require.NoError(t, err)
+	assert.True(t, exists, "expected tracklist entry to be unlinked")
+
+	trash, err := store.GetTrash(ctx)
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	require.NoError(t, store.RestoreTrash(ctx, trash[0].ID))
+
+	exists, err = store.RowExists(ctx, `SELECT EXISTS (SELECT 1 FROM release_tracks WHERE track_id = 2)`)
+	require.NoError(t, err)
+	assert.True(t, exists, "expected tracklist entry to be linked to the restored track")
+	count, err := store.Count(ctx, `SELECT COUNT(*) FROM listens WHERE track_id = 2`)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, count)
+}
+
+func TestTrashPurge(t *testing.T) {
+	testDataForTopItems(t)
+	ctx := context.Background()
+
+	require.NoError(t, store.DeleteTrack(ctx, 3))
+	require.NoError(t, store.DeleteAlbum(ctx, 4))
+
+	purged, err := store.PurgeTrash(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, purged)
+
+	trash, err := store.GetTrash(ctx)
+	require.NoError(t, err)
+	require.Len(t, trash, 2)
+	// most recently deleted first
+	assert.Equal(t, db.ItemTypeAlbum, trash[0].Type)
+	require.NoError(t, store.DeleteTrash(ctx, trash[0].ID))
+	assert.ErrorIs(t, store.DeleteTrash(ctx, trash[0].ID), pgx.ErrNoRows)
+
+	purged, err = store.PurgeTrash(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, purged)
+	count, err := store.Count(ctx, `SELECT COUNT(*) FROM trash_rows`)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, count)
+}
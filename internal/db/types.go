@@ -43,4 +43,169 @@ type ExportItem struct {
 	VariousArtists     bool
 	ReleaseAliases     []models.Alias
 	Artists            []models.ArtistWithFullAliases
+	ReleaseArtists     []models.ArtistWithFullAliases
+}
+
+type ItemType string
+
+const (
+	ItemTypeArtist ItemType = "artist"
+	ItemTypeAlbum  ItemType = "album"
+	ItemTypeTrack  ItemType = "track"
+)
+
+// A relation between two artists, read as "artist <relation> related artist"
+type ArtistRelationType string
+
+const (
+	ArtistRelationMemberOf   ArtistRelationType = "member_of"
+	ArtistRelationPerformsAs ArtistRelationType = "performs_as"
+)
+
+type ArtistSplitRuleKind string
+
+const (
+	ArtistSplitRuleSeparator ArtistSplitRuleKind = "separator"
+	ArtistSplitRuleProtected ArtistSplitRuleKind = "protected"
+)
+
+// A class of inconsistency in the catalog that the integrity check looks for
+type IntegrityCheck string
+
+const (
+	IntegrityCheckMissingPrimaryAliases IntegrityCheck = "missing_primary_aliases"
+	IntegrityCheckAlbumsWithoutArtists  IntegrityCheck = "albums_without_artists"
+	IntegrityCheckArtistsWithoutTracks  IntegrityCheck = "artists_without_tracks"
+	IntegrityCheckOrphanedImages        IntegrityCheck = "orphaned_images"
+)
+
+// An item that failed an integrity check. Orphaned images have no type or id, and are named by their
+// file name. Repairable is false for issues that can't be repaired automatically without losing data,
+// such as an item without any aliases to make primary.
+type IntegrityIssue struct {
+	Check      IntegrityCheck `json:"check"`
+	Type       ItemType       `json:"type,omitempty"`
+	ID         int32          `json:"id,omitempty"`
+	Name       string         `json:"name"`
+	Repairable bool           `json:"repairable"`
+}
+
+// Signals for two items that may be duplicates of each other, used to score merge candidates
+type DuplicatePair struct {
+	ID1          int32
+	ID2          int32
+	Name1        string
+	Name2        string
+	MbzID1       *uuid.UUID
+	MbzID2       *uuid.UUID
+	Similarity   float32 // highest trigram similarity between any aliases of the two items
+	SharedAlbums int64   // artists: album titles in common; tracks: 1 if both are on the same album
+	SharedTracks int64   // artists and albums: track titles in common
+	ListenCount1 int64
+	ListenCount2 int64
+}
+
+// An item without a MusicBrainz ID, with the information needed to search for it
+type UnmatchedItem struct {
+	ID             int32
+	Name           string // artist name, album title, or track title
+	ArtistName     string // primary artist of albums and tracks
+	AlbumTitle     string // tracks only
+	Duration       int32  // tracks only, in seconds
+	VariousArtists bool   // albums only
+}
+
+// The existing artist, album, or track most similar to a name that could not be matched exactly
+type FuzzyMatchCandidate struct {
+	ID    int32
+	Name  string  // the alias that was matched
+	Score float32 // trigram similarity between the alias and the name
+}
+
+// An album that has not been grouped with other editions yet
+type UngroupedAlbum struct {
+	ID       int32
+	Title    string
+	ArtistID int32 // the primary artist of the album
+}
+
+// An artist, album, or track with a MusicBrainz ID
+type MbzItem struct {
+	ID    int32
+	MbzID uuid.UUID
+}
+
+// An artist, album, or track with its primary name or title
+type NamedItem struct {
+	ID   int32
+	Name string
+}
+
+// The number of listens to albums released in a year, or in a decade starting with Year
+type ReleaseYearCount struct {
+	Year        int32 `json:"year"`
+	ListenCount int64 `json:"listen_count"`
+}
+
+// The number of listens to albums of a release type. Compilations are counted separately
+// from their primary type.
+type ReleaseTypeCount struct {
+	Type        string `json:"release_type"`
+	ListenCount int64  `json:"listen_count"`
+}
+
+// The number of listens to, and the number of artists listened to, from a country
+type CountryCount struct {
+	Country     string `json:"country"` // ISO 3166-1 alpha-2 code
+	ListenCount int64  `json:"listen_count"`
+	ArtistCount int64  `json:"artist_count"`
+}
+
+// One artist of the artist credit of a track or album, in credit order. Name is the name the
+// artist is credited as, and JoinPhrase joins it to the next artist (" feat. ", " x ", ...)
+type ArtistCredit struct {
+	ArtistID   int32
+	Name       string
+	JoinPhrase string
+}
+
+// One track of the tracklist of an album as released. MbzID is the MusicBrainz recording of the track
+type TracklistTrack struct {
+	DiscNumber int32
+	Position   int32
+	Title      string
+	Duration   int32
+	MbzID      uuid.UUID
+}
+
+// An artist, album or track that is hidden from charts, counts and search
+type HiddenItem struct {
+	Type ItemType `json:"type"`
+	ID   int32    `json:"id"`
+	Name string   `json:"name"`
+}
+
+// A deleted artist, album or track in the trash, with the number of listens, tracks, albums and
+// artists that were deleted along with it and are restored with it
+type TrashItem struct {
+	ID          int32     `json:"id"`
+	Type        ItemType  `json:"type"`
+	ItemID      int32     `json:"item_id"`
+	Name        string    `json:"name"`
+	DeletedAt   time.Time `json:"deleted_at"`
+	ListenCount int64     `json:"listen_count"`
+	TrackCount  int64     `json:"track_count"`
+	AlbumCount  int64     `json:"album_count"`
+	ArtistCount int64     `json:"artist_count"`
+}
+
+// What deleting or merging an item would change in the catalog. ListenCount is the number of listens
+// that would be deleted, or for a merge, moved to the item merged into. OrphanedImages are the images
+// that no artist or album, in the catalog or in the trash, would use anymore.
+type ImpactPreview struct {
+	ListenCount    int64       `json:"listen_count"`
+	TrackCount     int64       `json:"track_count"`
+	AlbumCount     int64       `json:"album_count"`
+	ArtistCount    int64       `json:"artist_count"`
+	OrphanedImages []uuid.UUID `json:"orphaned_images"`
 }
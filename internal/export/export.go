@@ -35,12 +35,19 @@ type KoitoAlbum struct {
 	MBID           *uuid.UUID     `json:"mbid"`
 	Aliases        []models.Alias `json:"aliases"`
 	VariousArtists bool           `json:"various_artists"`
+	// The album artists, when they differ from the artists of the track
+	Artists []KoitoArtist `json:"artists,omitempty"`
 }
+
+// Artists are listed in the order of their artist credit. CreditedName and JoinPhrase are
+// only set when the artist credit is known.
 type KoitoArtist struct {
-	ImageUrl  string         `json:"image_url"`
-	MBID      *uuid.UUID     `json:"mbid"`
-	IsPrimary bool           `json:"is_primary"`
-	Aliases   []models.Alias `json:"aliases"`
+	ImageUrl     string         `json:"image_url"`
+	MBID         *uuid.UUID     `json:"mbid"`
+	IsPrimary    bool           `json:"is_primary"`
+	Aliases      []models.Alias `json:"aliases"`
+	CreditedName string         `json:"credited_name,omitempty"`
+	JoinPhrase   string         `json:"join_phrase,omitempty"`
 }
 
 func ExportData(ctx context.Context, user *models.User, store db.DB, out io.Writer) error {
@@ -133,13 +140,37 @@ func convertToExportFormat(item *db.ExportItem) *KoitoListen {
 			Aliases:        item.ReleaseAliases,
 		},
 	}
-	for i := range item.Artists {
-		ret.Artists = append(ret.Artists, KoitoArtist{
-			IsPrimary: item.Artists[i].IsPrimary,
-			MBID:      item.Artists[i].MbzID,
-			Aliases:   item.Artists[i].Aliases,
-			ImageUrl:  item.Artists[i].ImageSource,
+	ret.Artists = convertArtistsToExportFormat(item.Artists)
+	if !sameArtists(item.Artists, item.ReleaseArtists) {
+		ret.Album.Artists = convertArtistsToExportFormat(item.ReleaseArtists)
+	}
+	return ret
+}
+
+func convertArtistsToExportFormat(artists []models.ArtistWithFullAliases) []KoitoArtist {
+	var ret []KoitoArtist
+	for i := range artists {
+		ret = append(ret, KoitoArtist{
+			IsPrimary:    artists[i].IsPrimary,
+			MBID:         artists[i].MbzID,
+			Aliases:      artists[i].Aliases,
+			ImageUrl:     artists[i].ImageSource,
+			CreditedName: artists[i].CreditedName,
+			JoinPhrase:   artists[i].JoinPhrase,
 		})
 	}
 	return ret
 }
+
+// sameArtists reports whether both lists have the same artists with the same credit
+func sameArtists(a, b []models.ArtistWithFullAliases) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].ID != b[i].ID || a[i].CreditedName != b[i].CreditedName || a[i].JoinPhrase != b[i].JoinPhrase {
+			return false
+		}
+	}
+	return true
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"slices"
 	"strings"
 
 	"github.com/gabehf/koito/internal/cfg"
@@ -45,39 +46,18 @@ func ImportKoitoFile(ctx context.Context, store db.DB, filename string) error {
 		// use this for save/get mbid for all artist/album/track
 		var mbid uuid.UUID
 
-		artistIds := make([]int32, 0)
-		for _, ia := range data.Listens[i].Artists {
-			mbid = uuid.Nil
-			if ia.MBID != nil {
-				mbid = *ia.MBID
-			}
-			artist, err := store.GetArtist(ctx, db.GetArtistOpts{
-				MusicBrainzID: mbid,
-				Name:          getPrimaryAliasFromAliasSlice(ia.Aliases),
-			})
-			if errors.Is(err, pgx.ErrNoRows) {
-				var imgid = uuid.Nil
-				// not a perfect way to check if the image url is an actual source vs manual upload but
-				// im like 99% sure it will work perfectly
-				if strings.HasPrefix(ia.ImageUrl, "http") {
-					imgid = uuid.New()
-				}
-				// save artist
-				artist, err := store.SaveArtist(ctx, db.SaveArtistOpts{
-					Name:          getPrimaryAliasFromAliasSlice(ia.Aliases),
-					Image:         imgid,
-					ImageSrc:      ia.ImageUrl,
-					MusicBrainzID: mbid,
-					Aliases:       utils.FlattenAliases(ia.Aliases),
-				})
-				if err != nil {
-					return fmt.Errorf("ImportKoitoFile: %w", err)
-				}
-				artistIds = append(artistIds, artist.ID)
-			} else if err != nil {
+		artistIds, err := importKoitoArtists(ctx, store, data.Listens[i].Artists)
+		if err != nil {
+			return fmt.Errorf("ImportKoitoFile: %w", err)
+		}
+		// exports from before album artists were stored only have the track artists
+		albumArtists := data.Listens[i].Artists
+		albumArtistIds := artistIds
+		if len(data.Listens[i].Album.Artists) > 0 {
+			albumArtists = data.Listens[i].Album.Artists
+			albumArtistIds, err = importKoitoArtists(ctx, store, albumArtists)
+			if err != nil {
 				return fmt.Errorf("ImportKoitoFile: %w", err)
-			} else {
-				artistIds = append(artistIds, artist.ID)
 			}
 		}
 		// call associate album
@@ -89,7 +69,7 @@ func ImportKoitoFile(ctx context.Context, store db.DB, filename string) error {
 		album, err := store.GetAlbum(ctx, db.GetAlbumOpts{
 			MusicBrainzID: mbid,
 			Title:         getPrimaryAliasFromAliasSlice(data.Listens[i].Album.Aliases),
-			ArtistID:      artistIds[0],
+			ArtistID:      albumArtistIds[0],
 		})
 		if errors.Is(err, pgx.ErrNoRows) {
 			var imgid = uuid.Nil
@@ -105,13 +85,19 @@ func ImportKoitoFile(ctx context.Context, store db.DB, filename string) error {
 				ImageSrc:       data.Listens[i].Album.ImageUrl,
 				MusicBrainzID:  mbid,
 				Aliases:        utils.FlattenAliases(data.Listens[i].Album.Aliases),
-				ArtistIDs:      artistIds,
+				ArtistIDs:      albumArtistIds,
 				VariousArtists: data.Listens[i].Album.VariousArtists,
 			})
 			if err != nil {
 				return fmt.Errorf("ImportKoitoFile: %w", err)
 			}
 			albumId = album.ID
+			if credit := koitoArtistCredit(albumArtists, albumArtistIds); credit != nil {
+				err = store.SetAlbumArtistCredit(ctx, albumId, credit)
+				if err != nil {
+					return fmt.Errorf("ImportKoitoFile: %w", err)
+				}
+			}
 		} else if err != nil {
 			return fmt.Errorf("ImportKoitoFile: %w", err)
 		} else {
@@ -145,6 +131,20 @@ func ImportKoitoFile(ctx context.Context, store db.DB, filename string) error {
 			if err != nil {
 				return fmt.Errorf("ImportKoitoFile: %w", err)
 			}
+			if credit := koitoArtistCredit(data.Listens[i].Artists, artistIds); credit != nil {
+				err = store.SetTrackArtistCredit(ctx, track.ID, credit)
+				if err != nil {
+					return fmt.Errorf("ImportKoitoFile: %w", err)
+				}
+			}
+			// the track artists are credited on the album, in case they are not its album artists
+			err = store.AddArtistsToAlbum(ctx, db.AddArtistsToAlbumOpts{
+				ArtistIDs: artistIds,
+				AlbumID:   albumId,
+			})
+			if err != nil {
+				return fmt.Errorf("ImportKoitoFile: %w", err)
+			}
 		} else if err != nil {
 			return fmt.Errorf("ImportKoitoFile: %w", err)
 		}
@@ -165,6 +165,67 @@ func ImportKoitoFile(ctx context.Context, store db.DB, filename string) error {
 
 	return finishImport(ctx, filename, count)
 }
+
+// importKoitoArtists finds or creates the artists of an exported listen, and returns their ids in order
+func importKoitoArtists(ctx context.Context, store db.DB, artists []export.KoitoArtist) ([]int32, error) {
+	artistIds := make([]int32, 0, len(artists))
+	for _, ia := range artists {
+		mbid := uuid.Nil
+		if ia.MBID != nil {
+			mbid = *ia.MBID
+		}
+		artist, err := store.GetArtist(ctx, db.GetArtistOpts{
+			MusicBrainzID: mbid,
+			Name:          getPrimaryAliasFromAliasSlice(ia.Aliases),
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			var imgid = uuid.Nil
+			// not a perfect way to check if the image url is an actual source vs manual upload but
+			// im like 99% sure it will work perfectly
+			if strings.HasPrefix(ia.ImageUrl, "http") {
+				imgid = uuid.New()
+			}
+			// save artist
+			artist, err := store.SaveArtist(ctx, db.SaveArtistOpts{
+				Name:          getPrimaryAliasFromAliasSlice(ia.Aliases),
+				Image:         imgid,
+				ImageSrc:      ia.ImageUrl,
+				MusicBrainzID: mbid,
+				Aliases:       utils.FlattenAliases(ia.Aliases),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("importKoitoArtists: %w", err)
+			}
+			artistIds = append(artistIds, artist.ID)
+		} else if err != nil {
+			return nil, fmt.Errorf("importKoitoArtists: %w", err)
+		} else {
+			artistIds = append(artistIds, artist.ID)
+		}
+	}
+	return artistIds, nil
+}
+
+// koitoArtistCredit returns the artist credit of exported artists, or nil if it was not exported.
+// Artists that were matched to the same artist only appear once in the credit.
+func koitoArtistCredit(artists []export.KoitoArtist, artistIds []int32) []db.ArtistCredit {
+	var credit []db.ArtistCredit
+	for i, a := range artists {
+		if a.CreditedName == "" {
+			break
+		}
+		if slices.ContainsFunc(credit, func(c db.ArtistCredit) bool { return c.ArtistID == artistIds[i] }) {
+			continue
+		}
+		credit = append(credit, db.ArtistCredit{
+			ArtistID:   artistIds[i],
+			Name:       a.CreditedName,
+			JoinPhrase: a.JoinPhrase,
+		})
+	}
+	return credit
+}
+
 func getPrimaryAliasFromAliasSlice(aliases []models.Alias) string {
 	for _, a := range aliases {
 		if a.Primary {
@@ -134,6 +134,7 @@ func ImportListenBrainzFile(ctx context.Context, store db.DB, mbzc mbz.MusicBrai
 			TrackTitle:         payload.TrackMeta.TrackName,
 			RecordingMbzID:     recordingMbzID,
 			ReleaseTitle:       payload.TrackMeta.ReleaseName,
+			AlbumArtist:        payload.TrackMeta.AdditionalInfo.AlbumArtist,
 			ReleaseMbzID:       releaseMbzID,
 			ReleaseGroupMbzID:  rgMbzID,
 			ArtistMbidMappings: artistMbidMap,
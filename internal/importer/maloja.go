@@ -6,7 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path"
-	"strings"
+	"regexp"
 	"time"
 
 	"github.com/gabehf/koito/internal/catalog"
@@ -32,6 +32,20 @@ type MalojaTrack struct {
 	} `json:"album"`
 }
 
+const malojaArtistSeparator = " \u2022 "
+
+var malojaArtistSeparatorPattern = regexp.MustCompile(regexp.QuoteMeta(malojaArtistSeparator))
+
+// splitMalojaArtists splits the artists of a scrobble on Maloja's separator and the custom separators,
+// keeping protected artist names whole
+func splitMalojaArtists(artists []string, rules catalog.ArtistSplitRules) []string {
+	martists := make([]string, 0)
+	for _, an := range artists {
+		martists = append(martists, rules.Split(an, malojaArtistSeparatorPattern)...)
+	}
+	return utils.UniqueIgnoringCase(martists)
+}
+
 func ImportMalojaFile(ctx context.Context, store db.DB, filename string) error {
 	l := logger.FromContext(ctx)
 	l.Info().Msgf("Beginning maloja import on file: %s", filename)
@@ -52,16 +66,17 @@ func ImportMalojaFile(ctx context.Context, store db.DB, filename string) error {
 	if err != nil {
 		return fmt.Errorf("ImportMalojaFile: %w", err)
 	}
+	rules, err := catalog.LoadArtistSplitRules(ctx, store)
+	if err != nil {
+		return fmt.Errorf("ImportMalojaFile: %w", err)
+	}
+	// the same rules are applied to every scrobble
+	rules = rules.Compile()
 	for _, item := range export.Scrobbles {
-		martists := make([]string, 0)
 		// Maloja has a tendency to have the the artist order ['feature', 'main \u2022 feature'], so
 		// here we try to turn that artist array into ['main', 'feature']
-		item.Track.Artists = utils.MoveFirstMatchToFront(item.Track.Artists, " \u2022 ")
-		for _, an := range item.Track.Artists {
-			ans := strings.Split(an, " \u2022 ")
-			martists = append(martists, ans...)
-		}
-		artists := utils.UniqueIgnoringCase(martists)
+		item.Track.Artists = utils.MoveFirstMatchToFront(item.Track.Artists, malojaArtistSeparator)
+		artists := splitMalojaArtists(item.Track.Artists, rules)
 		if len(item.Track.Artists) < 1 || item.Track.Title == "" {
 			l.Debug().Msg("Skipping invalid maloja import item")
 			continue
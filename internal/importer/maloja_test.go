@@ -0,0 +1,36 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/gabehf/koito/internal/catalog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitMalojaArtists(t *testing.T) {
+	rules := catalog.ArtistSplitRules{
+		Separators: []string{";", " / "},
+		Protected:  []string{"Simon & Garfunkel", "AC/DC", "Earth • Wind"},
+	}
+	cases := []struct {
+		artists []string
+		rules   catalog.ArtistSplitRules
+		want    []string
+	}{
+		{[]string{"NELKE"}, catalog.ArtistSplitRules{}, []string{"NELKE"}},
+		{[]string{"Main • Feature"}, catalog.ArtistSplitRules{}, []string{"Main", "Feature"}},
+		{[]string{"Main • Feature", "Feature"}, catalog.ArtistSplitRules{}, []string{"Main", "Feature"}},
+		{[]string{"Hatsune Miku; Kagamine Rin"}, catalog.ArtistSplitRules{}, []string{"Hatsune Miku; Kagamine Rin"}},
+		{[]string{"Hatsune Miku; Kagamine Rin"}, rules, []string{"Hatsune Miku", "Kagamine Rin"}},
+		{[]string{"Main • Feature / Other"}, rules, []string{"Main", "Feature", "Other"}},
+		{[]string{"AC/DC"}, rules, []string{"AC/DC"}},
+		{[]string{"Simon & Garfunkel • AC/DC"}, rules, []string{"Simon & Garfunkel", "AC/DC"}},
+		{[]string{"Earth • Wind • Fire"}, rules, []string{"Earth • Wind", "Fire"}},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.want, splitMalojaArtists(c.artists, c.rules), "splitting %q", c.artists)
+		// compiled rules, as used by the import, split the same way
+		assert.Equal(t, c.want, splitMalojaArtists(c.artists, c.rules.Compile()), "splitting %q with compiled rules", c.artists)
+	}
+}
@@ -0,0 +1,128 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gabehf/koito/internal/db"
+	"github.com/gabehf/koito/internal/logger"
+	"github.com/google/uuid"
+)
+
+// entity types that can be imported from a MusicBrainz JSON data dump
+var mbzDumpEntities = []string{"artist", "release-group", "release", "recording"}
+
+const mbzDumpBatchSize = 1000
+
+// a single entity can be several megabytes long
+const mbzDumpMaxLineSize = 64 << 20
+
+type mbzDumpEntity struct {
+	ID           string `json:"id"`
+	ReleaseGroup *struct {
+		ID string `json:"id"`
+	} `json:"release-group"`
+}
+
+// ImportMusicBrainzDump imports one entity file from a MusicBrainz JSON data dump (e.g. mbdump/release-group),
+// which contains one JSON encoded entity per line. The entity type is taken from the file name, and the file
+// may be gzip compressed. Entities that were already imported are replaced.
+func ImportMusicBrainzDump(ctx context.Context, store db.DB, filename string) error {
+	l := logger.FromContext(ctx)
+
+	entity := mbzDumpEntityType(filename)
+	if entity == "" {
+		return fmt.Errorf("ImportMusicBrainzDump: could not determine entity type of '%s'; file name must be one of %v", filename, mbzDumpEntities)
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return fmt.Errorf("ImportMusicBrainzDump: %w", err)
+	}
+	defer file.Close()
+
+	var r io.Reader = file
+	if strings.HasSuffix(filename, ".gz") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return fmt.Errorf("ImportMusicBrainzDump: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	l.Info().Msgf("Importing MusicBrainz %s entities from %s", entity, filename)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 1024*1024), mbzDumpMaxLineSize)
+	batch := make([]db.SaveMbzLocalEntityOpts, 0, mbzDumpBatchSize)
+	count := 0
+	line := 0
+	for scanner.Scan() {
+		line++
+		body := scanner.Bytes()
+		if len(bytes.TrimSpace(body)) == 0 {
+			continue
+		}
+		var e mbzDumpEntity
+		if err := json.Unmarshal(body, &e); err != nil {
+			l.Warn().Err(err).Msgf("Skipping invalid entity on line %d of %s", line, filename)
+			continue
+		}
+		id, err := uuid.Parse(e.ID)
+		if err != nil {
+			l.Warn().Err(err).Msgf("Skipping entity with invalid MusicBrainz ID on line %d of %s", line, filename)
+			continue
+		}
+		opts := db.SaveMbzLocalEntityOpts{
+			MusicBrainzID: id,
+			// the scanner reuses its buffer
+			Body: slices.Clone(body),
+		}
+		if e.ReleaseGroup != nil {
+			if rgID, err := uuid.Parse(e.ReleaseGroup.ID); err == nil {
+				opts.ReleaseGroupID = &rgID
+			}
+		}
+		batch = append(batch, opts)
+		if len(batch) >= mbzDumpBatchSize {
+			if err := store.SaveMbzLocalEntities(ctx, entity, batch); err != nil {
+				return fmt.Errorf("ImportMusicBrainzDump: %w", err)
+			}
+			count += len(batch)
+			batch = batch[:0]
+			l.Debug().Msgf("Imported %d MusicBrainz %s entities", count, entity)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("ImportMusicBrainzDump: %w", err)
+	}
+	if len(batch) > 0 {
+		if err := store.SaveMbzLocalEntities(ctx, entity, batch); err != nil {
+			return fmt.Errorf("ImportMusicBrainzDump: %w", err)
+		}
+		count += len(batch)
+	}
+
+	l.Info().Msgf("Finished importing %s; imported %d MusicBrainz %s entities", filename, count, entity)
+	return nil
+}
+
+func mbzDumpEntityType(filename string) string {
+	name := filepath.Base(filename)
+	name = strings.TrimSuffix(name, ".gz")
+	name = strings.TrimSuffix(name, filepath.Ext(name))
+	if slices.Contains(mbzDumpEntities, name) {
+		return name
+	}
+	return ""
+}
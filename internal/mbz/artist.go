@@ -11,11 +11,30 @@ import (
 )
 
 type MusicBrainzArtist struct {
-	Name     string                   `json:"name"`
-	SortName string                   `json:"sort_name"`
-	Gender   string                   `json:"gender"`
-	Area     MusicBrainzArea          `json:"area"`
-	Aliases  []MusicBrainzArtistAlias `json:"aliases"`
+	ID        string                      `json:"id"`
+	Name      string                      `json:"name"`
+	SortName  string                      `json:"sort-name"`
+	Type      string                      `json:"type"`
+	Gender    string                      `json:"gender"`
+	Area      MusicBrainzArea             `json:"area"`
+	LifeSpan  MusicBrainzLifeSpan         `json:"life-span"`
+	Aliases   []MusicBrainzArtistAlias    `json:"aliases"`
+	Genres    []MusicBrainzTag            `json:"genres"`
+	Tags      []MusicBrainzTag            `json:"tags"`
+	Relations []MusicBrainzArtistRelation `json:"relations"`
+}
+type MusicBrainzLifeSpan struct {
+	Begin string `json:"begin"`
+	End   string `json:"end"`
+	Ended bool   `json:"ended"`
+}
+
+// A relationship between two artists. Direction is "forward" when the artist the relationship was
+// fetched with is the first artist of the relationship type, e.g. the member for "member of band"
+type MusicBrainzArtistRelation struct {
+	Type      string            `json:"type"`
+	Direction string            `json:"direction"`
+	Artist    MusicBrainzArtist `json:"artist"`
 }
 type MusicBrainzArtistAlias struct {
 	Name    string `json:"name"`
@@ -23,27 +42,31 @@ type MusicBrainzArtistAlias struct {
 	Primary bool   `json:"primary"`
 }
 
-const artistAliasFmtStr = "%s/ws/2/artist/%s?inc=aliases"
+const artistAliasFmtStr = "%s/ws/2/artist/%s?inc=aliases+genres+tags+artist-rels"
 
-func (c *MusicBrainzClient) getArtist(ctx context.Context, id uuid.UUID) (*MusicBrainzArtist, error) {
+func (c *MusicBrainzClient) GetArtist(ctx context.Context, id uuid.UUID) (*MusicBrainzArtist, error) {
 	mbzArtist := new(MusicBrainzArtist)
-	err := c.getEntity(ctx, artistAliasFmtStr, id, mbzArtist)
+	err := c.getEntity(ctx, "artist", artistAliasFmtStr, id, mbzArtist)
 	if err != nil {
-		return nil, fmt.Errorf("getArtist: %w", err)
+		return nil, fmt.Errorf("GetArtist: %w", err)
 	}
 	return mbzArtist, nil
 }
 
 // Returns the artist name at index 0, and all primary aliases after.
 func (c *MusicBrainzClient) GetArtistPrimaryAliases(ctx context.Context, id uuid.UUID) ([]string, error) {
-	l := logger.FromContext(ctx)
-	artist, err := c.getArtist(ctx, id)
+	artist, err := c.GetArtist(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("GetArtistPrimaryAliases: %w", err)
 	}
 	if artist == nil {
 		return nil, errors.New("GetArtistPrimaryAliases: artist could not be found by musicbrainz")
 	}
+	return artistPrimaryAliases(ctx, artist), nil
+}
+
+func artistPrimaryAliases(ctx context.Context, artist *MusicBrainzArtist) []string {
+	l := logger.FromContext(ctx)
 	used := make(map[string]bool)
 	ret := make([]string, 1)
 	ret[0] = artist.Name
@@ -54,5 +77,5 @@ func (c *MusicBrainzClient) GetArtistPrimaryAliases(ctx context.Context, id uuid
 			ret = append(ret, alias.Name)
 		}
 	}
-	return ret, nil
+	return ret
 }
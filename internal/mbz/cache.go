@@ -0,0 +1,66 @@
+package mbz
+
+import (
+	"context"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ResponseCache stores raw MusicBrainz responses, keyed by entity type and MBID, so that
+// entities which have already been requested don't need to wait on the rate limit again.
+// Entries remember the inc parameter they were requested with, and are only returned for
+// requests with the same includes.
+type ResponseCache interface {
+	GetMbzCacheEntry(ctx context.Context, entity string, id uuid.UUID, includes string, fetchedAfter time.Time) ([]byte, error)
+	SaveMbzCacheEntry(ctx context.Context, entity string, id uuid.UUID, includes string, body []byte) error
+}
+
+type CacheStats struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
+
+var (
+	cacheHits   atomic.Int64
+	cacheMisses atomic.Int64
+)
+
+// GetCacheStats returns the number of cache hits and misses since the server started.
+func GetCacheStats() CacheStats {
+	return CacheStats{
+		Hits:   cacheHits.Load(),
+		Misses: cacheMisses.Load(),
+	}
+}
+
+// requestIncludes returns the inc parameter of a MusicBrainz request url
+func requestIncludes(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get("inc")
+}
+
+func (c *MusicBrainzClient) getCachedEntity(ctx context.Context, entity string, id uuid.UUID, includes string) ([]byte, bool) {
+	if c.cache == nil {
+		return nil, false
+	}
+	body, err := c.cache.GetMbzCacheEntry(ctx, entity, id, includes, time.Now().Add(-c.cacheTTL))
+	if err != nil {
+		cacheMisses.Add(1)
+		return nil, false
+	}
+	cacheHits.Add(1)
+	return body, true
+}
+
+func (c *MusicBrainzClient) cacheEntity(ctx context.Context, entity string, id uuid.UUID, includes string, body []byte) error {
+	if c.cache == nil {
+		return nil
+	}
+	return c.cache.SaveMbzCacheEntry(ctx, entity, id, includes, body)
+}
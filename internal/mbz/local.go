@@ -0,0 +1,121 @@
+package mbz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// LocalStore provides MusicBrainz entities that were imported from a MusicBrainz JSON data dump.
+type LocalStore interface {
+	GetMbzLocalEntity(ctx context.Context, entity string, id uuid.UUID) ([]byte, error)
+	GetMbzLocalReleases(ctx context.Context, releaseGroupID uuid.UUID) ([][]byte, error)
+}
+
+// MbzLocalCaller answers MusicBrainz lookups using only the entities in a LocalStore, for servers
+// that cannot reach MusicBrainz. Searching is not supported.
+type MbzLocalCaller struct {
+	store LocalStore
+}
+
+var ErrSearchUnavailable = errors.New("search is not available when using local MusicBrainz data")
+
+func NewMbzLocalCaller(store LocalStore) *MbzLocalCaller {
+	return &MbzLocalCaller{store: store}
+}
+
+func (m *MbzLocalCaller) getEntity(ctx context.Context, entity string, id uuid.UUID, result any) error {
+	body, err := m.store.GetMbzLocalEntity(ctx, entity, id)
+	if err != nil {
+		return fmt.Errorf("getEntity: %s %s: %w", entity, id, err)
+	}
+	err = json.Unmarshal(body, result)
+	if err != nil {
+		return fmt.Errorf("getEntity: %s %s: %w", entity, id, err)
+	}
+	return nil
+}
+
+func (m *MbzLocalCaller) GetArtist(ctx context.Context, id uuid.UUID) (*MusicBrainzArtist, error) {
+	artist := new(MusicBrainzArtist)
+	err := m.getEntity(ctx, "artist", id, artist)
+	if err != nil {
+		return nil, fmt.Errorf("GetArtist: %w", err)
+	}
+	return artist, nil
+}
+
+// Returns the artist name at index 0, and all primary aliases after.
+func (m *MbzLocalCaller) GetArtistPrimaryAliases(ctx context.Context, id uuid.UUID) ([]string, error) {
+	artist, err := m.GetArtist(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("GetArtistPrimaryAliases: %w", err)
+	}
+	return artistPrimaryAliases(ctx, artist), nil
+}
+
+// GetReleaseGroup returns the release group along with all of its releases that have been imported.
+func (m *MbzLocalCaller) GetReleaseGroup(ctx context.Context, id uuid.UUID) (*MusicBrainzReleaseGroup, error) {
+	rg := new(MusicBrainzReleaseGroup)
+	err := m.getEntity(ctx, "release-group", id, rg)
+	if err != nil {
+		return nil, fmt.Errorf("GetReleaseGroup: %w", err)
+	}
+	bodies, err := m.store.GetMbzLocalReleases(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("GetReleaseGroup: %w", err)
+	}
+	rg.Releases = make([]MusicBrainzRelease, 0, len(bodies))
+	for _, body := range bodies {
+		var release MusicBrainzRelease
+		err = json.Unmarshal(body, &release)
+		if err != nil {
+			return nil, fmt.Errorf("GetReleaseGroup: %w", err)
+		}
+		rg.Releases = append(rg.Releases, release)
+	}
+	return rg, nil
+}
+
+func (m *MbzLocalCaller) GetRelease(ctx context.Context, id uuid.UUID) (*MusicBrainzRelease, error) {
+	release := new(MusicBrainzRelease)
+	err := m.getEntity(ctx, "release", id, release)
+	if err != nil {
+		return nil, fmt.Errorf("GetRelease: %w", err)
+	}
+	return release, nil
+}
+
+func (m *MbzLocalCaller) GetReleaseTitles(ctx context.Context, RGID uuid.UUID) ([]string, error) {
+	rg, err := m.GetReleaseGroup(ctx, RGID)
+	if err != nil {
+		return nil, fmt.Errorf("GetReleaseTitles: %w", err)
+	}
+	return ReleaseGroupToTitles(rg), nil
+}
+
+func (m *MbzLocalCaller) GetTrack(ctx context.Context, id uuid.UUID) (*MusicBrainzTrack, error) {
+	track := new(MusicBrainzTrack)
+	err := m.getEntity(ctx, "recording", id, track)
+	if err != nil {
+		return nil, fmt.Errorf("GetTrack: %w", err)
+	}
+	return track, nil
+}
+
+func (m *MbzLocalCaller) SearchArtists(ctx context.Context, opts SearchArtistsOpts) ([]MusicBrainzArtistSearchResult, error) {
+	return nil, fmt.Errorf("SearchArtists: %w", ErrSearchUnavailable)
+}
+
+func (m *MbzLocalCaller) SearchReleases(ctx context.Context, opts SearchReleasesOpts) ([]MusicBrainzReleaseSearchResult, error) {
+	return nil, fmt.Errorf("SearchReleases: %w", ErrSearchUnavailable)
+}
+
+func (m *MbzLocalCaller) SearchRecordings(ctx context.Context, opts SearchRecordingsOpts) ([]MusicBrainzRecordingSearchResult, error) {
+	return nil, fmt.Errorf("SearchRecordings: %w", ErrSearchUnavailable)
+}
+
+func (m *MbzLocalCaller) Shutdown() {}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/gabehf/koito/internal/cfg"
 	"github.com/gabehf/koito/internal/logger"
@@ -23,22 +24,34 @@ type MusicBrainzClient struct {
 	url          string
 	userAgent    string
 	requestQueue *queue.RequestQueue
+	cache        ResponseCache
+	cacheTTL     time.Duration
 }
 
 type MusicBrainzCaller interface {
+	GetArtist(ctx context.Context, id uuid.UUID) (*MusicBrainzArtist, error)
 	GetArtistPrimaryAliases(ctx context.Context, id uuid.UUID) ([]string, error)
 	GetReleaseTitles(ctx context.Context, RGID uuid.UUID) ([]string, error)
 	GetTrack(ctx context.Context, id uuid.UUID) (*MusicBrainzTrack, error)
 	GetReleaseGroup(ctx context.Context, id uuid.UUID) (*MusicBrainzReleaseGroup, error)
 	GetRelease(ctx context.Context, id uuid.UUID) (*MusicBrainzRelease, error)
+	SearchArtists(ctx context.Context, opts SearchArtistsOpts) ([]MusicBrainzArtistSearchResult, error)
+	SearchReleases(ctx context.Context, opts SearchReleasesOpts) ([]MusicBrainzReleaseSearchResult, error)
+	SearchRecordings(ctx context.Context, opts SearchRecordingsOpts) ([]MusicBrainzRecordingSearchResult, error)
 	Shutdown()
 }
 
-func NewMusicBrainzClient() *MusicBrainzClient {
+// NewMusicBrainzClient creates a client for the configured MusicBrainz server. Responses are stored
+// in cache, unless it is nil or the cache TTL is set to 0.
+func NewMusicBrainzClient(cache ResponseCache) *MusicBrainzClient {
 	ret := new(MusicBrainzClient)
 	ret.url = cfg.MusicBrainzUrl()
 	ret.userAgent = cfg.UserAgent()
 	ret.requestQueue = queue.NewRequestQueue(cfg.MusicBrainzRateLimit(), cfg.MusicBrainzRateLimit())
+	if cfg.MusicBrainzCacheTTL() > 0 {
+		ret.cache = cache
+		ret.cacheTTL = cfg.MusicBrainzCacheTTL()
+	}
 	return ret
 }
 
@@ -46,9 +59,18 @@ func (c *MusicBrainzClient) Shutdown() {
 	c.requestQueue.Shutdown()
 }
 
-func (c *MusicBrainzClient) getEntity(ctx context.Context, fmtStr string, id uuid.UUID, result any) error {
+func (c *MusicBrainzClient) getEntity(ctx context.Context, entity string, fmtStr string, id uuid.UUID, result any) error {
 	l := logger.FromContext(ctx)
 	url := fmt.Sprintf(fmtStr, c.url, id.String())
+	includes := requestIncludes(url)
+	if body, ok := c.getCachedEntity(ctx, entity, id, includes); ok {
+		err := json.Unmarshal(body, result)
+		if err == nil {
+			l.Debug().Msgf("Using cached MusicBrainz response for %s %s", entity, id)
+			return nil
+		}
+		l.Warn().Err(err).Msgf("Failed to unmarshal cached MusicBrainz response for %s %s", entity, id)
+	}
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		l.Err(err).Msg("Failed to build MusicBrainz request")
@@ -67,6 +89,11 @@ func (c *MusicBrainzClient) getEntity(ctx context.Context, fmtStr string, id uui
 		return fmt.Errorf("getEntity: %w", err)
 	}
 
+	err = c.cacheEntity(ctx, entity, id, includes, body)
+	if err != nil {
+		l.Warn().Err(err).Msgf("Failed to cache MusicBrainz response for %s %s", entity, id)
+	}
+
 	return nil
 }
 
@@ -1,9 +1,11 @@
 package mbz
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/google/uuid"
 )
@@ -56,6 +58,14 @@ func (m *MbzMockCaller) GetTrack(ctx context.Context, id uuid.UUID) (*MusicBrain
 	return track, nil
 }
 
+func (m *MbzMockCaller) GetArtist(ctx context.Context, id uuid.UUID) (*MusicBrainzArtist, error) {
+	artist, exists := m.Artists[id]
+	if !exists {
+		return nil, fmt.Errorf("artist with ID %s not found", id)
+	}
+	return artist, nil
+}
+
 func (m *MbzMockCaller) GetArtistPrimaryAliases(ctx context.Context, id uuid.UUID) ([]string, error) {
 	artist, exists := m.Artists[id]
 	if !exists {
@@ -70,6 +80,85 @@ func (m *MbzMockCaller) GetArtistPrimaryAliases(ctx context.Context, id uuid.UUI
 	return ss, nil
 }
 
+// exact matches score 100, partial matches score 80
+func mockSearchScore(query, name string) int {
+	if strings.EqualFold(query, name) {
+		return 100
+	}
+	if strings.Contains(strings.ToLower(name), strings.ToLower(query)) {
+		return 80
+	}
+	return 0
+}
+
+func mockCreditMatches(artist string, credits []MusicBrainzArtistCredit) bool {
+	if artist == "" || len(credits) == 0 {
+		return true
+	}
+	for _, c := range credits {
+		if strings.EqualFold(c.Name, artist) || strings.EqualFold(c.Artist.Name, artist) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MbzMockCaller) SearchArtists(ctx context.Context, opts SearchArtistsOpts) ([]MusicBrainzArtistSearchResult, error) {
+	results := make([]MusicBrainzArtistSearchResult, 0)
+	for id, artist := range m.Artists {
+		if score := mockSearchScore(opts.Name, artist.Name); score > 0 && score >= opts.MinScore {
+			results = append(results, MusicBrainzArtistSearchResult{
+				ID:      id.String(),
+				Score:   score,
+				Name:    artist.Name,
+				Aliases: artist.Aliases,
+			})
+		}
+	}
+	slices.SortFunc(results, func(a, b MusicBrainzArtistSearchResult) int {
+		return cmp.Or(b.Score-a.Score, strings.Compare(a.ID, b.ID))
+	})
+	return results, nil
+}
+
+func (m *MbzMockCaller) SearchReleases(ctx context.Context, opts SearchReleasesOpts) ([]MusicBrainzReleaseSearchResult, error) {
+	results := make([]MusicBrainzReleaseSearchResult, 0)
+	for id, release := range m.Releases {
+		score := mockSearchScore(opts.Title, release.Title)
+		if score > 0 && score >= opts.MinScore && mockCreditMatches(opts.Artist, release.ArtistCredit) {
+			results = append(results, MusicBrainzReleaseSearchResult{
+				ID:           id.String(),
+				Score:        score,
+				Title:        release.Title,
+				ArtistCredit: release.ArtistCredit,
+				ReleaseGroup: release.ReleaseGroup,
+			})
+		}
+	}
+	slices.SortFunc(results, func(a, b MusicBrainzReleaseSearchResult) int {
+		return cmp.Or(b.Score-a.Score, strings.Compare(a.ID, b.ID))
+	})
+	return results, nil
+}
+
+func (m *MbzMockCaller) SearchRecordings(ctx context.Context, opts SearchRecordingsOpts) ([]MusicBrainzRecordingSearchResult, error) {
+	results := make([]MusicBrainzRecordingSearchResult, 0)
+	for id, track := range m.Tracks {
+		score := mockSearchScore(opts.Title, track.Title)
+		if score > 0 && score >= opts.MinScore && mockCreditMatches(opts.Artist, track.ArtistCredit) {
+			results = append(results, MusicBrainzRecordingSearchResult{
+				ID:               id.String(),
+				Score:            score,
+				MusicBrainzTrack: *track,
+			})
+		}
+	}
+	slices.SortFunc(results, func(a, b MusicBrainzRecordingSearchResult) int {
+		return cmp.Or(b.Score-a.Score, strings.Compare(a.ID, b.ID))
+	})
+	return results, nil
+}
+
 func (m *MbzMockCaller) Shutdown() {}
 
 type MbzErrorCaller struct{}
@@ -90,8 +179,24 @@ func (m *MbzErrorCaller) GetTrack(ctx context.Context, id uuid.UUID) (*MusicBrai
 	return nil, fmt.Errorf("error: GetTrack not implemented")
 }
 
+func (m *MbzErrorCaller) GetArtist(ctx context.Context, id uuid.UUID) (*MusicBrainzArtist, error) {
+	return nil, fmt.Errorf("error: GetArtist not implemented")
+}
+
 func (m *MbzErrorCaller) GetArtistPrimaryAliases(ctx context.Context, id uuid.UUID) ([]string, error) {
 	return nil, fmt.Errorf("error: GetArtistPrimaryAliases not implemented")
 }
 
+func (m *MbzErrorCaller) SearchArtists(ctx context.Context, opts SearchArtistsOpts) ([]MusicBrainzArtistSearchResult, error) {
+	return nil, fmt.Errorf("error: SearchArtists not implemented")
+}
+
+func (m *MbzErrorCaller) SearchReleases(ctx context.Context, opts SearchReleasesOpts) ([]MusicBrainzReleaseSearchResult, error) {
+	return nil, fmt.Errorf("error: SearchReleases not implemented")
+}
+
+func (m *MbzErrorCaller) SearchRecordings(ctx context.Context, opts SearchRecordingsOpts) ([]MusicBrainzRecordingSearchResult, error) {
+	return nil, fmt.Errorf("error: SearchRecordings not implemented")
+}
+
 func (m *MbzErrorCaller) Shutdown() {}
@@ -9,33 +9,63 @@ import (
 )
 
 type MusicBrainzReleaseGroup struct {
-	Title        string                    `json:"title"`
-	Type         string                    `json:"primary_type"`
-	ArtistCredit []MusicBrainzArtistCredit `json:"artist-credit"`
-	Releases     []MusicBrainzRelease      `json:"releases"`
+	Title            string                    `json:"title"`
+	Type             string                    `json:"primary-type"`
+	SecondaryTypes   []string                  `json:"secondary-types"`
+	FirstReleaseDate string                    `json:"first-release-date"`
+	ArtistCredit     []MusicBrainzArtistCredit `json:"artist-credit"`
+	Releases         []MusicBrainzRelease      `json:"releases"`
+	Genres           []MusicBrainzTag          `json:"genres"`
+	Tags             []MusicBrainzTag          `json:"tags"`
 }
 type MusicBrainzRelease struct {
-	Title              string                    `json:"title"`
-	ID                 string                    `json:"id"`
-	ArtistCredit       []MusicBrainzArtistCredit `json:"artist-credit"`
-	Status             string                    `json:"status"`
-	TextRepresentation TextRepresentation        `json:"text-representation"`
+	Title              string                         `json:"title"`
+	ID                 string                         `json:"id"`
+	ArtistCredit       []MusicBrainzArtistCredit      `json:"artist-credit"`
+	Status             string                         `json:"status"`
+	TextRepresentation TextRepresentation             `json:"text-representation"`
+	ReleaseGroup       MusicBrainzReleaseGroupSummary `json:"release-group"`
+	Media              []MusicBrainzMedium            `json:"media"`
+}
+type MusicBrainzReleaseGroupSummary struct {
+	ID          string `json:"id"`
+	PrimaryType string `json:"primary-type"`
+}
+
+// A disc (or other medium) of a release, with its tracklist
+type MusicBrainzMedium struct {
+	Position int                       `json:"position"`
+	Format   string                    `json:"format"`
+	Tracks   []MusicBrainzReleaseTrack `json:"tracks"`
+}
+type MusicBrainzReleaseTrack struct {
+	ID        string                      `json:"id"`
+	Position  int                         `json:"position"`
+	Title     string                      `json:"title"`
+	LengthMs  int                         `json:"length"`
+	Recording MusicBrainzRecordingSummary `json:"recording"`
+}
+type MusicBrainzRecordingSummary struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	LengthMs int    `json:"length"`
 }
 type MusicBrainzArtistCredit struct {
-	Artist MusicBrainzArtist `json:"artist"`
-	Name   string            `json:"name"`
+	Artist     MusicBrainzArtist `json:"artist"`
+	Name       string            `json:"name"`
+	JoinPhrase string            `json:"joinphrase"`
 }
 type TextRepresentation struct {
 	Language string `json:"language"`
 	Script   string `json:"script"`
 }
 
-const releaseGroupFmtStr = "%s/ws/2/release-group/%s?inc=releases+artists"
-const releaseFmtStr = "%s/ws/2/release/%s?inc=artists"
+const releaseGroupFmtStr = "%s/ws/2/release-group/%s?inc=releases+artists+genres+tags"
+const releaseFmtStr = "%s/ws/2/release/%s?inc=artists+release-groups+recordings"
 
 func (c *MusicBrainzClient) GetReleaseGroup(ctx context.Context, id uuid.UUID) (*MusicBrainzReleaseGroup, error) {
 	mbzRG := new(MusicBrainzReleaseGroup)
-	err := c.getEntity(ctx, releaseGroupFmtStr, id, mbzRG)
+	err := c.getEntity(ctx, "release-group", releaseGroupFmtStr, id, mbzRG)
 	if err != nil {
 		return nil, fmt.Errorf("GetReleaseGroup: %w", err)
 	}
@@ -44,7 +74,7 @@ func (c *MusicBrainzClient) GetReleaseGroup(ctx context.Context, id uuid.UUID) (
 
 func (c *MusicBrainzClient) GetRelease(ctx context.Context, id uuid.UUID) (*MusicBrainzRelease, error) {
 	mbzRelease := new(MusicBrainzRelease)
-	err := c.getEntity(ctx, releaseFmtStr, id, mbzRelease)
+	err := c.getEntity(ctx, "release", releaseFmtStr, id, mbzRelease)
 	if err != nil {
 		return nil, fmt.Errorf("GetRelease: %w", err)
 	}
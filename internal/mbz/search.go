@@ -0,0 +1,158 @@
+package mbz
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gabehf/koito/internal/logger"
+)
+
+type MusicBrainzArtistSearchResult struct {
+	ID      string                   `json:"id"`
+	Score   int                      `json:"score"`
+	Name    string                   `json:"name"`
+	Aliases []MusicBrainzArtistAlias `json:"aliases"`
+}
+type MusicBrainzReleaseSearchResult struct {
+	ID           string                         `json:"id"`
+	Score        int                            `json:"score"`
+	Title        string                         `json:"title"`
+	ArtistCredit []MusicBrainzArtistCredit      `json:"artist-credit"`
+	ReleaseGroup MusicBrainzReleaseGroupSummary `json:"release-group"`
+}
+type MusicBrainzRecordingSearchResult struct {
+	ID    string `json:"id"`
+	Score int    `json:"score"`
+	MusicBrainzTrack
+}
+
+const searchFmtStr = "%s/ws/2/%s?%s"
+const searchLimit = 5
+
+// in seconds
+const searchDurationLeeway = 10
+
+// characters with special meaning in lucene queries
+var luceneReplacer = strings.NewReplacer(
+	`\`, `\\`, `+`, `\+`, `-`, `\-`, `!`, `\!`, `(`, `\(`, `)`, `\)`, `{`, `\{`, `}`, `\}`,
+	`[`, `\[`, `]`, `\]`, `^`, `\^`, `"`, `\"`, `~`, `\~`, `*`, `\*`, `?`, `\?`, `:`, `\:`,
+	`/`, `\/`, `&&`, `\&&`, `||`, `\||`,
+)
+
+// Escapes s so it can be used as a phrase in a lucene query.
+func EscapeLucene(s string) string {
+	return luceneReplacer.Replace(s)
+}
+
+func (c *MusicBrainzClient) search(ctx context.Context, entity string, query string, result any) error {
+	l := logger.FromContext(ctx)
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("limit", fmt.Sprint(searchLimit))
+	req, err := http.NewRequest("GET", fmt.Sprintf(searchFmtStr, c.url, entity, params.Encode()), nil)
+	if err != nil {
+		l.Err(err).Msg("Failed to build MusicBrainz search request")
+		return fmt.Errorf("search: %w", err)
+	}
+	l.Debug().Msgf("Adding MusicBrainz %s search to queue: %s", entity, query)
+	body, err := c.queue(ctx, req)
+	if err != nil {
+		l.Err(err).Msg("MusicBrainz search request failed")
+		return fmt.Errorf("search: %w", err)
+	}
+
+	err = json.Unmarshal(body, result)
+	if err != nil {
+		l.Err(err).Str("body", string(body)).Msg("Failed to unmarshal MusicBrainz search response body")
+		return fmt.Errorf("search: %w", err)
+	}
+
+	return nil
+}
+
+type SearchArtistsOpts struct {
+	Name string
+	// results with a lower score are not returned
+	MinScore int
+}
+
+type SearchReleasesOpts struct {
+	Title    string
+	Artist   string // optional
+	MinScore int
+}
+
+type SearchRecordingsOpts struct {
+	Title    string
+	Artist   string
+	Release  string // optional, ranks recordings on this release higher
+	Duration int32  // optional, in seconds; ranks recordings of about this length higher
+	MinScore int
+}
+
+// Returns the artists that best match the name, ordered by score.
+func (c *MusicBrainzClient) SearchArtists(ctx context.Context, opts SearchArtistsOpts) ([]MusicBrainzArtistSearchResult, error) {
+	result := new(struct {
+		Artists []MusicBrainzArtistSearchResult `json:"artists"`
+	})
+	query := fmt.Sprintf(`artist:"%s"`, EscapeLucene(opts.Name))
+	err := c.search(ctx, "artist", query, result)
+	if err != nil {
+		return nil, fmt.Errorf("SearchArtists: %w", err)
+	}
+	return filterByScore(result.Artists, opts.MinScore, func(r MusicBrainzArtistSearchResult) int { return r.Score }), nil
+}
+
+// Returns the releases that best match the title and artist, ordered by score.
+func (c *MusicBrainzClient) SearchReleases(ctx context.Context, opts SearchReleasesOpts) ([]MusicBrainzReleaseSearchResult, error) {
+	result := new(struct {
+		Releases []MusicBrainzReleaseSearchResult `json:"releases"`
+	})
+	query := fmt.Sprintf(`+release:"%s"`, EscapeLucene(opts.Title))
+	if opts.Artist != "" {
+		query += fmt.Sprintf(` +artist:"%s"`, EscapeLucene(opts.Artist))
+	}
+	err := c.search(ctx, "release", query, result)
+	if err != nil {
+		return nil, fmt.Errorf("SearchReleases: %w", err)
+	}
+	return filterByScore(result.Releases, opts.MinScore, func(r MusicBrainzReleaseSearchResult) int { return r.Score }), nil
+}
+
+// Returns the recordings that best match the title and artist, ordered by score. The release and
+// duration are not required to match, but recordings that do are ranked higher.
+func (c *MusicBrainzClient) SearchRecordings(ctx context.Context, opts SearchRecordingsOpts) ([]MusicBrainzRecordingSearchResult, error) {
+	result := new(struct {
+		Recordings []MusicBrainzRecordingSearchResult `json:"recordings"`
+	})
+	query := fmt.Sprintf(`+recording:"%s"`, EscapeLucene(opts.Title))
+	if opts.Artist != "" {
+		query += fmt.Sprintf(` +artist:"%s"`, EscapeLucene(opts.Artist))
+	}
+	if opts.Release != "" {
+		query += fmt.Sprintf(` release:"%s"`, EscapeLucene(opts.Release))
+	}
+	if opts.Duration > 0 {
+		// lengths are in milliseconds
+		query += fmt.Sprintf(` dur:[%d TO %d]`, max(opts.Duration-searchDurationLeeway, 0)*1000, (opts.Duration+searchDurationLeeway)*1000)
+	}
+	err := c.search(ctx, "recording", query, result)
+	if err != nil {
+		return nil, fmt.Errorf("SearchRecordings: %w", err)
+	}
+	return filterByScore(result.Recordings, opts.MinScore, func(r MusicBrainzRecordingSearchResult) int { return r.Score }), nil
+}
+
+func filterByScore[T any](results []T, minScore int, score func(T) int) []T {
+	ret := make([]T, 0, len(results))
+	for _, r := range results {
+		if score(r) >= minScore {
+			ret = append(ret, r)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,100 @@
+package mbz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscapeLucene(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected string
+	}{
+		{"Tokyo Calling", "Tokyo Calling"},
+		{"a+b", `a\+b`},
+		{"a-ha", `a\-ha`},
+		{"Rock && Roll", `Rock \&& Roll`},
+		{"this || that", `this \|| that`},
+		// single ampersands and pipes have no special meaning
+		{"Simon & Garfunkel", "Simon & Garfunkel"},
+		{"A|B", "A|B"},
+		{"Help!", `Help\!`},
+		{"(What's the Story) Morning Glory?", `\(What's the Story\) Morning Glory\?`},
+		{"{Braces}", `\{Braces\}`},
+		{"[Brackets]", `\[Brackets\]`},
+		{"x^2", `x\^2`},
+		{`"Quoted"`, `\"Quoted\"`},
+		{"~tilde", `\~tilde`},
+		{"*NSYNC", `\*NSYNC`},
+		{"Re:Zero", `Re\:Zero`},
+		{`back\slash`, `back\\slash`},
+		{"AC/DC", `AC\/DC`},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, EscapeLucene(tt.in), tt.in)
+	}
+}
+
+func TestFilterByScore(t *testing.T) {
+	results := []MusicBrainzArtistSearchResult{
+		{ID: "a", Score: 100},
+		{ID: "b", Score: 90},
+		{ID: "c", Score: 90},
+		{ID: "d", Score: 89},
+		{ID: "e", Score: 0},
+	}
+	score := func(r MusicBrainzArtistSearchResult) int { return r.Score }
+	ids := func(rs []MusicBrainzArtistSearchResult) []string {
+		ret := make([]string, len(rs))
+		for i, r := range rs {
+			ret[i] = r.ID
+		}
+		return ret
+	}
+
+	tests := []struct {
+		minScore int
+		expected []string
+	}{
+		// results scoring exactly the minimum are kept, ties keep their order
+		{90, []string{"a", "b", "c"}},
+		{91, []string{"a"}},
+		{89, []string{"a", "b", "c", "d"}},
+		{0, []string{"a", "b", "c", "d", "e"}},
+		{101, []string{}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, ids(filterByScore(results, tt.minScore, score)), "min score %d", tt.minScore)
+	}
+
+	assert.Empty(t, filterByScore(nil, 50, score))
+}
+
+func TestMockSearchTies(t *testing.T) {
+	ctx := context.Background()
+	m := &MbzMockCaller{
+		Artists: map[uuid.UUID]*MusicBrainzArtist{
+			uuid.MustParse("00000000-0000-0000-0000-000000000002"): {Name: "Necry Talkie"},
+			uuid.MustParse("00000000-0000-0000-0000-000000000001"): {Name: "NECRY TALKIE"},
+			uuid.MustParse("00000000-0000-0000-0000-000000000003"): {Name: "Necry Talkie Band"},
+		},
+	}
+
+	// exact matches tie at the top and are ordered by ID, the partial match scores lower
+	results, err := m.SearchArtists(ctx, SearchArtistsOpts{Name: "necry talkie"})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000001", results[0].ID)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000002", results[1].ID)
+	assert.Equal(t, results[0].Score, results[1].Score)
+	assert.Greater(t, results[1].Score, results[2].Score)
+
+	results, err = m.SearchArtists(ctx, SearchArtistsOpts{Name: "necry talkie", MinScore: results[0].Score})
+	require.NoError(t, err)
+	assert.Len(t, results, 2)
+}
@@ -0,0 +1,21 @@
+package mbz
+
+// A genre or user submitted tag, along with the number of votes it has
+type MusicBrainzTag struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// TagNames returns the names of all genres, followed by the names of all tags that have not been voted down.
+func TagNames(genres, tags []MusicBrainzTag) []string {
+	ret := make([]string, 0, len(genres)+len(tags))
+	for _, g := range genres {
+		ret = append(ret, g.Name)
+	}
+	for _, t := range tags {
+		if t.Count > 0 {
+			ret = append(ret, t.Name)
+		}
+	}
+	return ret
+}
@@ -1,16 +1,61 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
 
 type Album struct {
-	ID             int32          `json:"id"`
-	MbzID          *uuid.UUID     `json:"musicbrainz_id"`
-	Title          string         `json:"title"`
-	Image          *uuid.UUID     `json:"image"`
-	Artists        []SimpleArtist `json:"artists"`
-	VariousArtists bool           `json:"is_various_artists"`
-	ListenCount    int64          `json:"listen_count"`
-	TimeListened   int64          `json:"time_listened"`
+	ID                int32            `json:"id"`
+	MbzID             *uuid.UUID       `json:"musicbrainz_id"`
+	ReleaseGroupMbzID *uuid.UUID       `json:"release_group_musicbrainz_id"`
+	Title             string           `json:"title"`
+	Image             *uuid.UUID       `json:"image"`
+	Artists           []SimpleArtist   `json:"artists"`
+	VariousArtists    bool             `json:"is_various_artists"`
+	ReleaseDate       string           `json:"release_date"`
+	ReleaseType       string           `json:"release_type"`
+	SecondaryTypes    []string         `json:"secondary_types"`
+	ListenCount       int64            `json:"listen_count"`
+	TimeListened      int64            `json:"time_listened"`
+	EditionOf         *int32           `json:"edition_of,omitempty"`
+	Editions          []AlbumEdition   `json:"editions,omitempty"`
+	Completion        *AlbumCompletion `json:"completion,omitempty"`
+}
+
+// Albums include their formatted artist credit
+func (a Album) MarshalJSON() ([]byte, error) {
+	type album Album
+	return json.Marshal(struct {
+		album
+		ArtistCredit string `json:"artist_credit"`
+	}{album(a), ArtistCreditString(a.Artists)})
+}
+
+// An edition (deluxe, remaster, ...) of an album
+type AlbumEdition struct {
+	ID    int32  `json:"id"`
+	Title string `json:"title"`
+}
+
+// How much of the tracklist of an album has been listened to
+type AlbumCompletion struct {
+	TrackCount      int64   `json:"track_count"`
+	HeardTrackCount int64   `json:"heard_track_count"`
+	Percent         float64 `json:"percent"`
+}
+
+// A track on the tracklist of an album. TrackID is the track in the catalog the entry is linked to,
+// and is nil for tracks that have never been listened to
+type TracklistTrack struct {
+	DiscNumber  int32      `json:"disc_number"`
+	Position    int32      `json:"position"`
+	Title       string     `json:"title"`
+	Duration    int32      `json:"duration"`
+	MbzID       *uuid.UUID `json:"musicbrainz_id"`
+	TrackID     *int32     `json:"track_id"`
+	ListenCount int64      `json:"listen_count"`
 }
 
 // type SimpleAlbum struct {
@@ -1,6 +1,10 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
 
 type Artist struct {
 	ID           int32      `json:"id"`
@@ -11,11 +15,61 @@ type Artist struct {
 	ListenCount  int64      `json:"listen_count"`
 	TimeListened int64      `json:"time_listened"`
 	IsPrimary    bool       `json:"is_primary,omitempty"`
+	ArtistInfo
+	RelatedArtists []RelatedArtist `json:"related_artists,omitempty"`
+}
+
+// An artist related to another artist, such as a member of a group or a solo project of a person.
+// Relation reads as "artist <relation> related artist", and is one of member_of, has_member,
+// performs_as, or performed_by
+type RelatedArtist struct {
+	ID       int32      `json:"id"`
+	Name     string     `json:"name"`
+	Image    *uuid.UUID `json:"image"`
+	Relation string     `json:"relation"`
+	Source   string     `json:"source"`
 }
 
+// Information about an artist from MusicBrainz. Dates can be just a year, or a year and month.
+type ArtistInfo struct {
+	SortName  string `json:"sort_name,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Gender    string `json:"gender,omitempty"`
+	Area      string `json:"area,omitempty"`
+	Country   string `json:"country,omitempty"` // ISO 3166-1 alpha-2 code
+	BeginDate string `json:"begin_date,omitempty"`
+	EndDate   string `json:"end_date,omitempty"`
+}
+
+// An artist of a track or album. When the artist credit of the track or album is known, the artist
+// is listed in credit order with the name it is credited as, and the phrase joining it to the next artist
 type SimpleArtist struct {
-	ID   int32  `json:"id"`
-	Name string `json:"name"`
+	ID           int32  `json:"id"`
+	Name         string `json:"name"`
+	CreditedName string `json:"credited_name,omitempty"`
+	JoinPhrase   string `json:"join_phrase,omitempty"`
+}
+
+// ArtistCreditString formats the artists of a track or album as they are credited, e.g. "A feat. B".
+// Artists without a join phrase are joined with " & ".
+func ArtistCreditString(artists []SimpleArtist) string {
+	var sb strings.Builder
+	for i, a := range artists {
+		if a.CreditedName != "" {
+			sb.WriteString(a.CreditedName)
+		} else {
+			sb.WriteString(a.Name)
+		}
+		if i == len(artists)-1 {
+			break
+		}
+		if a.JoinPhrase != "" {
+			sb.WriteString(a.JoinPhrase)
+		} else {
+			sb.WriteString(" & ")
+		}
+	}
+	return sb.String()
 }
 
 type ArtistWithFullAliases struct {
@@ -28,4 +82,6 @@ type ArtistWithFullAliases struct {
 	ListenCount  int64      `json:"listen_count"`
 	TimeListened int64      `json:"time_listened"`
 	IsPrimary    bool       `json:"is_primary,omitempty"`
+	CreditedName string     `json:"credited_name,omitempty"`
+	JoinPhrase   string     `json:"join_phrase,omitempty"`
 }
@@ -0,0 +1,9 @@
+package models
+
+// A rule for splitting submitted artist strings into artists. Kind is "separator" for an extra
+// separator that artists are split on, or "protected" for an artist name that is never split
+type ArtistSplitRule struct {
+	ID    int32  `json:"id"`
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
@@ -0,0 +1,13 @@
+package models
+
+// A fuzzy name match that listens were provisionally linked through, pending review by the user.
+// Name is the name that was submitted, and ItemName the name of the item it was matched to
+type FuzzyMatch struct {
+	ID          int32   `json:"id"`
+	Type        string  `json:"type"`
+	ItemID      int32   `json:"item_id"`
+	ItemName    string  `json:"item_name"`
+	Name        string  `json:"name"`
+	Score       float32 `json:"score"`
+	ListenCount int64   `json:"listen_count"`
+}
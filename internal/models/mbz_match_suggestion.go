@@ -0,0 +1,15 @@
+package models
+
+import "github.com/google/uuid"
+
+// A possible MusicBrainz match for an item that was not confident enough to be applied automatically
+type MbzMatchSuggestion struct {
+	ID         int32     `json:"id"`
+	Type       string    `json:"type"`
+	ItemID     int32     `json:"item_id"`
+	ItemName   string    `json:"item_name"`
+	MbzID      uuid.UUID `json:"musicbrainz_id"`
+	Name       string    `json:"name"`
+	ArtistName string    `json:"artist_name,omitempty"`
+	Score      int32     `json:"score"`
+}
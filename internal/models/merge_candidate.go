@@ -0,0 +1,12 @@
+package models
+
+type MergeCandidate struct {
+	ID       int32    `json:"id"`
+	Type     string   `json:"type"`
+	FromID   int32    `json:"from_id"`
+	FromName string   `json:"from_name"`
+	ToID     int32    `json:"to_id"`
+	ToName   string   `json:"to_name"`
+	Score    float32  `json:"score"`
+	Reasons  []string `json:"reasons"`
+}
@@ -0,0 +1,8 @@
+package models
+
+type Tag struct {
+	ID          int32  `json:"id"`
+	Name        string `json:"name"`
+	Source      string `json:"source,omitempty"`
+	ListenCount int64  `json:"listen_count,omitempty"`
+}
@@ -1,6 +1,10 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
 
 type Track struct {
 	ID           int32          `json:"id"`
@@ -13,3 +17,12 @@ type Track struct {
 	AlbumID      int32          `json:"album_id"`
 	TimeListened int64          `json:"time_listened"`
 }
+
+// Tracks include their formatted artist credit
+func (t Track) MarshalJSON() ([]byte, error) {
+	type track Track
+	return json.Marshal(struct {
+		track
+		ArtistCredit string `json:"artist_credit"`
+	}{track(t), ArtistCreditString(t.Artists)})
+}
@@ -61,7 +61,7 @@ func (q *Queries) DeleteTrackAlias(ctx context.Context, arg DeleteTrackAliasPara
 }
 
 const getAllArtistAliases = `-- name: GetAllArtistAliases :many
-SELECT artist_id, alias, source, is_primary FROM artist_aliases
+SELECT artist_id, alias, source, is_primary, match_key FROM artist_aliases
 WHERE artist_id = $1 ORDER BY is_primary DESC
 `
 
@@ -79,6 +79,7 @@ func (q *Queries) GetAllArtistAliases(ctx context.Context, artistID int32) ([]Ar
 			&i.Alias,
 			&i.Source,
 			&i.IsPrimary,
+			&i.MatchKey,
 		); err != nil {
 			return nil, err
 		}
@@ -91,7 +92,7 @@ func (q *Queries) GetAllArtistAliases(ctx context.Context, artistID int32) ([]Ar
 }
 
 const getAllReleaseAliases = `-- name: GetAllReleaseAliases :many
-SELECT release_id, alias, source, is_primary FROM release_aliases
+SELECT release_id, alias, source, is_primary, match_key FROM release_aliases
 WHERE release_id = $1 ORDER BY is_primary DESC
 `
 
@@ -109,6 +110,7 @@ func (q *Queries) GetAllReleaseAliases(ctx context.Context, releaseID int32) ([]
 			&i.Alias,
 			&i.Source,
 			&i.IsPrimary,
+			&i.MatchKey,
 		); err != nil {
 			return nil, err
 		}
@@ -121,7 +123,7 @@ func (q *Queries) GetAllReleaseAliases(ctx context.Context, releaseID int32) ([]
 }
 
 const getAllTrackAliases = `-- name: GetAllTrackAliases :many
-SELECT track_id, alias, is_primary, source FROM track_aliases
+SELECT track_id, alias, is_primary, source, match_key FROM track_aliases
 WHERE track_id = $1 ORDER BY is_primary DESC
 `
 
@@ -139,6 +141,7 @@ func (q *Queries) GetAllTrackAliases(ctx context.Context, trackID int32) ([]Trac
 			&i.Alias,
 			&i.IsPrimary,
 			&i.Source,
+			&i.MatchKey,
 		); err != nil {
 			return nil, err
 		}
@@ -151,7 +154,7 @@ func (q *Queries) GetAllTrackAliases(ctx context.Context, trackID int32) ([]Trac
 }
 
 const getArtistAlias = `-- name: GetArtistAlias :one
-SELECT artist_id, alias, source, is_primary FROM artist_aliases
+SELECT artist_id, alias, source, is_primary, match_key FROM artist_aliases
 WHERE alias = $1 LIMIT 1
 `
 
@@ -163,12 +166,56 @@ func (q *Queries) GetArtistAlias(ctx context.Context, alias string) (ArtistAlias
 		&i.Alias,
 		&i.Source,
 		&i.IsPrimary,
+		&i.MatchKey,
 	)
 	return i, err
 }
 
+const getArtistsWithoutRomanizedAlias = `-- name: GetArtistsWithoutRomanizedAlias :many
+SELECT a.id, a.name
+FROM artists_with_name a
+WHERE a.id > $1
+  AND a.name ~ '[^\x01-\x7F]'
+  AND NOT EXISTS (
+    SELECT 1 FROM artist_aliases al
+    WHERE al.artist_id = a.id AND al.source = 'Romanized'
+  )
+ORDER BY a.id
+LIMIT $2
+`
+
+type GetArtistsWithoutRomanizedAliasParams struct {
+	ID    int32
+	Limit int32
+}
+
+type GetArtistsWithoutRomanizedAliasRow struct {
+	ID   int32
+	Name string
+}
+
+func (q *Queries) GetArtistsWithoutRomanizedAlias(ctx context.Context, arg GetArtistsWithoutRomanizedAliasParams) ([]GetArtistsWithoutRomanizedAliasRow, error) {
+	rows, err := q.db.Query(ctx, getArtistsWithoutRomanizedAlias, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetArtistsWithoutRomanizedAliasRow
+	for rows.Next() {
+		var i GetArtistsWithoutRomanizedAliasRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getReleaseAlias = `-- name: GetReleaseAlias :one
-SELECT release_id, alias, source, is_primary FROM release_aliases
+SELECT release_id, alias, source, is_primary, match_key FROM release_aliases
 WHERE alias = $1 LIMIT 1
 `
 
@@ -180,12 +227,56 @@ func (q *Queries) GetReleaseAlias(ctx context.Context, alias string) (ReleaseAli
 		&i.Alias,
 		&i.Source,
 		&i.IsPrimary,
+		&i.MatchKey,
 	)
 	return i, err
 }
 
+const getReleasesWithoutRomanizedAlias = `-- name: GetReleasesWithoutRomanizedAlias :many
+SELECT r.id, r.title
+FROM releases_with_title r
+WHERE r.id > $1
+  AND r.title ~ '[^\x01-\x7F]'
+  AND NOT EXISTS (
+    SELECT 1 FROM release_aliases al
+    WHERE al.release_id = r.id AND al.source = 'Romanized'
+  )
+ORDER BY r.id
+LIMIT $2
+`
+
+type GetReleasesWithoutRomanizedAliasParams struct {
+	ID    int32
+	Limit int32
+}
+
+type GetReleasesWithoutRomanizedAliasRow struct {
+	ID    int32
+	Title string
+}
+
+func (q *Queries) GetReleasesWithoutRomanizedAlias(ctx context.Context, arg GetReleasesWithoutRomanizedAliasParams) ([]GetReleasesWithoutRomanizedAliasRow, error) {
+	rows, err := q.db.Query(ctx, getReleasesWithoutRomanizedAlias, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReleasesWithoutRomanizedAliasRow
+	for rows.Next() {
+		var i GetReleasesWithoutRomanizedAliasRow
+		if err := rows.Scan(&i.ID, &i.Title); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTrackAlias = `-- name: GetTrackAlias :one
-SELECT track_id, alias, is_primary, source FROM track_aliases
+SELECT track_id, alias, is_primary, source, match_key FROM track_aliases
 WHERE alias = $1 LIMIT 1
 `
 
@@ -197,10 +288,54 @@ func (q *Queries) GetTrackAlias(ctx context.Context, alias string) (TrackAlias,
 		&i.Alias,
 		&i.IsPrimary,
 		&i.Source,
+		&i.MatchKey,
 	)
 	return i, err
 }
 
+const getTracksWithoutRomanizedAlias = `-- name: GetTracksWithoutRomanizedAlias :many
+SELECT t.id, t.title
+FROM tracks_with_title t
+WHERE t.id > $1
+  AND t.title ~ '[^\x01-\x7F]'
+  AND NOT EXISTS (
+    SELECT 1 FROM track_aliases al
+    WHERE al.track_id = t.id AND al.source = 'Romanized'
+  )
+ORDER BY t.id
+LIMIT $2
+`
+
+type GetTracksWithoutRomanizedAliasParams struct {
+	ID    int32
+	Limit int32
+}
+
+type GetTracksWithoutRomanizedAliasRow struct {
+	ID    int32
+	Title string
+}
+
+func (q *Queries) GetTracksWithoutRomanizedAlias(ctx context.Context, arg GetTracksWithoutRomanizedAliasParams) ([]GetTracksWithoutRomanizedAliasRow, error) {
+	rows, err := q.db.Query(ctx, getTracksWithoutRomanizedAlias, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTracksWithoutRomanizedAliasRow
+	for rows.Next() {
+		var i GetTracksWithoutRomanizedAliasRow
+		if err := rows.Scan(&i.ID, &i.Title); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const insertArtistAlias = `-- name: InsertArtistAlias :exec
 INSERT INTO artist_aliases (artist_id, alias, source, is_primary)
 VALUES ($1, $2, $3, $4)
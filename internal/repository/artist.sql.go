@@ -13,20 +13,101 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countListensByArtistCountry = `-- name: CountListensByArtistCountry :many
+SELECT
+  a.country::text AS country,
+  COUNT(DISTINCT (l.track_id, l.listened_at)) AS listen_count,
+  COUNT(DISTINCT a.id) AS artist_count
+FROM listens l
+JOIN artist_tracks at ON l.track_id = at.track_id
+JOIN artists a ON a.id = at.artist_id
+WHERE l.listened_at BETWEEN $1 AND $2
+  AND a.country IS NOT NULL
+  AND listen_visible(l.track_id, l.client, $3)
+GROUP BY a.country
+ORDER BY listen_count DESC, country
+`
+
+type CountListensByArtistCountryParams struct {
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	IncludeHidden bool
+}
+
+type CountListensByArtistCountryRow struct {
+	Country     string
+	ListenCount int64
+	ArtistCount int64
+}
+
+func (q *Queries) CountListensByArtistCountry(ctx context.Context, arg CountListensByArtistCountryParams) ([]CountListensByArtistCountryRow, error) {
+	rows, err := q.db.Query(ctx, countListensByArtistCountry, arg.ListenedAt, arg.ListenedAt_2, arg.IncludeHidden)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountListensByArtistCountryRow
+	for rows.Next() {
+		var i CountListensByArtistCountryRow
+		if err := rows.Scan(&i.Country, &i.ListenCount, &i.ArtistCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const countTopArtists = `-- name: CountTopArtists :one
 SELECT COUNT(DISTINCT at.artist_id) AS total_count
 FROM listens l
 JOIN artist_tracks at ON l.track_id = at.track_id
 WHERE l.listened_at BETWEEN $1 AND $2
+  AND listen_visible(l.track_id, l.client, $3)
 `
 
 type CountTopArtistsParams struct {
-	ListenedAt   time.Time
-	ListenedAt_2 time.Time
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	IncludeHidden bool
 }
 
 func (q *Queries) CountTopArtists(ctx context.Context, arg CountTopArtistsParams) (int64, error) {
-	row := q.db.QueryRow(ctx, countTopArtists, arg.ListenedAt, arg.ListenedAt_2)
+	row := q.db.QueryRow(ctx, countTopArtists, arg.ListenedAt, arg.ListenedAt_2, arg.IncludeHidden)
+	var total_count int64
+	err := row.Scan(&total_count)
+	return total_count, err
+}
+
+const countTopArtistsByTag = `-- name: CountTopArtistsByTag :one
+SELECT COUNT(DISTINCT at.artist_id) AS total_count
+FROM listens l
+JOIN artist_tracks at ON l.track_id = at.track_id
+WHERE l.listened_at BETWEEN $1 AND $2
+AND listen_visible(l.track_id, l.client, $4)
+AND l.track_id IN (
+    SELECT tti.track_id FROM track_tags_inherited tti
+    JOIN tags tg ON tg.id = tti.tag_id
+    WHERE tg.name = $3
+)
+`
+
+type CountTopArtistsByTagParams struct {
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	Name          string
+	IncludeHidden bool
+}
+
+func (q *Queries) CountTopArtistsByTag(ctx context.Context, arg CountTopArtistsByTagParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countTopArtistsByTag,
+		arg.ListenedAt,
+		arg.ListenedAt_2,
+		arg.Name,
+		arg.IncludeHidden,
+	)
 	var total_count int64
 	err := row.Scan(&total_count)
 	return total_count, err
@@ -41,6 +122,36 @@ func (q *Queries) DeleteArtist(ctx context.Context, id int32) error {
 	return err
 }
 
+const deleteArtistRelease = `-- name: DeleteArtistRelease :exec
+DELETE FROM artist_releases
+WHERE artist_id = $1 AND release_id = $2
+`
+
+type DeleteArtistReleaseParams struct {
+	ArtistID  int32
+	ReleaseID int32
+}
+
+func (q *Queries) DeleteArtistRelease(ctx context.Context, arg DeleteArtistReleaseParams) error {
+	_, err := q.db.Exec(ctx, deleteArtistRelease, arg.ArtistID, arg.ReleaseID)
+	return err
+}
+
+const deleteArtistTrack = `-- name: DeleteArtistTrack :exec
+DELETE FROM artist_tracks
+WHERE artist_id = $1 AND track_id = $2
+`
+
+type DeleteArtistTrackParams struct {
+	ArtistID int32
+	TrackID  int32
+}
+
+func (q *Queries) DeleteArtistTrack(ctx context.Context, arg DeleteArtistTrackParams) error {
+	_, err := q.db.Exec(ctx, deleteArtistTrack, arg.ArtistID, arg.TrackID)
+	return err
+}
+
 const deleteConflictingArtistReleases = `-- name: DeleteConflictingArtistReleases :exec
 DELETE FROM artist_releases ar
 WHERE ar.artist_id = $1
@@ -77,14 +188,32 @@ func (q *Queries) DeleteConflictingArtistTracks(ctx context.Context, arg DeleteC
 	return err
 }
 
+const deleteNonAlbumArtistRelease = `-- name: DeleteNonAlbumArtistRelease :exec
+DELETE FROM artist_releases
+WHERE artist_id = $1 AND release_id = $2 AND NOT is_album_artist
+`
+
+type DeleteNonAlbumArtistReleaseParams struct {
+	ArtistID  int32
+	ReleaseID int32
+}
+
+// removes the credit of an artist on a release only if they are not one of its album artists
+func (q *Queries) DeleteNonAlbumArtistRelease(ctx context.Context, arg DeleteNonAlbumArtistReleaseParams) error {
+	_, err := q.db.Exec(ctx, deleteNonAlbumArtistRelease, arg.ArtistID, arg.ReleaseID)
+	return err
+}
+
 const getArtist = `-- name: GetArtist :one
 SELECT 
   a.id, a.musicbrainz_id, a.image, a.image_source, a.name,
+  ai.sort_name, ai.artist_type, ai.gender, ai.area, ai.country, ai.begin_date, ai.end_date,
   array_agg(aa.alias)::text[] AS aliases
 FROM artists_with_name a
+JOIN artists ai ON ai.id = a.id
 LEFT JOIN artist_aliases aa ON a.id = aa.artist_id
 WHERE a.id = $1
-GROUP BY a.id, a.musicbrainz_id, a.image, a.image_source, a.name
+GROUP BY a.id, a.musicbrainz_id, a.image, a.image_source, a.name, ai.sort_name, ai.artist_type, ai.gender, ai.area, ai.country, ai.begin_date, ai.end_date
 `
 
 type GetArtistRow struct {
@@ -93,6 +222,13 @@ type GetArtistRow struct {
 	Image         *uuid.UUID
 	ImageSource   pgtype.Text
 	Name          string
+	SortName      pgtype.Text
+	ArtistType    pgtype.Text
+	Gender        pgtype.Text
+	Area          pgtype.Text
+	Country       pgtype.Text
+	BeginDate     pgtype.Text
+	EndDate       pgtype.Text
 	Aliases       []string
 }
 
@@ -105,13 +241,20 @@ func (q *Queries) GetArtist(ctx context.Context, id int32) (GetArtistRow, error)
 		&i.Image,
 		&i.ImageSource,
 		&i.Name,
+		&i.SortName,
+		&i.ArtistType,
+		&i.Gender,
+		&i.Area,
+		&i.Country,
+		&i.BeginDate,
+		&i.EndDate,
 		&i.Aliases,
 	)
 	return i, err
 }
 
 const getArtistByImage = `-- name: GetArtistByImage :one
-SELECT id, musicbrainz_id, image, image_source FROM artists WHERE image = $1 LIMIT 1
+SELECT id, musicbrainz_id, image, image_source, sort_name, artist_type, gender, area, country, begin_date, end_date FROM artists WHERE image = $1 LIMIT 1
 `
 
 func (q *Queries) GetArtistByImage(ctx context.Context, image *uuid.UUID) (Artist, error) {
@@ -122,6 +265,70 @@ func (q *Queries) GetArtistByImage(ctx context.Context, image *uuid.UUID) (Artis
 		&i.MusicBrainzID,
 		&i.Image,
 		&i.ImageSource,
+		&i.SortName,
+		&i.ArtistType,
+		&i.Gender,
+		&i.Area,
+		&i.Country,
+		&i.BeginDate,
+		&i.EndDate,
+	)
+	return i, err
+}
+
+const getArtistByMatchKey = `-- name: GetArtistByMatchKey :one
+WITH artist_with_aliases AS (
+  SELECT 
+    a.id, a.musicbrainz_id, a.image, a.image_source, a.name,
+    ai.sort_name, ai.artist_type, ai.gender, ai.area, ai.country, ai.begin_date, ai.end_date,
+    COALESCE(array_agg(aa.alias), '{}')::text[] AS aliases
+  FROM artists_with_name a
+  JOIN artists ai ON ai.id = a.id
+  LEFT JOIN artist_aliases aa ON a.id = aa.artist_id
+  WHERE a.id = (
+    SELECT aa2.artist_id FROM artist_aliases aa2
+    WHERE aa2.match_key = match_key($1)
+    ORDER BY aa2.is_primary DESC, aa2.artist_id
+    LIMIT 1
+  )
+  GROUP BY a.id, a.musicbrainz_id, a.image, a.image_source, a.name, ai.sort_name, ai.artist_type, ai.gender, ai.area, ai.country, ai.begin_date, ai.end_date
+)
+SELECT id, musicbrainz_id, image, image_source, name, sort_name, artist_type, gender, area, country, begin_date, end_date, aliases FROM artist_with_aliases
+`
+
+type GetArtistByMatchKeyRow struct {
+	ID            int32
+	MusicBrainzID *uuid.UUID
+	Image         *uuid.UUID
+	ImageSource   pgtype.Text
+	Name          string
+	SortName      pgtype.Text
+	ArtistType    pgtype.Text
+	Gender        pgtype.Text
+	Area          pgtype.Text
+	Country       pgtype.Text
+	BeginDate     pgtype.Text
+	EndDate       pgtype.Text
+	Aliases       []string
+}
+
+func (q *Queries) GetArtistByMatchKey(ctx context.Context, name string) (GetArtistByMatchKeyRow, error) {
+	row := q.db.QueryRow(ctx, getArtistByMatchKey, name)
+	var i GetArtistByMatchKeyRow
+	err := row.Scan(
+		&i.ID,
+		&i.MusicBrainzID,
+		&i.Image,
+		&i.ImageSource,
+		&i.Name,
+		&i.SortName,
+		&i.ArtistType,
+		&i.Gender,
+		&i.Area,
+		&i.Country,
+		&i.BeginDate,
+		&i.EndDate,
+		&i.Aliases,
 	)
 	return i, err
 }
@@ -129,11 +336,13 @@ func (q *Queries) GetArtistByImage(ctx context.Context, image *uuid.UUID) (Artis
 const getArtistByMbzID = `-- name: GetArtistByMbzID :one
 SELECT 
   a.id, a.musicbrainz_id, a.image, a.image_source, a.name,
+  ai.sort_name, ai.artist_type, ai.gender, ai.area, ai.country, ai.begin_date, ai.end_date,
   array_agg(aa.alias)::text[] AS aliases
 FROM artists_with_name a
+JOIN artists ai ON ai.id = a.id
 LEFT JOIN artist_aliases aa ON a.id = aa.artist_id
 WHERE a.musicbrainz_id = $1
-GROUP BY a.id, a.musicbrainz_id, a.image, a.image_source, a.name
+GROUP BY a.id, a.musicbrainz_id, a.image, a.image_source, a.name, ai.sort_name, ai.artist_type, ai.gender, ai.area, ai.country, ai.begin_date, ai.end_date
 `
 
 type GetArtistByMbzIDRow struct {
@@ -142,6 +351,13 @@ type GetArtistByMbzIDRow struct {
 	Image         *uuid.UUID
 	ImageSource   pgtype.Text
 	Name          string
+	SortName      pgtype.Text
+	ArtistType    pgtype.Text
+	Gender        pgtype.Text
+	Area          pgtype.Text
+	Country       pgtype.Text
+	BeginDate     pgtype.Text
+	EndDate       pgtype.Text
 	Aliases       []string
 }
 
@@ -154,6 +370,13 @@ func (q *Queries) GetArtistByMbzID(ctx context.Context, musicbrainzID *uuid.UUID
 		&i.Image,
 		&i.ImageSource,
 		&i.Name,
+		&i.SortName,
+		&i.ArtistType,
+		&i.Gender,
+		&i.Area,
+		&i.Country,
+		&i.BeginDate,
+		&i.EndDate,
 		&i.Aliases,
 	)
 	return i, err
@@ -163,15 +386,17 @@ const getArtistByName = `-- name: GetArtistByName :one
 WITH artist_with_aliases AS (
   SELECT 
     a.id, a.musicbrainz_id, a.image, a.image_source, a.name,
+    ai.sort_name, ai.artist_type, ai.gender, ai.area, ai.country, ai.begin_date, ai.end_date,
     COALESCE(array_agg(aa.alias), '{}')::text[] AS aliases
   FROM artists_with_name a
+  JOIN artists ai ON ai.id = a.id
   LEFT JOIN artist_aliases aa ON a.id = aa.artist_id
   WHERE a.id IN (
     SELECT aa2.artist_id FROM artist_aliases aa2 WHERE aa2.alias = $1
   )
-  GROUP BY a.id, a.musicbrainz_id, a.image, a.image_source, a.name
+  GROUP BY a.id, a.musicbrainz_id, a.image, a.image_source, a.name, ai.sort_name, ai.artist_type, ai.gender, ai.area, ai.country, ai.begin_date, ai.end_date
 )
-SELECT id, musicbrainz_id, image, image_source, name, aliases FROM artist_with_aliases
+SELECT id, musicbrainz_id, image, image_source, name, sort_name, artist_type, gender, area, country, begin_date, end_date, aliases FROM artist_with_aliases
 `
 
 type GetArtistByNameRow struct {
@@ -180,6 +405,13 @@ type GetArtistByNameRow struct {
 	Image         *uuid.UUID
 	ImageSource   pgtype.Text
 	Name          string
+	SortName      pgtype.Text
+	ArtistType    pgtype.Text
+	Gender        pgtype.Text
+	Area          pgtype.Text
+	Country       pgtype.Text
+	BeginDate     pgtype.Text
+	EndDate       pgtype.Text
 	Aliases       []string
 }
 
@@ -192,6 +424,13 @@ func (q *Queries) GetArtistByName(ctx context.Context, alias string) (GetArtistB
 		&i.Image,
 		&i.ImageSource,
 		&i.Name,
+		&i.SortName,
+		&i.ArtistType,
+		&i.Gender,
+		&i.Area,
+		&i.Country,
+		&i.BeginDate,
+		&i.EndDate,
 		&i.Aliases,
 	)
 	return i, err
@@ -200,11 +439,12 @@ func (q *Queries) GetArtistByName(ctx context.Context, alias string) (GetArtistB
 const getReleaseArtists = `-- name: GetReleaseArtists :many
 SELECT 
   a.id, a.musicbrainz_id, a.image, a.image_source, a.name,
-  ar.is_primary as is_primary
+  ar.is_primary as is_primary,
+  ar.is_album_artist as is_album_artist
 FROM artists_with_name a
 LEFT JOIN artist_releases ar ON a.id = ar.artist_id
 WHERE ar.release_id = $1
-GROUP BY a.id, a.musicbrainz_id, a.image, a.image_source, a.name, ar.is_primary
+GROUP BY a.id, a.musicbrainz_id, a.image, a.image_source, a.name, ar.is_primary, ar.is_album_artist
 `
 
 type GetReleaseArtistsRow struct {
@@ -214,6 +454,7 @@ type GetReleaseArtistsRow struct {
 	ImageSource   pgtype.Text
 	Name          string
 	IsPrimary     pgtype.Bool
+	IsAlbumArtist pgtype.Bool
 }
 
 func (q *Queries) GetReleaseArtists(ctx context.Context, releaseID int32) ([]GetReleaseArtistsRow, error) {
@@ -232,6 +473,80 @@ func (q *Queries) GetReleaseArtists(ctx context.Context, releaseID int32) ([]Get
 			&i.ImageSource,
 			&i.Name,
 			&i.IsPrimary,
+			&i.IsAlbumArtist,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTopArtistsByTagPaginated = `-- name: GetTopArtistsByTagPaginated :many
+SELECT
+    a.id,
+    a.name,
+    a.musicbrainz_id,
+    a.image,
+    COUNT(*) AS listen_count
+FROM listens l
+JOIN tracks t ON l.track_id = t.id
+JOIN artist_tracks at ON at.track_id = t.id
+JOIN artists_with_name a ON a.id = at.artist_id
+WHERE l.listened_at BETWEEN $1 AND $2
+AND listen_visible(l.track_id, l.client, $6)
+AND l.track_id IN (
+    SELECT tti.track_id FROM track_tags_inherited tti
+    JOIN tags tg ON tg.id = tti.tag_id
+    WHERE tg.name = $3
+)
+GROUP BY a.id, a.name, a.musicbrainz_id, a.image, a.image_source, a.name
+ORDER BY listen_count DESC, a.id
+LIMIT $4 OFFSET $5
+`
+
+type GetTopArtistsByTagPaginatedParams struct {
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	Name          string
+	Limit         int32
+	Offset        int32
+	IncludeHidden bool
+}
+
+type GetTopArtistsByTagPaginatedRow struct {
+	ID            int32
+	Name          string
+	MusicBrainzID *uuid.UUID
+	Image         *uuid.UUID
+	ListenCount   int64
+}
+
+func (q *Queries) GetTopArtistsByTagPaginated(ctx context.Context, arg GetTopArtistsByTagPaginatedParams) ([]GetTopArtistsByTagPaginatedRow, error) {
+	rows, err := q.db.Query(ctx, getTopArtistsByTagPaginated,
+		arg.ListenedAt,
+		arg.ListenedAt_2,
+		arg.Name,
+		arg.Limit,
+		arg.Offset,
+		arg.IncludeHidden,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTopArtistsByTagPaginatedRow
+	for rows.Next() {
+		var i GetTopArtistsByTagPaginatedRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.MusicBrainzID,
+			&i.Image,
+			&i.ListenCount,
 		); err != nil {
 			return nil, err
 		}
@@ -255,16 +570,18 @@ JOIN tracks t ON l.track_id = t.id
 JOIN artist_tracks at ON at.track_id = t.id
 JOIN artists_with_name a ON a.id = at.artist_id
 WHERE l.listened_at BETWEEN $1 AND $2
+  AND listen_visible(l.track_id, l.client, $5)
 GROUP BY a.id, a.name, a.musicbrainz_id, a.image, a.image_source, a.name
 ORDER BY listen_count DESC, a.id
 LIMIT $3 OFFSET $4
 `
 
 type GetTopArtistsPaginatedParams struct {
-	ListenedAt   time.Time
-	ListenedAt_2 time.Time
-	Limit        int32
-	Offset       int32
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	Limit         int32
+	Offset        int32
+	IncludeHidden bool
 }
 
 type GetTopArtistsPaginatedRow struct {
@@ -281,6 +598,7 @@ func (q *Queries) GetTopArtistsPaginated(ctx context.Context, arg GetTopArtistsP
 		arg.ListenedAt_2,
 		arg.Limit,
 		arg.Offset,
+		arg.IncludeHidden,
 	)
 	if err != nil {
 		return nil, err
@@ -355,7 +673,7 @@ func (q *Queries) GetTrackArtists(ctx context.Context, trackID int32) ([]GetTrac
 const insertArtist = `-- name: InsertArtist :one
 INSERT INTO artists (musicbrainz_id, image, image_source)
 VALUES ($1, $2, $3)
-RETURNING id, musicbrainz_id, image, image_source
+RETURNING id, musicbrainz_id, image, image_source, sort_name, artist_type, gender, area, country, begin_date, end_date
 `
 
 type InsertArtistParams struct {
@@ -372,6 +690,13 @@ func (q *Queries) InsertArtist(ctx context.Context, arg InsertArtistParams) (Art
 		&i.MusicBrainzID,
 		&i.Image,
 		&i.ImageSource,
+		&i.SortName,
+		&i.ArtistType,
+		&i.Gender,
+		&i.Area,
+		&i.Country,
+		&i.BeginDate,
+		&i.EndDate,
 	)
 	return i, err
 }
@@ -392,6 +717,43 @@ func (q *Queries) UpdateArtistImage(ctx context.Context, arg UpdateArtistImagePa
 	return err
 }
 
+const updateArtistInfo = `-- name: UpdateArtistInfo :exec
+UPDATE artists SET
+  sort_name = $2,
+  artist_type = $3,
+  gender = $4,
+  area = $5,
+  country = $6,
+  begin_date = $7,
+  end_date = $8
+WHERE id = $1
+`
+
+type UpdateArtistInfoParams struct {
+	ID         int32
+	SortName   pgtype.Text
+	ArtistType pgtype.Text
+	Gender     pgtype.Text
+	Area       pgtype.Text
+	Country    pgtype.Text
+	BeginDate  pgtype.Text
+	EndDate    pgtype.Text
+}
+
+func (q *Queries) UpdateArtistInfo(ctx context.Context, arg UpdateArtistInfoParams) error {
+	_, err := q.db.Exec(ctx, updateArtistInfo,
+		arg.ID,
+		arg.SortName,
+		arg.ArtistType,
+		arg.Gender,
+		arg.Area,
+		arg.Country,
+		arg.BeginDate,
+		arg.EndDate,
+	)
+	return err
+}
+
 const updateArtistMbzID = `-- name: UpdateArtistMbzID :exec
 UPDATE artists SET musicbrainz_id = $2
 WHERE id = $1
@@ -438,3 +800,47 @@ func (q *Queries) UpdateArtistTracks(ctx context.Context, arg UpdateArtistTracks
 	_, err := q.db.Exec(ctx, updateArtistTracks, arg.ArtistID, arg.ArtistID_2)
 	return err
 }
+
+const updateConflictingArtistReleases = `-- name: UpdateConflictingArtistReleases :exec
+UPDATE artist_releases ar
+SET
+  is_album_artist = ar.is_album_artist OR f.is_album_artist,
+  credit_position = COALESCE(ar.credit_position, f.credit_position),
+  credited_name = CASE WHEN ar.credit_position IS NULL THEN f.credited_name ELSE ar.credited_name END,
+  join_phrase = CASE WHEN ar.credit_position IS NULL THEN f.join_phrase ELSE ar.join_phrase END
+FROM artist_releases f
+WHERE f.artist_id = $1
+  AND f.release_id = ar.release_id
+  AND ar.artist_id = $2
+`
+
+type UpdateConflictingArtistReleasesParams struct {
+	ArtistID   int32
+	ArtistID_2 int32
+}
+
+func (q *Queries) UpdateConflictingArtistReleases(ctx context.Context, arg UpdateConflictingArtistReleasesParams) error {
+	_, err := q.db.Exec(ctx, updateConflictingArtistReleases, arg.ArtistID, arg.ArtistID_2)
+	return err
+}
+
+const updateConflictingArtistTracks = `-- name: UpdateConflictingArtistTracks :exec
+UPDATE artist_tracks at
+SET credit_position = f.credit_position, credited_name = f.credited_name, join_phrase = f.join_phrase
+FROM artist_tracks f
+WHERE f.artist_id = $1
+  AND f.track_id = at.track_id
+  AND at.artist_id = $2
+  AND at.credit_position IS NULL
+  AND f.credit_position IS NOT NULL
+`
+
+type UpdateConflictingArtistTracksParams struct {
+	ArtistID   int32
+	ArtistID_2 int32
+}
+
+func (q *Queries) UpdateConflictingArtistTracks(ctx context.Context, arg UpdateConflictingArtistTracksParams) error {
+	_, err := q.db.Exec(ctx, updateConflictingArtistTracks, arg.ArtistID, arg.ArtistID_2)
+	return err
+}
@@ -17,15 +17,17 @@ const countListens = `-- name: CountListens :one
 SELECT COUNT(*) AS total_count
 FROM listens l
 WHERE l.listened_at BETWEEN $1 AND $2
+  AND listen_visible(l.track_id, l.client, $3)
 `
 
 type CountListensParams struct {
-	ListenedAt   time.Time
-	ListenedAt_2 time.Time
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	IncludeHidden bool
 }
 
 func (q *Queries) CountListens(ctx context.Context, arg CountListensParams) (int64, error) {
-	row := q.db.QueryRow(ctx, countListens, arg.ListenedAt, arg.ListenedAt_2)
+	row := q.db.QueryRow(ctx, countListens, arg.ListenedAt, arg.ListenedAt_2, arg.IncludeHidden)
 	var total_count int64
 	err := row.Scan(&total_count)
 	return total_count, err
@@ -37,16 +39,23 @@ FROM listens l
 JOIN artist_tracks at ON l.track_id = at.track_id
 WHERE l.listened_at BETWEEN $1 AND $2
   AND at.artist_id = $3
+  AND listen_visible(l.track_id, l.client, $4)
 `
 
 type CountListensFromArtistParams struct {
-	ListenedAt   time.Time
-	ListenedAt_2 time.Time
-	ArtistID     int32
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	ArtistID      int32
+	IncludeHidden bool
 }
 
 func (q *Queries) CountListensFromArtist(ctx context.Context, arg CountListensFromArtistParams) (int64, error) {
-	row := q.db.QueryRow(ctx, countListensFromArtist, arg.ListenedAt, arg.ListenedAt_2, arg.ArtistID)
+	row := q.db.QueryRow(ctx, countListensFromArtist,
+		arg.ListenedAt,
+		arg.ListenedAt_2,
+		arg.ArtistID,
+		arg.IncludeHidden,
+	)
 	var total_count int64
 	err := row.Scan(&total_count)
 	return total_count, err
@@ -58,16 +67,23 @@ FROM listens l
 JOIN tracks t ON l.track_id = t.id
 WHERE l.listened_at BETWEEN $1 AND $2
   AND t.release_id = $3
+  AND listen_visible(l.track_id, l.client, $4)
 `
 
 type CountListensFromReleaseParams struct {
-	ListenedAt   time.Time
-	ListenedAt_2 time.Time
-	ReleaseID    int32
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	ReleaseID     int32
+	IncludeHidden bool
 }
 
 func (q *Queries) CountListensFromRelease(ctx context.Context, arg CountListensFromReleaseParams) (int64, error) {
-	row := q.db.QueryRow(ctx, countListensFromRelease, arg.ListenedAt, arg.ListenedAt_2, arg.ReleaseID)
+	row := q.db.QueryRow(ctx, countListensFromRelease,
+		arg.ListenedAt,
+		arg.ListenedAt_2,
+		arg.ReleaseID,
+		arg.IncludeHidden,
+	)
 	var total_count int64
 	err := row.Scan(&total_count)
 	return total_count, err
@@ -78,16 +94,23 @@ SELECT COUNT(*) AS total_count
 FROM listens l
 WHERE l.listened_at BETWEEN $1 AND $2
   AND l.track_id = $3
+  AND listen_visible(l.track_id, l.client, $4)
 `
 
 type CountListensFromTrackParams struct {
-	ListenedAt   time.Time
-	ListenedAt_2 time.Time
-	TrackID      int32
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	TrackID       int32
+	IncludeHidden bool
 }
 
 func (q *Queries) CountListensFromTrack(ctx context.Context, arg CountListensFromTrackParams) (int64, error) {
-	row := q.db.QueryRow(ctx, countListensFromTrack, arg.ListenedAt, arg.ListenedAt_2, arg.TrackID)
+	row := q.db.QueryRow(ctx, countListensFromTrack,
+		arg.ListenedAt,
+		arg.ListenedAt_2,
+		arg.TrackID,
+		arg.IncludeHidden,
+	)
 	var total_count int64
 	err := row.Scan(&total_count)
 	return total_count, err
@@ -98,15 +121,17 @@ SELECT COALESCE(SUM(t.duration), 0)::BIGINT AS seconds_listened
 FROM listens l
 JOIN tracks t ON l.track_id = t.id
 WHERE l.listened_at BETWEEN $1 AND $2
+  AND listen_visible(l.track_id, l.client, $3)
 `
 
 type CountTimeListenedParams struct {
-	ListenedAt   time.Time
-	ListenedAt_2 time.Time
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	IncludeHidden bool
 }
 
 func (q *Queries) CountTimeListened(ctx context.Context, arg CountTimeListenedParams) (int64, error) {
-	row := q.db.QueryRow(ctx, countTimeListened, arg.ListenedAt, arg.ListenedAt_2)
+	row := q.db.QueryRow(ctx, countTimeListened, arg.ListenedAt, arg.ListenedAt_2, arg.IncludeHidden)
 	var seconds_listened int64
 	err := row.Scan(&seconds_listened)
 	return seconds_listened, err
@@ -119,16 +144,23 @@ JOIN tracks t ON l.track_id = t.id
 JOIN artist_tracks at ON t.id = at.track_id
 WHERE l.listened_at BETWEEN $1 AND $2
   AND at.artist_id = $3
+  AND listen_visible(l.track_id, l.client, $4)
 `
 
 type CountTimeListenedToArtistParams struct {
-	ListenedAt   time.Time
-	ListenedAt_2 time.Time
-	ArtistID     int32
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	ArtistID      int32
+	IncludeHidden bool
 }
 
 func (q *Queries) CountTimeListenedToArtist(ctx context.Context, arg CountTimeListenedToArtistParams) (int64, error) {
-	row := q.db.QueryRow(ctx, countTimeListenedToArtist, arg.ListenedAt, arg.ListenedAt_2, arg.ArtistID)
+	row := q.db.QueryRow(ctx, countTimeListenedToArtist,
+		arg.ListenedAt,
+		arg.ListenedAt_2,
+		arg.ArtistID,
+		arg.IncludeHidden,
+	)
 	var seconds_listened int64
 	err := row.Scan(&seconds_listened)
 	return seconds_listened, err
@@ -140,16 +172,23 @@ FROM listens l
 JOIN tracks t ON l.track_id = t.id
 WHERE l.listened_at BETWEEN $1 AND $2
   AND t.release_id = $3
+  AND listen_visible(l.track_id, l.client, $4)
 `
 
 type CountTimeListenedToReleaseParams struct {
-	ListenedAt   time.Time
-	ListenedAt_2 time.Time
-	ReleaseID    int32
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	ReleaseID     int32
+	IncludeHidden bool
 }
 
 func (q *Queries) CountTimeListenedToRelease(ctx context.Context, arg CountTimeListenedToReleaseParams) (int64, error) {
-	row := q.db.QueryRow(ctx, countTimeListenedToRelease, arg.ListenedAt, arg.ListenedAt_2, arg.ReleaseID)
+	row := q.db.QueryRow(ctx, countTimeListenedToRelease,
+		arg.ListenedAt,
+		arg.ListenedAt_2,
+		arg.ReleaseID,
+		arg.IncludeHidden,
+	)
 	var seconds_listened int64
 	err := row.Scan(&seconds_listened)
 	return seconds_listened, err
@@ -161,16 +200,23 @@ FROM listens l
 JOIN tracks t ON l.track_id = t.id
 WHERE l.listened_at BETWEEN $1 AND $2
   AND t.id = $3
+  AND listen_visible(l.track_id, l.client, $4)
 `
 
 type CountTimeListenedToTrackParams struct {
-	ListenedAt   time.Time
-	ListenedAt_2 time.Time
-	ID           int32
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	ID            int32
+	IncludeHidden bool
 }
 
 func (q *Queries) CountTimeListenedToTrack(ctx context.Context, arg CountTimeListenedToTrackParams) (int64, error) {
-	row := q.db.QueryRow(ctx, countTimeListenedToTrack, arg.ListenedAt, arg.ListenedAt_2, arg.ID)
+	row := q.db.QueryRow(ctx, countTimeListenedToTrack,
+		arg.ListenedAt,
+		arg.ListenedAt_2,
+		arg.ID,
+		arg.IncludeHidden,
+	)
 	var seconds_listened int64
 	err := row.Scan(&seconds_listened)
 	return seconds_listened, err
@@ -488,13 +534,16 @@ SELECT
         WHERE ra.release_id = r.id
     ) AS release_aliases,
 
-    -- Artists
+    -- Artists, in credit order
     (
         SELECT json_agg(json_build_object(
             'id', a.id,
             'musicbrainz_id', a.musicbrainz_id,
             'image', a.image,
             'image_source', a.image_source,
+            'is_primary', at.is_primary,
+            'credited_name', at.credited_name,
+            'join_phrase', at.join_phrase,
             'aliases', (
                 SELECT json_agg(json_build_object(
                     'alias', aa.alias,
@@ -504,11 +553,36 @@ SELECT
                 FROM artist_aliases aa
                 WHERE aa.artist_id = a.id
             )
-        ))
+        ) ORDER BY at.credit_position NULLS LAST, at.is_primary DESC, a.id)
         FROM artist_tracks at
         JOIN artists a ON a.id = at.artist_id
         WHERE at.track_id = t.id
-    ) AS artists
+    ) AS artists,
+
+    -- Album artists, in credit order
+    (
+        SELECT json_agg(json_build_object(
+            'id', a.id,
+            'musicbrainz_id', a.musicbrainz_id,
+            'image', a.image,
+            'image_source', a.image_source,
+            'is_primary', ar.is_primary,
+            'credited_name', ar.credited_name,
+            'join_phrase', ar.join_phrase,
+            'aliases', (
+                SELECT json_agg(json_build_object(
+                    'alias', aa.alias,
+                    'source', aa.source,
+                    'is_primary', aa.is_primary
+                ))
+                FROM artist_aliases aa
+                WHERE aa.artist_id = a.id
+            )
+        ) ORDER BY ar.credit_position NULLS LAST, ar.is_primary DESC, a.id)
+        FROM artist_releases ar
+        JOIN artists a ON a.id = ar.artist_id
+        WHERE ar.release_id = r.id AND ar.is_album_artist
+    ) AS release_artists
 
 FROM listens l
 JOIN tracks t ON l.track_id = t.id
@@ -542,6 +616,7 @@ type GetListensExportPageRow struct {
 	VariousArtists     bool
 	ReleaseAliases     []byte
 	Artists            []byte
+	ReleaseArtists     []byte
 }
 
 func (q *Queries) GetListensExportPage(ctx context.Context, arg GetListensExportPageParams) ([]GetListensExportPageRow, error) {
@@ -573,6 +648,7 @@ func (q *Queries) GetListensExportPage(ctx context.Context, arg GetListensExport
 			&i.VariousArtists,
 			&i.ReleaseAliases,
 			&i.Artists,
+			&i.ReleaseArtists,
 		); err != nil {
 			return nil, err
 		}
@@ -619,6 +695,7 @@ bucketed_listens AS (
   LEFT JOIN listens l
     ON l.listened_at >= b.bucket_start
     AND l.listened_at < b.bucket_start + $3::interval
+    AND listen_visible(l.track_id, l.client, $4)
   GROUP BY b.bucket_start
   ORDER BY b.bucket_start
 )
@@ -626,9 +703,10 @@ SELECT bucket_start, listen_count FROM bucketed_listens
 `
 
 type ListenActivityParams struct {
-	Column1 time.Time
-	Column2 time.Time
-	Column3 pgtype.Interval
+	Column1       time.Time
+	Column2       time.Time
+	Column3       pgtype.Interval
+	IncludeHidden bool
 }
 
 type ListenActivityRow struct {
@@ -637,7 +715,12 @@ type ListenActivityRow struct {
 }
 
 func (q *Queries) ListenActivity(ctx context.Context, arg ListenActivityParams) ([]ListenActivityRow, error) {
-	rows, err := q.db.Query(ctx, listenActivity, arg.Column1, arg.Column2, arg.Column3)
+	rows, err := q.db.Query(ctx, listenActivity,
+		arg.Column1,
+		arg.Column2,
+		arg.Column3,
+		arg.IncludeHidden,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -663,8 +746,11 @@ WITH buckets AS (
 filtered_listens AS (
   SELECT l.track_id, l.listened_at, l.client, l.user_id
   FROM listens l
-  JOIN artist_tracks t ON l.track_id = t.track_id
-  WHERE t.artist_id = $4
+  WHERE EXISTS (
+    SELECT 1 FROM artist_tracks t
+    WHERE t.track_id = l.track_id AND t.artist_id IN (SELECT artist_roll_up($4, $5))
+  )
+  AND listen_visible(l.track_id, l.client, $6)
 ),
 bucketed_listens AS (
   SELECT
@@ -681,10 +767,12 @@ SELECT bucket_start, listen_count FROM bucketed_listens
 `
 
 type ListenActivityForArtistParams struct {
-	Column1  time.Time
-	Column2  time.Time
-	Column3  pgtype.Interval
-	ArtistID int32
+	Column1        time.Time
+	Column2        time.Time
+	Column3        pgtype.Interval
+	ArtistID       int32
+	IncludeRelated bool
+	IncludeHidden  bool
 }
 
 type ListenActivityForArtistRow struct {
@@ -698,6 +786,8 @@ func (q *Queries) ListenActivityForArtist(ctx context.Context, arg ListenActivit
 		arg.Column2,
 		arg.Column3,
 		arg.ArtistID,
+		arg.IncludeRelated,
+		arg.IncludeHidden,
 	)
 	if err != nil {
 		return nil, err
@@ -726,6 +816,7 @@ filtered_listens AS (
   FROM listens l
   JOIN tracks t ON l.track_id = t.id
   WHERE t.release_id = $4
+    AND listen_visible(l.track_id, l.client, $5)
 ),
 bucketed_listens AS (
   SELECT
@@ -742,10 +833,11 @@ SELECT bucket_start, listen_count FROM bucketed_listens
 `
 
 type ListenActivityForReleaseParams struct {
-	Column1   time.Time
-	Column2   time.Time
-	Column3   pgtype.Interval
-	ReleaseID int32
+	Column1       time.Time
+	Column2       time.Time
+	Column3       pgtype.Interval
+	ReleaseID     int32
+	IncludeHidden bool
 }
 
 type ListenActivityForReleaseRow struct {
@@ -759,6 +851,7 @@ func (q *Queries) ListenActivityForRelease(ctx context.Context, arg ListenActivi
 		arg.Column2,
 		arg.Column3,
 		arg.ReleaseID,
+		arg.IncludeHidden,
 	)
 	if err != nil {
 		return nil, err
@@ -778,6 +871,73 @@ func (q *Queries) ListenActivityForRelease(ctx context.Context, arg ListenActivi
 	return items, nil
 }
 
+const listenActivityForTag = `-- name: ListenActivityForTag :many
+WITH buckets AS (
+  SELECT generate_series($1::timestamptz, $2::timestamptz, $3::interval) AS bucket_start
+),
+filtered_listens AS (
+  SELECT l.track_id, l.listened_at, l.client, l.user_id
+  FROM listens l
+  WHERE l.track_id IN (
+    SELECT tti.track_id FROM track_tags_inherited tti
+    JOIN tags tg ON tg.id = tti.tag_id
+    WHERE tg.name = $4
+  )
+  AND listen_visible(l.track_id, l.client, $5)
+),
+bucketed_listens AS (
+  SELECT
+    b.bucket_start,
+    COUNT(l.listened_at) AS listen_count
+  FROM buckets b
+  LEFT JOIN filtered_listens l
+    ON l.listened_at >= b.bucket_start
+    AND l.listened_at < b.bucket_start + $3::interval
+  GROUP BY b.bucket_start
+  ORDER BY b.bucket_start
+)
+SELECT bucket_start, listen_count FROM bucketed_listens
+`
+
+type ListenActivityForTagParams struct {
+	Column1       time.Time
+	Column2       time.Time
+	Column3       pgtype.Interval
+	Name          string
+	IncludeHidden bool
+}
+
+type ListenActivityForTagRow struct {
+	BucketStart time.Time
+	ListenCount int64
+}
+
+func (q *Queries) ListenActivityForTag(ctx context.Context, arg ListenActivityForTagParams) ([]ListenActivityForTagRow, error) {
+	rows, err := q.db.Query(ctx, listenActivityForTag,
+		arg.Column1,
+		arg.Column2,
+		arg.Column3,
+		arg.Name,
+		arg.IncludeHidden,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListenActivityForTagRow
+	for rows.Next() {
+		var i ListenActivityForTagRow
+		if err := rows.Scan(&i.BucketStart, &i.ListenCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listenActivityForTrack = `-- name: ListenActivityForTrack :many
 WITH buckets AS (
   SELECT generate_series($1::timestamptz, $2::timestamptz, $3::interval) AS bucket_start
@@ -787,6 +947,7 @@ filtered_listens AS (
   FROM listens l
   JOIN tracks t ON l.track_id = t.id
   WHERE t.id = $4
+    AND listen_visible(l.track_id, l.client, $5)
 ),
 bucketed_listens AS (
   SELECT
@@ -803,10 +964,11 @@ SELECT bucket_start, listen_count FROM bucketed_listens
 `
 
 type ListenActivityForTrackParams struct {
-	Column1 time.Time
-	Column2 time.Time
-	Column3 pgtype.Interval
-	ID      int32
+	Column1       time.Time
+	Column2       time.Time
+	Column3       pgtype.Interval
+	ID            int32
+	IncludeHidden bool
 }
 
 type ListenActivityForTrackRow struct {
@@ -820,6 +982,7 @@ func (q *Queries) ListenActivityForTrack(ctx context.Context, arg ListenActivity
 		arg.Column2,
 		arg.Column3,
 		arg.ID,
+		arg.IncludeHidden,
 	)
 	if err != nil {
 		return nil, err
@@ -68,6 +68,13 @@ type Artist struct {
 	MusicBrainzID *uuid.UUID
 	Image         *uuid.UUID
 	ImageSource   pgtype.Text
+	SortName      pgtype.Text
+	ArtistType    pgtype.Text
+	Gender        pgtype.Text
+	Area          pgtype.Text
+	Country       pgtype.Text
+	BeginDate     pgtype.Text
+	EndDate       pgtype.Text
 }
 
 type ArtistAlias struct {
@@ -75,18 +82,46 @@ type ArtistAlias struct {
 	Alias     string
 	Source    string
 	IsPrimary bool
+	MatchKey  pgtype.Text
 }
 
 type ArtistRelease struct {
-	ArtistID  int32
-	ReleaseID int32
-	IsPrimary bool
+	ArtistID       int32
+	ReleaseID      int32
+	IsPrimary      bool
+	IsAlbumArtist  bool
+	CreditPosition pgtype.Int4
+	CreditedName   pgtype.Text
+	JoinPhrase     string
+}
+
+type ArtistRelation struct {
+	ArtistID        int32
+	RelatedArtistID int32
+	Relation        string
+	Source          string
+}
+
+type ArtistSplitRule struct {
+	ID        int32
+	Kind      string
+	Value     string
+	CreatedAt time.Time
+}
+
+type ArtistTag struct {
+	ArtistID int32
+	TagID    int32
+	Source   string
 }
 
 type ArtistTrack struct {
-	ArtistID  int32
-	TrackID   int32
-	IsPrimary bool
+	ArtistID       int32
+	TrackID        int32
+	IsPrimary      bool
+	CreditPosition pgtype.Int4
+	CreditedName   pgtype.Text
+	JoinPhrase     string
 }
 
 type ArtistsWithName struct {
@@ -97,6 +132,38 @@ type ArtistsWithName struct {
 	Name          string
 }
 
+type FuzzyMatch struct {
+	ID        int32
+	ItemType  string
+	ItemID    int32
+	Name      string
+	Score     float32
+	Rejected  bool
+	CreatedAt time.Time
+}
+
+type FuzzyMatchListen struct {
+	FuzzyMatchID int32
+	TrackID      int32
+	ListenedAt   time.Time
+}
+
+type HiddenArtist struct {
+	ArtistID int32
+}
+
+type HiddenClient struct {
+	Client string
+}
+
+type HiddenRelease struct {
+	ReleaseID int32
+}
+
+type HiddenTrack struct {
+	TrackID int32
+}
+
 type Listen struct {
 	TrackID    int32
 	ListenedAt time.Time
@@ -104,12 +171,66 @@ type Listen struct {
 	UserID     int32
 }
 
+type MbzEnrichmentAttempt struct {
+	ItemType    string
+	ItemID      int32
+	AttemptedAt time.Time
+}
+
+type MbzLocalEntity struct {
+	EntityType     string
+	MusicBrainzID  uuid.UUID
+	ReleaseGroupID *uuid.UUID
+	Body           []byte
+}
+
+type MbzMatchSuggestion struct {
+	ID            int32
+	ItemType      string
+	ItemID        int32
+	MusicBrainzID uuid.UUID
+	Name          string
+	ArtistName    string
+	Score         int32
+	Dismissed     bool
+	CreatedAt     time.Time
+}
+
+type MbzResponseCache struct {
+	EntityType    string
+	MusicBrainzID uuid.UUID
+	Body          []byte
+	FetchedAt     time.Time
+	Includes      string
+}
+
+type MbzTagFetch struct {
+	ItemType  string
+	ItemID    int32
+	FetchedAt time.Time
+}
+
+type MergeCandidate struct {
+	ID        int32
+	ItemType  string
+	FromID    int32
+	ToID      int32
+	Score     float32
+	Reasons   []string
+	Dismissed bool
+	CreatedAt time.Time
+}
+
 type Release struct {
-	ID             int32
-	MusicBrainzID  *uuid.UUID
-	Image          *uuid.UUID
-	VariousArtists bool
-	ImageSource    pgtype.Text
+	ID                int32
+	MusicBrainzID     *uuid.UUID
+	Image             *uuid.UUID
+	VariousArtists    bool
+	ImageSource       pgtype.Text
+	ReleaseDate       pgtype.Text
+	ReleaseType       pgtype.Text
+	SecondaryTypes    []string
+	ReleaseGroupMbzID *uuid.UUID
 }
 
 type ReleaseAlias struct {
@@ -117,6 +238,29 @@ type ReleaseAlias struct {
 	Alias     string
 	Source    string
 	IsPrimary bool
+	MatchKey  pgtype.Text
+}
+
+type ReleaseEdition struct {
+	ReleaseID int32
+	EditionOf pgtype.Int4
+	Source    string
+}
+
+type ReleaseTag struct {
+	ReleaseID int32
+	TagID     int32
+	Source    string
+}
+
+type ReleaseTrack struct {
+	ReleaseID     int32
+	DiscNumber    int32
+	Position      int32
+	Title         string
+	Duration      int32
+	MusicBrainzID *uuid.UUID
+	TrackID       pgtype.Int4
 }
 
 type ReleasesWithTitle struct {
@@ -126,6 +270,9 @@ type ReleasesWithTitle struct {
 	VariousArtists bool
 	ImageSource    pgtype.Text
 	Title          string
+	ReleaseDate    pgtype.Text
+	ReleaseType    pgtype.Text
+	SecondaryTypes []string
 }
 
 type Session struct {
@@ -136,6 +283,11 @@ type Session struct {
 	Persistent bool
 }
 
+type Tag struct {
+	ID   int32
+	Name string
+}
+
 type Track struct {
 	ID            int32
 	MusicBrainzID *uuid.UUID
@@ -148,6 +300,18 @@ type TrackAlias struct {
 	Alias     string
 	IsPrimary bool
 	Source    string
+	MatchKey  pgtype.Text
+}
+
+type TrackTag struct {
+	TrackID int32
+	TagID   int32
+	Source  string
+}
+
+type TrackTagsInherited struct {
+	TrackID int32
+	TagID   int32
 }
 
 type TracksWithTitle struct {
@@ -158,6 +322,20 @@ type TracksWithTitle struct {
 	Title         string
 }
 
+type Trash struct {
+	ID        int32
+	ItemType  string
+	ItemID    int32
+	Name      string
+	DeletedAt time.Time
+}
+
+type TrashRow struct {
+	TrashID   int32
+	TableName string
+	Row       []byte
+}
+
 type User struct {
 	ID       int32
 	Username string
@@ -14,30 +14,154 @@ import (
 )
 
 const associateArtistToRelease = `-- name: AssociateArtistToRelease :exec
-INSERT INTO artist_releases (artist_id, release_id)
-VALUES ($1, $2)
-ON CONFLICT DO NOTHING
+INSERT INTO artist_releases (artist_id, release_id, is_album_artist)
+VALUES ($1, $2, $3)
+ON CONFLICT (artist_id, release_id) DO UPDATE
+SET is_album_artist = artist_releases.is_album_artist OR EXCLUDED.is_album_artist
 `
 
 type AssociateArtistToReleaseParams struct {
-	ArtistID  int32
-	ReleaseID int32
+	ArtistID      int32
+	ReleaseID     int32
+	IsAlbumArtist bool
 }
 
 func (q *Queries) AssociateArtistToRelease(ctx context.Context, arg AssociateArtistToReleaseParams) error {
-	_, err := q.db.Exec(ctx, associateArtistToRelease, arg.ArtistID, arg.ReleaseID)
+	_, err := q.db.Exec(ctx, associateArtistToRelease, arg.ArtistID, arg.ReleaseID, arg.IsAlbumArtist)
 	return err
 }
 
-const countReleasesFromArtist = `-- name: CountReleasesFromArtist :one
+const countArtistTracksInRelease = `-- name: CountArtistTracksInRelease :one
 SELECT COUNT(*)
-FROM releases r 
+FROM tracks t
+JOIN artist_tracks at ON t.id = at.track_id
+WHERE at.artist_id = $1 AND t.release_id = $2
+`
+
+type CountArtistTracksInReleaseParams struct {
+	ArtistID  int32
+	ReleaseID int32
+}
+
+func (q *Queries) CountArtistTracksInRelease(ctx context.Context, arg CountArtistTracksInReleaseParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countArtistTracksInRelease, arg.ArtistID, arg.ReleaseID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countListensByReleaseType = `-- name: CountListensByReleaseType :many
+SELECT
+  (CASE WHEN 'Compilation' = ANY(r.secondary_types) THEN 'Compilation' ELSE r.release_type END)::text AS release_type,
+  COUNT(*) AS listen_count
+FROM listens l
+JOIN tracks t ON l.track_id = t.id
+JOIN releases r ON t.release_id = r.id
+WHERE l.listened_at BETWEEN $1 AND $2
+  AND r.release_type IS NOT NULL
+  AND listen_visible(l.track_id, l.client, $3)
+GROUP BY 1
+ORDER BY listen_count DESC, release_type
+`
+
+type CountListensByReleaseTypeParams struct {
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	IncludeHidden bool
+}
+
+type CountListensByReleaseTypeRow struct {
+	ReleaseType string
+	ListenCount int64
+}
+
+func (q *Queries) CountListensByReleaseType(ctx context.Context, arg CountListensByReleaseTypeParams) ([]CountListensByReleaseTypeRow, error) {
+	rows, err := q.db.Query(ctx, countListensByReleaseType, arg.ListenedAt, arg.ListenedAt_2, arg.IncludeHidden)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountListensByReleaseTypeRow
+	for rows.Next() {
+		var i CountListensByReleaseTypeRow
+		if err := rows.Scan(&i.ReleaseType, &i.ListenCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countListensByReleaseYear = `-- name: CountListensByReleaseYear :many
+SELECT
+  (LEFT(r.release_date, 4)::int / $3::int * $3::int)::int AS year,
+  COUNT(*) AS listen_count
+FROM listens l
+JOIN tracks t ON l.track_id = t.id
+JOIN releases r ON t.release_id = r.id
+WHERE l.listened_at BETWEEN $1 AND $2
+  AND r.release_date IS NOT NULL
+  AND listen_visible(l.track_id, l.client, $4)
+GROUP BY year
+ORDER BY year
+`
+
+type CountListensByReleaseYearParams struct {
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	Column3       int32
+	IncludeHidden bool
+}
+
+type CountListensByReleaseYearRow struct {
+	Year        int32
+	ListenCount int64
+}
+
+func (q *Queries) CountListensByReleaseYear(ctx context.Context, arg CountListensByReleaseYearParams) ([]CountListensByReleaseYearRow, error) {
+	rows, err := q.db.Query(ctx, countListensByReleaseYear,
+		arg.ListenedAt,
+		arg.ListenedAt_2,
+		arg.Column3,
+		arg.IncludeHidden,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountListensByReleaseYearRow
+	for rows.Next() {
+		var i CountListensByReleaseYearRow
+		if err := rows.Scan(&i.Year, &i.ListenCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countReleasesFromArtist = `-- name: CountReleasesFromArtist :one
+SELECT COUNT(DISTINCT r.id)
+FROM releases r
 JOIN artist_releases ar ON r.id = ar.release_id
-WHERE ar.artist_id = $1
+WHERE ar.artist_id IN (SELECT artist_roll_up($1, $2)) AND ar.is_album_artist
+  AND release_visible(r.id, $3)
 `
 
-func (q *Queries) CountReleasesFromArtist(ctx context.Context, artistID int32) (int64, error) {
-	row := q.db.QueryRow(ctx, countReleasesFromArtist, artistID)
+type CountReleasesFromArtistParams struct {
+	ArtistID       int32
+	IncludeRelated bool
+	IncludeHidden  bool
+}
+
+func (q *Queries) CountReleasesFromArtist(ctx context.Context, arg CountReleasesFromArtistParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countReleasesFromArtist, arg.ArtistID, arg.IncludeRelated, arg.IncludeHidden)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -49,15 +173,49 @@ FROM listens l
 JOIN tracks t ON l.track_id = t.id
 JOIN releases r ON t.release_id = r.id
 WHERE l.listened_at BETWEEN $1 AND $2
+  AND listen_visible(l.track_id, l.client, $3)
 `
 
 type CountTopReleasesParams struct {
-	ListenedAt   time.Time
-	ListenedAt_2 time.Time
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	IncludeHidden bool
 }
 
 func (q *Queries) CountTopReleases(ctx context.Context, arg CountTopReleasesParams) (int64, error) {
-	row := q.db.QueryRow(ctx, countTopReleases, arg.ListenedAt, arg.ListenedAt_2)
+	row := q.db.QueryRow(ctx, countTopReleases, arg.ListenedAt, arg.ListenedAt_2, arg.IncludeHidden)
+	var total_count int64
+	err := row.Scan(&total_count)
+	return total_count, err
+}
+
+const countTopReleasesByTag = `-- name: CountTopReleasesByTag :one
+SELECT COUNT(DISTINCT t.release_id) AS total_count
+FROM listens l
+JOIN tracks t ON l.track_id = t.id
+WHERE l.listened_at BETWEEN $1 AND $2
+AND listen_visible(l.track_id, l.client, $4)
+AND l.track_id IN (
+    SELECT tti.track_id FROM track_tags_inherited tti
+    JOIN tags tg ON tg.id = tti.tag_id
+    WHERE tg.name = $3
+)
+`
+
+type CountTopReleasesByTagParams struct {
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	Name          string
+	IncludeHidden bool
+}
+
+func (q *Queries) CountTopReleasesByTag(ctx context.Context, arg CountTopReleasesByTagParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countTopReleasesByTag,
+		arg.ListenedAt,
+		arg.ListenedAt_2,
+		arg.Name,
+		arg.IncludeHidden,
+	)
 	var total_count int64
 	err := row.Scan(&total_count)
 	return total_count, err
@@ -86,20 +244,26 @@ func (q *Queries) DeleteReleasesFromArtist(ctx context.Context, artistID int32)
 
 const getRelease = `-- name: GetRelease :one
 SELECT 
-  id, musicbrainz_id, image, various_artists, image_source, title,
-  get_artists_for_release(id) AS artists
-FROM releases_with_title
-WHERE id = $1 LIMIT 1
+  r.id, r.musicbrainz_id, r.image, r.various_artists, r.image_source, r.title, r.release_date, r.release_type, r.secondary_types,
+  rel.release_group_mbz_id,
+  get_artists_for_release(r.id) AS artists
+FROM releases_with_title r
+JOIN releases rel ON rel.id = r.id
+WHERE r.id = $1 LIMIT 1
 `
 
 type GetReleaseRow struct {
-	ID             int32
-	MusicBrainzID  *uuid.UUID
-	Image          *uuid.UUID
-	VariousArtists bool
-	ImageSource    pgtype.Text
-	Title          string
-	Artists        []byte
+	ID                int32
+	MusicBrainzID     *uuid.UUID
+	Image             *uuid.UUID
+	VariousArtists    bool
+	ImageSource       pgtype.Text
+	Title             string
+	ReleaseDate       pgtype.Text
+	ReleaseType       pgtype.Text
+	SecondaryTypes    []string
+	ReleaseGroupMbzID *uuid.UUID
+	Artists           []byte
 }
 
 func (q *Queries) GetRelease(ctx context.Context, id int32) (GetReleaseRow, error) {
@@ -112,16 +276,55 @@ func (q *Queries) GetRelease(ctx context.Context, id int32) (GetReleaseRow, erro
 		&i.VariousArtists,
 		&i.ImageSource,
 		&i.Title,
+		&i.ReleaseDate,
+		&i.ReleaseType,
+		&i.SecondaryTypes,
+		&i.ReleaseGroupMbzID,
 		&i.Artists,
 	)
 	return i, err
 }
 
+const getReleaseByArtistAndMatchKeys = `-- name: GetReleaseByArtistAndMatchKeys :one
+SELECT r.id, r.musicbrainz_id, r.image, r.various_artists, r.image_source, r.title, r.release_date, r.release_type, r.secondary_types
+FROM releases_with_title r
+JOIN artist_releases ar ON r.id = ar.release_id
+WHERE ar.artist_id = $2 AND ar.is_album_artist
+  AND r.id IN (
+    SELECT ra.release_id FROM release_aliases ra
+    WHERE ra.match_key IN (SELECT match_key(title) FROM unnest($1::TEXT[]) AS title)
+  )
+ORDER BY r.id
+LIMIT 1
+`
+
+type GetReleaseByArtistAndMatchKeysParams struct {
+	Column1  []string
+	ArtistID int32
+}
+
+func (q *Queries) GetReleaseByArtistAndMatchKeys(ctx context.Context, arg GetReleaseByArtistAndMatchKeysParams) (ReleasesWithTitle, error) {
+	row := q.db.QueryRow(ctx, getReleaseByArtistAndMatchKeys, arg.Column1, arg.ArtistID)
+	var i ReleasesWithTitle
+	err := row.Scan(
+		&i.ID,
+		&i.MusicBrainzID,
+		&i.Image,
+		&i.VariousArtists,
+		&i.ImageSource,
+		&i.Title,
+		&i.ReleaseDate,
+		&i.ReleaseType,
+		&i.SecondaryTypes,
+	)
+	return i, err
+}
+
 const getReleaseByArtistAndTitle = `-- name: GetReleaseByArtistAndTitle :one
-SELECT r.id, r.musicbrainz_id, r.image, r.various_artists, r.image_source, r.title
+SELECT r.id, r.musicbrainz_id, r.image, r.various_artists, r.image_source, r.title, r.release_date, r.release_type, r.secondary_types
 FROM releases_with_title r
 JOIN artist_releases ar ON r.id = ar.release_id
-WHERE r.title = $1 AND ar.artist_id = $2
+WHERE r.title = $1 AND ar.artist_id = $2 AND ar.is_album_artist
 LIMIT 1
 `
 
@@ -140,15 +343,18 @@ func (q *Queries) GetReleaseByArtistAndTitle(ctx context.Context, arg GetRelease
 		&i.VariousArtists,
 		&i.ImageSource,
 		&i.Title,
+		&i.ReleaseDate,
+		&i.ReleaseType,
+		&i.SecondaryTypes,
 	)
 	return i, err
 }
 
 const getReleaseByArtistAndTitles = `-- name: GetReleaseByArtistAndTitles :one
-SELECT r.id, r.musicbrainz_id, r.image, r.various_artists, r.image_source, r.title
+SELECT r.id, r.musicbrainz_id, r.image, r.various_artists, r.image_source, r.title, r.release_date, r.release_type, r.secondary_types
 FROM releases_with_title r
 JOIN artist_releases ar ON r.id = ar.release_id
-WHERE r.title = ANY ($1::TEXT[]) AND ar.artist_id = $2
+WHERE r.title = ANY ($1::TEXT[]) AND ar.artist_id = $2 AND ar.is_album_artist
 LIMIT 1
 `
 
@@ -167,12 +373,15 @@ func (q *Queries) GetReleaseByArtistAndTitles(ctx context.Context, arg GetReleas
 		&i.VariousArtists,
 		&i.ImageSource,
 		&i.Title,
+		&i.ReleaseDate,
+		&i.ReleaseType,
+		&i.SecondaryTypes,
 	)
 	return i, err
 }
 
 const getReleaseByImageID = `-- name: GetReleaseByImageID :one
-SELECT id, musicbrainz_id, image, various_artists, image_source FROM releases
+SELECT id, musicbrainz_id, image, various_artists, image_source, release_date, release_type, secondary_types, release_group_mbz_id FROM releases
 WHERE image = $1 LIMIT 1
 `
 
@@ -185,12 +394,16 @@ func (q *Queries) GetReleaseByImageID(ctx context.Context, image *uuid.UUID) (Re
 		&i.Image,
 		&i.VariousArtists,
 		&i.ImageSource,
+		&i.ReleaseDate,
+		&i.ReleaseType,
+		&i.SecondaryTypes,
+		&i.ReleaseGroupMbzID,
 	)
 	return i, err
 }
 
 const getReleaseByMbzID = `-- name: GetReleaseByMbzID :one
-SELECT id, musicbrainz_id, image, various_artists, image_source, title FROM releases_with_title
+SELECT id, musicbrainz_id, image, various_artists, image_source, title, release_date, release_type, secondary_types FROM releases_with_title
 WHERE musicbrainz_id = $1 LIMIT 1
 `
 
@@ -204,13 +417,99 @@ func (q *Queries) GetReleaseByMbzID(ctx context.Context, musicbrainzID *uuid.UUI
 		&i.VariousArtists,
 		&i.ImageSource,
 		&i.Title,
+		&i.ReleaseDate,
+		&i.ReleaseType,
+		&i.SecondaryTypes,
+	)
+	return i, err
+}
+
+const getReleaseByReleaseGroupMbzID = `-- name: GetReleaseByReleaseGroupMbzID :one
+SELECT r.id, r.musicbrainz_id, r.image, r.various_artists, r.image_source, r.title, r.release_date, r.release_type, r.secondary_types
+FROM releases_with_title r
+JOIN releases rel ON rel.id = r.id
+WHERE rel.release_group_mbz_id = $1
+ORDER BY r.id
+LIMIT 1
+`
+
+func (q *Queries) GetReleaseByReleaseGroupMbzID(ctx context.Context, releaseGroupMbzID *uuid.UUID) (ReleasesWithTitle, error) {
+	row := q.db.QueryRow(ctx, getReleaseByReleaseGroupMbzID, releaseGroupMbzID)
+	var i ReleasesWithTitle
+	err := row.Scan(
+		&i.ID,
+		&i.MusicBrainzID,
+		&i.Image,
+		&i.VariousArtists,
+		&i.ImageSource,
+		&i.Title,
+		&i.ReleaseDate,
+		&i.ReleaseType,
+		&i.SecondaryTypes,
 	)
 	return i, err
 }
 
+const getReleasesInReleaseGroup = `-- name: GetReleasesInReleaseGroup :many
+SELECT
+  r.id, r.musicbrainz_id, r.image, r.various_artists, r.image_source, r.title, r.release_date, r.release_type, r.secondary_types,
+  (SELECT COUNT(*) FROM listens l JOIN tracks t ON l.track_id = t.id WHERE t.release_id = r.id) AS listen_count,
+  get_artists_for_release(r.id) AS artists
+FROM releases_with_title r
+JOIN releases rel ON rel.id = r.id
+WHERE rel.release_group_mbz_id = $1
+ORDER BY r.release_date NULLS LAST, r.id
+`
+
+type GetReleasesInReleaseGroupRow struct {
+	ID             int32
+	MusicBrainzID  *uuid.UUID
+	Image          *uuid.UUID
+	VariousArtists bool
+	ImageSource    pgtype.Text
+	Title          string
+	ReleaseDate    pgtype.Text
+	ReleaseType    pgtype.Text
+	SecondaryTypes []string
+	ListenCount    int64
+	Artists        []byte
+}
+
+func (q *Queries) GetReleasesInReleaseGroup(ctx context.Context, releaseGroupMbzID *uuid.UUID) ([]GetReleasesInReleaseGroupRow, error) {
+	rows, err := q.db.Query(ctx, getReleasesInReleaseGroup, releaseGroupMbzID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReleasesInReleaseGroupRow
+	for rows.Next() {
+		var i GetReleasesInReleaseGroupRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.MusicBrainzID,
+			&i.Image,
+			&i.VariousArtists,
+			&i.ImageSource,
+			&i.Title,
+			&i.ReleaseDate,
+			&i.ReleaseType,
+			&i.SecondaryTypes,
+			&i.ListenCount,
+			&i.Artists,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getReleasesWithoutImages = `-- name: GetReleasesWithoutImages :many
 SELECT
-  r.id, r.musicbrainz_id, r.image, r.various_artists, r.image_source, r.title,
+  r.id, r.musicbrainz_id, r.image, r.various_artists, r.image_source, r.title, r.release_date, r.release_type, r.secondary_types,
   get_artists_for_release(r.id) AS artists
 FROM releases_with_title r 
 WHERE r.image IS NULL 
@@ -231,6 +530,9 @@ type GetReleasesWithoutImagesRow struct {
 	VariousArtists bool
 	ImageSource    pgtype.Text
 	Title          string
+	ReleaseDate    pgtype.Text
+	ReleaseType    pgtype.Text
+	SecondaryTypes []string
 	Artists        []byte
 }
 
@@ -250,6 +552,9 @@ func (q *Queries) GetReleasesWithoutImages(ctx context.Context, arg GetReleasesW
 			&i.VariousArtists,
 			&i.ImageSource,
 			&i.Title,
+			&i.ReleaseDate,
+			&i.ReleaseType,
+			&i.SecondaryTypes,
 			&i.Artists,
 		); err != nil {
 			return nil, err
@@ -262,28 +567,116 @@ func (q *Queries) GetReleasesWithoutImages(ctx context.Context, arg GetReleasesW
 	return items, nil
 }
 
-const getTopReleasesFromArtist = `-- name: GetTopReleasesFromArtist :many
+const getTopReleasesByTagPaginated = `-- name: GetTopReleasesByTagPaginated :many
 SELECT
   r.id, r.musicbrainz_id, r.image, r.various_artists, r.image_source, r.title,
+  r.release_date, r.release_type, r.secondary_types,
   COUNT(*) AS listen_count,
   get_artists_for_release(r.id) AS artists
 FROM listens l
 JOIN tracks t ON l.track_id = t.id
 JOIN releases_with_title r ON t.release_id = r.id
-JOIN artist_releases ar ON r.id = ar.release_id
-WHERE ar.artist_id = $5
+WHERE l.listened_at BETWEEN $1 AND $2
+AND listen_visible(l.track_id, l.client, $6)
+AND l.track_id IN (
+    SELECT tti.track_id FROM track_tags_inherited tti
+    JOIN tags tg ON tg.id = tti.tag_id
+    WHERE tg.name = $3
+)
+GROUP BY r.id, r.title, r.musicbrainz_id, r.various_artists, r.image, r.image_source, r.release_date, r.release_type, r.secondary_types
+ORDER BY listen_count DESC, r.id
+LIMIT $4 OFFSET $5
+`
+
+type GetTopReleasesByTagPaginatedParams struct {
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	Name          string
+	Limit         int32
+	Offset        int32
+	IncludeHidden bool
+}
+
+type GetTopReleasesByTagPaginatedRow struct {
+	ID             int32
+	MusicBrainzID  *uuid.UUID
+	Image          *uuid.UUID
+	VariousArtists bool
+	ImageSource    pgtype.Text
+	Title          string
+	ReleaseDate    pgtype.Text
+	ReleaseType    pgtype.Text
+	SecondaryTypes []string
+	ListenCount    int64
+	Artists        []byte
+}
+
+func (q *Queries) GetTopReleasesByTagPaginated(ctx context.Context, arg GetTopReleasesByTagPaginatedParams) ([]GetTopReleasesByTagPaginatedRow, error) {
+	rows, err := q.db.Query(ctx, getTopReleasesByTagPaginated,
+		arg.ListenedAt,
+		arg.ListenedAt_2,
+		arg.Name,
+		arg.Limit,
+		arg.Offset,
+		arg.IncludeHidden,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTopReleasesByTagPaginatedRow
+	for rows.Next() {
+		var i GetTopReleasesByTagPaginatedRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.MusicBrainzID,
+			&i.Image,
+			&i.VariousArtists,
+			&i.ImageSource,
+			&i.Title,
+			&i.ReleaseDate,
+			&i.ReleaseType,
+			&i.SecondaryTypes,
+			&i.ListenCount,
+			&i.Artists,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTopReleasesFromArtist = `-- name: GetTopReleasesFromArtist :many
+SELECT
+  r.id, r.musicbrainz_id, r.image, r.various_artists, r.image_source, r.title, r.release_date, r.release_type, r.secondary_types,
+  COUNT(*) AS listen_count,
+  get_artists_for_release(r.id) AS artists
+FROM listens l
+JOIN tracks t ON l.track_id = t.id
+JOIN releases_with_title r ON t.release_id = r.id
+WHERE EXISTS (
+    SELECT 1 FROM artist_releases ar
+    WHERE ar.release_id = r.id AND ar.is_album_artist AND ar.artist_id IN (SELECT artist_roll_up($5, $6))
+  )
   AND l.listened_at BETWEEN $1 AND $2
-GROUP BY r.id, r.title, r.musicbrainz_id, r.various_artists, r.image, r.image_source
+  AND listen_visible(l.track_id, l.client, $7)
+GROUP BY r.id, r.title, r.musicbrainz_id, r.various_artists, r.image, r.image_source, r.release_date, r.release_type, r.secondary_types
 ORDER BY listen_count DESC, r.id
 LIMIT $3 OFFSET $4
 `
 
 type GetTopReleasesFromArtistParams struct {
-	ListenedAt   time.Time
-	ListenedAt_2 time.Time
-	Limit        int32
-	Offset       int32
-	ArtistID     int32
+	ListenedAt     time.Time
+	ListenedAt_2   time.Time
+	Limit          int32
+	Offset         int32
+	ArtistID       int32
+	IncludeRelated bool
+	IncludeHidden  bool
 }
 
 type GetTopReleasesFromArtistRow struct {
@@ -293,6 +686,9 @@ type GetTopReleasesFromArtistRow struct {
 	VariousArtists bool
 	ImageSource    pgtype.Text
 	Title          string
+	ReleaseDate    pgtype.Text
+	ReleaseType    pgtype.Text
+	SecondaryTypes []string
 	ListenCount    int64
 	Artists        []byte
 }
@@ -304,6 +700,8 @@ func (q *Queries) GetTopReleasesFromArtist(ctx context.Context, arg GetTopReleas
 		arg.Limit,
 		arg.Offset,
 		arg.ArtistID,
+		arg.IncludeRelated,
+		arg.IncludeHidden,
 	)
 	if err != nil {
 		return nil, err
@@ -319,6 +717,9 @@ func (q *Queries) GetTopReleasesFromArtist(ctx context.Context, arg GetTopReleas
 			&i.VariousArtists,
 			&i.ImageSource,
 			&i.Title,
+			&i.ReleaseDate,
+			&i.ReleaseType,
+			&i.SecondaryTypes,
 			&i.ListenCount,
 			&i.Artists,
 		); err != nil {
@@ -334,23 +735,25 @@ func (q *Queries) GetTopReleasesFromArtist(ctx context.Context, arg GetTopReleas
 
 const getTopReleasesPaginated = `-- name: GetTopReleasesPaginated :many
 SELECT
-  r.id, r.musicbrainz_id, r.image, r.various_artists, r.image_source, r.title,
+  r.id, r.musicbrainz_id, r.image, r.various_artists, r.image_source, r.title, r.release_date, r.release_type, r.secondary_types,
   COUNT(*) AS listen_count,
   get_artists_for_release(r.id) AS artists
 FROM listens l
 JOIN tracks t ON l.track_id = t.id
 JOIN releases_with_title r ON t.release_id = r.id
 WHERE l.listened_at BETWEEN $1 AND $2
-GROUP BY r.id, r.title, r.musicbrainz_id, r.various_artists, r.image, r.image_source
+  AND listen_visible(l.track_id, l.client, $5)
+GROUP BY r.id, r.title, r.musicbrainz_id, r.various_artists, r.image, r.image_source, r.release_date, r.release_type, r.secondary_types
 ORDER BY listen_count DESC, r.id
 LIMIT $3 OFFSET $4
 `
 
 type GetTopReleasesPaginatedParams struct {
-	ListenedAt   time.Time
-	ListenedAt_2 time.Time
-	Limit        int32
-	Offset       int32
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	Limit         int32
+	Offset        int32
+	IncludeHidden bool
 }
 
 type GetTopReleasesPaginatedRow struct {
@@ -360,6 +763,9 @@ type GetTopReleasesPaginatedRow struct {
 	VariousArtists bool
 	ImageSource    pgtype.Text
 	Title          string
+	ReleaseDate    pgtype.Text
+	ReleaseType    pgtype.Text
+	SecondaryTypes []string
 	ListenCount    int64
 	Artists        []byte
 }
@@ -370,6 +776,7 @@ func (q *Queries) GetTopReleasesPaginated(ctx context.Context, arg GetTopRelease
 		arg.ListenedAt_2,
 		arg.Limit,
 		arg.Offset,
+		arg.IncludeHidden,
 	)
 	if err != nil {
 		return nil, err
@@ -385,6 +792,9 @@ func (q *Queries) GetTopReleasesPaginated(ctx context.Context, arg GetTopRelease
 			&i.VariousArtists,
 			&i.ImageSource,
 			&i.Title,
+			&i.ReleaseDate,
+			&i.ReleaseType,
+			&i.SecondaryTypes,
 			&i.ListenCount,
 			&i.Artists,
 		); err != nil {
@@ -399,16 +809,20 @@ func (q *Queries) GetTopReleasesPaginated(ctx context.Context, arg GetTopRelease
 }
 
 const insertRelease = `-- name: InsertRelease :one
-INSERT INTO releases (musicbrainz_id, various_artists, image, image_source)
-VALUES ($1, $2, $3, $4)
-RETURNING id, musicbrainz_id, image, various_artists, image_source
+INSERT INTO releases (musicbrainz_id, various_artists, image, image_source, release_date, release_type, secondary_types, release_group_mbz_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, musicbrainz_id, image, various_artists, image_source, release_date, release_type, secondary_types, release_group_mbz_id
 `
 
 type InsertReleaseParams struct {
-	MusicBrainzID  *uuid.UUID
-	VariousArtists bool
-	Image          *uuid.UUID
-	ImageSource    pgtype.Text
+	MusicBrainzID     *uuid.UUID
+	VariousArtists    bool
+	Image             *uuid.UUID
+	ImageSource       pgtype.Text
+	ReleaseDate       pgtype.Text
+	ReleaseType       pgtype.Text
+	SecondaryTypes    []string
+	ReleaseGroupMbzID *uuid.UUID
 }
 
 func (q *Queries) InsertRelease(ctx context.Context, arg InsertReleaseParams) (Release, error) {
@@ -417,6 +831,10 @@ func (q *Queries) InsertRelease(ctx context.Context, arg InsertReleaseParams) (R
 		arg.VariousArtists,
 		arg.Image,
 		arg.ImageSource,
+		arg.ReleaseDate,
+		arg.ReleaseType,
+		arg.SecondaryTypes,
+		arg.ReleaseGroupMbzID,
 	)
 	var i Release
 	err := row.Scan(
@@ -425,10 +843,29 @@ func (q *Queries) InsertRelease(ctx context.Context, arg InsertReleaseParams) (R
 		&i.Image,
 		&i.VariousArtists,
 		&i.ImageSource,
+		&i.ReleaseDate,
+		&i.ReleaseType,
+		&i.SecondaryTypes,
+		&i.ReleaseGroupMbzID,
 	)
 	return i, err
 }
 
+const updateReleaseGroupMbzID = `-- name: UpdateReleaseGroupMbzID :exec
+UPDATE releases SET release_group_mbz_id = $2
+WHERE id = $1
+`
+
+type UpdateReleaseGroupMbzIDParams struct {
+	ID                int32
+	ReleaseGroupMbzID *uuid.UUID
+}
+
+func (q *Queries) UpdateReleaseGroupMbzID(ctx context.Context, arg UpdateReleaseGroupMbzIDParams) error {
+	_, err := q.db.Exec(ctx, updateReleaseGroupMbzID, arg.ID, arg.ReleaseGroupMbzID)
+	return err
+}
+
 const updateReleaseImage = `-- name: UpdateReleaseImage :exec
 UPDATE releases SET image = $2, image_source = $3
 WHERE id = $1
@@ -445,6 +882,28 @@ func (q *Queries) UpdateReleaseImage(ctx context.Context, arg UpdateReleaseImage
 	return err
 }
 
+const updateReleaseInfo = `-- name: UpdateReleaseInfo :exec
+UPDATE releases SET release_date = $2, release_type = $3, secondary_types = $4
+WHERE id = $1
+`
+
+type UpdateReleaseInfoParams struct {
+	ID             int32
+	ReleaseDate    pgtype.Text
+	ReleaseType    pgtype.Text
+	SecondaryTypes []string
+}
+
+func (q *Queries) UpdateReleaseInfo(ctx context.Context, arg UpdateReleaseInfoParams) error {
+	_, err := q.db.Exec(ctx, updateReleaseInfo,
+		arg.ID,
+		arg.ReleaseDate,
+		arg.ReleaseType,
+		arg.SecondaryTypes,
+	)
+	return err
+}
+
 const updateReleaseMbzID = `-- name: UpdateReleaseMbzID :exec
 UPDATE releases SET musicbrainz_id = $2
 WHERE id = $1
@@ -24,7 +24,8 @@ FROM (
         ROW_NUMBER() OVER (PARTITION BY a.id ORDER BY similarity(aa.alias, $1) DESC) AS rn
     FROM artist_aliases aa
     JOIN artists_with_name a ON aa.artist_id = a.id
-    WHERE similarity(aa.alias, $1) > 0.22
+    WHERE artist_visible(a.id, $3)
+      AND similarity(aa.alias, $1) > 0.22
 ) ranked
 WHERE rn = 1
 ORDER BY score DESC
@@ -32,8 +33,9 @@ LIMIT $2
 `
 
 type SearchArtistsParams struct {
-	Similarity string
-	Limit      int32
+	Similarity    string
+	Limit         int32
+	IncludeHidden bool
 }
 
 type SearchArtistsRow struct {
@@ -45,7 +47,7 @@ type SearchArtistsRow struct {
 }
 
 func (q *Queries) SearchArtists(ctx context.Context, arg SearchArtistsParams) ([]SearchArtistsRow, error) {
-	rows, err := q.db.Query(ctx, searchArtists, arg.Similarity, arg.Limit)
+	rows, err := q.db.Query(ctx, searchArtists, arg.Similarity, arg.Limit, arg.IncludeHidden)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +84,8 @@ FROM (
         ROW_NUMBER() OVER (PARTITION BY a.id ORDER BY aa.alias) AS rn
     FROM artist_aliases aa
     JOIN artists_with_name a ON aa.artist_id = a.id
-    WHERE aa.alias ILIKE $1 || '%'
+    WHERE artist_visible(a.id, $3)
+      AND aa.alias ILIKE $1 || '%'
 ) ranked
 WHERE rn = 1
 ORDER BY score DESC
@@ -90,8 +93,9 @@ LIMIT $2
 `
 
 type SearchArtistsBySubstringParams struct {
-	Column1 pgtype.Text
-	Limit   int32
+	Column1       pgtype.Text
+	Limit         int32
+	IncludeHidden bool
 }
 
 type SearchArtistsBySubstringRow struct {
@@ -103,7 +107,7 @@ type SearchArtistsBySubstringRow struct {
 }
 
 func (q *Queries) SearchArtistsBySubstring(ctx context.Context, arg SearchArtistsBySubstringParams) ([]SearchArtistsBySubstringRow, error) {
-	rows, err := q.db.Query(ctx, searchArtistsBySubstring, arg.Column1, arg.Limit)
+	rows, err := q.db.Query(ctx, searchArtistsBySubstring, arg.Column1, arg.Limit, arg.IncludeHidden)
 	if err != nil {
 		return nil, err
 	}
@@ -148,7 +152,8 @@ FROM (
         ROW_NUMBER() OVER (PARTITION BY r.id ORDER BY similarity(ra.alias, $1) DESC) AS rn
     FROM release_aliases ra
     JOIN releases_with_title r ON ra.release_id = r.id
-    WHERE similarity(ra.alias, $1) > 0.22
+    WHERE release_visible(r.id, $3)
+      AND similarity(ra.alias, $1) > 0.22
 ) ranked
 WHERE rn = 1
 ORDER BY score DESC, title
@@ -156,8 +161,9 @@ LIMIT $2
 `
 
 type SearchReleasesParams struct {
-	Similarity string
-	Limit      int32
+	Similarity    string
+	Limit         int32
+	IncludeHidden bool
 }
 
 type SearchReleasesRow struct {
@@ -171,7 +177,7 @@ type SearchReleasesRow struct {
 }
 
 func (q *Queries) SearchReleases(ctx context.Context, arg SearchReleasesParams) ([]SearchReleasesRow, error) {
-	rows, err := q.db.Query(ctx, searchReleases, arg.Similarity, arg.Limit)
+	rows, err := q.db.Query(ctx, searchReleases, arg.Similarity, arg.Limit, arg.IncludeHidden)
 	if err != nil {
 		return nil, err
 	}
@@ -218,7 +224,8 @@ FROM (
         ROW_NUMBER() OVER (PARTITION BY r.id ORDER BY ra.alias) AS rn
     FROM release_aliases ra
     JOIN releases_with_title r ON ra.release_id = r.id
-    WHERE ra.alias ILIKE $1 || '%'
+    WHERE release_visible(r.id, $3)
+      AND ra.alias ILIKE $1 || '%'
 ) ranked
 WHERE rn = 1
 ORDER BY score DESC, title
@@ -226,8 +233,9 @@ LIMIT $2
 `
 
 type SearchReleasesBySubstringParams struct {
-	Column1 pgtype.Text
-	Limit   int32
+	Column1       pgtype.Text
+	Limit         int32
+	IncludeHidden bool
 }
 
 type SearchReleasesBySubstringRow struct {
@@ -241,7 +249,7 @@ type SearchReleasesBySubstringRow struct {
 }
 
 func (q *Queries) SearchReleasesBySubstring(ctx context.Context, arg SearchReleasesBySubstringParams) ([]SearchReleasesBySubstringRow, error) {
-	rows, err := q.db.Query(ctx, searchReleasesBySubstring, arg.Column1, arg.Limit)
+	rows, err := q.db.Query(ctx, searchReleasesBySubstring, arg.Column1, arg.Limit, arg.IncludeHidden)
 	if err != nil {
 		return nil, err
 	}
@@ -289,7 +297,8 @@ FROM (
     FROM track_aliases ta
     JOIN tracks_with_title t ON ta.track_id = t.id
     JOIN releases r ON t.release_id = r.id
-    WHERE similarity(ta.alias, $1) > 0.22
+    WHERE track_visible(t.id, $3)
+      AND similarity(ta.alias, $1) > 0.22
 ) ranked
 WHERE rn = 1
 ORDER BY score DESC, title
@@ -297,8 +306,9 @@ LIMIT $2
 `
 
 type SearchTracksParams struct {
-	Similarity string
-	Limit      int32
+	Similarity    string
+	Limit         int32
+	IncludeHidden bool
 }
 
 type SearchTracksRow struct {
@@ -312,7 +322,7 @@ type SearchTracksRow struct {
 }
 
 func (q *Queries) SearchTracks(ctx context.Context, arg SearchTracksParams) ([]SearchTracksRow, error) {
-	rows, err := q.db.Query(ctx, searchTracks, arg.Similarity, arg.Limit)
+	rows, err := q.db.Query(ctx, searchTracks, arg.Similarity, arg.Limit, arg.IncludeHidden)
 	if err != nil {
 		return nil, err
 	}
@@ -360,7 +370,8 @@ FROM (
     FROM track_aliases ta
     JOIN tracks_with_title t ON ta.track_id = t.id
     JOIN releases r ON t.release_id = r.id
-    WHERE ta.alias ILIKE $1 || '%'
+    WHERE track_visible(t.id, $3)
+      AND ta.alias ILIKE $1 || '%'
 ) ranked
 WHERE rn = 1
 ORDER BY score DESC, title
@@ -368,8 +379,9 @@ LIMIT $2
 `
 
 type SearchTracksBySubstringParams struct {
-	Column1 pgtype.Text
-	Limit   int32
+	Column1       pgtype.Text
+	Limit         int32
+	IncludeHidden bool
 }
 
 type SearchTracksBySubstringRow struct {
@@ -383,7 +395,7 @@ type SearchTracksBySubstringRow struct {
 }
 
 func (q *Queries) SearchTracksBySubstring(ctx context.Context, arg SearchTracksBySubstringParams) ([]SearchTracksBySubstringRow, error) {
-	rows, err := q.db.Query(ctx, searchTracksBySubstring, arg.Column1, arg.Limit)
+	rows, err := q.db.Query(ctx, searchTracksBySubstring, arg.Column1, arg.Limit, arg.IncludeHidden)
 	if err != nil {
 		return nil, err
 	}
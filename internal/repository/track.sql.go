@@ -32,15 +32,17 @@ const countTopTracks = `-- name: CountTopTracks :one
 SELECT COUNT(DISTINCT l.track_id) AS total_count
 FROM listens l
 WHERE l.listened_at BETWEEN $1 AND $2
+  AND listen_visible(l.track_id, l.client, $3)
 `
 
 type CountTopTracksParams struct {
-	ListenedAt   time.Time
-	ListenedAt_2 time.Time
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	IncludeHidden bool
 }
 
 func (q *Queries) CountTopTracks(ctx context.Context, arg CountTopTracksParams) (int64, error) {
-	row := q.db.QueryRow(ctx, countTopTracks, arg.ListenedAt, arg.ListenedAt_2)
+	row := q.db.QueryRow(ctx, countTopTracks, arg.ListenedAt, arg.ListenedAt_2, arg.IncludeHidden)
 	var total_count int64
 	err := row.Scan(&total_count)
 	return total_count, err
@@ -51,17 +53,26 @@ SELECT COUNT(DISTINCT l.track_id) AS total_count
 FROM listens l
 JOIN artist_tracks at ON l.track_id = at.track_id
 WHERE l.listened_at BETWEEN $1 AND $2
-AND at.artist_id = $3
+AND at.artist_id IN (SELECT artist_roll_up($3, $4))
+AND listen_visible(l.track_id, l.client, $5)
 `
 
 type CountTopTracksByArtistParams struct {
-	ListenedAt   time.Time
-	ListenedAt_2 time.Time
-	ArtistID     int32
+	ListenedAt     time.Time
+	ListenedAt_2   time.Time
+	ArtistID       int32
+	IncludeRelated bool
+	IncludeHidden  bool
 }
 
 func (q *Queries) CountTopTracksByArtist(ctx context.Context, arg CountTopTracksByArtistParams) (int64, error) {
-	row := q.db.QueryRow(ctx, countTopTracksByArtist, arg.ListenedAt, arg.ListenedAt_2, arg.ArtistID)
+	row := q.db.QueryRow(ctx, countTopTracksByArtist,
+		arg.ListenedAt,
+		arg.ListenedAt_2,
+		arg.ArtistID,
+		arg.IncludeRelated,
+		arg.IncludeHidden,
+	)
 	var total_count int64
 	err := row.Scan(&total_count)
 	return total_count, err
@@ -73,16 +84,54 @@ FROM listens l
 JOIN tracks t ON l.track_id = t.id
 WHERE l.listened_at BETWEEN $1 AND $2
 AND t.release_id = $3
+AND listen_visible(l.track_id, l.client, $4)
 `
 
 type CountTopTracksByReleaseParams struct {
-	ListenedAt   time.Time
-	ListenedAt_2 time.Time
-	ReleaseID    int32
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	ReleaseID     int32
+	IncludeHidden bool
 }
 
 func (q *Queries) CountTopTracksByRelease(ctx context.Context, arg CountTopTracksByReleaseParams) (int64, error) {
-	row := q.db.QueryRow(ctx, countTopTracksByRelease, arg.ListenedAt, arg.ListenedAt_2, arg.ReleaseID)
+	row := q.db.QueryRow(ctx, countTopTracksByRelease,
+		arg.ListenedAt,
+		arg.ListenedAt_2,
+		arg.ReleaseID,
+		arg.IncludeHidden,
+	)
+	var total_count int64
+	err := row.Scan(&total_count)
+	return total_count, err
+}
+
+const countTopTracksByTag = `-- name: CountTopTracksByTag :one
+SELECT COUNT(DISTINCT l.track_id) AS total_count
+FROM listens l
+WHERE l.listened_at BETWEEN $1 AND $2
+AND listen_visible(l.track_id, l.client, $4)
+AND l.track_id IN (
+    SELECT tti.track_id FROM track_tags_inherited tti
+    JOIN tags tg ON tg.id = tti.tag_id
+    WHERE tg.name = $3
+)
+`
+
+type CountTopTracksByTagParams struct {
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	Name          string
+	IncludeHidden bool
+}
+
+func (q *Queries) CountTopTracksByTag(ctx context.Context, arg CountTopTracksByTagParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countTopTracksByTag,
+		arg.ListenedAt,
+		arg.ListenedAt_2,
+		arg.Name,
+		arg.IncludeHidden,
+	)
 	var total_count int64
 	err := row.Scan(&total_count)
 	return total_count, err
@@ -142,20 +191,25 @@ SELECT
 FROM listens l
 JOIN tracks_with_title t ON l.track_id = t.id
 JOIN releases r ON t.release_id = r.id
-JOIN artist_tracks at ON at.track_id = t.id
 WHERE l.listened_at BETWEEN $1 AND $2
-  AND at.artist_id = $5
+  AND listen_visible(l.track_id, l.client, $7)
+  AND EXISTS (
+    SELECT 1 FROM artist_tracks at
+    WHERE at.track_id = t.id AND at.artist_id IN (SELECT artist_roll_up($5, $6))
+  )
 GROUP BY t.id, t.title, t.musicbrainz_id, t.release_id, r.image
 ORDER BY listen_count DESC, t.id
 LIMIT $3 OFFSET $4
 `
 
 type GetTopTracksByArtistPaginatedParams struct {
-	ListenedAt   time.Time
-	ListenedAt_2 time.Time
-	Limit        int32
-	Offset       int32
-	ArtistID     int32
+	ListenedAt     time.Time
+	ListenedAt_2   time.Time
+	Limit          int32
+	Offset         int32
+	ArtistID       int32
+	IncludeRelated bool
+	IncludeHidden  bool
 }
 
 type GetTopTracksByArtistPaginatedRow struct {
@@ -175,6 +229,8 @@ func (q *Queries) GetTopTracksByArtistPaginated(ctx context.Context, arg GetTopT
 		arg.Limit,
 		arg.Offset,
 		arg.ArtistID,
+		arg.IncludeRelated,
+		arg.IncludeHidden,
 	)
 	if err != nil {
 		return nil, err
@@ -202,6 +258,84 @@ func (q *Queries) GetTopTracksByArtistPaginated(ctx context.Context, arg GetTopT
 	return items, nil
 }
 
+const getTopTracksByTagPaginated = `-- name: GetTopTracksByTagPaginated :many
+SELECT
+    t.id,
+    t.title,
+    t.musicbrainz_id,
+    t.release_id,
+    r.image,
+    COUNT(*) AS listen_count,
+    get_artists_for_track(t.id) AS artists
+FROM listens l
+JOIN tracks_with_title t ON l.track_id = t.id
+JOIN releases r ON t.release_id = r.id
+WHERE l.listened_at BETWEEN $1 AND $2
+AND listen_visible(l.track_id, l.client, $6)
+AND l.track_id IN (
+    SELECT tti.track_id FROM track_tags_inherited tti
+    JOIN tags tg ON tg.id = tti.tag_id
+    WHERE tg.name = $3
+)
+GROUP BY t.id, t.title, t.musicbrainz_id, t.release_id, r.image
+ORDER BY listen_count DESC, t.id
+LIMIT $4 OFFSET $5
+`
+
+type GetTopTracksByTagPaginatedParams struct {
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	Name          string
+	Limit         int32
+	Offset        int32
+	IncludeHidden bool
+}
+
+type GetTopTracksByTagPaginatedRow struct {
+	ID            int32
+	Title         string
+	MusicBrainzID *uuid.UUID
+	ReleaseID     int32
+	Image         *uuid.UUID
+	ListenCount   int64
+	Artists       []byte
+}
+
+func (q *Queries) GetTopTracksByTagPaginated(ctx context.Context, arg GetTopTracksByTagPaginatedParams) ([]GetTopTracksByTagPaginatedRow, error) {
+	rows, err := q.db.Query(ctx, getTopTracksByTagPaginated,
+		arg.ListenedAt,
+		arg.ListenedAt_2,
+		arg.Name,
+		arg.Limit,
+		arg.Offset,
+		arg.IncludeHidden,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTopTracksByTagPaginatedRow
+	for rows.Next() {
+		var i GetTopTracksByTagPaginatedRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.MusicBrainzID,
+			&i.ReleaseID,
+			&i.Image,
+			&i.ListenCount,
+			&i.Artists,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTopTracksInReleasePaginated = `-- name: GetTopTracksInReleasePaginated :many
 SELECT
     t.id,
@@ -216,17 +350,19 @@ JOIN tracks_with_title t ON l.track_id = t.id
 JOIN releases r ON t.release_id = r.id
 WHERE l.listened_at BETWEEN $1 AND $2
   AND t.release_id = $5
+  AND listen_visible(l.track_id, l.client, $6)
 GROUP BY t.id, t.title, t.musicbrainz_id, t.release_id, r.image
 ORDER BY listen_count DESC, t.id
 LIMIT $3 OFFSET $4
 `
 
 type GetTopTracksInReleasePaginatedParams struct {
-	ListenedAt   time.Time
-	ListenedAt_2 time.Time
-	Limit        int32
-	Offset       int32
-	ReleaseID    int32
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	Limit         int32
+	Offset        int32
+	ReleaseID     int32
+	IncludeHidden bool
 }
 
 type GetTopTracksInReleasePaginatedRow struct {
@@ -246,6 +382,7 @@ func (q *Queries) GetTopTracksInReleasePaginated(ctx context.Context, arg GetTop
 		arg.Limit,
 		arg.Offset,
 		arg.ReleaseID,
+		arg.IncludeHidden,
 	)
 	if err != nil {
 		return nil, err
@@ -286,16 +423,18 @@ FROM listens l
 JOIN tracks_with_title t ON l.track_id = t.id
 JOIN releases r ON t.release_id = r.id
 WHERE l.listened_at BETWEEN $1 AND $2
+  AND listen_visible(l.track_id, l.client, $5)
 GROUP BY t.id, t.title, t.musicbrainz_id, t.release_id, r.image
 ORDER BY listen_count DESC, t.id
 LIMIT $3 OFFSET $4
 `
 
 type GetTopTracksPaginatedParams struct {
-	ListenedAt   time.Time
-	ListenedAt_2 time.Time
-	Limit        int32
-	Offset       int32
+	ListenedAt    time.Time
+	ListenedAt_2  time.Time
+	Limit         int32
+	Offset        int32
+	IncludeHidden bool
 }
 
 type GetTopTracksPaginatedRow struct {
@@ -314,6 +453,7 @@ func (q *Queries) GetTopTracksPaginated(ctx context.Context, arg GetTopTracksPag
 		arg.ListenedAt_2,
 		arg.Limit,
 		arg.Offset,
+		arg.IncludeHidden,
 	)
 	if err != nil {
 		return nil, err
@@ -394,6 +534,38 @@ func (q *Queries) GetTrackByMbzID(ctx context.Context, musicbrainzID *uuid.UUID)
 	return i, err
 }
 
+const getTrackByMatchKeyAndArtists = `-- name: GetTrackByMatchKeyAndArtists :one
+SELECT t.id, t.musicbrainz_id, t.duration, t.release_id, t.title
+FROM tracks_with_title t
+JOIN artist_tracks at ON at.track_id = t.id
+WHERE t.id IN (
+    SELECT ta.track_id FROM track_aliases ta WHERE ta.match_key = match_key($1)
+  )
+  AND at.artist_id = ANY($2::int[])
+GROUP BY t.id, t.title, t.musicbrainz_id, t.duration, t.release_id
+HAVING COUNT(DISTINCT at.artist_id) = cardinality($2::int[])
+ORDER BY t.id
+LIMIT 1
+`
+
+type GetTrackByMatchKeyAndArtistsParams struct {
+	Name    string
+	Column2 []int32
+}
+
+func (q *Queries) GetTrackByMatchKeyAndArtists(ctx context.Context, arg GetTrackByMatchKeyAndArtistsParams) (TracksWithTitle, error) {
+	row := q.db.QueryRow(ctx, getTrackByMatchKeyAndArtists, arg.Name, arg.Column2)
+	var i TracksWithTitle
+	err := row.Scan(
+		&i.ID,
+		&i.MusicBrainzID,
+		&i.Duration,
+		&i.ReleaseID,
+		&i.Title,
+	)
+	return i, err
+}
+
 const getTrackByTitleAndArtists = `-- name: GetTrackByTitleAndArtists :one
 SELECT t.id, t.musicbrainz_id, t.duration, t.release_id, t.title
 FROM tracks_with_title t
@@ -506,3 +678,18 @@ func (q *Queries) UpdateTrackPrimaryArtist(ctx context.Context, arg UpdateTrackP
 	_, err := q.db.Exec(ctx, updateTrackPrimaryArtist, arg.ArtistID, arg.TrackID, arg.IsPrimary)
 	return err
 }
+
+const updateTrackRelease = `-- name: UpdateTrackRelease :exec
+UPDATE tracks SET release_id = $2
+WHERE id = $1
+`
+
+type UpdateTrackReleaseParams struct {
+	ID        int32
+	ReleaseID int32
+}
+
+func (q *Queries) UpdateTrackRelease(ctx context.Context, arg UpdateTrackReleaseParams) error {
+	_, err := q.db.Exec(ctx, updateTrackRelease, arg.ID, arg.ReleaseID)
+	return err
+}
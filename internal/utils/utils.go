@@ -335,3 +335,9 @@ func FlattenAliases(aliases []models.Alias) []string {
 	}
 	return ret
 }
+
+// NormalizeTag lowercases a tag and collapses its whitespace, so that the same tag
+// from different sources is only stored once.
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.Join(strings.Fields(tag), " "))
+}
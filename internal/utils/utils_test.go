@@ -47,3 +47,9 @@ func TestRemoveInBoth(t *testing.T) {
 		assert.EqualValues(t, expected[i+2], r)
 	}
 }
+
+func TestNormalizeTag(t *testing.T) {
+	assert.Equal(t, "j-pop", utils.NormalizeTag("J-Pop"))
+	assert.Equal(t, "alternative rock", utils.NormalizeTag("  Alternative\tRock "))
+	assert.Equal(t, "", utils.NormalizeTag("   "))
+}
@@ -1,4 +1,3 @@
-// unused
 package romanizer
 
 import (
@@ -0,0 +1,29 @@
+package romanizer_test
+
+import (
+	"testing"
+
+	"github.com/gabehf/koito/romanizer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRomanize(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{"ヨルシカ", "yorushika"},
+		{"あいみょん", "aimiyon"},
+		{"아이유", "aiyu"},
+		{"Кино", "Kino"},
+		{"  Кино  ", "Kino"},
+		// already in Latin script
+		{"ATARASHII GAKKO!", ""},
+		{"Sigur Rós", ""},
+		{"Beyoncé", ""},
+		{"", ""},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, romanizer.Romanize(c.input), c.input)
+	}
+}